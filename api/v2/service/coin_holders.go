@@ -0,0 +1,115 @@
+package service
+
+import (
+	"encoding/hex"
+	"net/http"
+	"strconv"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultCoinHoldersLimit = 100
+	maxCoinHoldersLimit     = 1000
+)
+
+type coinHolder struct {
+	Address string `json:"address"`
+	Balance string `json:"balance"`
+}
+
+type coinHoldersResponse struct {
+	Coin         delegationsCoin `json:"coin"`
+	HoldersCount uint64          `json:"holders_count"`
+	Holders      []*coinHolder   `json:"holders"`
+	NextCursor   string          `json:"next_cursor,omitempty"`
+}
+
+// coinHolders returns a page of holders of the coin ordered by balance and the number of its holders
+func (s *Service) coinHolders(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("coin_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": map[string]string{
+				"message": err.Error(),
+			},
+		})
+		return
+	}
+
+	limit := defaultCoinHoldersLimit
+	if limitS := c.Query("limit"); limitS != "" {
+		limit, err = strconv.Atoi(limitS)
+		if err != nil || limit <= 0 || limit > maxCoinHoldersLimit {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": map[string]string{
+					"message": "limit should be from 1 to " + strconv.Itoa(maxCoinHoldersLimit),
+				},
+			})
+			return
+		}
+	}
+
+	var cursor []byte
+	if cursorS := c.Query("cursor"); cursorS != "" {
+		cursor, err = hex.DecodeString(cursorS)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": map[string]string{
+					"message": "invalid cursor",
+				},
+			})
+			return
+		}
+	}
+
+	var height uint64
+	if heightS := c.Query("height"); heightS != "" {
+		height, err = strconv.ParseUint(heightS, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": map[string]string{
+					"message": err.Error(),
+				},
+			})
+			return
+		}
+	}
+
+	cState, err := s.blockchain.GetStateForHeight(height)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": map[string]string{
+				"message": err.Error(),
+			},
+		})
+		return
+	}
+
+	coinID := types.CoinID(id)
+	if !cState.Coins().Exists(coinID) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": map[string]string{
+				"message": "Coin not found",
+			},
+		})
+		return
+	}
+
+	holders, next := cState.Accounts().CoinHolders(coinID, limit, cursor)
+	response := &coinHoldersResponse{
+		Coin:         delegationsCoinOf(cState, coinID),
+		HoldersCount: cState.Accounts().HoldersCount(coinID),
+		Holders:      make([]*coinHolder, 0, len(holders)),
+		NextCursor:   hex.EncodeToString(next),
+	}
+	for _, holder := range holders {
+		response.Holders = append(response.Holders, &coinHolder{
+			Address: holder.Address.String(),
+			Balance: holder.Value.String(),
+		})
+	}
+
+	c.JSON(http.StatusOK, response)
+}
@@ -53,5 +53,16 @@ func (s *Service) CustomHandlers() http.Handler {
 	gin.SetMode(gin.ReleaseMode)
 	r := gin.Default()
 	r.GET("/change_amounts_for_price/:coin0/:coin1/:price", s.changeAmountsForPrice)
+	r.GET("/delegations/:address", s.delegations)
+	r.GET("/estimate_staking_reward/:public_key/:coin_id/:value", s.estimateStakingReward)
+	r.GET("/multisig_proposal/:id", s.multisigProposal)
+	r.GET("/vestings/:address", s.vestings)
+	r.GET("/standing_order/:id", s.standingOrder)
+	r.GET("/htlc/:id", s.htlc)
+	r.GET("/coin_holders/:coin_id", s.coinHolders)
+	r.GET("/distribution/:id", s.distribution)
+	r.GET("/treasury", s.treasury)
+	r.GET("/governance_proposals", s.governanceProposals)
+	r.GET("/governance_proposal/:kind/:target_height", s.governanceProposal)
 	return r
 }
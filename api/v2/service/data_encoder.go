@@ -2,6 +2,7 @@ package service
 
 import (
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 
@@ -279,7 +280,7 @@ func encode(data transaction.Data, txType transaction.TxType, rCoins coins.RCoin
 			MinimumValueToBuy: d.MinimumValueToBuy.String(),
 		}
 	case transaction.TypeCreateToken:
-		d := data.(*transaction.CreateTokenData)
+		d := data.(*transaction.CreateTokenDataV350)
 		m = &pb.CreateTokenData{
 			Name:          d.Name,
 			Symbol:        d.Symbol.String(),
@@ -317,7 +318,7 @@ func encode(data transaction.Data, txType transaction.TxType, rCoins coins.RCoin
 			Value: d.Value.String(),
 		}
 	case transaction.TypeEditCandidateCommission:
-		d := data.(*transaction.EditCandidateCommission)
+		d := data.(*transaction.EditCandidateCommissionV350)
 		m = &pb.EditCandidateCommission{
 			PubKey:     d.PubKey.String(),
 			Commission: uint64(d.Commission),
@@ -366,7 +367,7 @@ func encode(data transaction.Data, txType transaction.TxType, rCoins coins.RCoin
 			Id: uint64(d.ID),
 		}
 	case transaction.TypeMoveStake:
-		d := data.(*transaction.MoveStakeData)
+		d := data.(*transaction.MoveStakeDataV350)
 		m = &pb.MoveStakeData{
 			FromPubKey: d.FromPubKey.String(),
 			ToPubKey:   d.ToPubKey.String(),
@@ -389,6 +390,395 @@ func encode(data transaction.Data, txType transaction.TxType, rCoins coins.RCoin
 			},
 			Value: d.Value.String(),
 		}
+	case transaction.TypeEditCandidateDelegation:
+		d := data.(*transaction.EditCandidateDelegationData)
+		allowList := make([]string, 0, len(d.AllowList))
+		for _, address := range d.AllowList {
+			allowList = append(allowList, address.String())
+		}
+		dataStruct, err := toStruct(map[string]interface{}{
+			"pub_key":        d.PubKey.String(),
+			"max_stake":      d.MaxStake.String(),
+			"min_delegation": d.MinDelegation.String(),
+			"allow_list":     allowList,
+		})
+		if err != nil {
+			return nil, err
+		}
+		m = dataStruct
+	case transaction.TypeSubmitMultisigProposal:
+		d := data.(*transaction.SubmitMultisigProposalData)
+		dataStruct, err := toStruct(map[string]interface{}{
+			"multisig": d.Multisig.String(),
+			"type":     uint64(d.Type),
+			"data":     hex.EncodeToString(d.Data),
+			"gas_coin": map[string]interface{}{
+				"id":     uint64(d.GasCoin),
+				"symbol": rCoins.GetCoin(d.GasCoin).GetFullSymbol(),
+			},
+			"expire_height": d.ExpireHeight,
+		})
+		if err != nil {
+			return nil, err
+		}
+		m = dataStruct
+	case transaction.TypeApproveMultisigProposal:
+		d := data.(*transaction.ApproveMultisigProposalData)
+		dataStruct, err := toStruct(map[string]interface{}{
+			"id": d.ID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		m = dataStruct
+	case transaction.TypeCreateVesting:
+		d := data.(*transaction.CreateVestingData)
+		dataStruct, err := toStruct(map[string]interface{}{
+			"to": d.To.String(),
+			"coin": map[string]interface{}{
+				"id":     uint64(d.Coin),
+				"symbol": rCoins.GetCoin(d.Coin).GetFullSymbol(),
+			},
+			"value":        d.Value.String(),
+			"start_height": d.StartHeight,
+			"cliff_height": d.CliffHeight,
+			"end_height":   d.EndHeight,
+		})
+		if err != nil {
+			return nil, err
+		}
+		m = dataStruct
+	case transaction.TypeBatch:
+		d := data.(*transaction.BatchData)
+		items := make([]map[string]interface{}, 0, len(d.Items))
+		for _, item := range d.Items {
+			items = append(items, map[string]interface{}{
+				"type": uint64(item.Type),
+				"data": hex.EncodeToString(item.Data),
+			})
+		}
+		dataStruct, err := toStruct(map[string]interface{}{
+			"items": items,
+		})
+		if err != nil {
+			return nil, err
+		}
+		m = dataStruct
+	case transaction.TypeRevokeCheck:
+		d := data.(*transaction.RevokeCheckData)
+		dataStruct, err := toStruct(map[string]interface{}{
+			"raw_check": base64.StdEncoding.EncodeToString(d.RawCheck),
+		})
+		if err != nil {
+			return nil, err
+		}
+		m = dataStruct
+	case transaction.TypeRedeemChecks:
+		d := data.(*transaction.RedeemChecksData)
+		checks := make([]map[string]interface{}, 0, len(d.Checks))
+		for _, item := range d.Checks {
+			checks = append(checks, map[string]interface{}{
+				"raw_check": base64.StdEncoding.EncodeToString(item.RawCheck),
+				"proof":     base64.StdEncoding.EncodeToString(item.Proof[:]),
+			})
+		}
+		dataStruct, err := toStruct(map[string]interface{}{
+			"checks": checks,
+		})
+		if err != nil {
+			return nil, err
+		}
+		m = dataStruct
+	case transaction.TypeCreateStandingOrder:
+		d := data.(*transaction.CreateStandingOrderData)
+		dataStruct, err := toStruct(map[string]interface{}{
+			"recipient": d.Recipient.String(),
+			"coin": map[string]interface{}{
+				"id":     uint64(d.Coin),
+				"symbol": rCoins.GetCoin(d.Coin).GetFullSymbol(),
+			},
+			"amount":   d.Amount.String(),
+			"interval": d.Interval,
+			"count":    d.Count,
+		})
+		if err != nil {
+			return nil, err
+		}
+		m = dataStruct
+	case transaction.TypeCancelStandingOrder:
+		d := data.(*transaction.CancelStandingOrderData)
+		dataStruct, err := toStruct(map[string]interface{}{
+			"id": d.ID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		m = dataStruct
+	case transaction.TypeCreateHTLC:
+		d := data.(*transaction.CreateHTLCData)
+		dataStruct, err := toStruct(map[string]interface{}{
+			"recipient": d.Recipient.String(),
+			"coin": map[string]interface{}{
+				"id":     uint64(d.Coin),
+				"symbol": rCoins.GetCoin(d.Coin).GetFullSymbol(),
+			},
+			"value":    d.Value.String(),
+			"hashlock": hex.EncodeToString(d.Hashlock[:]),
+			"timeout":  d.Timeout,
+		})
+		if err != nil {
+			return nil, err
+		}
+		m = dataStruct
+	case transaction.TypeClaimHTLC:
+		d := data.(*transaction.ClaimHTLCData)
+		dataStruct, err := toStruct(map[string]interface{}{
+			"id":       d.ID,
+			"preimage": hex.EncodeToString(d.Preimage),
+		})
+		if err != nil {
+			return nil, err
+		}
+		m = dataStruct
+	case transaction.TypeRefundHTLC:
+		d := data.(*transaction.RefundHTLCData)
+		dataStruct, err := toStruct(map[string]interface{}{
+			"id": d.ID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		m = dataStruct
+	case transaction.TypeRotateKey:
+		d := data.(*transaction.RotateKeyData)
+		dataStruct, err := toStruct(map[string]interface{}{
+			"account": d.Account.String(),
+			"new_key": d.NewKey.String(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		m = dataStruct
+	case transaction.TypeSetKeyGuardian:
+		d := data.(*transaction.SetKeyGuardianData)
+		dataStruct, err := toStruct(map[string]interface{}{
+			"account":  d.Account.String(),
+			"guardian": d.Guardian.String(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		m = dataStruct
+	case transaction.TypeSetAccountPolicy:
+		d := data.(*transaction.SetAccountPolicyData)
+		var limits []interface{}
+		for _, limit := range d.Limits {
+			limits = append(limits, map[string]interface{}{
+				"coin": map[string]interface{}{
+					"id":     uint64(limit.Coin),
+					"symbol": rCoins.GetCoin(limit.Coin).GetFullSymbol(),
+				},
+				"value": limit.Value.String(),
+			})
+		}
+		var recipients []interface{}
+		for _, recipient := range d.Recipients {
+			recipients = append(recipients, recipient.String())
+		}
+		var forbiddenTypes []interface{}
+		for _, txType := range d.ForbiddenTypes {
+			forbiddenTypes = append(forbiddenTypes, txType.UInt64())
+		}
+		dataStruct, err := toStruct(map[string]interface{}{
+			"limits":          limits,
+			"recipients":      recipients,
+			"forbidden_types": forbiddenTypes,
+		})
+		if err != nil {
+			return nil, err
+		}
+		m = dataStruct
+	case transaction.TypeEditCoinMetadata:
+		d := data.(*transaction.EditCoinMetadataData)
+		dataStruct, err := toStruct(map[string]interface{}{
+			"coin": map[string]interface{}{
+				"id":     uint64(d.Coin),
+				"symbol": rCoins.GetCoin(d.Coin).GetFullSymbol(),
+			},
+			"description": d.Description,
+			"uri":         d.URI,
+			"icon_hash":   d.IconHash.String(),
+			"decimals":    d.Decimals,
+		})
+		if err != nil {
+			return nil, err
+		}
+		m = dataStruct
+	case transaction.TypePauseToken:
+		d := data.(*transaction.PauseTokenData)
+		dataStruct, err := toStruct(map[string]interface{}{
+			"coin": map[string]interface{}{
+				"id":     uint64(d.Coin),
+				"symbol": rCoins.GetCoin(d.Coin).GetFullSymbol(),
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+		m = dataStruct
+	case transaction.TypeUnpauseToken:
+		d := data.(*transaction.UnpauseTokenData)
+		dataStruct, err := toStruct(map[string]interface{}{
+			"coin": map[string]interface{}{
+				"id":     uint64(d.Coin),
+				"symbol": rCoins.GetCoin(d.Coin).GetFullSymbol(),
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+		m = dataStruct
+	case transaction.TypeFreezeAddress:
+		d := data.(*transaction.FreezeAddressData)
+		dataStruct, err := toStruct(map[string]interface{}{
+			"coin": map[string]interface{}{
+				"id":     uint64(d.Coin),
+				"symbol": rCoins.GetCoin(d.Coin).GetFullSymbol(),
+			},
+			"address": d.Address.String(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		m = dataStruct
+	case transaction.TypeUnfreezeAddress:
+		d := data.(*transaction.UnfreezeAddressData)
+		dataStruct, err := toStruct(map[string]interface{}{
+			"coin": map[string]interface{}{
+				"id":     uint64(d.Coin),
+				"symbol": rCoins.GetCoin(d.Coin).GetFullSymbol(),
+			},
+			"address": d.Address.String(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		m = dataStruct
+	case transaction.TypeSetTokenTransferFee:
+		d := data.(*transaction.SetTokenTransferFeeData)
+		dataStruct, err := toStruct(map[string]interface{}{
+			"coin": map[string]interface{}{
+				"id":     uint64(d.Coin),
+				"symbol": rCoins.GetCoin(d.Coin).GetFullSymbol(),
+			},
+			"bps":  d.Bps,
+			"burn": d.Burn,
+		})
+		if err != nil {
+			return nil, err
+		}
+		m = dataStruct
+	case transaction.TypeDistributeToHolders:
+		d := data.(*transaction.DistributeToHoldersData)
+		dataStruct, err := toStruct(map[string]interface{}{
+			"coin": map[string]interface{}{
+				"id":     uint64(d.Coin),
+				"symbol": rCoins.GetCoin(d.Coin).GetFullSymbol(),
+			},
+			"value": d.Value.String(),
+			"holder_coin": map[string]interface{}{
+				"id":     uint64(d.HolderCoin),
+				"symbol": rCoins.GetCoin(d.HolderCoin).GetFullSymbol(),
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+		m = dataStruct
+	case transaction.TypeMigrateCoinToToken:
+		d := data.(*transaction.MigrateCoinToTokenData)
+		dataStruct, err := toStruct(map[string]interface{}{
+			"coin": map[string]interface{}{
+				"id":     uint64(d.Coin),
+				"symbol": rCoins.GetCoin(d.Coin).GetFullSymbol(),
+			},
+			"mintable": d.Mintable,
+			"burnable": d.Burnable,
+		})
+		if err != nil {
+			return nil, err
+		}
+		m = dataStruct
+	case transaction.TypeCreateParamProposal:
+		d := data.(*transaction.CreateParamProposalData)
+		dataStruct, err := toStruct(map[string]interface{}{
+			"key":           d.Key,
+			"value":         d.Value,
+			"target_height": d.TargetHeight,
+			"deposit":       d.Deposit.String(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		m = dataStruct
+	case transaction.TypeVoteParamProposal:
+		d := data.(*transaction.VoteParamProposalData)
+		dataStruct, err := toStruct(map[string]interface{}{
+			"pub_key": d.PubKey.String(),
+			"id":      d.ID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		m = dataStruct
+	case transaction.TypeCreateTreasuryProposal:
+		d := data.(*transaction.CreateTreasuryProposalData)
+		dataStruct, err := toStruct(map[string]interface{}{
+			"recipient": d.Recipient.String(),
+			"coin": map[string]interface{}{
+				"id":     uint64(d.Coin),
+				"symbol": rCoins.GetCoin(d.Coin).GetFullSymbol(),
+			},
+			"amount":      d.Amount.String(),
+			"description": d.Description,
+		})
+		if err != nil {
+			return nil, err
+		}
+		m = dataStruct
+	case transaction.TypeVoteTreasuryProposal:
+		d := data.(*transaction.VoteTreasuryProposalData)
+		dataStruct, err := toStruct(map[string]interface{}{
+			"pub_key": d.PubKey.String(),
+			"id":      d.ID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		m = dataStruct
+	case transaction.TypeVoteAsDelegator:
+		d := data.(*transaction.VoteAsDelegatorData)
+		dataStruct, err := toStruct(map[string]interface{}{
+			"kind":    uint64(d.Kind),
+			"height":  d.Height,
+			"option":  uint64(d.Option),
+			"abstain": d.Abstain,
+		})
+		if err != nil {
+			return nil, err
+		}
+		m = dataStruct
+	case transaction.TypeRedeemCheckV2:
+		d := data.(*transaction.RedeemCheckV2Data)
+		dataStruct, err := toStruct(map[string]interface{}{
+			"raw_check": base64.StdEncoding.EncodeToString(d.RawCheck),
+			"proof":     base64.StdEncoding.EncodeToString(d.Proof[:]),
+			"value":     d.Value.String(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		m = dataStruct
 	default:
 		return nil, errors.New("unknown tx type")
 	}
@@ -0,0 +1,232 @@
+package service
+
+import (
+	"encoding/hex"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/developers"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/gin-gonic/gin"
+)
+
+type delegationsCoin struct {
+	ID     uint64 `json:"id"`
+	Symbol string `json:"symbol"`
+}
+
+type delegationsStake struct {
+	PublicKey          string          `json:"public_key"`
+	Coin               delegationsCoin `json:"coin"`
+	Value              string          `json:"value"`
+	BipValue           string          `json:"bip_value"`
+	NextRewardEstimate string          `json:"next_reward_estimate"`
+}
+
+type delegationsWait struct {
+	PublicKey string          `json:"public_key"`
+	Coin      delegationsCoin `json:"coin"`
+	Value     string          `json:"value"`
+}
+
+type delegationsFrozen struct {
+	Height             uint64          `json:"height"`
+	CandidateKey       string          `json:"candidate_key,omitempty"`
+	MoveToCandidateKey string          `json:"move_to_candidate_key,omitempty"`
+	Coin               delegationsCoin `json:"coin"`
+	Value              string          `json:"value"`
+}
+
+type delegationsResponse struct {
+	Address               string               `json:"address"`
+	Stakes                []*delegationsStake  `json:"stakes"`
+	Pending               []*delegationsWait   `json:"pending"`
+	WaitList              []*delegationsWait   `json:"wait_list"`
+	Frozen                []*delegationsFrozen `json:"frozen"`
+	LockedStakeUntilBlock uint64               `json:"locked_stake_until_block"`
+	IsStakeLocked         bool                 `json:"is_stake_locked"`
+}
+
+// delegations returns all stakes, pending delegations, waitlist items and frozen funds of an address
+func (s *Service) delegations(c *gin.Context) {
+	addressS := c.Param("address")
+	if !strings.HasPrefix(strings.Title(addressS), "Mx") {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": map[string]string{
+				"message": "invalid address",
+			},
+		})
+		return
+	}
+	decodeString, err := hex.DecodeString(addressS[2:])
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": map[string]string{
+				"message": "invalid address",
+			},
+		})
+		return
+	}
+	address := types.BytesToAddress(decodeString)
+
+	var height uint64
+	if heightS := c.Query("height"); heightS != "" {
+		height, err = strconv.ParseUint(heightS, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": map[string]string{
+					"message": err.Error(),
+				},
+			})
+			return
+		}
+	}
+
+	cState, err := s.blockchain.GetStateForHeight(height)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": map[string]string{
+				"message": err.Error(),
+			},
+		})
+		return
+	}
+
+	currentHeight := height
+	if currentHeight == 0 {
+		currentHeight = s.blockchain.Height()
+	}
+
+	if height != 0 {
+		cState.Candidates().LoadCandidates()
+		cState.Validators().LoadValidators()
+	}
+
+	res := &delegationsResponse{
+		Address:  address.String(),
+		Stakes:   []*delegationsStake{},
+		Pending:  []*delegationsWait{},
+		WaitList: []*delegationsWait{},
+		Frozen:   []*delegationsFrozen{},
+	}
+
+	for _, id := range cState.Candidates().GetDelegatorCandidateIDs(address) {
+		pubKey := cState.Candidates().PubKey(id)
+		if !cState.Candidates().Exists(pubKey) {
+			continue
+		}
+		if height != 0 {
+			cState.Candidates().LoadStakesOfCandidate(pubKey)
+		}
+
+		for _, stake := range cState.Candidates().GetStakes(pubKey) {
+			if stake.Owner != address {
+				continue
+			}
+			res.Stakes = append(res.Stakes, &delegationsStake{
+				PublicKey:          pubKey.String(),
+				Coin:               delegationsCoinOf(cState, stake.Coin),
+				Value:              stake.Value.String(),
+				BipValue:           stake.BipValue.String(),
+				NextRewardEstimate: s.nextRewardEstimate(cState, pubKey, stake.BipValue, currentHeight).String(),
+			})
+		}
+
+		for _, update := range cState.Candidates().GetUpdates(pubKey) {
+			if update.Owner != address {
+				continue
+			}
+			res.Pending = append(res.Pending, &delegationsWait{
+				PublicKey: pubKey.String(),
+				Coin:      delegationsCoinOf(cState, update.Coin),
+				Value:     update.Value.String(),
+			})
+		}
+	}
+
+	if waitList := cState.WaitList().GetByAddress(address); waitList != nil {
+		for _, item := range waitList.List {
+			res.WaitList = append(res.WaitList, &delegationsWait{
+				PublicKey: cState.Candidates().PubKey(item.CandidateId).String(),
+				Coin:      delegationsCoinOf(cState, item.Coin),
+				Value:     item.Value.String(),
+			})
+		}
+	}
+
+	for _, funds := range cState.FrozenFunds().GetFrozenFundsAll(c.Request.Context(), currentHeight, currentHeight+cState.Governance().UnbondPeriod()+1) {
+		if funds == nil {
+			continue
+		}
+
+		for _, fund := range funds.List {
+			if fund.Address != address {
+				continue
+			}
+
+			frozen := &delegationsFrozen{
+				Height: funds.Height(),
+				Coin:   delegationsCoinOf(cState, fund.Coin),
+				Value:  fund.Value.String(),
+			}
+			if fund.CandidateKey != nil {
+				frozen.CandidateKey = fund.CandidateKey.String()
+			}
+			if fund.GetMoveToCandidateID() != 0 {
+				frozen.MoveToCandidateKey = cState.Candidates().PubKey(fund.GetMoveToCandidateID()).String()
+			}
+			res.Frozen = append(res.Frozen, frozen)
+		}
+	}
+
+	res.LockedStakeUntilBlock = cState.Accounts().GetLockStakeUntilBlock(address)
+	res.IsStakeLocked = res.LockedStakeUntilBlock > currentHeight
+
+	c.JSON(http.StatusOK, res)
+}
+
+func delegationsCoinOf(cState *state.CheckState, coinID types.CoinID) delegationsCoin {
+	return delegationsCoin{
+		ID:     uint64(coinID),
+		Symbol: cState.Coins().GetCoin(coinID).GetFullSymbol(),
+	}
+}
+
+// nextRewardEstimate returns a share of a stake in the reward of a validator at the next payout: the reward accumulated
+// since the last payout and the reward for the remaining blocks of the period at the current block reward and voting power
+func (s *Service) nextRewardEstimate(cState *state.CheckState, pubKey types.Pubkey, bipValue *big.Int, height uint64) *big.Int {
+	validator := cState.Validators().GetByPublicKey(pubKey)
+	if validator == nil || validator.GetTotalBipStake().Sign() != 1 {
+		return big.NewInt(0)
+	}
+
+	candidate := cState.Candidates().GetCandidate(pubKey)
+
+	totalPower := big.NewInt(0)
+	for _, val := range cState.Validators().GetValidators() {
+		totalPower.Add(totalPower, val.GetTotalBipStake())
+	}
+
+	reward := big.NewInt(0).Set(validator.GetAccumReward())
+
+	period := s.blockchain.UpdateStakesAndPayRewardsPeriod()
+	remaining := period - height%period
+	if totalPower.Sign() == 1 {
+		projected := big.NewInt(0).Mul(s.blockchain.GetRewardForBlock(), big.NewInt(0).SetUint64(remaining))
+		projected.Mul(projected, validator.GetTotalBipStake())
+		projected.Div(projected, totalPower)
+		reward.Add(reward, projected)
+	}
+
+	reward.Mul(reward, big.NewInt(100-int64(cState.Governance().DAOCommission())-int64(developers.Commission)))
+	reward.Div(reward, big.NewInt(100))
+	reward.Mul(reward, big.NewInt(int64(100-candidate.Commission)))
+	reward.Div(reward, big.NewInt(100))
+	reward.Mul(reward, bipValue)
+	reward.Div(reward, validator.GetTotalBipStake())
+
+	return reward
+}
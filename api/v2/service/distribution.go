@@ -0,0 +1,76 @@
+package service
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type distributionResponse struct {
+	ID         uint64          `json:"id"`
+	Owner      string          `json:"owner"`
+	Coin       delegationsCoin `json:"coin"`
+	Value      string          `json:"value"`
+	HolderCoin delegationsCoin `json:"holder_coin"`
+	Holders    uint64          `json:"holders"`
+	Paid       uint64          `json:"paid_holders"`
+	PaidValue  string          `json:"paid_value"`
+}
+
+// distribution returns progress of a distribution to holders which is not completed yet
+func (s *Service) distribution(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": map[string]string{
+				"message": err.Error(),
+			},
+		})
+		return
+	}
+
+	var height uint64
+	if heightS := c.Query("height"); heightS != "" {
+		height, err = strconv.ParseUint(heightS, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": map[string]string{
+					"message": err.Error(),
+				},
+			})
+			return
+		}
+	}
+
+	cState, err := s.blockchain.GetStateForHeight(height)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": map[string]string{
+				"message": err.Error(),
+			},
+		})
+		return
+	}
+
+	distribution := cState.Distributions().GetDistribution(id)
+	if distribution == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": map[string]string{
+				"message": "Distribution not found",
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, &distributionResponse{
+		ID:         id,
+		Owner:      distribution.Owner.String(),
+		Coin:       delegationsCoinOf(cState, distribution.Coin),
+		Value:      distribution.Amount.String(),
+		HolderCoin: delegationsCoinOf(cState, distribution.HolderCoin),
+		Holders:    distribution.Holders,
+		Paid:       distribution.GetCursor(),
+		PaidValue:  distribution.GetPaid().String(),
+	})
+}
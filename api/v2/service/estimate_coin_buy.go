@@ -299,7 +299,7 @@ func (s *Service) calcBuyPoolWithCommission(ctx context.Context, commissions *co
 		return nil, nil, timeoutStatus.Err()
 	}
 
-	valuePool, errPool := s.calcBuyFromPool(ctx, valueToBuy, cState, coinFrom, coinTo, route, commissionPoolSwapper)
+	valuePool, errPool := s.calcBuyFromPool(ctx, transaction.TransferFeeGross(cState, coinTo.ID(), valueToBuy), cState, coinFrom, coinTo, route, commissionPoolSwapper)
 	if errPool != nil {
 		return nil, nil, errPool
 	}
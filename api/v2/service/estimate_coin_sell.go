@@ -297,7 +297,7 @@ func (s *Service) calcSellFromPool(ctx context.Context, value *big.Int, cState *
 		sellCoinID = buyCoinID
 	}
 
-	return sellValue, nil
+	return big.NewInt(0).Sub(sellValue, transaction.TransferFee(cState, coinTo.ID(), sellValue)), nil
 }
 
 func (s *Service) calcSellFromBancor(value *big.Int, coinTo transaction.CalculateCoin, coinFrom transaction.CalculateCoin) (*big.Int, error) {
@@ -0,0 +1,159 @@
+package service
+
+import (
+	"encoding/hex"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/developers"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/gin-gonic/gin"
+)
+
+const secondsPerYear = 365 * 24 * 60 * 60
+
+type estimateStakingRewardResponse struct {
+	PublicKey        string          `json:"public_key"`
+	Coin             delegationsCoin `json:"coin"`
+	Value            string          `json:"value"`
+	BipValue         string          `json:"bip_value"`
+	IsValidator      bool            `json:"is_validator"`
+	Commission       uint32          `json:"commission"`
+	RewardForBlock   string          `json:"reward_for_block"`
+	Period           uint64          `json:"period"`
+	PeriodReward     string          `json:"period_reward"`
+	AnnualizedReward string          `json:"annualized_reward"`
+	APR              string          `json:"apr"`
+}
+
+// estimateStakingReward simulates one rewards period for a new stake of given coin and value in a candidate
+func (s *Service) estimateStakingReward(c *gin.Context) {
+	publicKey := c.Param("public_key")
+	if !strings.HasPrefix(publicKey, "Mp") {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": map[string]string{
+				"message": "invalid public_key",
+			},
+		})
+		return
+	}
+	decodeString, err := hex.DecodeString(publicKey[2:])
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": map[string]string{
+				"message": err.Error(),
+			},
+		})
+		return
+	}
+	pubKey := types.BytesToPubkey(decodeString)
+
+	coinI, err := strconv.ParseUint(c.Param("coin_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": map[string]string{
+				"message": err.Error(),
+			},
+		})
+		return
+	}
+	value, ok := big.NewInt(0).SetString(c.Param("value"), 10)
+	if !ok || value.Sign() == -1 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": map[string]string{
+				"message": "invalid value",
+			},
+		})
+		return
+	}
+
+	cState := s.blockchain.CurrentState()
+
+	candidate := cState.Candidates().GetCandidate(pubKey)
+	if candidate == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": map[string]string{
+				"message": "Candidate not found",
+			},
+		})
+		return
+	}
+
+	coinID := types.CoinID(coinI)
+	coin := cState.Coins().GetCoin(coinID)
+	if coin == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": map[string]string{
+				"message": "Coin not found",
+			},
+		})
+		return
+	}
+
+	bipValue := customCoinBipBalance(value, coin)
+
+	totalPower := big.NewInt(0)
+	for _, validator := range cState.Validators().GetValidators() {
+		totalPower.Add(totalPower, validator.GetTotalBipStake())
+	}
+
+	candidateStake := cState.Candidates().GetTotalStake(pubKey)
+	isValidator := cState.Validators().GetByPublicKey(pubKey) != nil
+	if !isValidator {
+		// assume the candidate takes a validator slot with the new stake
+		totalPower.Add(totalPower, candidateStake)
+	}
+	candidateStake = big.NewInt(0).Add(candidateStake, bipValue)
+	totalPower.Add(totalPower, bipValue)
+
+	rewardForBlock := s.blockchain.GetRewardForBlock()
+	period := s.blockchain.UpdateStakesAndPayRewardsPeriod()
+
+	periodReward := big.NewInt(0)
+	if totalPower.Sign() == 1 && candidateStake.Sign() == 1 {
+		// reward accumulated by the candidate
+		periodReward.Mul(rewardForBlock, big.NewInt(int64(period)))
+		periodReward.Mul(periodReward, candidateStake)
+		periodReward.Div(periodReward, totalPower)
+
+		// DAO and developers commissions
+		periodReward.Mul(periodReward, big.NewInt(100-int64(cState.Governance().DAOCommission())-int64(developers.Commission)))
+		periodReward.Div(periodReward, big.NewInt(100))
+
+		// validator commission
+		periodReward.Mul(periodReward, big.NewInt(int64(100-candidate.Commission)))
+		periodReward.Div(periodReward, big.NewInt(100))
+
+		// share of the stake
+		periodReward.Mul(periodReward, bipValue)
+		periodReward.Div(periodReward, candidateStake)
+	}
+
+	periodsPerYear := big.NewFloat(secondsPerYear / (float64(period) * s.blockchain.AverageBlockTime()))
+	annualizedReward, _ := big.NewFloat(0).Mul(new(big.Float).SetInt(periodReward), periodsPerYear).Int(nil)
+
+	apr := big.NewFloat(0)
+	if bipValue.Sign() == 1 {
+		apr.Quo(new(big.Float).SetInt(annualizedReward), new(big.Float).SetInt(bipValue))
+		apr.Mul(apr, big.NewFloat(100))
+	}
+
+	c.JSON(http.StatusOK, &estimateStakingRewardResponse{
+		PublicKey: pubKey.String(),
+		Coin: delegationsCoin{
+			ID:     uint64(coinID),
+			Symbol: coin.GetFullSymbol(),
+		},
+		Value:            value.String(),
+		BipValue:         bipValue.String(),
+		IsValidator:      isValidator,
+		Commission:       candidate.Commission,
+		RewardForBlock:   rewardForBlock.String(),
+		Period:           period,
+		PeriodReward:     periodReward.String(),
+		AnnualizedReward: annualizedReward.String(),
+		APR:              apr.Text('f', 2),
+	})
+}
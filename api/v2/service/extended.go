@@ -0,0 +1,240 @@
+package service
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	pb "github.com/MinterTeam/node-grpc-gateway/api_pb"
+	"github.com/gin-gonic/gin"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// The responses of Candidate, CoinInfo, CoinInfoById and Address are generated from the gateway protos, which have
+// no fields for pending commissions, coin metadata, holders and freeze state. The handlers below replace the gateway
+// HTTP routes of these methods and return the same JSON with the fields added, gRPC clients get the responses without them.
+
+// ExtendedPaths are the gateway HTTP routes served by ExtendedHandlers
+var ExtendedPaths = []string{
+	"/candidate/{public_key}",
+	"/coin_info/{symbol}",
+	"/coin_info_by_id/{id}",
+	"/address/{address}",
+}
+
+// ExtendedHandlers return http methods which replace the gateway routes of ExtendedPaths
+func (s *Service) ExtendedHandlers() http.Handler {
+	gin.SetMode(gin.ReleaseMode)
+	r := gin.Default()
+	r.GET("/candidate/:public_key", s.candidate)
+	r.GET("/coin_info/:symbol", s.coinInfo)
+	r.GET("/coin_info_by_id/:id", s.coinInfoById)
+	r.GET("/address/:address", s.address)
+	return r
+}
+
+type pendingCommission struct {
+	Commission      uint32 `json:"commission"`
+	EffectiveHeight uint64 `json:"effective_height"`
+}
+
+type coinMetadata struct {
+	Description string `json:"description"`
+	URI         string `json:"uri"`
+	IconHash    string `json:"icon_hash"`
+	Decimals    uint32 `json:"decimals"`
+}
+
+type addressFreezeCoin struct {
+	Coin    delegationsCoin `json:"coin"`
+	Balance string          `json:"balance"`
+	Paused  bool            `json:"paused"`
+	Frozen  bool            `json:"frozen"`
+}
+
+// candidate returns the Candidate response with the announced commission which has not taken effect yet
+func (s *Service) candidate(c *gin.Context) {
+	height, ok := queryHeight(c)
+	if !ok {
+		return
+	}
+
+	resp, err := s.Candidate(c.Request.Context(), &pb.CandidateRequest{
+		PublicKey:     c.Param("public_key"),
+		Height:        height,
+		NotShowStakes: c.Query("not_show_stakes") == "true",
+	})
+	s.writeExtended(c, height, resp, err, func(cState *state.CheckState) gin.H {
+		if height != 0 {
+			cState.Candidates().LoadCandidates()
+		}
+
+		var pending *pendingCommission
+		if commission := cState.Candidates().GetPendingCommission(types.HexToPubkey(resp.PublicKey)); commission != nil {
+			pending = &pendingCommission{
+				Commission:      commission.Commission,
+				EffectiveHeight: commission.Height,
+			}
+		}
+		return gin.H{"pending_commission": pending}
+	})
+}
+
+// coinInfo returns the CoinInfo response with the metadata and the number of holders of the coin
+func (s *Service) coinInfo(c *gin.Context) {
+	height, ok := queryHeight(c)
+	if !ok {
+		return
+	}
+
+	resp, err := s.CoinInfo(c.Request.Context(), &pb.CoinInfoRequest{Symbol: c.Param("symbol"), Height: height})
+	s.writeExtended(c, height, resp, err, func(cState *state.CheckState) gin.H {
+		return coinInfoFields(cState, types.CoinID(resp.Id))
+	})
+}
+
+// coinInfoById returns the CoinInfoById response with the metadata and the number of holders of the coin
+func (s *Service) coinInfoById(c *gin.Context) {
+	height, ok := queryHeight(c)
+	if !ok {
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": map[string]string{
+				"message": err.Error(),
+			},
+		})
+		return
+	}
+
+	resp, err := s.CoinInfoById(c.Request.Context(), &pb.CoinIdRequest{Id: id, Height: height})
+	s.writeExtended(c, height, resp, err, func(cState *state.CheckState) gin.H {
+		return coinInfoFields(cState, types.CoinID(resp.Id))
+	})
+}
+
+func coinInfoFields(cState *state.CheckState, coinID types.CoinID) gin.H {
+	var metadata *coinMetadata
+	if m := cState.Coins().GetMetadata(coinID); m != nil {
+		metadata = &coinMetadata{
+			Description: m.Description,
+			URI:         m.URI,
+			IconHash:    m.IconHash.String(),
+			Decimals:    m.Decimals,
+		}
+	}
+
+	return gin.H{
+		"metadata":      metadata,
+		"holders_count": strconv.FormatUint(cState.Accounts().HoldersCount(coinID), 10),
+	}
+}
+
+// address returns the Address response with freeze state of freezable tokens held by the address
+func (s *Service) address(c *gin.Context) {
+	height, ok := queryHeight(c)
+	if !ok {
+		return
+	}
+
+	resp, err := s.Address(c.Request.Context(), &pb.AddressRequest{
+		Address:   c.Param("address"),
+		Height:    height,
+		Delegated: c.Query("delegated") == "true",
+	})
+	s.writeExtended(c, height, resp, err, func(cState *state.CheckState) gin.H {
+		address := types.HexToAddress(c.Param("address"))
+
+		freeze := []*addressFreezeCoin{}
+		for _, balance := range cState.Accounts().GetBalances(address) {
+			if !cState.Coins().IsFreezable(balance.Coin.ID) {
+				continue
+			}
+
+			freeze = append(freeze, &addressFreezeCoin{
+				Coin:    delegationsCoinOf(cState, balance.Coin.ID),
+				Balance: balance.Value.String(),
+				Paused:  cState.Coins().IsPaused(balance.Coin.ID),
+				Frozen:  cState.Coins().IsFrozen(balance.Coin.ID, address),
+			})
+		}
+		return gin.H{"freeze": freeze}
+	})
+}
+
+func queryHeight(c *gin.Context) (uint64, bool) {
+	heightS := c.Query("height")
+	if heightS == "" {
+		return 0, true
+	}
+
+	height, err := strconv.ParseUint(heightS, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": map[string]string{
+				"message": err.Error(),
+			},
+		})
+		return 0, false
+	}
+
+	return height, true
+}
+
+// writeExtended writes the response of the gateway method in the gateway JSON form with the fields returned by extend
+func (s *Service) writeExtended(c *gin.Context, height uint64, resp proto.Message, err error, extend func(cState *state.CheckState) gin.H) {
+	if err != nil {
+		st, _ := status.FromError(err)
+		c.JSON(runtime.HTTPStatusFromCode(st.Code()), gin.H{
+			"error": map[string]string{
+				"code":    strconv.Itoa(int(st.Code())),
+				"message": st.Message(),
+			},
+		})
+		return
+	}
+
+	data, err := protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}.Marshal(resp)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": map[string]string{
+				"message": err.Error(),
+			},
+		})
+		return
+	}
+
+	result := map[string]interface{}{}
+	if err := json.Unmarshal(data, &result); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": map[string]string{
+				"message": err.Error(),
+			},
+		})
+		return
+	}
+
+	cState, err := s.blockchain.GetStateForHeight(height)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": map[string]string{
+				"message": err.Error(),
+			},
+		})
+		return
+	}
+
+	for key, value := range extend(cState) {
+		result[key] = value
+	}
+
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,306 @@
+package service
+
+import (
+	"math/big"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/minter"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/commission"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/delegatorvotes"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	pb "github.com/MinterTeam/node-grpc-gateway/api_pb"
+	"github.com/gin-gonic/gin"
+)
+
+var governanceKinds = map[string]byte{
+	"commission": delegatorvotes.KindCommission,
+	"update":     delegatorvotes.KindUpdate,
+	"halt":       delegatorvotes.KindHalt,
+}
+
+var governanceKindNames = map[byte]string{
+	delegatorvotes.KindCommission: "commission",
+	delegatorvotes.KindUpdate:     "update",
+	delegatorvotes.KindHalt:       "halt",
+}
+
+type governanceOption struct {
+	Index      int                         `json:"index"`
+	Commission *pb.PriceCommissionResponse `json:"commission,omitempty"`
+	Version    string                      `json:"version,omitempty"`
+	Votes      []string                    `json:"votes"`
+	Power      string                      `json:"power"`
+	Percent    string                      `json:"percent"`
+}
+
+type governanceDelegatorVote struct {
+	Address string `json:"address"`
+	Option  uint32 `json:"option"`
+	Abstain bool   `json:"abstain"`
+}
+
+type governanceProposal struct {
+	Kind                string                    `json:"kind"`
+	Height              uint64                    `json:"height"`
+	BlocksRemaining     uint64                    `json:"blocks_remaining"`
+	TotalPower          string                    `json:"total_power"`
+	Options             []governanceOption        `json:"options"`
+	LeadingOption       int                       `json:"leading_option"`
+	ThresholdPercent    string                    `json:"threshold_percent"`
+	QuorumReached       bool                      `json:"quorum_reached"`
+	DelegatorVotes      []governanceDelegatorVote `json:"delegator_votes"`
+	NonVotingValidators []string                  `json:"non_voting_validators"`
+}
+
+type governanceProposalsResponse struct {
+	Proposals []*governanceProposal `json:"proposals"`
+}
+
+// governanceProposals returns commission, update and halt votings with stake-weighted tallies
+func (s *Service) governanceProposals(c *gin.Context) {
+	height, ok := governanceStateHeight(c)
+	if !ok {
+		return
+	}
+
+	cState, err := s.blockchain.GetStateForHeight(height)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": map[string]string{
+				"message": err.Error(),
+			},
+		})
+		return
+	}
+
+	current := height
+	if current == 0 {
+		current = s.blockchain.Height()
+	}
+
+	powers, totalPower := s.governancePowers(cState)
+
+	response := &governanceProposalsResponse{Proposals: []*governanceProposal{}}
+	for _, kind := range []byte{delegatorvotes.KindCommission, delegatorvotes.KindUpdate, delegatorvotes.KindHalt} {
+		var heights []uint64
+		switch kind {
+		case delegatorvotes.KindCommission:
+			heights = cState.Commission().GetHeights()
+		case delegatorvotes.KindUpdate:
+			heights = cState.Updates().GetHeights()
+		case delegatorvotes.KindHalt:
+			heights = cState.Halts().GetHeights()
+		}
+
+		for _, targetHeight := range heights {
+			if proposal := governanceProposalOf(cState, kind, targetHeight, current, powers, totalPower); proposal != nil {
+				response.Proposals = append(response.Proposals, proposal)
+			}
+		}
+	}
+
+	sort.SliceStable(response.Proposals, func(i, j int) bool {
+		return response.Proposals[i].Height < response.Proposals[j].Height
+	})
+
+	c.JSON(http.StatusOK, response)
+}
+
+// governanceProposal returns the commission, update or halt voting at the target height with stake-weighted tally
+func (s *Service) governanceProposal(c *gin.Context) {
+	kind, ok := governanceKinds[c.Param("kind")]
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": map[string]string{
+				"message": "kind should be one of commission, update, halt",
+			},
+		})
+		return
+	}
+
+	targetHeight, err := strconv.ParseUint(c.Param("target_height"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": map[string]string{
+				"message": err.Error(),
+			},
+		})
+		return
+	}
+
+	height, ok := governanceStateHeight(c)
+	if !ok {
+		return
+	}
+
+	cState, err := s.blockchain.GetStateForHeight(height)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": map[string]string{
+				"message": err.Error(),
+			},
+		})
+		return
+	}
+
+	current := height
+	if current == 0 {
+		current = s.blockchain.Height()
+	}
+
+	powers, totalPower := s.governancePowers(cState)
+	proposal := governanceProposalOf(cState, kind, targetHeight, current, powers, totalPower)
+	if proposal == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": map[string]string{
+				"message": "voting not found",
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, proposal)
+}
+
+func governanceStateHeight(c *gin.Context) (uint64, bool) {
+	heightS := c.Query("height")
+	if heightS == "" {
+		return 0, true
+	}
+
+	height, err := strconv.ParseUint(heightS, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": map[string]string{
+				"message": err.Error(),
+			},
+		})
+		return 0, false
+	}
+
+	return height, true
+}
+
+// governancePowers returns voting powers of present validators the same way the node counts them for votings
+func (s *Service) governancePowers(cState *state.CheckState) (map[types.Pubkey]*big.Int, *big.Int) {
+	powers := map[types.Pubkey]*big.Int{}
+	totalPower := big.NewInt(0)
+	for _, val := range cState.Validators().GetValidators() {
+		if val.IsToDrop() || s.blockchain.GetValidatorStatus(val.GetAddress()) != minter.ValidatorPresent {
+			continue
+		}
+
+		powers[val.PubKey] = val.GetTotalBipStake()
+		totalPower.Add(totalPower, val.GetTotalBipStake())
+	}
+
+	if totalPower.Sign() == 0 {
+		totalPower = big.NewInt(1)
+	}
+
+	return powers, totalPower
+}
+
+func governanceProposalOf(cState *state.CheckState, kind byte, targetHeight, current uint64, powers map[types.Pubkey]*big.Int, totalPower *big.Int) *governanceProposal {
+	var options [][]types.Pubkey
+	var responseOptions []governanceOption
+	switch kind {
+	case delegatorvotes.KindCommission:
+		for i, vote := range cState.Commission().GetVotes(targetHeight) {
+			price := commission.Decode(vote.Price)
+			options = append(options, vote.Votes)
+			responseOptions = append(responseOptions, governanceOption{
+				Index:      i,
+				Commission: priceCommissionResponse(price, cState.Coins().GetCoin(price.Coin)),
+			})
+		}
+	case delegatorvotes.KindUpdate:
+		for i, vote := range cState.Updates().GetVotes(targetHeight) {
+			options = append(options, vote.Votes)
+			responseOptions = append(responseOptions, governanceOption{
+				Index:   i,
+				Version: vote.Version,
+			})
+		}
+	case delegatorvotes.KindHalt:
+		if halts := cState.Halts().GetHaltBlocks(targetHeight); halts != nil {
+			votes := make([]types.Pubkey, 0, len(halts.List))
+			for _, halt := range halts.List {
+				votes = append(votes, halt.Pubkey)
+			}
+			options = append(options, votes)
+			responseOptions = append(responseOptions, governanceOption{Index: 0})
+		}
+	}
+
+	if len(options) == 0 {
+		return nil
+	}
+
+	delegatorVotes := cState.DelegatorVotes().GetVotes(kind, targetHeight)
+	if len(delegatorVotes) != 0 {
+		for pubkey := range powers {
+			cState.Candidates().LoadStakesOfCandidate(pubkey)
+		}
+	}
+
+	tally := cState.DelegatorVotes().Tally(kind, targetHeight, options, powers)
+
+	total := new(big.Float).SetInt(totalPower)
+	voted := map[types.Pubkey]struct{}{}
+	leading, leadingShare := 0, big.NewFloat(0)
+	for i, option := range options {
+		votes := make([]string, 0, len(option))
+		for _, pubkey := range option {
+			voted[pubkey] = struct{}{}
+			votes = append(votes, pubkey.String())
+		}
+
+		share := new(big.Float).Quo(new(big.Float).SetInt(tally[i]), total)
+		if leadingShare.Cmp(share) == -1 {
+			leading, leadingShare = i, share
+		}
+
+		responseOptions[i].Votes = votes
+		responseOptions[i].Power = tally[i].String()
+		responseOptions[i].Percent = new(big.Float).Mul(share, big.NewFloat(100)).Text('f', 2)
+	}
+
+	nonVoting := make([]string, 0, len(powers))
+	for pubkey := range powers {
+		if _, ok := voted[pubkey]; !ok {
+			nonVoting = append(nonVoting, pubkey.String())
+		}
+	}
+	sort.Strings(nonVoting)
+
+	responseDelegatorVotes := make([]governanceDelegatorVote, 0, len(delegatorVotes))
+	for _, vote := range delegatorVotes {
+		responseDelegatorVotes = append(responseDelegatorVotes, governanceDelegatorVote{
+			Address: vote.Address.String(),
+			Option:  vote.Option,
+			Abstain: vote.Abstain,
+		})
+	}
+
+	var blocksRemaining uint64
+	if targetHeight > current {
+		blocksRemaining = targetHeight - current
+	}
+
+	return &governanceProposal{
+		Kind:                governanceKindNames[kind],
+		Height:              targetHeight,
+		BlocksRemaining:     blocksRemaining,
+		TotalPower:          totalPower.String(),
+		Options:             responseOptions,
+		LeadingOption:       leading,
+		ThresholdPercent:    new(big.Float).Quo(new(big.Float).Mul(leadingShare, big.NewFloat(100)), big.NewFloat(minter.VotingPowerConsensus)).Text('f', 2),
+		QuorumReached:       leadingShare.Cmp(big.NewFloat(minter.VotingPowerConsensus)) == 1,
+		DelegatorVotes:      responseDelegatorVotes,
+		NonVotingValidators: nonVoting,
+	}
+}
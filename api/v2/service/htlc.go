@@ -0,0 +1,75 @@
+package service
+
+import (
+	"encoding/hex"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type htlcResponse struct {
+	ID        uint64          `json:"id"`
+	Sender    string          `json:"sender"`
+	Recipient string          `json:"recipient"`
+	Coin      delegationsCoin `json:"coin"`
+	Value     string          `json:"value"`
+	Hashlock  string          `json:"hashlock"`
+	Timeout   uint64          `json:"timeout"`
+}
+
+// htlc returns a hash time-locked contract which is neither claimed nor refunded
+func (s *Service) htlc(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": map[string]string{
+				"message": err.Error(),
+			},
+		})
+		return
+	}
+
+	var height uint64
+	if heightS := c.Query("height"); heightS != "" {
+		height, err = strconv.ParseUint(heightS, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": map[string]string{
+					"message": err.Error(),
+				},
+			})
+			return
+		}
+	}
+
+	cState, err := s.blockchain.GetStateForHeight(height)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": map[string]string{
+				"message": err.Error(),
+			},
+		})
+		return
+	}
+
+	htlc := cState.HTLCs().GetHTLC(id)
+	if htlc == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": map[string]string{
+				"message": "HTLC not found",
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, &htlcResponse{
+		ID:        id,
+		Sender:    htlc.Sender.String(),
+		Recipient: htlc.Recipient.String(),
+		Coin:      delegationsCoinOf(cState, htlc.Coin),
+		Value:     htlc.Amount.String(),
+		Hashlock:  hex.EncodeToString(htlc.Hashlock[:]),
+		Timeout:   htlc.Timeout,
+	})
+}
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"encoding/hex"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type multisigProposalApproval struct {
+	Address string `json:"address"`
+	Weight  uint32 `json:"weight"`
+}
+
+type multisigProposalResponse struct {
+	ID             uint64                      `json:"id"`
+	Multisig       string                      `json:"multisig"`
+	Proposer       string                      `json:"proposer"`
+	Type           uint64                      `json:"type"`
+	Data           string                      `json:"data"`
+	GasCoin        delegationsCoin             `json:"gas_coin"`
+	ExpireHeight   uint64                      `json:"expire_height"`
+	Approvals      []*multisigProposalApproval `json:"approvals"`
+	ApprovedWeight uint32                      `json:"approved_weight"`
+	Threshold      uint32                      `json:"threshold"`
+}
+
+// multisigProposal returns a proposed multisig tx and approvals collected for it
+func (s *Service) multisigProposal(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": map[string]string{
+				"message": err.Error(),
+			},
+		})
+		return
+	}
+
+	var height uint64
+	if heightS := c.Query("height"); heightS != "" {
+		height, err = strconv.ParseUint(heightS, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": map[string]string{
+					"message": err.Error(),
+				},
+			})
+			return
+		}
+	}
+
+	cState, err := s.blockchain.GetStateForHeight(height)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": map[string]string{
+				"message": err.Error(),
+			},
+		})
+		return
+	}
+
+	proposal := cState.MultisigProposals().GetProposal(id)
+	if proposal == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": map[string]string{
+				"message": "Multisig proposal not found",
+			},
+		})
+		return
+	}
+
+	multisig := cState.Accounts().GetAccount(proposal.Multisig).Multisig()
+
+	res := &multisigProposalResponse{
+		ID:           id,
+		Multisig:     proposal.Multisig.String(),
+		Proposer:     proposal.Proposer.String(),
+		Type:         uint64(proposal.TxType),
+		Data:         hex.EncodeToString(proposal.TxData),
+		GasCoin:      delegationsCoinOf(cState, proposal.GasCoin),
+		ExpireHeight: proposal.ExpireHeight,
+		Approvals:    []*multisigProposalApproval{},
+		Threshold:    multisig.Threshold,
+	}
+	for _, address := range proposal.GetApprovals() {
+		weight := multisig.GetWeight(address)
+		res.Approvals = append(res.Approvals, &multisigProposalApproval{
+			Address: address.String(),
+			Weight:  weight,
+		})
+		res.ApprovedWeight += weight
+	}
+
+	c.JSON(http.StatusOK, res)
+}
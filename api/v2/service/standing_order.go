@@ -0,0 +1,78 @@
+package service
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type standingOrderResponse struct {
+	ID         uint64          `json:"id"`
+	Owner      string          `json:"owner"`
+	Recipient  string          `json:"recipient"`
+	Coin       delegationsCoin `json:"coin"`
+	Amount     string          `json:"amount"`
+	Interval   uint64          `json:"interval"`
+	NextHeight uint64          `json:"next_height"`
+	Remaining  uint64          `json:"remaining"`
+	Failures   uint64          `json:"failures"`
+}
+
+// standingOrder returns a recurring payment and its progress
+func (s *Service) standingOrder(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": map[string]string{
+				"message": err.Error(),
+			},
+		})
+		return
+	}
+
+	var height uint64
+	if heightS := c.Query("height"); heightS != "" {
+		height, err = strconv.ParseUint(heightS, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": map[string]string{
+					"message": err.Error(),
+				},
+			})
+			return
+		}
+	}
+
+	cState, err := s.blockchain.GetStateForHeight(height)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": map[string]string{
+				"message": err.Error(),
+			},
+		})
+		return
+	}
+
+	order := cState.StandingOrders().GetOrder(id)
+	if order == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": map[string]string{
+				"message": "Standing order not found",
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, &standingOrderResponse{
+		ID:         id,
+		Owner:      order.Owner.String(),
+		Recipient:  order.Recipient.String(),
+		Coin:       delegationsCoinOf(cState, order.Coin),
+		Amount:     order.Amount.String(),
+		Interval:   order.Interval,
+		NextHeight: order.GetNextHeight(),
+		Remaining:  order.GetRemaining(),
+		Failures:   order.GetFailures(),
+	})
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/gin-gonic/gin"
+)
+
+type treasuryBalance struct {
+	Coin  delegationsCoin `json:"coin"`
+	Value string          `json:"value"`
+}
+
+type treasuryProposal struct {
+	ID          uint64          `json:"id"`
+	Proposer    string          `json:"proposer"`
+	Recipient   string          `json:"recipient"`
+	Coin        delegationsCoin `json:"coin"`
+	Value       string          `json:"value"`
+	Description string          `json:"description"`
+	VotingEnd   uint64          `json:"voting_end"`
+	Votes       []string        `json:"votes"`
+}
+
+type treasuryResponse struct {
+	Balances  []treasuryBalance  `json:"balances"`
+	Proposals []treasuryProposal `json:"proposals"`
+}
+
+// treasury returns balances of the DAO treasury and spend proposals which voting is not over
+func (s *Service) treasury(c *gin.Context) {
+	var height uint64
+	if heightS := c.Query("height"); heightS != "" {
+		var err error
+		height, err = strconv.ParseUint(heightS, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": map[string]string{
+					"message": err.Error(),
+				},
+			})
+			return
+		}
+	}
+
+	cState, err := s.blockchain.GetStateForHeight(height)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": map[string]string{
+				"message": err.Error(),
+			},
+		})
+		return
+	}
+
+	balances := cState.Treasury().GetBalances()
+	coins := make([]types.CoinID, 0, len(balances))
+	for coin := range balances {
+		coins = append(coins, coin)
+	}
+	sort.Slice(coins, func(i, j int) bool {
+		return coins[i] < coins[j]
+	})
+
+	response := &treasuryResponse{
+		Balances:  make([]treasuryBalance, 0, len(coins)),
+		Proposals: []treasuryProposal{},
+	}
+	for _, coin := range coins {
+		response.Balances = append(response.Balances, treasuryBalance{
+			Coin:  delegationsCoinOf(cState, coin),
+			Value: balances[coin].String(),
+		})
+	}
+
+	for _, proposal := range cState.Treasury().GetProposals() {
+		pubkeys := proposal.GetVotes()
+		votes := make([]string, 0, len(pubkeys))
+		for _, vote := range pubkeys {
+			votes = append(votes, vote.String())
+		}
+
+		response.Proposals = append(response.Proposals, treasuryProposal{
+			ID:          proposal.ID(),
+			Proposer:    proposal.Proposer.String(),
+			Recipient:   proposal.Recipient.String(),
+			Coin:        delegationsCoinOf(cState, proposal.Coin),
+			Value:       proposal.Amount.String(),
+			Description: proposal.Description,
+			VotingEnd:   proposal.VotingEnd,
+			Votes:       votes,
+		})
+	}
+
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,128 @@
+package service
+
+import (
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/gin-gonic/gin"
+)
+
+type vestingSchedule struct {
+	Coin        delegationsCoin `json:"coin"`
+	Value       string          `json:"value"`
+	StartHeight uint64          `json:"start_height"`
+	CliffHeight uint64          `json:"cliff_height"`
+	EndHeight   uint64          `json:"end_height"`
+	Vested      string          `json:"vested"`
+	Unvested    string          `json:"unvested"`
+}
+
+type vestingBalance struct {
+	Coin      delegationsCoin `json:"coin"`
+	Balance   string          `json:"balance"`
+	Unvested  string          `json:"unvested"`
+	Spendable string          `json:"spendable"`
+}
+
+type vestingsResponse struct {
+	Address  string             `json:"address"`
+	Height   uint64             `json:"height"`
+	Vestings []*vestingSchedule `json:"vestings"`
+	Balances []*vestingBalance  `json:"balances"`
+}
+
+// vestings returns vesting schedules of an address with vested and unvested amounts for the next block
+func (s *Service) vestings(c *gin.Context) {
+	addressS := c.Param("address")
+	if !strings.HasPrefix(strings.Title(addressS), "Mx") {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": map[string]string{
+				"message": "invalid address",
+			},
+		})
+		return
+	}
+	decodeString, err := hex.DecodeString(addressS[2:])
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": map[string]string{
+				"message": "invalid address",
+			},
+		})
+		return
+	}
+	address := types.BytesToAddress(decodeString)
+
+	var height uint64
+	if heightS := c.Query("height"); heightS != "" {
+		height, err = strconv.ParseUint(heightS, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": map[string]string{
+					"message": err.Error(),
+				},
+			})
+			return
+		}
+	}
+
+	cState, err := s.blockchain.GetStateForHeight(height)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": map[string]string{
+				"message": err.Error(),
+			},
+		})
+		return
+	}
+
+	currentHeight := height
+	if currentHeight == 0 {
+		currentHeight = s.blockchain.Height()
+	}
+
+	res := &vestingsResponse{
+		Address:  address.String(),
+		Height:   currentHeight + 1,
+		Vestings: []*vestingSchedule{},
+		Balances: []*vestingBalance{},
+	}
+
+	var coins []types.CoinID
+	for _, vesting := range cState.Accounts().GetVestings(address) {
+		res.Vestings = append(res.Vestings, &vestingSchedule{
+			Coin:        delegationsCoinOf(cState, vesting.Coin),
+			Value:       vesting.Value.String(),
+			StartHeight: vesting.StartHeight,
+			CliffHeight: vesting.CliffHeight,
+			EndHeight:   vesting.EndHeight,
+			Vested:      vesting.Vested(res.Height).String(),
+			Unvested:    vesting.Unvested(res.Height).String(),
+		})
+
+		known := false
+		for _, coin := range coins {
+			if coin == vesting.Coin {
+				known = true
+				break
+			}
+		}
+		if !known {
+			coins = append(coins, vesting.Coin)
+		}
+	}
+
+	for _, coin := range coins {
+		res.Balances = append(res.Balances, &vestingBalance{
+			Coin:      delegationsCoinOf(cState, coin),
+			Balance:   cState.Accounts().GetBalance(address, coin).String(),
+			Unvested:  cState.Accounts().GetUnvestedBalance(address, coin).String(),
+			Spendable: cState.Accounts().GetSpendableBalance(address, coin).String(),
+		})
+	}
+
+	c.JSON(http.StatusOK, res)
+}
@@ -101,6 +101,15 @@ func Run(srv *service.Service, addrGRPC, addrAPI string, logger log.Logger) erro
 	if err != nil {
 		return err
 	}
+	extended := srv.ExtendedHandlers()
+	for _, path := range service.ExtendedPaths {
+		err = gwmux.HandlePath(http.MethodGet, path, func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+			extended.ServeHTTP(w, r)
+		})
+		if err != nil {
+			return err
+		}
+	}
 
 	mux := http.NewServeMux()
 	const openapi = "/v2/openapi-ui/"
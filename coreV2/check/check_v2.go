@@ -0,0 +1,174 @@
+package check
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/crypto"
+	"github.com/MinterTeam/minter-go-node/rlp"
+)
+
+// CheckV2 is a check which can be issued by a multisig account and redeemed partially.
+//
+// MaxRedeemPerCall - maximum amount of coins to redeem at once, zero means no limit.
+// Multisig - address of the issuing multisig, empty address means the check is issued by the single signer.
+// Signatures - signatures of the issuer or of the multisig members.
+type CheckV2 struct {
+	Nonce            []byte
+	ChainID          types.ChainID
+	DueBlock         uint64
+	Coin             types.CoinID
+	Value            *big.Int
+	GasCoin          types.CoinID
+	MaxRedeemPerCall *big.Int
+	Multisig         types.Address
+	Lock             *big.Int
+	Signatures       []Signature
+}
+
+// Signature is a signature of the check issuer
+type Signature struct {
+	V *big.Int
+	R *big.Int
+	S *big.Int
+}
+
+// IsMultisig returns true if the check is issued by a multisig account
+func (check *CheckV2) IsMultisig() bool {
+	return check.Multisig != types.Address{}
+}
+
+// Signers returns addresses recovered from signatures of the check
+func (check *CheckV2) Signers() ([]types.Address, error) {
+	hash := check.Hash()
+	signers := make([]types.Address, 0, len(check.Signatures))
+	for _, sig := range check.Signatures {
+		signer, err := recoverPlain(hash, sig.R, sig.S, sig.V)
+		if err != nil {
+			return nil, err
+		}
+		signers = append(signers, signer)
+	}
+
+	return signers, nil
+}
+
+// Sender returns address of the check issuer, weights of multisig signers should be checked against the state
+func (check *CheckV2) Sender() (types.Address, error) {
+	if check.IsMultisig() {
+		return check.Multisig, nil
+	}
+
+	if len(check.Signatures) != 1 {
+		return types.Address{}, errors.New("incorrect check signature")
+	}
+
+	signers, err := check.Signers()
+	if err != nil {
+		return types.Address{}, err
+	}
+
+	return signers[0], nil
+}
+
+// LockPubKey returns bytes of public key, which is used for proving check's recipient rights
+func (check *CheckV2) LockPubKey() ([]byte, error) {
+	sig := check.Lock.Bytes()
+
+	if len(sig) < 65 {
+		sig = append(make([]byte, 65-len(sig)), sig...)
+	}
+
+	hash := check.HashWithoutLock()
+
+	pub, err := crypto.Ecrecover(hash[:], sig)
+	if err != nil {
+		return nil, err
+	}
+	if len(pub) == 0 || pub[0] != 4 {
+		return nil, errors.New("invalid public key")
+	}
+
+	return pub, nil
+}
+
+// HashWithoutLock returns a types.Hash to be used in process of signing and checking Lock
+func (check *CheckV2) HashWithoutLock() types.Hash {
+	return rlpHash([]interface{}{
+		check.Nonce,
+		check.ChainID,
+		check.DueBlock,
+		check.Coin,
+		check.Value,
+		check.GasCoin,
+		check.MaxRedeemPerCall,
+		check.Multisig,
+	})
+}
+
+// Hash returns a types.Hash to be used in process of signing a Check by issuer
+func (check *CheckV2) Hash() types.Hash {
+	return rlpHash([]interface{}{
+		check.Nonce,
+		check.ChainID,
+		check.DueBlock,
+		check.Coin,
+		check.Value,
+		check.GasCoin,
+		check.MaxRedeemPerCall,
+		check.Multisig,
+		check.Lock,
+	})
+}
+
+// Sign adds a signature of the given private key to the check, returns error
+func (check *CheckV2) Sign(prv *ecdsa.PrivateKey) error {
+	h := check.Hash()
+	sig, err := crypto.Sign(h[:], prv)
+	if err != nil {
+		return err
+	}
+
+	check.Signatures = append(check.Signatures, Signature{
+		R: new(big.Int).SetBytes(sig[:32]),
+		S: new(big.Int).SetBytes(sig[32:64]),
+		V: new(big.Int).SetBytes([]byte{sig[64] + 27}),
+	})
+
+	return nil
+}
+
+func (check *CheckV2) String() string {
+	sender, _ := check.Sender()
+
+	return fmt.Sprintf("Check sender: %s nonce: %x, dueBlock: %d, value: %s %s", sender.String(), check.Nonce,
+		check.DueBlock, check.Value.String(), check.Coin.String())
+}
+
+// DecodeV2FromBytes decodes check v2 from bytes
+func DecodeV2FromBytes(buf []byte) (*CheckV2, error) {
+	var check CheckV2
+	err := rlp.DecodeBytes(buf, &check)
+	if err != nil {
+		return nil, err
+	}
+
+	if check.Value == nil || check.MaxRedeemPerCall == nil || check.Lock == nil {
+		return nil, errors.New("incorrect check data")
+	}
+
+	if len(check.Signatures) == 0 {
+		return nil, errors.New("incorrect check signature")
+	}
+
+	for _, sig := range check.Signatures {
+		if sig.S == nil || sig.R == nil || sig.V == nil {
+			return nil, errors.New("incorrect check signature")
+		}
+	}
+
+	return &check, nil
+}
@@ -30,6 +30,35 @@ const (
 	WrongUpdateVersionName       uint32 = 122
 	WrongDueHeight               uint32 = 123
 	Unavailable                  uint32 = 124
+	WrongVestingSchedule         uint32 = 125
+	WrongBatch                   uint32 = 127
+	WrongSponsorship             uint32 = 128
+	TooHighSponsoredFee          uint32 = 129
+	IsNotCheckIssuer             uint32 = 130
+	WrongCheckRedeemValue        uint32 = 131
+	WrongStandingOrderSchedule   uint32 = 132
+	StandingOrderNotExists       uint32 = 133
+	IsNotOwnerOfStandingOrder    uint32 = 134
+	HTLCNotExists                uint32 = 135
+	WrongHTLCTimeout             uint32 = 136
+	WrongHTLCPreimage            uint32 = 137
+	KeyIsNotAuthorized           uint32 = 138
+	IsNotKeyGuardian             uint32 = 139
+	TxTypeForbiddenByPolicy      uint32 = 140
+	RecipientNotAllowedByPolicy  uint32 = 141
+	PolicyLimitExceeded          uint32 = 142
+	WrongAccountPolicy           uint32 = 143
+	WrongCoinMetadata            uint32 = 144
+	CoinIsPaused                 uint32 = 145
+	AddressIsFrozen              uint32 = 146
+	CoinIsNotFreezable           uint32 = 147
+	WrongTransferFee             uint32 = 148
+	WrongDistribution            uint32 = 149
+	WrongParamProposal           uint32 = 150
+	ParamProposalNotExists       uint32 = 151
+	WrongTreasuryProposal        uint32 = 152
+	TreasuryProposalNotExists    uint32 = 153
+	WrongDelegatorVote           uint32 = 154
 
 	// coin creation
 	CoinHasNotReserve uint32 = 200
@@ -66,6 +95,11 @@ const (
 	TooBigStake           uint32 = 415
 	UnbondBlocked         uint32 = 416
 	EqualPubKey           uint32 = 417
+	DelegatorNotAllowed   uint32 = 418
+	TooLowDelegation      uint32 = 419
+	StakeLimitReached     uint32 = 420
+	TooLargeAllowList     uint32 = 421
+	StakeInRedelegation   uint32 = 422
 
 	// check
 	CheckInvalidLock uint32 = 501
@@ -85,6 +119,11 @@ const (
 	DifferentCountAddressesAndWeights uint32 = 607
 	IncorrectTotalWeights             uint32 = 608
 	NotEnoughMultisigVotes            uint32 = 609
+	IsNotMultisigMember               uint32 = 610
+	MultisigProposalNotExists         uint32 = 611
+	MultisigProposalAlreadyApproved   uint32 = 612
+	WrongMultisigProposalExpireHeight uint32 = 613
+	WrongMultisigProposalTxType       uint32 = 614
 
 	// swap pool
 	SwapPoolUnknown              uint32 = 700
@@ -940,3 +979,400 @@ func NewEqualPubKey(pubKey string) *equalPubKey {
 		PublicKey: pubKey,
 	}
 }
+
+type delegatorNotAllowed struct {
+	Code      string `json:"code,omitempty"`
+	Sender    string `json:"sender,omitempty"`
+	PublicKey string `json:"public_key,omitempty"`
+}
+
+func NewDelegatorNotAllowed(sender string, pubKey string) *delegatorNotAllowed {
+	return &delegatorNotAllowed{Code: strconv.Itoa(int(DelegatorNotAllowed)), Sender: sender, PublicKey: pubKey}
+}
+
+type tooLowDelegation struct {
+	Code          string `json:"code,omitempty"`
+	PublicKey     string `json:"public_key,omitempty"`
+	Value         string `json:"value,omitempty"`
+	CoinSymbol    string `json:"coin_symbol,omitempty"`
+	CoinId        string `json:"coin_id,omitempty"`
+	MinDelegation string `json:"min_delegation,omitempty"`
+}
+
+func NewTooLowDelegation(pubKey string, value string, coinId string, coinSymbol string, minDelegation string) *tooLowDelegation {
+	return &tooLowDelegation{Code: strconv.Itoa(int(TooLowDelegation)), PublicKey: pubKey, Value: value, CoinId: coinId, CoinSymbol: coinSymbol, MinDelegation: minDelegation}
+}
+
+type stakeLimitReached struct {
+	Code       string `json:"code,omitempty"`
+	PublicKey  string `json:"public_key,omitempty"`
+	Value      string `json:"value,omitempty"`
+	CoinSymbol string `json:"coin_symbol,omitempty"`
+	CoinId     string `json:"coin_id,omitempty"`
+	MaxStake   string `json:"max_stake,omitempty"`
+}
+
+func NewStakeLimitReached(pubKey string, value string, coinId string, coinSymbol string, maxStake string) *stakeLimitReached {
+	return &stakeLimitReached{Code: strconv.Itoa(int(StakeLimitReached)), PublicKey: pubKey, Value: value, CoinId: coinId, CoinSymbol: coinSymbol, MaxStake: maxStake}
+}
+
+type tooLargeAllowList struct {
+	Code            string `json:"code,omitempty"`
+	CountAddresses  string `json:"count_addresses,omitempty"`
+	MaxCountAddress string `json:"max_count_addresses,omitempty"`
+}
+
+func NewTooLargeAllowList(count string, max string) *tooLargeAllowList {
+	return &tooLargeAllowList{Code: strconv.Itoa(int(TooLargeAllowList)), CountAddresses: count, MaxCountAddress: max}
+}
+
+type stakeInRedelegation struct {
+	Code              string `json:"code,omitempty"`
+	PublicKey         string `json:"public_key,omitempty"`
+	Sender            string `json:"sender,omitempty"`
+	CoinSymbol        string `json:"coin_symbol,omitempty"`
+	CoinId            string `json:"coin_id,omitempty"`
+	RedelegatingValue string `json:"redelegating_value,omitempty"`
+	AvailableValue    string `json:"available_value,omitempty"`
+	NeededValue       string `json:"needed_value,omitempty"`
+}
+
+func NewStakeInRedelegation(pubKey string, sender string, coinId string, coinSymbol string, redelegatingValue string, availableValue string, neededValue string) *stakeInRedelegation {
+	return &stakeInRedelegation{Code: strconv.Itoa(int(StakeInRedelegation)), PublicKey: pubKey, Sender: sender, CoinId: coinId, CoinSymbol: coinSymbol, RedelegatingValue: redelegatingValue, AvailableValue: availableValue, NeededValue: neededValue}
+}
+
+type isNotMultisigMember struct {
+	Code     string `json:"code,omitempty"`
+	Multisig string `json:"multisig,omitempty"`
+	Address  string `json:"address,omitempty"`
+}
+
+func NewIsNotMultisigMember(multisig string, address string) *isNotMultisigMember {
+	return &isNotMultisigMember{Code: strconv.Itoa(int(IsNotMultisigMember)), Multisig: multisig, Address: address}
+}
+
+type multisigProposalNotExists struct {
+	Code string `json:"code,omitempty"`
+	ID   string `json:"id,omitempty"`
+}
+
+func NewMultisigProposalNotExists(id string) *multisigProposalNotExists {
+	return &multisigProposalNotExists{Code: strconv.Itoa(int(MultisigProposalNotExists)), ID: id}
+}
+
+type multisigProposalAlreadyApproved struct {
+	Code    string `json:"code,omitempty"`
+	ID      string `json:"id,omitempty"`
+	Address string `json:"address,omitempty"`
+}
+
+func NewMultisigProposalAlreadyApproved(id string, address string) *multisigProposalAlreadyApproved {
+	return &multisigProposalAlreadyApproved{Code: strconv.Itoa(int(MultisigProposalAlreadyApproved)), ID: id, Address: address}
+}
+
+type wrongMultisigProposalExpireHeight struct {
+	Code          string `json:"code,omitempty"`
+	ExpireHeight  string `json:"expire_height,omitempty"`
+	CurrentHeight string `json:"current_height,omitempty"`
+}
+
+func NewWrongMultisigProposalExpireHeight(expireHeight string, currentHeight string) *wrongMultisigProposalExpireHeight {
+	return &wrongMultisigProposalExpireHeight{Code: strconv.Itoa(int(WrongMultisigProposalExpireHeight)), ExpireHeight: expireHeight, CurrentHeight: currentHeight}
+}
+
+type wrongMultisigProposalTxType struct {
+	Code   string `json:"code,omitempty"`
+	TxType string `json:"tx_type,omitempty"`
+}
+
+func NewWrongMultisigProposalTxType(txType string) *wrongMultisigProposalTxType {
+	return &wrongMultisigProposalTxType{Code: strconv.Itoa(int(WrongMultisigProposalTxType)), TxType: txType}
+}
+
+type wrongVestingSchedule struct {
+	Code          string `json:"code,omitempty"`
+	StartHeight   string `json:"start_height,omitempty"`
+	CliffHeight   string `json:"cliff_height,omitempty"`
+	EndHeight     string `json:"end_height,omitempty"`
+	CurrentHeight string `json:"current_height,omitempty"`
+}
+
+func NewWrongVestingSchedule(startHeight string, cliffHeight string, endHeight string, currentHeight string) *wrongVestingSchedule {
+	return &wrongVestingSchedule{Code: strconv.Itoa(int(WrongVestingSchedule)), StartHeight: startHeight, CliffHeight: cliffHeight, EndHeight: endHeight, CurrentHeight: currentHeight}
+}
+
+type wrongBatch struct {
+	Code   string `json:"code,omitempty"`
+	Index  string `json:"index,omitempty"`
+	TxType string `json:"tx_type,omitempty"`
+}
+
+func NewWrongBatch(index string, txType string) *wrongBatch {
+	return &wrongBatch{Code: strconv.Itoa(int(WrongBatch)), Index: index, TxType: txType}
+}
+
+type wrongSponsorship struct {
+	Code    string `json:"code,omitempty"`
+	Sponsor string `json:"sponsor,omitempty"`
+	TxType  string `json:"tx_type,omitempty"`
+}
+
+func NewWrongSponsorship(sponsor string, txType string) *wrongSponsorship {
+	return &wrongSponsorship{Code: strconv.Itoa(int(WrongSponsorship)), Sponsor: sponsor, TxType: txType}
+}
+
+type tooHighSponsoredFee struct {
+	Code   string `json:"code,omitempty"`
+	MaxFee string `json:"max_fee,omitempty"`
+	Fee    string `json:"fee,omitempty"`
+}
+
+func NewTooHighSponsoredFee(maxFee string, fee string) *tooHighSponsoredFee {
+	return &tooHighSponsoredFee{Code: strconv.Itoa(int(TooHighSponsoredFee)), MaxFee: maxFee, Fee: fee}
+}
+
+type isNotCheckIssuer struct {
+	Code   string `json:"code,omitempty"`
+	Sender string `json:"sender,omitempty"`
+	Issuer string `json:"issuer,omitempty"`
+}
+
+func NewIsNotCheckIssuer(sender string, issuer string) *isNotCheckIssuer {
+	return &isNotCheckIssuer{Code: strconv.Itoa(int(IsNotCheckIssuer)), Sender: sender, Issuer: issuer}
+}
+
+type wrongCheckRedeemValue struct {
+	Code      string `json:"code,omitempty"`
+	Value     string `json:"value,omitempty"`
+	Remaining string `json:"remaining,omitempty"`
+	MaxValue  string `json:"max_value,omitempty"`
+}
+
+func NewWrongCheckRedeemValue(value string, remaining string, maxValue string) *wrongCheckRedeemValue {
+	return &wrongCheckRedeemValue{Code: strconv.Itoa(int(WrongCheckRedeemValue)), Value: value, Remaining: remaining, MaxValue: maxValue}
+}
+
+type wrongStandingOrderSchedule struct {
+	Code     string `json:"code,omitempty"`
+	Interval string `json:"interval,omitempty"`
+	Count    string `json:"count,omitempty"`
+}
+
+func NewWrongStandingOrderSchedule(interval string, count string) *wrongStandingOrderSchedule {
+	return &wrongStandingOrderSchedule{Code: strconv.Itoa(int(WrongStandingOrderSchedule)), Interval: interval, Count: count}
+}
+
+type standingOrderNotExists struct {
+	Code string `json:"code,omitempty"`
+	ID   string `json:"id,omitempty"`
+}
+
+func NewStandingOrderNotExists(id string) *standingOrderNotExists {
+	return &standingOrderNotExists{Code: strconv.Itoa(int(StandingOrderNotExists)), ID: id}
+}
+
+type isNotOwnerOfStandingOrder struct {
+	Code   string `json:"code,omitempty"`
+	ID     string `json:"id,omitempty"`
+	Sender string `json:"sender,omitempty"`
+}
+
+func NewIsNotOwnerOfStandingOrder(id string, sender string) *isNotOwnerOfStandingOrder {
+	return &isNotOwnerOfStandingOrder{Code: strconv.Itoa(int(IsNotOwnerOfStandingOrder)), ID: id, Sender: sender}
+}
+
+type htlcNotExists struct {
+	Code string `json:"code,omitempty"`
+	ID   string `json:"id,omitempty"`
+}
+
+func NewHTLCNotExists(id string) *htlcNotExists {
+	return &htlcNotExists{Code: strconv.Itoa(int(HTLCNotExists)), ID: id}
+}
+
+type wrongHTLCTimeout struct {
+	Code         string `json:"code,omitempty"`
+	Timeout      string `json:"timeout,omitempty"`
+	CurrentBlock string `json:"current_block,omitempty"`
+}
+
+func NewWrongHTLCTimeout(timeout string, currentBlock string) *wrongHTLCTimeout {
+	return &wrongHTLCTimeout{Code: strconv.Itoa(int(WrongHTLCTimeout)), Timeout: timeout, CurrentBlock: currentBlock}
+}
+
+type wrongHTLCPreimage struct {
+	Code     string `json:"code,omitempty"`
+	Hashlock string `json:"hashlock,omitempty"`
+}
+
+func NewWrongHTLCPreimage(hashlock string) *wrongHTLCPreimage {
+	return &wrongHTLCPreimage{Code: strconv.Itoa(int(WrongHTLCPreimage)), Hashlock: hashlock}
+}
+
+type keyIsNotAuthorized struct {
+	Code    string `json:"code,omitempty"`
+	Account string `json:"account,omitempty"`
+	Signer  string `json:"signer,omitempty"`
+}
+
+func NewKeyIsNotAuthorized(account string, signer string) *keyIsNotAuthorized {
+	return &keyIsNotAuthorized{Code: strconv.Itoa(int(KeyIsNotAuthorized)), Account: account, Signer: signer}
+}
+
+type isNotKeyGuardian struct {
+	Code    string `json:"code,omitempty"`
+	Account string `json:"account,omitempty"`
+	Sender  string `json:"sender,omitempty"`
+}
+
+func NewIsNotKeyGuardian(account string, sender string) *isNotKeyGuardian {
+	return &isNotKeyGuardian{Code: strconv.Itoa(int(IsNotKeyGuardian)), Account: account, Sender: sender}
+}
+
+type txTypeForbiddenByPolicy struct {
+	Code    string `json:"code,omitempty"`
+	Address string `json:"address,omitempty"`
+	TxType  string `json:"tx_type,omitempty"`
+}
+
+func NewTxTypeForbiddenByPolicy(address string, txType string) *txTypeForbiddenByPolicy {
+	return &txTypeForbiddenByPolicy{Code: strconv.Itoa(int(TxTypeForbiddenByPolicy)), Address: address, TxType: txType}
+}
+
+type recipientNotAllowedByPolicy struct {
+	Code      string `json:"code,omitempty"`
+	Address   string `json:"address,omitempty"`
+	Recipient string `json:"recipient,omitempty"`
+}
+
+func NewRecipientNotAllowedByPolicy(address string, recipient string) *recipientNotAllowedByPolicy {
+	return &recipientNotAllowedByPolicy{Code: strconv.Itoa(int(RecipientNotAllowedByPolicy)), Address: address, Recipient: recipient}
+}
+
+type policyLimitExceeded struct {
+	Code    string `json:"code,omitempty"`
+	Address string `json:"address,omitempty"`
+	CoinID  string `json:"coin_id,omitempty"`
+	Limit   string `json:"limit,omitempty"`
+	Spent   string `json:"spent,omitempty"`
+}
+
+func NewPolicyLimitExceeded(address string, coinID string, limit string, spent string) *policyLimitExceeded {
+	return &policyLimitExceeded{Code: strconv.Itoa(int(PolicyLimitExceeded)), Address: address, CoinID: coinID, Limit: limit, Spent: spent}
+}
+
+type wrongAccountPolicy struct {
+	Code   string `json:"code,omitempty"`
+	Reason string `json:"reason,omitempty"`
+}
+
+func NewWrongAccountPolicy(reason string) *wrongAccountPolicy {
+	return &wrongAccountPolicy{Code: strconv.Itoa(int(WrongAccountPolicy)), Reason: reason}
+}
+
+type wrongCoinMetadata struct {
+	Code   string `json:"code,omitempty"`
+	CoinID string `json:"coin_id,omitempty"`
+	Reason string `json:"reason,omitempty"`
+}
+
+func NewWrongCoinMetadata(coinID string, reason string) *wrongCoinMetadata {
+	return &wrongCoinMetadata{Code: strconv.Itoa(int(WrongCoinMetadata)), CoinID: coinID, Reason: reason}
+}
+
+type coinIsPaused struct {
+	Code   string `json:"code,omitempty"`
+	CoinID string `json:"coin_id,omitempty"`
+}
+
+func NewCoinIsPaused(coinID string) *coinIsPaused {
+	return &coinIsPaused{Code: strconv.Itoa(int(CoinIsPaused)), CoinID: coinID}
+}
+
+type addressIsFrozen struct {
+	Code    string `json:"code,omitempty"`
+	CoinID  string `json:"coin_id,omitempty"`
+	Address string `json:"address,omitempty"`
+}
+
+func NewAddressIsFrozen(coinID string, address string) *addressIsFrozen {
+	return &addressIsFrozen{Code: strconv.Itoa(int(AddressIsFrozen)), CoinID: coinID, Address: address}
+}
+
+type coinIsNotFreezable struct {
+	Code   string `json:"code,omitempty"`
+	CoinID string `json:"coin_id,omitempty"`
+}
+
+func NewCoinIsNotFreezable(coinID string) *coinIsNotFreezable {
+	return &coinIsNotFreezable{Code: strconv.Itoa(int(CoinIsNotFreezable)), CoinID: coinID}
+}
+
+type wrongTransferFee struct {
+	Code   string `json:"code,omitempty"`
+	CoinID string `json:"coin_id,omitempty"`
+	Reason string `json:"reason,omitempty"`
+}
+
+func NewWrongTransferFee(coinID string, reason string) *wrongTransferFee {
+	return &wrongTransferFee{Code: strconv.Itoa(int(WrongTransferFee)), CoinID: coinID, Reason: reason}
+}
+
+type wrongDistribution struct {
+	Code         string `json:"code,omitempty"`
+	HolderCoinID string `json:"holder_coin_id,omitempty"`
+	Reason       string `json:"reason,omitempty"`
+}
+
+func NewWrongDistribution(holderCoinID string, reason string) *wrongDistribution {
+	return &wrongDistribution{Code: strconv.Itoa(int(WrongDistribution)), HolderCoinID: holderCoinID, Reason: reason}
+}
+
+type wrongParamProposal struct {
+	Code   string `json:"code,omitempty"`
+	Key    string `json:"key,omitempty"`
+	Value  string `json:"value,omitempty"`
+	Reason string `json:"reason,omitempty"`
+}
+
+func NewWrongParamProposal(key string, value string, reason string) *wrongParamProposal {
+	return &wrongParamProposal{Code: strconv.Itoa(int(WrongParamProposal)), Key: key, Value: value, Reason: reason}
+}
+
+type paramProposalNotExists struct {
+	Code string `json:"code,omitempty"`
+	ID   string `json:"id,omitempty"`
+}
+
+func NewParamProposalNotExists(id string) *paramProposalNotExists {
+	return &paramProposalNotExists{Code: strconv.Itoa(int(ParamProposalNotExists)), ID: id}
+}
+
+type wrongTreasuryProposal struct {
+	Code   string `json:"code,omitempty"`
+	Reason string `json:"reason,omitempty"`
+}
+
+func NewWrongTreasuryProposal(reason string) *wrongTreasuryProposal {
+	return &wrongTreasuryProposal{Code: strconv.Itoa(int(WrongTreasuryProposal)), Reason: reason}
+}
+
+type treasuryProposalNotExists struct {
+	Code string `json:"code,omitempty"`
+	ID   string `json:"id,omitempty"`
+}
+
+func NewTreasuryProposalNotExists(id string) *treasuryProposalNotExists {
+	return &treasuryProposalNotExists{Code: strconv.Itoa(int(TreasuryProposalNotExists)), ID: id}
+}
+
+type wrongDelegatorVote struct {
+	Code   string `json:"code,omitempty"`
+	Kind   string `json:"kind,omitempty"`
+	Height string `json:"height,omitempty"`
+	Reason string `json:"reason,omitempty"`
+}
+
+func NewWrongDelegatorVote(kind string, height string, reason string) *wrongDelegatorVote {
+	return &wrongDelegatorVote{Code: strconv.Itoa(int(WrongDelegatorVote)), Kind: kind, Height: height, Reason: reason}
+}
@@ -32,6 +32,22 @@ func init() {
 	tmjson.RegisterType(&RemoveCandidateEvent{}, TypeRemoveCandidateEvent)
 	tmjson.RegisterType(&UpdatedBlockRewardEvent{}, TypeUpdatedBlockRewardEvent)
 	tmjson.RegisterType(&UnlockEvent{}, TypeUnlockEvent)
+	tmjson.RegisterType(&CommissionChangeAnnouncedEvent{}, TypeCommissionChangeAnnouncedEvent)
+	tmjson.RegisterType(&CommissionChangedEvent{}, TypeCommissionChangedEvent)
+	tmjson.RegisterType(&StandingOrderPaymentEvent{}, TypeStandingOrderPaymentEvent)
+	tmjson.RegisterType(&HTLCClaimedEvent{}, TypeHTLCClaimedEvent)
+	tmjson.RegisterType(&HTLCRefundedEvent{}, TypeHTLCRefundedEvent)
+	tmjson.RegisterType(&TokenPausedEvent{}, TypeTokenPausedEvent)
+	tmjson.RegisterType(&TokenUnpausedEvent{}, TypeTokenUnpausedEvent)
+	tmjson.RegisterType(&AddressFrozenEvent{}, TypeAddressFrozenEvent)
+	tmjson.RegisterType(&AddressUnfrozenEvent{}, TypeAddressUnfrozenEvent)
+	tmjson.RegisterType(&DistributionPaymentEvent{}, TypeDistributionPaymentEvent)
+	tmjson.RegisterType(&DistributionCompletedEvent{}, TypeDistributionCompletedEvent)
+	tmjson.RegisterType(&ParamProposalTalliedEvent{}, TypeParamProposalTalliedEvent)
+	tmjson.RegisterType(&ParamChangedEvent{}, TypeParamChangedEvent)
+	tmjson.RegisterType(&TreasuryIncomeEvent{}, TypeTreasuryIncomeEvent)
+	tmjson.RegisterType(&TreasurySpendEvent{}, TypeTreasurySpendEvent)
+	tmjson.RegisterType(&TreasurySpendFailedEvent{}, TypeTreasurySpendFailedEvent)
 }
 
 // IEventsDB is an interface of Events
@@ -21,6 +21,28 @@ const (
 	TypeOrderExpiredEvent       = "minter/OrderExpiredEvent"
 	TypeRemoveCandidateEvent    = "minter/RemoveCandidateEvent"
 	TypeUpdatedBlockRewardEvent = "minter/UpdatedBlockRewardEvent"
+
+	TypeCommissionChangeAnnouncedEvent = "minter/CommissionChangeAnnouncedEvent"
+	TypeCommissionChangedEvent         = "minter/CommissionChangedEvent"
+
+	TypeStandingOrderPaymentEvent = "minter/StandingOrderPaymentEvent"
+	TypeHTLCClaimedEvent          = "minter/HTLCClaimedEvent"
+	TypeHTLCRefundedEvent         = "minter/HTLCRefundedEvent"
+
+	TypeTokenPausedEvent     = "minter/TokenPausedEvent"
+	TypeTokenUnpausedEvent   = "minter/TokenUnpausedEvent"
+	TypeAddressFrozenEvent   = "minter/AddressFrozenEvent"
+	TypeAddressUnfrozenEvent = "minter/AddressUnfrozenEvent"
+
+	TypeDistributionPaymentEvent   = "minter/DistributionPaymentEvent"
+	TypeDistributionCompletedEvent = "minter/DistributionCompletedEvent"
+
+	TypeParamProposalTalliedEvent = "minter/ParamProposalTalliedEvent"
+	TypeParamChangedEvent         = "minter/ParamChangedEvent"
+
+	TypeTreasuryIncomeEvent      = "minter/TreasuryIncomeEvent"
+	TypeTreasurySpendEvent       = "minter/TreasurySpendEvent"
+	TypeTreasurySpendFailedEvent = "minter/TreasurySpendFailedEvent"
 )
 
 type Stake interface {
@@ -667,3 +689,178 @@ type UpdatedBlockRewardEvent struct {
 func (pe *UpdatedBlockRewardEvent) Type() string {
 	return TypeUpdatedBlockRewardEvent
 }
+
+type CommissionChangeAnnouncedEvent struct {
+	CandidatePubKey types.Pubkey `json:"candidate_pub_key"`
+	OldCommission   uint32       `json:"old_commission"`
+	NewCommission   uint32       `json:"new_commission"`
+	EffectiveHeight uint64       `json:"effective_height"`
+}
+
+func (ce *CommissionChangeAnnouncedEvent) Type() string {
+	return TypeCommissionChangeAnnouncedEvent
+}
+
+type CommissionChangedEvent struct {
+	CandidatePubKey types.Pubkey `json:"candidate_pub_key"`
+	OldCommission   uint32       `json:"old_commission"`
+	NewCommission   uint32       `json:"new_commission"`
+}
+
+func (ce *CommissionChangedEvent) Type() string {
+	return TypeCommissionChangedEvent
+}
+
+type StandingOrderPaymentEvent struct {
+	ID          uint64        `json:"id"`
+	Owner       types.Address `json:"owner"`
+	Recipient   types.Address `json:"recipient"`
+	Coin        uint64        `json:"coin"`
+	Amount      string        `json:"amount"`
+	TransferFee string        `json:"transfer_fee,omitempty"`
+}
+
+func (se *StandingOrderPaymentEvent) Type() string {
+	return TypeStandingOrderPaymentEvent
+}
+
+type HTLCClaimedEvent struct {
+	ID        uint64        `json:"id"`
+	Hashlock  string        `json:"hashlock"`
+	Preimage  string        `json:"preimage"`
+	Sender    types.Address `json:"sender"`
+	Recipient types.Address `json:"recipient"`
+	Coin      uint64        `json:"coin"`
+	Amount    string        `json:"amount"`
+}
+
+func (he *HTLCClaimedEvent) Type() string {
+	return TypeHTLCClaimedEvent
+}
+
+type HTLCRefundedEvent struct {
+	ID       uint64        `json:"id"`
+	Hashlock string        `json:"hashlock"`
+	Sender   types.Address `json:"sender"`
+	Coin     uint64        `json:"coin"`
+	Amount   string        `json:"amount"`
+}
+
+func (he *HTLCRefundedEvent) Type() string {
+	return TypeHTLCRefundedEvent
+}
+
+type TokenPausedEvent struct {
+	Coin uint64 `json:"coin"`
+}
+
+func (te *TokenPausedEvent) Type() string {
+	return TypeTokenPausedEvent
+}
+
+type TokenUnpausedEvent struct {
+	Coin uint64 `json:"coin"`
+}
+
+func (te *TokenUnpausedEvent) Type() string {
+	return TypeTokenUnpausedEvent
+}
+
+type AddressFrozenEvent struct {
+	Coin    uint64        `json:"coin"`
+	Address types.Address `json:"address"`
+}
+
+func (ae *AddressFrozenEvent) Type() string {
+	return TypeAddressFrozenEvent
+}
+
+type AddressUnfrozenEvent struct {
+	Coin    uint64        `json:"coin"`
+	Address types.Address `json:"address"`
+}
+
+func (ae *AddressUnfrozenEvent) Type() string {
+	return TypeAddressUnfrozenEvent
+}
+
+type DistributionPaymentEvent struct {
+	ID        uint64        `json:"id"`
+	Recipient types.Address `json:"recipient"`
+	Coin      uint64        `json:"coin"`
+	Amount    string        `json:"amount"`
+}
+
+func (de *DistributionPaymentEvent) Type() string {
+	return TypeDistributionPaymentEvent
+}
+
+type DistributionCompletedEvent struct {
+	ID     uint64        `json:"id"`
+	Owner  types.Address `json:"owner"`
+	Coin   uint64        `json:"coin"`
+	Paid   string        `json:"paid"`
+	Refund string        `json:"refund"`
+}
+
+func (de *DistributionCompletedEvent) Type() string {
+	return TypeDistributionCompletedEvent
+}
+
+type ParamProposalTalliedEvent struct {
+	ID           uint64 `json:"id"`
+	Key          string `json:"key"`
+	Value        uint64 `json:"value"`
+	TargetHeight uint64 `json:"target_height"`
+	VotedPower   string `json:"voted_power"`
+	TotalPower   string `json:"total_power"`
+	Passed       bool   `json:"passed"`
+}
+
+func (pe *ParamProposalTalliedEvent) Type() string {
+	return TypeParamProposalTalliedEvent
+}
+
+type ParamChangedEvent struct {
+	ID    uint64 `json:"id"`
+	Key   string `json:"key"`
+	Value uint64 `json:"value"`
+}
+
+func (pe *ParamChangedEvent) Type() string {
+	return TypeParamChangedEvent
+}
+
+type TreasuryIncomeEvent struct {
+	Coin            uint64       `json:"coin"`
+	Amount          string       `json:"amount"`
+	ValidatorPubKey types.Pubkey `json:"validator_pub_key"`
+}
+
+func (te *TreasuryIncomeEvent) Type() string {
+	return TypeTreasuryIncomeEvent
+}
+
+type TreasurySpendEvent struct {
+	ID          uint64        `json:"id"`
+	Recipient   types.Address `json:"recipient"`
+	Coin        uint64        `json:"coin"`
+	Amount      string        `json:"amount"`
+	Description string        `json:"description"`
+}
+
+func (te *TreasurySpendEvent) Type() string {
+	return TypeTreasurySpendEvent
+}
+
+type TreasurySpendFailedEvent struct {
+	ID        uint64        `json:"id"`
+	Recipient types.Address `json:"recipient"`
+	Coin      uint64        `json:"coin"`
+	Amount    string        `json:"amount"`
+	Reason    string        `json:"reason"`
+}
+
+func (te *TreasurySpendFailedEvent) Type() string {
+	return TypeTreasurySpendFailedEvent
+}
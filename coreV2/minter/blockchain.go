@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/MinterTeam/minter-go-node/coreV2/state/candidates"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/delegatorvotes"
 	"github.com/MinterTeam/minter-go-node/helpers"
 	"github.com/cosmos/cosmos-sdk/snapshots"
 	snapshottypes "github.com/cosmos/cosmos-sdk/snapshots/types"
@@ -50,7 +51,14 @@ const (
 	minMaxGas     = 5000
 )
 
-const votingPowerConsensus = 2. / 3.
+// VotingPowerConsensus is the share of the validators voting power needed to accept halt, commission and update votes
+const VotingPowerConsensus = 2. / 3.
+
+// maxStandingOrdersPerBlock limits the number of standing order payments in EndBlock, the rest are postponed to the next block
+const maxStandingOrdersPerBlock = 1000
+
+// maxDistributionPaymentsPerBlock limits the number of holders paid by distributions in EndBlock, the rest are paid in the next blocks
+const maxDistributionPaymentsPerBlock = 1000
 
 // Blockchain is a main structure of Minter
 type Blockchain struct {
@@ -162,6 +170,7 @@ func NewMinterBlockchain(storages *utils.Storage, cfg *config.Config, ctx contex
 			V320: {},
 			V330: {},
 			V340: {}, // TODO: Only for release version
+			V350: {}, // indexes of delegators and holders
 		},
 		executor: GetExecutor(V3),
 	}
@@ -177,6 +186,8 @@ func graceForUpdate(height uint64) *upgrades.GracePeriod {
 
 func GetExecutor(v string) transaction.ExecutorTx {
 	switch v {
+	case V350:
+		return transaction.NewExecutorV350(transaction.GetDataV350)
 	//case V3:
 	//	return transaction.NewExecutorV3(transaction.GetDataV3)
 	//case v260, v261, v262:
@@ -196,8 +207,21 @@ const ( // known update versions
 	V320 = "v320" // hotfix
 	V330 = "v330" // hotfix
 	V340 = "v340" // hotfix
+	V350 = "v350" // indexes of delegators and holders
 )
 
+// migrate fills state indexes introduced by the update from the existing state
+func (blockchain *Blockchain) migrate(version string) {
+	switch version {
+	case V350:
+		blockchain.stateDeliver.Candidates.EnableDelegatorsIndex()
+		blockchain.stateDeliver.Candidates.ReindexDelegators()
+		blockchain.stateDeliver.Accounts.EnableHoldersIndex()
+		blockchain.stateDeliver.Accounts.ReindexHolders()
+		blockchain.stateDeliver.Governance.Enable()
+	}
+}
+
 func (blockchain *Blockchain) initState() {
 	initialHeight := blockchain.appDB.GetStartHeight()
 	currentHeight := blockchain.appDB.GetLastHeight()
@@ -232,6 +256,13 @@ func (blockchain *Blockchain) initState() {
 		blockchain.executor = GetExecutor(v.Name)
 	}
 
+	// the indexes are filled by the migration at the upgrade block and kept by every commit after it
+	if h := blockchain.appDB.GetVersionHeight(V350); h > 0 && currentHeight >= h {
+		stateDeliver.Candidates.EnableDelegatorsIndex()
+		stateDeliver.Accounts.EnableHoldersIndex()
+		stateDeliver.Governance.Enable()
+	}
+
 }
 
 // InitChain initialize blockchain with validators and other info. Only called once.
@@ -313,7 +344,7 @@ func (blockchain *Blockchain) BeginBlock(req abciTypes.RequestBeginBlock) abciTy
 	blockchain.StatisticData().PushStartBlock(&statistics.StartRequest{Height: int64(height), Now: time.Now(), HeaderTime: req.Header.Time})
 
 	// compute max gas
-	maxGas := blockchain.calcMaxGas()
+	maxGas := blockchain.calcMaxGas(height)
 	blockchain.stateDeliver.App.SetMaxGas(maxGas)
 	blockchain.appDB.AddBlocksTime(req.Header.Time)
 
@@ -363,7 +394,10 @@ func (blockchain *Blockchain) BeginBlock(req abciTypes.RequestBeginBlock) abciTy
 			continue
 		}
 
-		blockchain.stateDeliver.FrozenFunds.PunishFrozenFundsWithID(height, height+types.GetUnbondPeriod(), candidate.ID)
+		blockchain.stateDeliver.FrozenFunds.PunishFrozenFundsWithID(height, height+blockchain.stateDeliver.Governance.UnbondPeriod(), candidate.ID)
+		if h := blockchain.appDB.GetVersionHeight(V350); h > 0 && height > h {
+			blockchain.stateDeliver.Redelegations.PunishRedelegationsWithID(height, height+blockchain.stateDeliver.Governance.UnbondPeriod(), candidate.ID, candidate.PubKey)
+		}
 		blockchain.stateDeliver.Validators.PunishByzantineValidator(address)
 		blockchain.stateDeliver.Candidates.PunishByzantineCandidate(height, address)
 	}
@@ -407,6 +441,11 @@ func (blockchain *Blockchain) BeginBlock(req abciTypes.RequestBeginBlock) abciTy
 	}
 
 	blockchain.stateDeliver.Halts.Delete(height)
+	if h := blockchain.appDB.GetVersionHeight(V350); h > 0 && height > h {
+		blockchain.stateDeliver.DelegatorVotes.Delete(delegatorvotes.KindHalt, height)
+		blockchain.stateDeliver.Redelegations.Delete(height)
+		blockchain.stateDeliver.MultisigProposals.DeleteExpired(height)
+	}
 
 	return abciTypes.ResponseBeginBlock{}
 }
@@ -415,6 +454,18 @@ func (blockchain *Blockchain) BeginBlock(req abciTypes.RequestBeginBlock) abciTy
 func (blockchain *Blockchain) EndBlock(req abciTypes.RequestEndBlock) abciTypes.ResponseEndBlock {
 	height := uint64(req.Height)
 	atomic.StoreUint64(&blockchain.height, height)
+
+	if h := blockchain.appDB.GetVersionHeight(V350); h > 0 && height > h {
+		// apply announced commissions of candidates
+		blockchain.stateDeliver.Candidates.ApplyPendingCommissions(height)
+
+		// pay due standing orders
+		blockchain.stateDeliver.StandingOrders.Execute(height, maxStandingOrdersPerBlock)
+
+		// pay holders of active distributions
+		blockchain.stateDeliver.Distributions.Process(maxDistributionPaymentsPerBlock)
+	}
+
 	vals := blockchain.stateDeliver.Validators.GetValidators()
 
 	hasDroppedValidators := false
@@ -474,7 +525,9 @@ func (blockchain *Blockchain) EndBlock(req abciTypes.RequestEndBlock) abciTypes.
 	if height%blockchain.updateStakesAndPayRewardsPeriod == 0 {
 		PayRewards := blockchain.stateDeliver.Validators.PayRewardsV3
 
-		if h := blockchain.appDB.GetVersionHeight(V340); h > 0 && height > h {
+		if h := blockchain.appDB.GetVersionHeight(V350); h > 0 && height > h {
+			PayRewards = blockchain.stateDeliver.Validators.PayRewardsV350
+		} else if h := blockchain.appDB.GetVersionHeight(V340); h > 0 && height > h {
 			PayRewards = blockchain.stateDeliver.Validators.PayRewardsV5Fix2
 		} else if h := blockchain.appDB.GetVersionHeight(V330); h > 0 && height > h {
 			if height < h+blockchain.updateStakesAndPayRewardsPeriod && types.CurrentChainID == types.ChainMainnet {
@@ -564,11 +617,24 @@ func (blockchain *Blockchain) EndBlock(req abciTypes.RequestEndBlock) abciTypes.
 			})
 		}
 		blockchain.stateDeliver.Commission.Delete(height)
+		if h := blockchain.appDB.GetVersionHeight(V350); h > 0 && height > h {
+			blockchain.stateDeliver.DelegatorVotes.Delete(delegatorvotes.KindCommission, height)
+		}
+	}
+
+	if h := blockchain.appDB.GetVersionHeight(V350); h > 0 && height > h {
+		// count votes for parameter changes and apply passed ones
+		blockchain.stateDeliver.Governance.Tally(height, blockchain.totalPower, blockchain.validatorPower)
+		blockchain.stateDeliver.Governance.Apply(height)
+
+		// pay treasury spend proposals approved by validators
+		blockchain.spendTreasury(height)
 	}
 
 	{
 		if v, ok := blockchain.isUpdateNetworkBlockV2(height); ok {
 			blockchain.appDB.AddVersion(v, height)
+			blockchain.migrate(v)
 			blockchain.eventsDB.AddEvent(&eventsdb.UpdateNetworkEvent{
 				Version: v,
 			})
@@ -576,6 +642,9 @@ func (blockchain *Blockchain) EndBlock(req abciTypes.RequestEndBlock) abciTypes.
 			blockchain.executor = GetExecutor(v)
 		}
 		blockchain.stateDeliver.Updates.Delete(height)
+		if h := blockchain.appDB.GetVersionHeight(V350); h > 0 && height > h {
+			blockchain.stateDeliver.DelegatorVotes.Delete(delegatorvotes.KindUpdate, height)
+		}
 	}
 
 	hasChangedPublicKeys := false
@@ -12,6 +12,7 @@ import (
 	eventsdb "github.com/MinterTeam/minter-go-node/coreV2/events"
 	"github.com/MinterTeam/minter-go-node/coreV2/rewards"
 	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/delegatorvotes"
 	validators2 "github.com/MinterTeam/minter-go-node/coreV2/state/validators"
 	"github.com/MinterTeam/minter-go-node/coreV2/statistics"
 	"github.com/MinterTeam/minter-go-node/coreV2/types"
@@ -185,6 +186,32 @@ func (blockchain *Blockchain) GetEmission() *big.Int {
 	return emission
 }
 
+// GetRewardForBlock returns current price-based reward for a block or zero if the emission is over
+func (blockchain *Blockchain) GetRewardForBlock() *big.Int {
+	if blockchain.GetEmission().Cmp(blockchain.rewardsCounter.TotalEmissionBig()) != -1 {
+		return big.NewInt(0)
+	}
+
+	reward, _ := blockchain.CurrentState().App().Reward()
+	if reward == nil {
+		return big.NewInt(0)
+	}
+
+	return big.NewInt(0).Set(reward)
+}
+
+// AverageBlockTime returns average time in seconds between the latest blocks
+func (blockchain *Blockchain) AverageBlockTime() float64 {
+	const defaultBlockTime = 5
+
+	delta, count := blockchain.appDB.GetLastBlockTimeDelta()
+	if delta <= 0 || count <= 0 {
+		return defaultBlockTime
+	}
+
+	return float64(delta) / float64(count)
+}
+
 // GetStateForHeight returns immutable state of Minter Blockchain for given height
 func (blockchain *Blockchain) GetStateForHeight(height uint64) (*state.CheckState, error) {
 	if height > 0 {
@@ -231,13 +258,18 @@ func (blockchain *Blockchain) MinGasPrice() uint32 {
 	return 1
 }
 
-func (blockchain *Blockchain) calcMaxGas() uint64 {
+func (blockchain *Blockchain) calcMaxGas(height uint64) uint64 {
 	const targetTime = 7
 
+	maxGas := uint64(defaultMaxGas)
+	if h := blockchain.appDB.GetVersionHeight(V350); h > 0 && height > h {
+		maxGas = blockchain.stateDeliver.Governance.MaxGas()
+	}
+
 	// check if blocks are created in time
 	delta, count := blockchain.appDB.GetLastBlockTimeDelta()
 	if delta == 0 {
-		return defaultMaxGas
+		return maxGas
 	}
 
 	// get current max gas
@@ -250,8 +282,8 @@ func (blockchain *Blockchain) calcMaxGas() uint64 {
 	}
 
 	// check if max gas is too high
-	if newMaxGas > defaultMaxGas {
-		return defaultMaxGas
+	if newMaxGas > maxGas {
+		return maxGas
 	}
 
 	// check if max gas is too low
@@ -302,19 +334,18 @@ func (blockchain *Blockchain) isApplicationHalted(height uint64) bool {
 		return false
 	}
 
-	totalVotedPower := big.NewInt(0)
+	votes := make([]types.Pubkey, 0, len(halts.List))
 	for _, halt := range halts.List {
-		if power, ok := blockchain.validatorsPowers[halt.Pubkey]; ok {
-			totalVotedPower.Add(totalVotedPower, power)
-		}
+		votes = append(votes, halt.Pubkey)
 	}
+	totalVotedPower := blockchain.stateDeliver.DelegatorVotes.Tally(delegatorvotes.KindHalt, height, [][]types.Pubkey{votes}, blockchain.validatorsPowers)[0]
 
 	votingResult := new(big.Float).Quo(
 		new(big.Float).SetInt(totalVotedPower),
 		new(big.Float).SetInt(blockchain.totalPower),
 	)
 
-	if votingResult.Cmp(big.NewFloat(votingPowerConsensus)) == 1 {
+	if votingResult.Cmp(big.NewFloat(VotingPowerConsensus)) == 1 {
 		return true
 	}
 
@@ -347,29 +378,70 @@ func (blockchain *Blockchain) isUpdateCommissionsBlock(height uint64) []byte {
 			price = commission.Price
 		}
 	}
-	if maxVotingResult.Cmp(big.NewFloat(votingPowerConsensus)) == 1 {
+	if maxVotingResult.Cmp(big.NewFloat(VotingPowerConsensus)) == 1 {
 		return []byte(price)
 	}
 
 	return nil
 }
 
+// validatorPower returns the voting power of the validator, nil if it is not a validator
+func (blockchain *Blockchain) validatorPower(pubkey types.Pubkey) *big.Int {
+	return blockchain.validatorsPowers[pubkey]
+}
+
+// isTreasurySpendApproved returns true if validators with more than 2/3 of voting power voted for the spend proposal
+func (blockchain *Blockchain) isTreasurySpendApproved(votes []types.Pubkey) bool {
+	totalVotedPower := big.NewInt(0)
+	for _, vote := range votes {
+		if power, ok := blockchain.validatorsPowers[vote]; ok {
+			totalVotedPower.Add(totalVotedPower, power)
+		}
+	}
+	votingResult := new(big.Float).Quo(
+		new(big.Float).SetInt(totalVotedPower),
+		new(big.Float).SetInt(blockchain.totalPower),
+	)
+
+	return votingResult.Cmp(big.NewFloat(VotingPowerConsensus)) == 1
+}
+
+// spendTreasury pays the spend proposals approved by validators which voting ends at the height, the proposals which can't be paid are marked as failed
+func (blockchain *Blockchain) spendTreasury(height uint64) {
+	for _, proposal := range blockchain.stateDeliver.Treasury.GetVotes(height) {
+		if !blockchain.isTreasurySpendApproved(proposal.GetVotes()) {
+			continue
+		}
+
+		if err := blockchain.stateDeliver.Treasury.Spend(proposal.ID()); err != nil {
+			blockchain.eventsDB.AddEvent(&eventsdb.TreasurySpendFailedEvent{
+				ID:        proposal.ID(),
+				Recipient: proposal.Recipient,
+				Coin:      uint64(proposal.Coin),
+				Amount:    proposal.Amount.String(),
+				Reason:    err.Error(),
+			})
+		}
+	}
+	blockchain.stateDeliver.Treasury.Delete(height)
+}
+
 func (blockchain *Blockchain) isUpdateCommissionsBlockV2(height uint64) []byte {
 	commissions := blockchain.stateDeliver.Commission.GetVotes(height)
 	if len(commissions) == 0 {
 		return nil
 	}
-	// calculate total power of validators
+	options := make([][]types.Pubkey, 0, len(commissions))
+	for _, commission := range commissions {
+		options = append(options, commission.Votes)
+	}
+	// calculate total power of validators and delegators who have overridden their votes
+	powers := blockchain.stateDeliver.DelegatorVotes.Tally(delegatorvotes.KindCommission, height, options, blockchain.validatorsPowers)
 	maxVotingResult := big.NewFloat(0)
 
 	var price string
-	for _, commission := range commissions {
-		totalVotedPower := big.NewInt(0)
-		for _, vote := range commission.Votes {
-			if power, ok := blockchain.validatorsPowers[vote]; ok {
-				totalVotedPower.Add(totalVotedPower, power)
-			}
-		}
+	for i, commission := range commissions {
+		totalVotedPower := powers[i]
 		votingResult := new(big.Float).Quo(
 			new(big.Float).SetInt(totalVotedPower),
 			new(big.Float).SetInt(blockchain.totalPower),
@@ -380,7 +452,7 @@ func (blockchain *Blockchain) isUpdateCommissionsBlockV2(height uint64) []byte {
 			price = commission.Price
 		}
 	}
-	if maxVotingResult.Cmp(big.NewFloat(votingPowerConsensus)) == 1 {
+	if maxVotingResult.Cmp(big.NewFloat(VotingPowerConsensus)) == 1 {
 		return []byte(price)
 	}
 
@@ -392,16 +464,16 @@ func (blockchain *Blockchain) isUpdateNetworkBlockV2(height uint64) (string, boo
 	if len(versions) == 0 {
 		return "", false
 	}
-	// calculate total power of validators
+	options := make([][]types.Pubkey, 0, len(versions))
+	for _, v := range versions {
+		options = append(options, v.Votes)
+	}
+	// calculate total power of validators and delegators who have overridden their votes
+	powers := blockchain.stateDeliver.DelegatorVotes.Tally(delegatorvotes.KindUpdate, height, options, blockchain.validatorsPowers)
 	maxVotingResult := big.NewFloat(0)
 	var version string
-	for _, v := range versions {
-		totalVotedPower := big.NewInt(0)
-		for _, vote := range v.Votes {
-			if power, ok := blockchain.validatorsPowers[vote]; ok {
-				totalVotedPower.Add(totalVotedPower, power)
-			}
-		}
+	for i, v := range versions {
+		totalVotedPower := powers[i]
 		votingResult := new(big.Float).Quo(
 			new(big.Float).SetInt(totalVotedPower),
 			new(big.Float).SetInt(blockchain.totalPower),
@@ -412,7 +484,7 @@ func (blockchain *Blockchain) isUpdateNetworkBlockV2(height uint64) (string, boo
 			version = v.Version
 		}
 	}
-	if maxVotingResult.Cmp(big.NewFloat(votingPowerConsensus)) == 1 {
+	if maxVotingResult.Cmp(big.NewFloat(VotingPowerConsensus)) == 1 {
 		return version, true
 	}
 
@@ -15,6 +15,7 @@ import (
 	"github.com/MinterTeam/minter-go-node/config"
 	"github.com/MinterTeam/minter-go-node/coreV2/developers"
 	eventsdb "github.com/MinterTeam/minter-go-node/coreV2/events"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
 	"github.com/MinterTeam/minter-go-node/coreV2/state/candidates"
 	"github.com/MinterTeam/minter-go-node/coreV2/statistics"
 	"github.com/MinterTeam/minter-go-node/coreV2/transaction"
@@ -32,6 +33,7 @@ import (
 	"github.com/tendermint/tendermint/proxy"
 	rpc "github.com/tendermint/tendermint/rpc/client/local"
 	types2 "github.com/tendermint/tendermint/types"
+	db "github.com/tendermint/tm-db"
 )
 
 func initTestNode(t *testing.T, initialHeight int64) (*Blockchain, *rpc.Local, *privval.FilePV, func()) {
@@ -1022,6 +1024,71 @@ func makeTestValidatorsAndCandidates(pubkeys []string, stake *big.Int) ([]types.
 	return vals, cands
 }
 
+func TestBlockchain_SpendTreasury(t *testing.T) {
+	t.Parallel()
+	events := &eventsdb.MockEvents{}
+	stateDeliver, err := state.NewState(0, db.NewMemDB(), events, 1, 1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first, second, third := types.Pubkey{1}, types.Pubkey{2}, types.Pubkey{3}
+	blockchain := &Blockchain{
+		stateDeliver: stateDeliver,
+		eventsDB:     events,
+		validatorsPowers: map[types.Pubkey]*big.Int{
+			first:  big.NewInt(40),
+			second: big.NewInt(30),
+			third:  big.NewInt(30),
+		},
+		totalPower: big.NewInt(100),
+	}
+
+	coin := types.GetBaseCoinID()
+	stateDeliver.Treasury.AddIncome(coin, big.NewInt(1000))
+
+	approved := stateDeliver.Treasury.Create(types.Address{1}, types.Address{2}, coin, big.NewInt(600), "approved", 10)
+	stateDeliver.Treasury.AddVote(approved, first)
+	stateDeliver.Treasury.AddVote(approved, second)
+
+	rejected := stateDeliver.Treasury.Create(types.Address{1}, types.Address{3}, coin, big.NewInt(100), "rejected", 10)
+	stateDeliver.Treasury.AddVote(rejected, first)
+	stateDeliver.Treasury.AddVote(rejected, types.Pubkey{4})
+
+	tooLarge := stateDeliver.Treasury.Create(types.Address{1}, types.Address{4}, coin, big.NewInt(600), "too large", 10)
+	stateDeliver.Treasury.AddVote(tooLarge, first)
+	stateDeliver.Treasury.AddVote(tooLarge, second)
+	stateDeliver.Treasury.AddVote(tooLarge, third)
+
+	blockchain.spendTreasury(10)
+
+	if balance := stateDeliver.Accounts.GetBalance(types.Address{2}, coin); balance.Cmp(big.NewInt(600)) != 0 {
+		t.Fatalf("Approved proposal is not paid, balance %s", balance)
+	}
+	if balance := stateDeliver.Accounts.GetBalance(types.Address{3}, coin); balance.Sign() != 0 {
+		t.Fatalf("Rejected proposal is paid, balance %s", balance)
+	}
+	if balance := stateDeliver.Accounts.GetBalance(types.Address{4}, coin); balance.Sign() != 0 {
+		t.Fatalf("Proposal is paid over the treasury balance, balance %s", balance)
+	}
+	if balance := stateDeliver.Treasury.GetBalance(coin); balance.Cmp(big.NewInt(400)) != 0 {
+		t.Fatalf("Treasury balance is not correct. Expected %d, got %s", 400, balance)
+	}
+	if len(stateDeliver.Treasury.GetProposals()) != 0 {
+		t.Fatal("Proposals are not deleted at the end of voting")
+	}
+
+	var failed []*eventsdb.TreasurySpendFailedEvent
+	for _, event := range events.LoadEvents(0) {
+		if e, ok := event.(*eventsdb.TreasurySpendFailedEvent); ok {
+			failed = append(failed, e)
+		}
+	}
+	if len(failed) != 1 || failed[0].ID != tooLarge {
+		t.Fatalf("Proposal over the treasury balance is not marked as failed: %v", failed)
+	}
+}
+
 func getTestGenesis(pv *privval.FilePV, home string, initialState int64) func() (*types2.GenesisDoc, error) {
 	return func() (*types2.GenesisDoc, error) {
 		validators, candidates := makeTestValidatorsAndCandidates([]string{string(pv.Key.PubKey.Bytes()[:])}, helpers.BipToPip(big.NewInt(12444011)))
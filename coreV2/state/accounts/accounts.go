@@ -2,22 +2,31 @@ package accounts
 
 import (
 	"bytes"
+	"encoding/binary"
 	"fmt"
 	"math/big"
 	"sort"
 	"sync"
-	"sync/atomic"
 
 	"github.com/MinterTeam/minter-go-node/coreV2/state/bus"
 	"github.com/MinterTeam/minter-go-node/coreV2/state/coins"
 	"github.com/MinterTeam/minter-go-node/coreV2/types"
 	"github.com/MinterTeam/minter-go-node/rlp"
-	"github.com/cosmos/iavl"
+	"github.com/MinterTeam/minter-go-node/tree"
 )
 
 const mainPrefix = byte('a')
 const coinsPrefix = byte('c')
 const balancePrefix = byte('b')
+const vestingsPrefix = byte('v')
+const policyPrefix = byte('p')
+
+// holdersPrefix is a separate top-level index of balances by coins, keys are coin ID followed by holder address
+const holdersPrefix = byte('n')
+
+// richListPrefix is a separate top-level index of holders by coins ordered by balance, keys are coin ID followed by
+// the 32-byte balance and holder address. The key of the coin ID alone keeps the number of its holders.
+const richListPrefix = byte('e')
 
 type RAccounts interface {
 	// Deprecated
@@ -27,8 +36,19 @@ type RAccounts interface {
 	GetAccount(address types.Address) *Model
 	GetNonce(address types.Address) uint64
 	GetLockStakeUntilBlock(address types.Address) uint64
+	GetAuthorizedKey(address types.Address) types.Address
+	GetGuardian(address types.Address) types.Address
 	GetBalance(address types.Address, coin types.CoinID) *big.Int
+	GetSpendableBalance(address types.Address, coin types.CoinID) *big.Int
+	GetUnvestedBalance(address types.Address, coin types.CoinID) *big.Int
+	GetVestings(address types.Address) []Vesting
+	GetPolicy(address types.Address) Policy
+	GetPolicyState(address types.Address) PolicyState
+	GetPolicySpent(address types.Address, coin types.CoinID) *big.Int
 	GetBalances(address types.Address) []Balance
+	GetHolders(coin types.CoinID) []Holder
+	CoinHolders(coin types.CoinID, limit int, cursor []byte) ([]Holder, []byte)
+	HoldersCount(coin types.CoinID) uint64
 	ExistsMultisig(msigAddress types.Address) bool
 }
 
@@ -36,9 +56,11 @@ type Accounts struct {
 	list  map[types.Address]*Model
 	dirty map[types.Address]struct{}
 
-	db  atomic.Value
+	db  tree.AtomicTree
 	bus *bus.Bus
 
+	holdersIndex bool
+
 	lock        sync.RWMutex
 	lockDirties sync.RWMutex
 }
@@ -48,8 +70,13 @@ type Balance struct {
 	Value *big.Int
 }
 
-func NewAccounts(stateBus *bus.Bus, db *iavl.ImmutableTree) *Accounts {
-	immutableTree := atomic.Value{}
+type Holder struct {
+	Address types.Address
+	Value   *big.Int
+}
+
+func NewAccounts(stateBus *bus.Bus, db tree.ImmutableTree) *Accounts {
+	immutableTree := tree.AtomicTree{}
 	if db != nil {
 		immutableTree.Store(db)
 	}
@@ -59,19 +86,33 @@ func NewAccounts(stateBus *bus.Bus, db *iavl.ImmutableTree) *Accounts {
 	return accounts
 }
 
-func (a *Accounts) immutableTree() *iavl.ImmutableTree {
-	db := a.db.Load()
-	if db == nil {
-		return nil
-	}
-	return db.(*iavl.ImmutableTree)
+func (a *Accounts) immutableTree() tree.ImmutableTree {
+	return a.db.Load()
 }
 
-func (a *Accounts) SetImmutableTree(immutableTree *iavl.ImmutableTree) {
+func (a *Accounts) SetImmutableTree(immutableTree tree.ImmutableTree) {
 	a.db.Store(immutableTree)
 }
 
-func (a *Accounts) Commit(db *iavl.MutableTree, version int64) error {
+// EnableHoldersIndex makes Commit keep the holders indexes and the holders counts of coins.
+// The indexes exist from the V350 upgrade on, they are filled for the earlier balances by ReindexHolders
+func (a *Accounts) EnableHoldersIndex() {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	a.holdersIndex = true
+}
+
+func (a *Accounts) isHoldersIndexEnabled() bool {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+
+	return a.holdersIndex
+}
+
+func (a *Accounts) Commit(db tree.MutableTree, version int64) error {
+	holdersIndex := a.isHoldersIndexEnabled()
+	holdersCount := map[types.CoinID]int64{}
 	accounts := a.getOrderedDirtyAccounts()
 	for _, address := range accounts {
 		account := a.getFromMap(address)
@@ -131,8 +172,14 @@ func (a *Accounts) Commit(db *iavl.MutableTree, version int64) error {
 				switch balance.Sign() {
 				case 0:
 					db.Remove(path)
+					if holdersIndex {
+						setHolder(db, coin, address, balance, holdersCount)
+					}
 				case 1:
 					db.Set(path, balance.Bytes())
+					if holdersIndex {
+						setHolder(db, coin, address, balance, holdersCount)
+					}
 				case -1:
 					if version < 4415830 && types.CurrentChainID == types.ChainMainnet {
 						db.Set(path, balance.Bytes())
@@ -146,11 +193,108 @@ func (a *Accounts) Commit(db *iavl.MutableTree, version int64) error {
 			account.dirtyBalances = map[types.CoinID]struct{}{}
 			account.lock.Unlock()
 		}
+
+		// save vesting schedules
+		account.lock.Lock()
+		for key := range account.dirtyVestings {
+			path := getVestingPath(address, []byte(key))
+			vesting := findVesting(account.vestings, []byte(key))
+			if vesting == nil {
+				db.Remove(path)
+				continue
+			}
+
+			data, err := rlp.EncodeToBytes(vesting)
+			if err != nil {
+				account.lock.Unlock()
+				return fmt.Errorf("can't encode vesting at %x: %v", address[:], err)
+			}
+			db.Set(path, data)
+		}
+		account.dirtyVestings = nil
+		account.lock.Unlock()
+
+		// save policy
+		account.lock.Lock()
+		if account.hasDirtyPolicy {
+			account.hasDirtyPolicy = false
+
+			path := []byte{mainPrefix}
+			path = append(path, address[:]...)
+			path = append(path, policyPrefix)
+
+			if account.policy == nil || account.policy.isEmpty() {
+				db.Remove(path)
+			} else {
+				data, err := rlp.EncodeToBytes(account.policy)
+				if err != nil {
+					account.lock.Unlock()
+					return fmt.Errorf("can't encode policy at %x: %v", address[:], err)
+				}
+				db.Set(path, data)
+			}
+		}
+		account.lock.Unlock()
+	}
+
+	coins := make([]types.CoinID, 0, len(holdersCount))
+	for coin := range holdersCount {
+		coins = append(coins, coin)
+	}
+	sort.Slice(coins, func(i, j int) bool {
+		return coins[i] < coins[j]
+	})
+
+	for _, coin := range coins {
+		if holdersCount[coin] == 0 {
+			continue
+		}
+
+		var count uint64
+		path := getHoldersCountPath(coin)
+		if _, enc := db.Get(path); len(enc) != 0 {
+			if err := rlp.DecodeBytes(enc, &count); err != nil {
+				return fmt.Errorf("can't decode holders count of coin %d: %v", coin, err)
+			}
+		}
+
+		count = uint64(int64(count) + holdersCount[coin])
+		if count == 0 {
+			db.Remove(path)
+			continue
+		}
+
+		data, err := rlp.EncodeToBytes(count)
+		if err != nil {
+			return fmt.Errorf("can't encode holders count of coin %d: %v", coin, err)
+		}
+		db.Set(path, data)
 	}
 
 	return nil
 }
 
+// setHolder updates the holders indexes with the committed balance and counts holders added and removed by coins
+func setHolder(db tree.MutableTree, coin types.CoinID, address types.Address, balance *big.Int, holdersCount map[types.CoinID]int64) {
+	path := getHolderPath(coin, address)
+	if _, enc := db.Get(path); len(enc) != 0 {
+		db.Remove(getRichListPath(coin, big.NewInt(0).SetBytes(enc), address))
+		if balance.Sign() == 0 {
+			holdersCount[coin]--
+		}
+	} else if balance.Sign() == 1 {
+		holdersCount[coin]++
+	}
+
+	if balance.Sign() == 0 {
+		db.Remove(path)
+		return
+	}
+
+	db.Set(path, balance.Bytes())
+	db.Set(getRichListPath(coin, balance, address), balance.Bytes())
+}
+
 func (a *Accounts) HasDirtyCoins(account *Model) bool {
 	account.lock.RLock()
 	defer account.lock.RUnlock()
@@ -217,6 +361,225 @@ func (a *Accounts) GetBalance(address types.Address, coin types.CoinID) *big.Int
 	return big.NewInt(0).Set(balance)
 }
 
+// GetSpendableBalance returns the balance of the address excluding funds which are not vested yet
+func (a *Accounts) GetSpendableBalance(address types.Address, coin types.CoinID) *big.Int {
+	balance := a.GetBalance(address, coin)
+	balance.Sub(balance, a.GetUnvestedBalance(address, coin))
+	if balance.Sign() == -1 {
+		return big.NewInt(0)
+	}
+
+	return balance
+}
+
+// GetUnvestedBalance returns the amount of coin which is not released by vesting schedules of the address at the current height
+func (a *Accounts) GetUnvestedBalance(address types.Address, coin types.CoinID) *big.Int {
+	height := a.currentHeight()
+
+	unvested := big.NewInt(0)
+	for _, vesting := range a.GetVestings(address) {
+		if vesting.Coin != coin {
+			continue
+		}
+		unvested.Add(unvested, vesting.Unvested(height))
+	}
+
+	return unvested
+}
+
+// GetVestings returns vesting schedules of the address
+func (a *Accounts) GetVestings(address types.Address) []Vesting {
+	account := a.getOrNew(address)
+	a.loadVestings(account)
+
+	return account.getVestings()
+}
+
+// AddVesting attaches a vesting schedule to the address, schedules which are completely released are removed.
+// Schedules of the same coin with equal heights are merged into one
+func (a *Accounts) AddVesting(address types.Address, vesting Vesting) {
+	height := a.currentHeight()
+	key := vestingKey(vesting)
+
+	var vestings []Vesting
+	dirtyKeys := [][]byte{key}
+	merged := false
+	for _, v := range a.GetVestings(address) {
+		if v.EndHeight <= height {
+			dirtyKeys = append(dirtyKeys, vestingKey(v))
+			continue
+		}
+		if bytes.Equal(vestingKey(v), key) {
+			v.Value = big.NewInt(0).Add(v.Value, vesting.Value)
+			merged = true
+		}
+		vestings = append(vestings, v)
+	}
+	if !merged {
+		vestings = append(vestings, Vesting{
+			Coin:        vesting.Coin,
+			Value:       big.NewInt(0).Set(vesting.Value),
+			StartHeight: vesting.StartHeight,
+			CliffHeight: vesting.CliffHeight,
+			EndHeight:   vesting.EndHeight,
+		})
+	}
+
+	a.getOrNew(address).setVestings(vestings, dirtyKeys)
+}
+
+func (a *Accounts) loadVestings(account *Model) {
+	account.lock.Lock()
+	defer account.lock.Unlock()
+
+	if account.isVestingsLoaded {
+		return
+	}
+	account.isVestingsLoaded = true
+
+	immutableTree := a.immutableTree()
+	if immutableTree == nil {
+		return
+	}
+
+	start, end := getVestingPath(account.address, nil), getVestingPath(account.address, nil)
+	end[len(end)-1]++
+	immutableTree.IterateRange(start, end, true, func(key []byte, value []byte) bool {
+		vesting := Vesting{}
+		if err := rlp.DecodeBytes(value, &vesting); err != nil {
+			panic(fmt.Sprintf("failed to decode vesting at address %s: %s", account.address.String(), err))
+		}
+		account.vestings = append(account.vestings, vesting)
+		return false
+	})
+}
+
+// vestingKey returns the key of the schedule in the storage of the account.
+// Keys start with the end height, so schedules are stored in order of their release
+func vestingKey(vesting Vesting) []byte {
+	key := make([]byte, 24, 24+len(vesting.Coin.Bytes()))
+	binary.BigEndian.PutUint64(key[:8], vesting.EndHeight)
+	binary.BigEndian.PutUint64(key[8:16], vesting.CliffHeight)
+	binary.BigEndian.PutUint64(key[16:], vesting.StartHeight)
+	return append(key, vesting.Coin.Bytes()...)
+}
+
+func getVestingPath(address types.Address, key []byte) []byte {
+	path := []byte{mainPrefix}
+	path = append(path, address[:]...)
+	path = append(path, vestingsPrefix)
+	return append(path, key...)
+}
+
+func findVesting(vestings []Vesting, key []byte) *Vesting {
+	for i := range vestings {
+		if bytes.Equal(vestingKey(vestings[i]), key) {
+			return &vestings[i]
+		}
+	}
+	return nil
+}
+
+func (a *Accounts) loadPolicy(account *Model) {
+	account.lock.Lock()
+	defer account.lock.Unlock()
+
+	if account.isPolicyLoaded {
+		return
+	}
+	account.isPolicyLoaded = true
+
+	immutableTree := a.immutableTree()
+	if immutableTree == nil {
+		return
+	}
+
+	path := []byte{mainPrefix}
+	path = append(path, account.address[:]...)
+	path = append(path, policyPrefix)
+
+	_, enc := immutableTree.Get(path)
+	if len(enc) == 0 {
+		return
+	}
+
+	account.policy = &PolicyState{}
+	if err := rlp.DecodeBytes(enc, account.policy); err != nil {
+		panic(fmt.Sprintf("failed to decode policy at address %s: %s", account.address.String(), err))
+	}
+}
+
+// GetPolicyState returns the policy of the address actual at the current height
+func (a *Accounts) GetPolicyState(address types.Address) PolicyState {
+	account := a.getOrNew(address)
+	a.loadPolicy(account)
+
+	policy := account.getPolicy()
+	if policy == nil {
+		return PolicyState{}
+	}
+
+	return *policy.actual(a.currentHeight())
+}
+
+// SetPolicyState replaces the policy of the address
+func (a *Accounts) SetPolicyState(address types.Address, policy PolicyState) {
+	a.getOrNew(address).setPolicy(&policy)
+}
+
+// GetPolicy returns the policy which restricts transactions of the address at the current height
+func (a *Accounts) GetPolicy(address types.Address) Policy {
+	return a.GetPolicyState(address).Policy
+}
+
+// SetPendingPolicy schedules the change of the policy of the address at given height
+func (a *Accounts) SetPendingPolicy(address types.Address, policy Policy, height uint64) {
+	state := a.GetPolicyState(address)
+	state.Pending = policy
+	state.PendingHeight = height
+	a.SetPolicyState(address, state)
+}
+
+// GetPolicySpent returns the value of coin sent by the address within the current policy period
+func (a *Accounts) GetPolicySpent(address types.Address, coin types.CoinID) *big.Int {
+	for _, spent := range a.GetPolicyState(address).Spent {
+		if spent.Coin == coin {
+			return spent.Value
+		}
+	}
+
+	return big.NewInt(0)
+}
+
+// AddPolicySpent accounts the value of coin sent by the address if the coin is limited by its policy
+func (a *Accounts) AddPolicySpent(address types.Address, coin types.CoinID, value *big.Int) {
+	state := a.GetPolicyState(address)
+	if state.Policy.GetLimit(coin) == nil {
+		return
+	}
+
+	for i, spent := range state.Spent {
+		if spent.Coin == coin {
+			state.Spent[i].Value.Add(spent.Value, value)
+			a.SetPolicyState(address, state)
+			return
+		}
+	}
+
+	state.Spent = append(state.Spent, PolicyLimit{Coin: coin, Value: big.NewInt(0).Set(value)})
+	a.SetPolicyState(address, state)
+}
+
+// currentHeight returns the height of the block which is being processed on top of the committed state
+func (a *Accounts) currentHeight() uint64 {
+	immutableTree := a.immutableTree()
+	if immutableTree == nil {
+		return 0
+	}
+
+	return uint64(immutableTree.Version()) + 1
+}
+
 func (a *Accounts) SubBalance(address types.Address, coin types.CoinID, amount *big.Int) {
 	balance := big.NewInt(0).Sub(a.GetBalance(address, coin), amount)
 	a.SetBalance(address, coin, balance)
@@ -377,6 +740,37 @@ func (a *Accounts) GetLockStakeUntilBlock(address types.Address) uint64 {
 	return account.getLockStakeUntilBlock()
 }
 
+// SetAuthorizedKey binds the account to the new key, the empty address resets it to the original key
+func (a *Accounts) SetAuthorizedKey(address types.Address, key types.Address) {
+	account := a.getOrNew(address)
+	rotation := account.getKeyRotation()
+	rotation.AuthorizedKey = key
+	account.setKeyRotation(rotation)
+}
+
+// GetAuthorizedKey returns the address of the key allowed to sign transactions of the account
+func (a *Accounts) GetAuthorizedKey(address types.Address) types.Address {
+	account := a.getOrNew(address)
+	if key := account.getKeyRotation().AuthorizedKey; key != (types.Address{}) {
+		return key
+	}
+
+	return address
+}
+
+func (a *Accounts) SetGuardian(address types.Address, guardian types.Address) {
+	account := a.getOrNew(address)
+	rotation := account.getKeyRotation()
+	rotation.Guardian = guardian
+	account.setKeyRotation(rotation)
+}
+
+func (a *Accounts) GetGuardian(address types.Address) types.Address {
+	account := a.getOrNew(address)
+
+	return account.getKeyRotation().Guardian
+}
+
 func (a *Accounts) GetBalances(address types.Address) []Balance {
 	account := a.getOrNew(address)
 
@@ -391,6 +785,141 @@ func (a *Accounts) GetBalances(address types.Address) []Balance {
 	return balances
 }
 
+// GetHolders returns addresses with positive balances of the coin ordered by address.
+// Committed balances are read from the holders index and overridden by balances changed in the current block.
+func (a *Accounts) GetHolders(coin types.CoinID) []Holder {
+	balances := map[types.Address]*big.Int{}
+
+	if immutableTree := a.immutableTree(); immutableTree != nil {
+		prefix := append([]byte{holdersPrefix}, coin.Bytes()...)
+		immutableTree.IterateRange(prefix, append([]byte{holdersPrefix}, (coin+1).Bytes()...), true, func(key []byte, value []byte) bool {
+			balances[types.BytesToAddress(key[len(prefix):])] = big.NewInt(0).SetBytes(value)
+			return false
+		})
+	}
+
+	a.lockDirties.RLock()
+	dirty := make([]types.Address, 0, len(a.dirty))
+	for address := range a.dirty {
+		dirty = append(dirty, address)
+	}
+	a.lockDirties.RUnlock()
+
+	for _, address := range dirty {
+		account := a.getFromMap(address)
+		if account == nil || !account.isBalanceDirty(coin) {
+			continue
+		}
+
+		if balance := account.getBalance(coin); balance.Sign() == 1 {
+			balances[address] = big.NewInt(0).Set(balance)
+		} else {
+			delete(balances, address)
+		}
+	}
+
+	holders := make([]Holder, 0, len(balances))
+	for address, balance := range balances {
+		holders = append(holders, Holder{Address: address, Value: balance})
+	}
+	sort.Slice(holders, func(i, j int) bool {
+		return bytes.Compare(holders[i].Address.Bytes(), holders[j].Address.Bytes()) == -1
+	})
+
+	return holders
+}
+
+// CoinHolders returns at most limit committed holders of the coin ordered by balance descending, starting after the cursor.
+// The returned cursor points to the last returned holder and is nil if there are no more holders.
+func (a *Accounts) CoinHolders(coin types.CoinID, limit int, cursor []byte) ([]Holder, []byte) {
+	immutableTree := a.immutableTree()
+	if immutableTree == nil || limit <= 0 {
+		return nil, nil
+	}
+
+	prefix := append([]byte{richListPrefix}, coin.Bytes()...)
+	end := append([]byte{richListPrefix}, (coin + 1).Bytes()...)
+	if len(cursor) != 0 {
+		end = append(append([]byte{}, prefix...), cursor...)
+	}
+
+	var (
+		holders []Holder
+		last    []byte
+		hasNext bool
+	)
+	immutableTree.IterateRange(prefix, end, false, func(key []byte, value []byte) bool {
+		if len(key) != len(prefix)+32+types.AddressLength {
+			return false
+		}
+
+		if len(holders) == limit {
+			hasNext = true
+			return true
+		}
+
+		holders = append(holders, Holder{
+			Address: types.BytesToAddress(key[len(prefix)+32:]),
+			Value:   big.NewInt(0).SetBytes(value),
+		})
+		last = append([]byte{}, key[len(prefix):]...)
+		return false
+	})
+
+	if !hasNext {
+		return holders, nil
+	}
+
+	return holders, last
+}
+
+// ReindexHolders marks all committed positive balances as dirty to fill the holders indexes and counts on commit.
+// Balances which are already indexed are rewritten without changing the counts.
+func (a *Accounts) ReindexHolders() {
+	immutableTree := a.immutableTree()
+	if immutableTree == nil {
+		return
+	}
+
+	var addresses []types.Address
+	immutableTree.IterateRange([]byte{mainPrefix}, []byte{mainPrefix + 1}, true, func(key []byte, value []byte) bool {
+		if len(key) == 1+types.AddressLength {
+			addresses = append(addresses, types.BytesToAddress(key[1:]))
+		}
+		return false
+	})
+
+	for _, address := range addresses {
+		account := a.get(address)
+		account.lock.RLock()
+		coins := append([]types.CoinID{}, account.coins...)
+		account.lock.RUnlock()
+
+		for _, coin := range coins {
+			if balance := a.GetBalance(address, coin); balance.Sign() == 1 {
+				account.setBalance(coin, balance)
+			}
+		}
+	}
+}
+
+// HoldersCount returns the number of committed holders of the coin
+func (a *Accounts) HoldersCount(coin types.CoinID) uint64 {
+	immutableTree := a.immutableTree()
+	if immutableTree == nil {
+		return 0
+	}
+
+	var count uint64
+	if _, enc := immutableTree.Get(getHoldersCountPath(coin)); len(enc) != 0 {
+		if err := rlp.DecodeBytes(enc, &count); err != nil {
+			panic(fmt.Sprintf("failed to decode holders count of coin %d: %s", coin, err))
+		}
+	}
+
+	return count
+}
+
 func (a *Accounts) markDirty(addr types.Address) {
 	a.lockDirties.Lock()
 	defer a.lockDirties.Unlock()
@@ -430,6 +959,36 @@ func (a *Accounts) Export(state *types.AppState) {
 			LockStakeUntilBlock: account.LockStakeUntilBlock,
 		}
 
+		if policy := a.GetPolicyState(account.address); !policy.isEmpty() {
+			acc.Policy = exportPolicy(policy.Policy)
+			if policy.PendingHeight != 0 {
+				acc.Policy.Pending = exportPolicy(policy.Pending)
+				acc.Policy.PendingHeight = policy.PendingHeight
+			}
+			acc.Policy.PeriodStart = policy.PeriodStart
+			for _, spent := range policy.Spent {
+				acc.Policy.Spent = append(acc.Policy.Spent, types.Balance{Coin: uint64(spent.Coin), Value: spent.Value.String()})
+			}
+		}
+
+		rotation := account.getKeyRotation()
+		if rotation.AuthorizedKey != (types.Address{}) {
+			acc.AuthorizedKey = &rotation.AuthorizedKey
+		}
+		if rotation.Guardian != (types.Address{}) {
+			acc.Guardian = &rotation.Guardian
+		}
+
+		for _, vesting := range a.GetVestings(account.address) {
+			acc.Vestings = append(acc.Vestings, types.Vesting{
+				Coin:        uint64(vesting.Coin),
+				Value:       vesting.Value.String(),
+				StartHeight: vesting.StartHeight,
+				CliffHeight: vesting.CliffHeight,
+				EndHeight:   vesting.EndHeight,
+			})
+		}
+
 		if account.IsMultisig() {
 			var weights []uint64
 			for _, weight := range account.MultisigData.Weights {
@@ -442,7 +1001,7 @@ func (a *Accounts) Export(state *types.AppState) {
 			}
 		}
 
-		if len(acc.Balance) == 0 && acc.Nonce == 0 && acc.MultisigData == nil {
+		if len(acc.Balance) == 0 && acc.Nonce == 0 && acc.MultisigData == nil && acc.AuthorizedKey == nil && acc.Guardian == nil && acc.Policy == nil {
 			return false
 		}
 
@@ -452,6 +1011,20 @@ func (a *Accounts) Export(state *types.AppState) {
 	})
 }
 
+func exportPolicy(policy Policy) *types.Policy {
+	exported := &types.Policy{
+		Recipients: policy.Recipients,
+	}
+	for _, limit := range policy.Limits {
+		exported.Limits = append(exported.Limits, types.Balance{Coin: uint64(limit.Coin), Value: limit.Value.String()})
+	}
+	for _, txType := range policy.ForbiddenTypes {
+		exported.ForbiddenTypes = append(exported.ForbiddenTypes, uint64(txType))
+	}
+
+	return exported
+}
+
 func (a *Accounts) GetAccount(address types.Address) *Model {
 	return a.getOrNew(address)
 }
@@ -469,3 +1042,20 @@ func (a *Accounts) setToMap(address types.Address, model *Model) {
 
 	a.list[address] = model
 }
+
+func getHolderPath(coin types.CoinID, address types.Address) []byte {
+	path := []byte{holdersPrefix}
+	path = append(path, coin.Bytes()...)
+	return append(path, address[:]...)
+}
+
+func getRichListPath(coin types.CoinID, balance *big.Int, address types.Address) []byte {
+	path := []byte{richListPrefix}
+	path = append(path, coin.Bytes()...)
+	path = append(path, balance.FillBytes(make([]byte, 32))...)
+	return append(path, address[:]...)
+}
+
+func getHoldersCountPath(coin types.CoinID) []byte {
+	return append([]byte{richListPrefix}, coin.Bytes()...)
+}
@@ -344,3 +344,313 @@ func TestAccounts_Export(t *testing.T) {
 		t.Fatal("not equal JSON")
 	}
 }
+
+func TestAccounts_AddVesting(t *testing.T) {
+	t.Parallel()
+	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024, 0)
+	b := bus.NewBus()
+	b.SetChecker(checker.NewChecker(b))
+	accounts := NewAccounts(b, mutableTree.GetLastImmutable())
+
+	address := types.Address{4}
+	accounts.SetBalance(address, 0, big.NewInt(1500))
+	accounts.AddVesting(address, Vesting{
+		Coin:        0,
+		Value:       big.NewInt(1000),
+		StartHeight: 0,
+		CliffHeight: 2,
+		EndHeight:   10,
+	})
+
+	// the first block is before the cliff
+	if unvested := accounts.GetUnvestedBalance(address, 0); unvested.Cmp(big.NewInt(1000)) != 0 {
+		t.Fatalf("unvested balance is not correct, want %d, got %s", 1000, unvested)
+	}
+	if spendable := accounts.GetSpendableBalance(address, 0); spendable.Cmp(big.NewInt(500)) != 0 {
+		t.Fatalf("spendable balance is not correct, want %d, got %s", 500, spendable)
+	}
+
+	for i := 0; i < 4; i++ {
+		_, _, err := mutableTree.Commit(accounts)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	accounts = NewAccounts(b, mutableTree.GetLastImmutable())
+
+	// the fifth block releases a half of the schedule
+	if unvested := accounts.GetUnvestedBalance(address, 0); unvested.Cmp(big.NewInt(500)) != 0 {
+		t.Fatalf("unvested balance is not correct, want %d, got %s", 500, unvested)
+	}
+	if spendable := accounts.GetSpendableBalance(address, 0); spendable.Cmp(big.NewInt(1000)) != 0 {
+		t.Fatalf("spendable balance is not correct, want %d, got %s", 1000, spendable)
+	}
+
+	vestings := accounts.GetVestings(address)
+	if len(vestings) != 1 {
+		t.Fatalf("count of vestings is not correct, want %d, got %d", 1, len(vestings))
+	}
+	if vested := vestings[0].Vested(10); vested.Cmp(big.NewInt(1000)) != 0 {
+		t.Fatalf("vested value is not correct, want %d, got %s", 1000, vested)
+	}
+}
+
+func TestAccounts_AddVestingsOfManySchedules(t *testing.T) {
+	t.Parallel()
+	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024, 0)
+	b := bus.NewBus()
+	b.SetChecker(checker.NewChecker(b))
+	accounts := NewAccounts(b, mutableTree.GetLastImmutable())
+
+	address := types.Address{4}
+	accounts.SetBalance(address, 0, big.NewInt(10000))
+	for i := uint64(0); i < 20; i++ {
+		accounts.AddVesting(address, Vesting{
+			Coin:        0,
+			Value:       big.NewInt(100),
+			StartHeight: 0,
+			CliffHeight: 0,
+			EndHeight:   3 + i,
+		})
+	}
+	// the schedule with equal heights is merged
+	accounts.AddVesting(address, Vesting{
+		Coin:        0,
+		Value:       big.NewInt(100),
+		StartHeight: 0,
+		CliffHeight: 0,
+		EndHeight:   22,
+	})
+
+	_, _, err := mutableTree.Commit(accounts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	accounts = NewAccounts(b, mutableTree.GetLastImmutable())
+
+	vestings := accounts.GetVestings(address)
+	if len(vestings) != 20 {
+		t.Fatalf("count of vestings is not correct, want %d, got %d", 20, len(vestings))
+	}
+	if last := vestings[len(vestings)-1]; last.EndHeight != 22 || last.Value.Cmp(big.NewInt(200)) != 0 {
+		t.Fatalf("merged vesting is not correct, want %d, got %s", 200, last.Value)
+	}
+
+	for i := 0; i < 4; i++ {
+		_, _, err := mutableTree.Commit(accounts)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// the sixth block removes schedules released up to it
+	accounts = NewAccounts(b, mutableTree.GetLastImmutable())
+	accounts.AddVesting(address, Vesting{
+		Coin:        0,
+		Value:       big.NewInt(100),
+		StartHeight: 0,
+		CliffHeight: 0,
+		EndHeight:   100,
+	})
+
+	_, _, err = mutableTree.Commit(accounts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	accounts = NewAccounts(b, mutableTree.GetLastImmutable())
+	if vestings := accounts.GetVestings(address); len(vestings) != 17 {
+		t.Fatalf("count of vestings is not correct, want %d, got %d", 17, len(vestings))
+	}
+}
+
+func TestAccounts_KeyRotation(t *testing.T) {
+	t.Parallel()
+	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024, 0)
+	b := bus.NewBus()
+	b.SetChecker(checker.NewChecker(b))
+	accounts := NewAccounts(b, mutableTree.GetLastImmutable())
+	accounts.SetBalance([20]byte{4}, 0, big.NewInt(1000))
+	accounts.SetAuthorizedKey([20]byte{4}, [20]byte{5})
+	accounts.SetGuardian([20]byte{4}, [20]byte{6})
+
+	_, _, err := mutableTree.Commit(accounts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	accounts = NewAccounts(b, mutableTree.GetLastImmutable())
+	if key := accounts.GetAuthorizedKey([20]byte{4}); key != [20]byte{5} {
+		t.Fatalf("authorized key %s", key.String())
+	}
+	if guardian := accounts.GetGuardian([20]byte{4}); guardian != [20]byte{6} {
+		t.Fatalf("guardian %s", guardian.String())
+	}
+
+	accounts.SetAuthorizedKey([20]byte{4}, types.Address{})
+	if key := accounts.GetAuthorizedKey([20]byte{4}); key != [20]byte{4} {
+		t.Fatalf("authorized key is not reset %s", key.String())
+	}
+}
+
+func TestAccounts_PendingPolicy(t *testing.T) {
+	t.Parallel()
+	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024, 0)
+	b := bus.NewBus()
+	b.SetChecker(checker.NewChecker(b))
+	accounts := NewAccounts(b, mutableTree.GetLastImmutable())
+	accounts.SetBalance([20]byte{4}, 0, big.NewInt(1000))
+	accounts.SetPendingPolicy([20]byte{4}, Policy{ForbiddenTypes: []byte{1}}, 2)
+
+	if !accounts.GetPolicy([20]byte{4}).IsEmpty() {
+		t.Fatal("policy takes effect before pending height")
+	}
+
+	_, _, err := mutableTree.Commit(accounts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	accounts = NewAccounts(b, mutableTree.GetLastImmutable())
+	if policy := accounts.GetPolicy([20]byte{4}); !policy.IsForbiddenType(1) {
+		t.Fatal("pending policy does not take effect")
+	}
+}
+
+func TestAccounts_GetHolders(t *testing.T) {
+	t.Parallel()
+	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024, 0)
+	b := bus.NewBus()
+	b.SetChecker(checker.NewChecker(b))
+	accounts := NewAccounts(b, mutableTree.GetLastImmutable())
+	accounts.EnableHoldersIndex()
+
+	accounts.SetBalance([20]byte{2}, 1, big.NewInt(200))
+	accounts.SetBalance([20]byte{1}, 1, big.NewInt(100))
+	accounts.SetBalance([20]byte{3}, 2, big.NewInt(300))
+
+	_, _, err := mutableTree.Commit(accounts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	accounts = NewAccounts(b, mutableTree.GetLastImmutable())
+	accounts.EnableHoldersIndex()
+	accounts.SetBalance([20]byte{2}, 1, big.NewInt(0))
+	accounts.SetBalance([20]byte{4}, 1, big.NewInt(400))
+
+	holders := accounts.GetHolders(1)
+	if len(holders) != 2 {
+		t.Fatalf("holders count %d", len(holders))
+	}
+
+	if holders[0].Address != [20]byte{1} || holders[0].Value.Cmp(big.NewInt(100)) != 0 {
+		t.Fatalf("first holder %s: %s", holders[0].Address.String(), holders[0].Value)
+	}
+
+	if holders[1].Address != [20]byte{4} || holders[1].Value.Cmp(big.NewInt(400)) != 0 {
+		t.Fatalf("second holder %s: %s", holders[1].Address.String(), holders[1].Value)
+	}
+}
+
+func TestAccounts_CoinHolders(t *testing.T) {
+	t.Parallel()
+	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024, 0)
+	b := bus.NewBus()
+	b.SetChecker(checker.NewChecker(b))
+	accounts := NewAccounts(b, mutableTree.GetLastImmutable())
+	accounts.EnableHoldersIndex()
+
+	accounts.SetBalance([20]byte{1}, 1, big.NewInt(100))
+	accounts.SetBalance([20]byte{2}, 1, big.NewInt(300))
+	accounts.SetBalance([20]byte{3}, 1, big.NewInt(200))
+	accounts.SetBalance([20]byte{4}, 2, big.NewInt(1000))
+
+	_, _, err := mutableTree.Commit(accounts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	accounts.SetBalance([20]byte{1}, 1, big.NewInt(400))
+	accounts.SetBalance([20]byte{2}, 1, big.NewInt(0))
+	accounts.SetBalance([20]byte{5}, 1, big.NewInt(50))
+
+	_, _, err = mutableTree.Commit(accounts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if count := accounts.HoldersCount(1); count != 3 {
+		t.Fatalf("holders count %d", count)
+	}
+
+	holders, cursor := accounts.CoinHolders(1, 2, nil)
+	if len(holders) != 2 || cursor == nil {
+		t.Fatalf("first page %d holders, cursor %x", len(holders), cursor)
+	}
+
+	if holders[0].Address != [20]byte{1} || holders[0].Value.Cmp(big.NewInt(400)) != 0 {
+		t.Fatalf("first holder %s: %s", holders[0].Address.String(), holders[0].Value)
+	}
+
+	if holders[1].Address != [20]byte{3} || holders[1].Value.Cmp(big.NewInt(200)) != 0 {
+		t.Fatalf("second holder %s: %s", holders[1].Address.String(), holders[1].Value)
+	}
+
+	holders, cursor = accounts.CoinHolders(1, 2, cursor)
+	if len(holders) != 1 || cursor != nil {
+		t.Fatalf("second page %d holders, cursor %x", len(holders), cursor)
+	}
+
+	if holders[0].Address != [20]byte{5} || holders[0].Value.Cmp(big.NewInt(50)) != 0 {
+		t.Fatalf("third holder %s: %s", holders[0].Address.String(), holders[0].Value)
+	}
+}
+
+func TestAccounts_ReindexHolders(t *testing.T) {
+	t.Parallel()
+	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024, 0)
+	b := bus.NewBus()
+	b.SetChecker(checker.NewChecker(b))
+	accounts := NewAccounts(b, mutableTree.GetLastImmutable())
+
+	balances := map[types.Address]*big.Int{
+		{1}: big.NewInt(100),
+		{2}: big.NewInt(300),
+	}
+	for address, balance := range balances {
+		accounts.SetBalance(address, 1, balance)
+	}
+
+	_, _, err := mutableTree.Commit(accounts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	accounts = NewAccounts(b, mutableTree.GetLastImmutable())
+	if count := accounts.HoldersCount(1); count != 0 {
+		t.Fatalf("index is kept before it is enabled: %d holders", count)
+	}
+
+	for i := 0; i < 2; i++ {
+		accounts.EnableHoldersIndex()
+		accounts.ReindexHolders()
+
+		_, _, err = mutableTree.Commit(accounts)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		accounts = NewAccounts(b, mutableTree.GetLastImmutable())
+		if count := accounts.HoldersCount(1); count != 2 {
+			t.Fatalf("holders count %d", count)
+		}
+
+		holders, _ := accounts.CoinHolders(1, 10, nil)
+		if len(holders) != 2 || holders[0].Address != [20]byte{2} || holders[1].Address != [20]byte{1} {
+			t.Fatalf("wrong holders %v", holders)
+		}
+	}
+}
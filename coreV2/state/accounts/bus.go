@@ -17,6 +17,10 @@ func (b *Bus) AddBalance(address types.Address, coin types.CoinID, value *big.In
 	b.accounts.AddBalance(address, coin, value)
 }
 
+func (b *Bus) SubBalance(address types.Address, coin types.CoinID, value *big.Int) {
+	b.accounts.SubBalance(address, coin, value)
+}
+
 func (b *Bus) IsX3Mining(address types.Address, height uint64) bool {
 	return b.accounts.IsX3Mining(address, height)
 }
@@ -26,3 +30,25 @@ func (b *Bus) GetLockStakeUntilBlock(address types.Address) (height uint64) {
 func (b *Bus) GetBalance(address types.Address, coin types.CoinID) *big.Int {
 	return b.accounts.GetBalance(address, coin)
 }
+func (b *Bus) GetSpendableBalance(address types.Address, coin types.CoinID) *big.Int {
+	return b.accounts.GetSpendableBalance(address, coin)
+}
+
+// IsAllowedByPolicy checks the transfer of value of coin to the recipient against the policy of the address
+func (b *Bus) IsAllowedByPolicy(address types.Address, recipient types.Address, coin types.CoinID, value *big.Int) bool {
+	policy := b.accounts.GetPolicy(address)
+	if !policy.IsAllowedRecipient(recipient) {
+		return false
+	}
+
+	limit := policy.GetLimit(coin)
+	if limit == nil {
+		return true
+	}
+
+	return big.NewInt(0).Add(b.accounts.GetPolicySpent(address, coin), value).Cmp(limit) != 1
+}
+
+func (b *Bus) AddPolicySpent(address types.Address, coin types.CoinID, value *big.Int) {
+	b.accounts.AddPolicySpent(address, coin, value)
+}
@@ -16,6 +16,7 @@ type Model struct {
 
 	// forward compatible
 	LockStakeUntilBlock uint64
+	KeyRotation         []KeyRotation `rlp:"tail"` // empty unless the key of the account is rotated or the guardian is set
 
 	address  types.Address
 	coins    []types.CoinID
@@ -27,10 +28,25 @@ type Model struct {
 
 	isNew bool
 
+	vestings         []Vesting
+	isVestingsLoaded bool
+	dirtyVestings    map[string]struct{}
+
+	policy         *PolicyState
+	isPolicyLoaded bool
+	hasDirtyPolicy bool
+
 	markDirty func(types.Address)
 	lock      sync.RWMutex
 }
 
+// KeyRotation holds the key which signs transactions of the account instead of the original one
+// and the guardian multisig which is able to rotate it, the empty addresses are not set
+type KeyRotation struct {
+	AuthorizedKey types.Address
+	Guardian      types.Address
+}
+
 type Multisig struct {
 	Threshold uint32
 	Weights   []uint32
@@ -39,6 +55,139 @@ type Multisig struct {
 	lock sync.RWMutex
 }
 
+// Vesting is a schedule of gradual release of funds received by an account.
+// Nothing is released before the cliff height, then funds are released linearly from the start height up to the end height
+type Vesting struct {
+	Coin        types.CoinID
+	Value       *big.Int
+	StartHeight uint64
+	CliffHeight uint64
+	EndHeight   uint64
+}
+
+// Unvested returns the amount which is not released yet at given height
+func (v *Vesting) Unvested(height uint64) *big.Int {
+	if height < v.CliffHeight || height <= v.StartHeight {
+		return big.NewInt(0).Set(v.Value)
+	}
+	if height >= v.EndHeight {
+		return big.NewInt(0)
+	}
+
+	vested := big.NewInt(0).Mul(v.Value, big.NewInt(0).SetUint64(height-v.StartHeight))
+	vested.Div(vested, big.NewInt(0).SetUint64(v.EndHeight-v.StartHeight))
+
+	return vested.Sub(v.Value, vested)
+}
+
+// Vested returns the amount which is already released at given height
+func (v *Vesting) Vested(height uint64) *big.Int {
+	return big.NewInt(0).Sub(v.Value, v.Unvested(height))
+}
+
+// PolicyPeriod is the number of blocks in a day, outflow limits of the account policy are measured within it
+const PolicyPeriod uint64 = 17280
+
+// Policy restricts outgoing transactions of the account
+type Policy struct {
+	Limits         []PolicyLimit   // max value of coin sent by Send, Multisend, standing orders and redeemed checks within PolicyPeriod blocks
+	Recipients     []types.Address // allowed recipients of Send, Multisend, standing orders and redeemed checks, empty list allows any
+	ForbiddenTypes []byte          // types of transactions the account is not allowed to send
+}
+
+type PolicyLimit struct {
+	Coin  types.CoinID
+	Value *big.Int
+}
+
+// IsEmpty returns true if the policy has no restrictions
+func (p Policy) IsEmpty() bool {
+	return len(p.Limits) == 0 && len(p.Recipients) == 0 && len(p.ForbiddenTypes) == 0
+}
+
+// IsOutflowRestricted returns true if the policy limits outflows or their recipients,
+// transactions which move value of the account other than Send and Multisend are not allowed by such policy
+func (p Policy) IsOutflowRestricted() bool {
+	return len(p.Limits) != 0 || len(p.Recipients) != 0
+}
+
+// GetLimit returns the outflow limit of coin or nil if coin is not limited
+func (p Policy) GetLimit(coin types.CoinID) *big.Int {
+	for _, limit := range p.Limits {
+		if limit.Coin == coin {
+			return limit.Value
+		}
+	}
+
+	return nil
+}
+
+// IsAllowedRecipient returns true if coins can be sent to the address
+func (p Policy) IsAllowedRecipient(address types.Address) bool {
+	if len(p.Recipients) == 0 {
+		return true
+	}
+
+	for _, recipient := range p.Recipients {
+		if recipient == address {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsForbiddenType returns true if transactions of the type can not be sent by the account
+func (p Policy) IsForbiddenType(txType byte) bool {
+	for _, forbidden := range p.ForbiddenTypes {
+		if forbidden == txType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// PolicyState is the policy of the account with its pending change and the outflow of the current period
+type PolicyState struct {
+	Policy        Policy
+	Pending       Policy
+	PendingHeight uint64 // height the pending policy takes effect at, zero if there is no pending change
+	PeriodStart   uint64
+	Spent         []PolicyLimit
+}
+
+// actual returns the copy of the state at given height with the pending policy applied and the expired period reset
+func (s *PolicyState) actual(height uint64) *PolicyState {
+	actual := &PolicyState{
+		Policy:        s.Policy,
+		Pending:       s.Pending,
+		PendingHeight: s.PendingHeight,
+		PeriodStart:   s.PeriodStart,
+		Spent:         make([]PolicyLimit, 0, len(s.Spent)),
+	}
+	for _, spent := range s.Spent {
+		actual.Spent = append(actual.Spent, PolicyLimit{Coin: spent.Coin, Value: big.NewInt(0).Set(spent.Value)})
+	}
+
+	if actual.PendingHeight != 0 && height >= actual.PendingHeight {
+		actual.Policy = actual.Pending
+		actual.Pending = Policy{}
+		actual.PendingHeight = 0
+	}
+
+	if height >= actual.PeriodStart+PolicyPeriod {
+		actual.PeriodStart = height
+		actual.Spent = nil
+	}
+
+	return actual
+}
+
+func (s *PolicyState) isEmpty() bool {
+	return s.Policy.IsEmpty() && s.PendingHeight == 0 && len(s.Spent) == 0
+}
+
 func CreateMultisigAddress(owner types.Address, nonce uint64) types.Address {
 	b, err := rlp.EncodeToBytes(&struct {
 		Owner types.Address
@@ -197,3 +346,70 @@ func (model *Model) setLockStakeUntilBlock(h uint64) {
 	model.isDirty = true
 	model.markDirty(model.address)
 }
+
+func (model *Model) getKeyRotation() KeyRotation {
+	model.lock.RLock()
+	defer model.lock.RUnlock()
+
+	if len(model.KeyRotation) == 0 {
+		return KeyRotation{}
+	}
+
+	return model.KeyRotation[0]
+}
+
+func (model *Model) setKeyRotation(rotation KeyRotation) {
+	model.lock.Lock()
+	defer model.lock.Unlock()
+
+	if rotation == (KeyRotation{}) {
+		model.KeyRotation = nil
+	} else {
+		model.KeyRotation = []KeyRotation{rotation}
+	}
+
+	model.isDirty = true
+	model.markDirty(model.address)
+}
+
+func (model *Model) getPolicy() *PolicyState {
+	model.lock.RLock()
+	defer model.lock.RUnlock()
+
+	return model.policy
+}
+
+func (model *Model) setPolicy(policy *PolicyState) {
+	model.lock.Lock()
+	defer model.lock.Unlock()
+
+	model.policy = policy
+	model.isPolicyLoaded = true
+	model.hasDirtyPolicy = true
+	model.markDirty(model.address)
+}
+
+func (model *Model) getVestings() []Vesting {
+	model.lock.RLock()
+	defer model.lock.RUnlock()
+
+	vestings := make([]Vesting, len(model.vestings))
+	copy(vestings, model.vestings)
+
+	return vestings
+}
+
+func (model *Model) setVestings(vestings []Vesting, dirtyKeys [][]byte) {
+	model.lock.Lock()
+	model.vestings = vestings
+	model.isVestingsLoaded = true
+	if model.dirtyVestings == nil {
+		model.dirtyVestings = map[string]struct{}{}
+	}
+	for _, key := range dirtyKeys {
+		model.dirtyVestings[string(key)] = struct{}{}
+	}
+	model.lock.Unlock()
+
+	model.markDirty(model.address)
+}
@@ -4,12 +4,11 @@ import (
 	"fmt"
 	"math/big"
 	"sync"
-	"sync/atomic"
 
 	"github.com/MinterTeam/minter-go-node/coreV2/state/bus"
 	"github.com/MinterTeam/minter-go-node/coreV2/types"
 	"github.com/MinterTeam/minter-go-node/rlp"
-	"github.com/cosmos/iavl"
+	"github.com/MinterTeam/minter-go-node/tree"
 )
 
 const mainPrefix = 'd'
@@ -28,14 +27,14 @@ type App struct {
 	model   *Model
 	isDirty bool
 
-	db atomic.Value
+	db tree.AtomicTree
 
 	bus *bus.Bus
 	mx  sync.Mutex
 }
 
-func NewApp(stateBus *bus.Bus, db *iavl.ImmutableTree) *App {
-	immutableTree := atomic.Value{}
+func NewApp(stateBus *bus.Bus, db tree.ImmutableTree) *App {
+	immutableTree := tree.AtomicTree{}
 	if db != nil {
 		immutableTree.Store(db)
 	}
@@ -45,19 +44,15 @@ func NewApp(stateBus *bus.Bus, db *iavl.ImmutableTree) *App {
 	return app
 }
 
-func (a *App) immutableTree() *iavl.ImmutableTree {
-	db := a.db.Load()
-	if db == nil {
-		return nil
-	}
-	return db.(*iavl.ImmutableTree)
+func (a *App) immutableTree() tree.ImmutableTree {
+	return a.db.Load()
 }
 
-func (a *App) SetImmutableTree(immutableTree *iavl.ImmutableTree) {
+func (a *App) SetImmutableTree(immutableTree tree.ImmutableTree) {
 	a.db.Store(immutableTree)
 }
 
-func (a *App) Commit(db *iavl.MutableTree, version int64) error {
+func (a *App) Commit(db tree.MutableTree, version int64) error {
 	a.mx.Lock()
 	defer a.mx.Unlock()
 
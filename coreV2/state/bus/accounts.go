@@ -7,7 +7,11 @@ import (
 
 type Accounts interface {
 	AddBalance(types.Address, types.CoinID, *big.Int)
+	SubBalance(types.Address, types.CoinID, *big.Int)
 	IsX3Mining(addr types.Address, height uint64) bool
 	GetLockStakeUntilBlock(address types.Address) (height uint64)
 	GetBalance(address types.Address, coin types.CoinID) *big.Int
+	GetSpendableBalance(address types.Address, coin types.CoinID) *big.Int
+	IsAllowedByPolicy(address types.Address, recipient types.Address, coin types.CoinID, value *big.Int) bool
+	AddPolicySpent(address types.Address, coin types.CoinID, value *big.Int)
 }
@@ -13,6 +13,8 @@ type Bus struct {
 	events      eventsdb.IEventsDB
 	checker     Checker
 	validators  Validators
+	governance  Governance
+	treasury    Treasury
 }
 
 func NewBus() *Bus {
@@ -98,3 +100,19 @@ func (b *Bus) SetChecker(checker Checker) {
 func (b *Bus) Checker() Checker {
 	return b.checker
 }
+
+func (b *Bus) SetGovernance(governance Governance) {
+	b.governance = governance
+}
+
+func (b *Bus) Governance() Governance {
+	return b.governance
+}
+
+func (b *Bus) SetTreasury(treasury Treasury) {
+	b.treasury = treasury
+}
+
+func (b *Bus) Treasury() Treasury {
+	return b.treasury
+}
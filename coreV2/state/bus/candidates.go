@@ -13,6 +13,7 @@ type Candidates interface {
 	GetCandidate(types.Pubkey) *Candidate
 	GetCandidateByTendermintAddress(types.TmAddress) *Candidate
 	TotalStakes() *big.Int
+	SlashStake(types.Address, uint32, types.CoinID, *big.Int, types.Pubkey) *big.Int
 }
 
 type Stake struct {
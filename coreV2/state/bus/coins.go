@@ -13,6 +13,9 @@ type Coins interface {
 	GetCoin(types.CoinID) *Coin
 	SubCoinVolume(types.CoinID, *big.Int)
 	SubCoinReserve(types.CoinID, *big.Int)
+	IsCoinPaused(types.CoinID) bool
+	IsCoinFrozen(types.CoinID, types.Address) bool
+	PayTransferFee(id types.CoinID, sender types.Address, value *big.Int) *big.Int
 }
 
 type Coin struct {
@@ -15,4 +15,5 @@ type FrozenFund struct {
 
 type FrozenFunds interface {
 	AddFrozenFund(uint64, types.Address, *types.Pubkey, uint32, types.CoinID, *big.Int)
+	SlashFrozenFund(uint64, uint64, types.Address, uint32, types.CoinID, *big.Int, types.Pubkey) *big.Int
 }
@@ -0,0 +1,9 @@
+package bus
+
+type Governance interface {
+	UnbondPeriod() uint64
+	ValidatorMaxAbsentTimes() uint64
+	ValidatorMaxAbsentWindow() uint64
+	MaxDelegatorsPerCandidate() uint64
+	DAOCommission() uint64
+}
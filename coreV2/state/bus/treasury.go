@@ -0,0 +1,11 @@
+package bus
+
+import (
+	"math/big"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+)
+
+type Treasury interface {
+	AddIncome(types.CoinID, *big.Int)
+}
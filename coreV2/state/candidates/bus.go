@@ -36,6 +36,11 @@ func (b *Bus) Punish(height uint64, address types.TmAddress) {
 	b.candidates.Punish(height, address)
 }
 
+// SlashStake slashes given value from a stake of an address at a candidate with given ID
+func (b *Bus) SlashStake(address types.Address, candidateID uint32, coin types.CoinID, value *big.Int, punishedPubKey types.Pubkey) *big.Int {
+	return b.candidates.SlashStake(address, b.candidates.PubKey(candidateID), coin, value, punishedPubKey)
+}
+
 // ID returns id by a public key
 func (b *Bus) ID(pubkey types.Pubkey) uint32 {
 	return b.candidates.ID(pubkey)
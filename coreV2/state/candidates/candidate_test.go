@@ -703,6 +703,10 @@ type fr struct {
 func (fr *fr) AddFrozenFund(_ uint64, _ types.Address, _ *types.Pubkey, _ uint32, _ types.CoinID, value *big.Int) {
 	fr.unbounds = append(fr.unbounds, value)
 }
+
+func (fr *fr) SlashFrozenFund(_, _ uint64, _ types.Address, _ uint32, _ types.CoinID, _ *big.Int, _ types.Pubkey) *big.Int {
+	return big.NewInt(0)
+}
 func TestCandidates_PunishByzantineCandidate(t *testing.T) {
 	t.Parallel()
 	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024, 0)
@@ -929,6 +933,77 @@ func TestCandidates_IsDelegatorStakeSufficient_false(t *testing.T) {
 	}
 }
 
+type mockGovernance struct {
+	maxDelegators uint64
+}
+
+func (m mockGovernance) UnbondPeriod() uint64              { return types.GetUnbondPeriod() }
+func (m mockGovernance) ValidatorMaxAbsentTimes() uint64   { return 12 }
+func (m mockGovernance) ValidatorMaxAbsentWindow() uint64  { return 24 }
+func (m mockGovernance) MaxDelegatorsPerCandidate() uint64 { return m.maxDelegators }
+func (m mockGovernance) DAOCommission() uint64             { return 10 }
+
+func TestCandidates_RecalculateStakes_maxDelegatorsByGovernance(t *testing.T) {
+	t.Parallel()
+	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024, 0)
+	b := bus.NewBus()
+	wl := waitlist.NewWaitList(b, mutableTree.GetLastImmutable())
+
+	b.SetWaitList(waitlist.NewBus(wl))
+	b.SetChecker(checker.NewChecker(b))
+	b.SetEvents(eventsdb.NewEventsStore(db.NewMemDB()))
+	b.SetGovernance(mockGovernance{maxDelegators: 2})
+	candidates := NewCandidates(b, mutableTree.GetLastImmutable())
+
+	candidates.Create([20]byte{1}, [20]byte{2}, [20]byte{3}, [32]byte{4}, 10, 0, 0)
+	candidates.SetStakes([32]byte{4}, []types.Stake{
+		{
+			Owner:    [20]byte{1},
+			Coin:     0,
+			Value:    "100",
+			BipValue: "100",
+		},
+		{
+			Owner:    [20]byte{2},
+			Coin:     0,
+			Value:    "200",
+			BipValue: "200",
+		},
+	}, []types.Stake{
+		{
+			Owner:    [20]byte{3},
+			Coin:     0,
+			Value:    "300",
+			BipValue: "300",
+		},
+		{
+			Owner:    [20]byte{5},
+			Coin:     0,
+			Value:    "50",
+			BipValue: "50",
+		},
+	})
+
+	candidates.recalculateStakes(0)
+	_, _, err := mutableTree.Commit(candidates, wl)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if count := len(candidates.GetStakes([32]byte{4})); count != 2 {
+		t.Fatalf("Stakes count is not limited by governance. Expected %d, got %d", 2, count)
+	}
+	if candidates.GetStakeOfAddress([32]byte{4}, [20]byte{1}, 0) != nil || candidates.GetStakeOfAddress([32]byte{4}, [20]byte{5}, 0) != nil {
+		t.Fatal("The smallest stakes are not kicked")
+	}
+	if candidates.GetStakeOfAddress([32]byte{4}, [20]byte{3}, 0) == nil {
+		t.Fatal("The largest update is not added")
+	}
+	if candidates.IsDelegatorStakeSufficient([20]byte{6}, [32]byte{4}, 0, big.NewInt(10)) {
+		t.Fatal("Stake over the limit set by governance is sufficient")
+	}
+}
+
 func TestCandidates_GetNewCandidates(t *testing.T) {
 	t.Parallel()
 	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024, 0)
@@ -1167,3 +1242,93 @@ func TestCandidates_CalculateBipValue_RecalculateStakes_GetTotalStake(t *testing
 		t.Fatalf("total stake %s", totalStake.String())
 	}
 }
+
+func TestCandidates_Commit_DelegatorCandidateIDs(t *testing.T) {
+	t.Parallel()
+	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024, 0)
+	b := bus.NewBus()
+	b.SetChecker(checker.NewChecker(b))
+	candidates := NewCandidates(b, mutableTree.GetLastImmutable())
+	candidates.EnableDelegatorsIndex()
+
+	candidates.Create([20]byte{1}, [20]byte{2}, [20]byte{3}, [32]byte{4}, 10, 0, 0)
+	candidates.Create([20]byte{1}, [20]byte{2}, [20]byte{3}, [32]byte{5}, 10, 0, 0)
+
+	delegator := types.Address{1, 1}
+	candidates.Delegate(delegator, [32]byte{4}, 0, big.NewInt(10000000), big.NewInt(10000000))
+	candidates.Delegate(delegator, [32]byte{5}, 0, big.NewInt(10000000), big.NewInt(10000000))
+
+	_, _, err := mutableTree.Commit(candidates)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ids := NewCandidates(b, mutableTree.GetLastImmutable()).GetDelegatorCandidateIDs(delegator)
+	if len(ids) != 2 || ids[0] != candidates.ID([32]byte{4}) || ids[1] != candidates.ID([32]byte{5}) {
+		t.Fatalf("wrong candidate ids of delegator: %v", ids)
+	}
+
+	candidates.RecalculateStakes(0)
+
+	_, _, err = mutableTree.Commit(candidates)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	candidates.SubStake(delegator, [32]byte{4}, 0, big.NewInt(10000000))
+
+	_, _, err = mutableTree.Commit(candidates)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ids = NewCandidates(b, mutableTree.GetLastImmutable()).GetDelegatorCandidateIDs(delegator)
+	if len(ids) != 1 || ids[0] != candidates.ID([32]byte{5}) {
+		t.Fatalf("wrong candidate ids of delegator: %v", ids)
+	}
+}
+
+func TestCandidates_ReindexDelegators(t *testing.T) {
+	t.Parallel()
+	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024, 0)
+	b := bus.NewBus()
+	b.SetChecker(checker.NewChecker(b))
+	candidates := NewCandidates(b, mutableTree.GetLastImmutable())
+
+	candidates.Create([20]byte{1}, [20]byte{2}, [20]byte{3}, [32]byte{4}, 10, 0, 0)
+	candidates.Create([20]byte{1}, [20]byte{2}, [20]byte{3}, [32]byte{5}, 10, 0, 0)
+
+	stakeID, pendingID := candidates.ID([32]byte{4}), candidates.ID([32]byte{5})
+	staker, pending := types.Address{1, 1}, types.Address{2, 2}
+	candidates.Delegate(staker, [32]byte{4}, 0, big.NewInt(10000000), big.NewInt(10000000))
+	candidates.RecalculateStakes(0)
+	candidates.Delegate(pending, [32]byte{5}, 0, big.NewInt(10000000), big.NewInt(10000000))
+
+	_, _, err := mutableTree.Commit(candidates)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	candidates = NewCandidates(b, mutableTree.GetLastImmutable())
+	candidates.LoadCandidatesDeliver()
+	candidates.LoadStakes()
+	if ids := candidates.GetDelegatorCandidateIDs(staker); len(ids) != 0 {
+		t.Fatalf("index is kept before it is enabled: %v", ids)
+	}
+
+	candidates.EnableDelegatorsIndex()
+	candidates.ReindexDelegators()
+
+	_, _, err = mutableTree.Commit(candidates)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	candidates = NewCandidates(b, mutableTree.GetLastImmutable())
+	if ids := candidates.GetDelegatorCandidateIDs(staker); len(ids) != 1 || ids[0] != stakeID {
+		t.Fatalf("wrong candidate ids of delegator: %v", ids)
+	}
+	if ids := candidates.GetDelegatorCandidateIDs(pending); len(ids) != 1 || ids[0] != pendingID {
+		t.Fatalf("wrong candidate ids of pending delegator: %v", ids)
+	}
+}
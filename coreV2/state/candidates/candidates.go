@@ -7,7 +7,6 @@ import (
 	"github.com/MinterTeam/minter-go-node/coreV2/dao"
 	"github.com/MinterTeam/minter-go-node/coreV2/developers"
 	"log"
-	"sync/atomic"
 
 	eventsdb "github.com/MinterTeam/minter-go-node/coreV2/events"
 	"github.com/MinterTeam/minter-go-node/coreV2/state/bus"
@@ -15,7 +14,7 @@ import (
 	"github.com/MinterTeam/minter-go-node/formula"
 	"github.com/MinterTeam/minter-go-node/helpers"
 	"github.com/MinterTeam/minter-go-node/rlp"
-	"github.com/cosmos/iavl"
+	"github.com/MinterTeam/minter-go-node/tree"
 
 	"math/big"
 	"sort"
@@ -39,6 +38,9 @@ const (
 	stakesPrefix           = 's'
 	totalStakePrefix       = 't'
 	updatesPrefix          = 'u'
+	delegationPrefix       = 'l'
+	delegatorsPrefix       = mainPrefix + 'a'
+	pendingCommissionsKey  = mainPrefix + 'm'
 )
 
 var (
@@ -68,7 +70,14 @@ type RCandidates interface {
 	LoadStakes()
 	GetCandidates() []*Candidate
 	GetStakes(pubkey types.Pubkey) []*stake
+	GetUpdates(pubkey types.Pubkey) []*stake
 	IsCandidateJailed(pubkey types.Pubkey, block uint64) bool
+	GetDelegationSettings(pubkey types.Pubkey) *DelegationSettings
+	IsDelegatorAllowed(address types.Address, pubkey types.Pubkey) bool
+	IsDelegationWithinLimits(pubkey types.Pubkey, coin types.CoinID, amount *big.Int) (low, big bool)
+	ID(pubKey types.Pubkey) uint32
+	GetDelegatorCandidateIDs(address types.Address) []uint32
+	GetPendingCommission(pubkey types.Pubkey) *PendingCommission
 }
 
 // Candidates struct is a store of Candidates state
@@ -80,7 +89,7 @@ type Candidates struct {
 	pubKeyIDs map[types.Pubkey]uint32
 	maxID     uint32
 
-	db  atomic.Value
+	db  tree.AtomicTree
 	bus *bus.Bus
 
 	lock                sync.RWMutex
@@ -91,6 +100,16 @@ type Candidates struct {
 	deletedCandidates      map[types.Pubkey]*deletedID
 	dirtyDeletedCandidates bool
 	muDeletedCandidates    sync.RWMutex
+
+	delegators      map[types.Address][]uint32
+	dirtyDelegators map[types.Address]struct{}
+	delegatorsIndex bool
+	muDelegators    sync.RWMutex
+
+	pendingCommissions         map[uint32]*PendingCommission
+	isPendingCommissionsLoaded bool
+	isPendingCommissionsDirty  bool
+	muPendingCommissions       sync.RWMutex
 }
 
 type deletedID struct {
@@ -100,8 +119,8 @@ type deletedID struct {
 }
 
 // NewCandidates returns newly created Candidates state with a given bus and iavl
-func NewCandidates(bus *bus.Bus, db *iavl.ImmutableTree) *Candidates {
-	immutableTree := atomic.Value{}
+func NewCandidates(bus *bus.Bus, db tree.ImmutableTree) *Candidates {
+	immutableTree := tree.AtomicTree{}
 	loaded := false
 	if db != nil {
 		immutableTree.Store(db)
@@ -113,6 +132,8 @@ func NewCandidates(bus *bus.Bus, db *iavl.ImmutableTree) *Candidates {
 		loaded:            loaded,
 		bus:               bus,
 		deletedCandidates: map[types.Pubkey]*deletedID{},
+		delegators:        map[types.Address][]uint32{},
+		dirtyDelegators:   map[types.Address]struct{}{},
 		blockList:         map[types.Pubkey]struct{}{},
 		pubKeyIDs:         map[types.Pubkey]uint32{},
 		list:              map[uint32]*Candidate{},
@@ -123,15 +144,11 @@ func NewCandidates(bus *bus.Bus, db *iavl.ImmutableTree) *Candidates {
 	return candidates
 }
 
-func (c *Candidates) immutableTree() *iavl.ImmutableTree {
-	db := c.db.Load()
-	if db == nil {
-		return nil
-	}
-	return db.(*iavl.ImmutableTree)
+func (c *Candidates) immutableTree() tree.ImmutableTree {
+	return c.db.Load()
 }
 
-func (c *Candidates) SetImmutableTree(immutableTree *iavl.ImmutableTree) {
+func (c *Candidates) SetImmutableTree(immutableTree tree.ImmutableTree) {
 	if c.immutableTree() == nil && c.loaded {
 		c.loaded = false
 	}
@@ -180,7 +197,8 @@ func (c *Candidates) IsCandidateJailed(pubkey types.Pubkey, block uint64) bool {
 }
 
 // Commit writes changes to iavl, may return an error
-func (c *Candidates) Commit(db *iavl.MutableTree, version int64) error {
+func (c *Candidates) Commit(db tree.MutableTree, version int64) error {
+	delegatorsIndex := c.isDelegatorsIndexEnabled()
 	keys := c.getOrderedCandidates()
 
 	c.lock.RLock()
@@ -273,7 +291,7 @@ func (c *Candidates) Commit(db *iavl.MutableTree, version int64) error {
 			if id.isDirty {
 				id.isDirty = false
 				db.IterateRange(append([]byte{mainPrefix}, idBytes(id.ID)...), append([]byte{mainPrefix}, idBytes(id.ID+1)...), true, func(key []byte, value []byte) bool {
-					if len(key) <= 5 || !(key[5] == stakesPrefix || key[5] == updatesPrefix || key[5] == totalStakePrefix) {
+					if len(key) <= 5 || !(key[5] == stakesPrefix || key[5] == updatesPrefix || key[5] == totalStakePrefix || key[5] == delegationPrefix) {
 						return false
 					}
 
@@ -342,6 +360,9 @@ func (c *Candidates) Commit(db *iavl.MutableTree, version int64) error {
 				db.Remove(path)
 
 				candidate.lock.Lock()
+				if stake != nil {
+					candidate.addDirtyDelegator(stake.Owner)
+				}
 				candidate.stakes[index] = nil
 				candidate.lock.Unlock()
 				continue
@@ -373,6 +394,72 @@ func (c *Candidates) Commit(db *iavl.MutableTree, version int64) error {
 			path = append(path, updatesPrefix)
 			db.Set(path, data)
 		}
+
+		candidate.lock.RLock()
+		delegationSettingsDirty := candidate.isDelegationSettingsDirty
+		candidate.lock.RUnlock()
+
+		if delegationSettingsDirty && delegatorsIndex {
+			candidate.lock.Lock()
+			candidate.isDelegationSettingsDirty = false
+			settings := candidate.delegationSettings
+			candidate.lock.Unlock()
+
+			path := []byte{mainPrefix}
+			path = append(path, candidate.idBytes()...)
+			path = append(path, delegationPrefix)
+
+			if settings == nil {
+				db.Remove(path)
+			} else {
+				data, err := rlp.EncodeToBytes(settings)
+				if err != nil {
+					return fmt.Errorf("can't encode candidate delegation settings: %v", err)
+				}
+				db.Set(path, data)
+			}
+		}
+
+		if dirtyDelegators := candidate.popDirtyDelegators(); len(dirtyDelegators) != 0 {
+			delegators := candidate.getDelegators()
+			for _, address := range dirtyDelegators {
+				_, has := delegators[address]
+				c.setDelegatorCandidate(address, candidate.ID, has)
+			}
+		}
+	}
+
+	if err := c.commitPendingCommissions(db); err != nil {
+		return err
+	}
+
+	c.muDelegators.Lock()
+	defer c.muDelegators.Unlock()
+
+	dirtyDelegators := make([]types.Address, 0, len(c.dirtyDelegators))
+	for address := range c.dirtyDelegators {
+		dirtyDelegators = append(dirtyDelegators, address)
+	}
+	sort.SliceStable(dirtyDelegators, func(i, j int) bool {
+		return bytes.Compare(dirtyDelegators[i].Bytes(), dirtyDelegators[j].Bytes()) == -1
+	})
+
+	for _, address := range dirtyDelegators {
+		delete(c.dirtyDelegators, address)
+
+		path := append([]byte{delegatorsPrefix}, address.Bytes()...)
+		ids := c.delegators[address]
+		if len(ids) == 0 {
+			delete(c.delegators, address)
+			db.Remove(path)
+			continue
+		}
+
+		data, err := rlp.EncodeToBytes(ids)
+		if err != nil {
+			return fmt.Errorf("can't encode candidates of delegator: %v", err)
+		}
+		db.Set(path, data)
 	}
 
 	return nil
@@ -448,31 +535,91 @@ func (c *Candidates) PunishByzantineCandidate(height uint64, tmAddress types.TmA
 		slashed := big.NewInt(0).Set(stake.Value)
 		slashed.Sub(slashed, newValue)
 
-		if !stake.Coin.IsBaseCoin() {
-			coin := c.bus.Coins().GetCoin(stake.Coin)
-			ret := formula.CalculateSaleReturn(coin.Volume, coin.Reserve, coin.Crr, slashed)
+		c.slash(stake.Owner, stake.Coin, slashed, candidate.PubKey)
 
-			c.bus.Coins().SubCoinVolume(coin.ID, slashed)
-			c.bus.Coins().SubCoinReserve(coin.ID, ret)
+		c.bus.Checker().AddCoin(stake.Coin, big.NewInt(0).Neg(newValue))
+		c.bus.FrozenFunds().AddFrozenFund(height+c.unbondPeriod(), stake.Owner, &candidate.PubKey, candidate.ID, stake.Coin, newValue)
+		stake.setValue(big.NewInt(0))
+	}
+}
+
+// maxDelegators returns the number of stakes a candidate can have, it can be changed by governance
+func (c *Candidates) maxDelegators() int {
+	if c.bus.Governance() == nil {
+		return MaxDelegatorsPerCandidate
+	}
+	return int(c.bus.Governance().MaxDelegatorsPerCandidate())
+}
+
+// unbondPeriod returns the number of blocks funds are frozen for, it can be changed by governance
+func (c *Candidates) unbondPeriod() uint64 {
+	if c.bus.Governance() == nil {
+		return types.GetUnbondPeriod()
+	}
+	return c.bus.Governance().UnbondPeriod()
+}
+
+// SlashStake slashes given value from a stake or a pending update of an address at a candidate.
+// Returns slashed value, which is limited by the stake value
+func (c *Candidates) SlashStake(address types.Address, pubkey types.Pubkey, coin types.CoinID, value *big.Int, punishedPubKey types.Pubkey) *big.Int {
+	candidate := c.GetCandidate(pubkey)
+	if candidate == nil {
+		return big.NewInt(0)
+	}
 
-			c.bus.App().AddTotalSlashed(ret)
-		} else {
-			c.bus.App().AddTotalSlashed(slashed)
+	stake := c.GetStakeOfAddress(pubkey, address, coin)
+	if stake == nil {
+		candidate.lock.RLock()
+		for _, update := range candidate.updates {
+			if update.Owner == address && update.Coin == coin {
+				stake = update
+				break
+			}
 		}
+		candidate.lock.RUnlock()
+	}
+	if stake == nil {
+		return big.NewInt(0)
+	}
 
-		c.bus.Checker().AddCoin(stake.Coin, big.NewInt(0).Neg(slashed))
+	stake.lock.RLock()
+	slashed := big.NewInt(0).Set(value)
+	if slashed.Cmp(stake.Value) == 1 {
+		slashed.Set(stake.Value)
+	}
+	stake.lock.RUnlock()
 
-		c.bus.Events().AddEvent(&eventsdb.SlashEvent{
-			Address:         stake.Owner,
-			Amount:          slashed.String(),
-			Coin:            uint64(stake.Coin),
-			ValidatorPubKey: candidate.PubKey,
-		})
+	if slashed.Sign() != 1 {
+		return slashed
+	}
 
-		c.bus.Checker().AddCoin(stake.Coin, big.NewInt(0).Neg(newValue))
-		c.bus.FrozenFunds().AddFrozenFund(height+types.GetUnbondPeriod(), stake.Owner, &candidate.PubKey, candidate.ID, stake.Coin, newValue)
-		stake.setValue(big.NewInt(0))
+	stake.subValue(slashed)
+	c.slash(address, coin, slashed, punishedPubKey)
+
+	return slashed
+}
+
+func (c *Candidates) slash(owner types.Address, coinID types.CoinID, slashed *big.Int, pubkey types.Pubkey) {
+	if !coinID.IsBaseCoin() {
+		coin := c.bus.Coins().GetCoin(coinID)
+		ret := formula.CalculateSaleReturn(coin.Volume, coin.Reserve, coin.Crr, slashed)
+
+		c.bus.Coins().SubCoinVolume(coin.ID, slashed)
+		c.bus.Coins().SubCoinReserve(coin.ID, ret)
+
+		c.bus.App().AddTotalSlashed(ret)
+	} else {
+		c.bus.App().AddTotalSlashed(slashed)
 	}
+
+	c.bus.Checker().AddCoin(coinID, big.NewInt(0).Neg(slashed))
+
+	c.bus.Events().AddEvent(&eventsdb.SlashEvent{
+		Address:         owner,
+		Amount:          slashed.String(),
+		Coin:            uint64(coinID),
+		ValidatorPubKey: pubkey,
+	})
 }
 
 // GetCandidateByTendermintAddress finds and returns candidate with given tendermint-address
@@ -539,6 +686,15 @@ func (c *Candidates) recalculateStakes(height uint64) {
 			update.setBipValue(c.calculateBipValue(update.Coin, update.Value, false, true, coinsCache))
 		}
 
+		// free places are taken only below the limit set by governance, the stakes over the lowered limit are kept
+		limit := c.maxDelegators()
+		count := 0
+		for _, stake := range stakes {
+			if stake != nil {
+				count++
+			}
+		}
+
 		for _, update := range candidate.updates {
 			// find and replace smallest stake
 			index := -1
@@ -546,6 +702,9 @@ func (c *Candidates) recalculateStakes(height uint64) {
 
 			for i, stake := range stakes {
 				if stake == nil {
+					if count >= limit {
+						continue
+					}
 					index = i
 					smallestStake = big.NewInt(0)
 					break
@@ -565,6 +724,8 @@ func (c *Candidates) recalculateStakes(height uint64) {
 
 			if stakes[index] != nil {
 				c.stakeKick(stakes[index].Owner, stakes[index].Value, stakes[index].Coin, candidate.PubKey, height)
+			} else {
+				count++
 			}
 
 			candidate.setStakeAtIndex(index, update, true)
@@ -666,7 +827,7 @@ func (c *Candidates) GetCandidate(pubkey types.Pubkey) *Candidate {
 // IsDelegatorStakeSufficient determines if given stake is sufficient to add it to a candidate
 func (c *Candidates) IsDelegatorStakeSufficient(address types.Address, pubkey types.Pubkey, coin types.CoinID, amount *big.Int) bool {
 	stakes := c.GetStakes(pubkey)
-	if len(stakes) < MaxDelegatorsPerCandidate {
+	if len(stakes) < c.maxDelegators() {
 		return true
 	}
 
@@ -687,7 +848,7 @@ func (c *Candidates) IsDelegatorStakeAllowed(address types.Address, pubkey types
 	stakeValue := c.calculateBipValue(coin, amount, true, true, nil)
 
 	stakes := c.GetStakes(pubkey)
-	if len(stakes) < MaxDelegatorsPerCandidate {
+	if len(stakes) < c.maxDelegators() {
 		low = false
 	} else {
 		for _, stake := range stakes {
@@ -726,6 +887,97 @@ func (c *Candidates) IsDelegatorStakeAllowed(address types.Address, pubkey types
 	return false, false
 }
 
+// GetDelegationSettings returns delegation restrictions of a candidate or nil if there are none
+func (c *Candidates) GetDelegationSettings(pubkey types.Pubkey) *DelegationSettings {
+	candidate := c.getFromMap(pubkey)
+	if candidate == nil {
+		return nil
+	}
+
+	return c.loadDelegationSettings(candidate)
+}
+
+// SetDelegationSettings sets delegation restrictions of a candidate, empty settings remove restrictions
+func (c *Candidates) SetDelegationSettings(pubkey types.Pubkey, settings *DelegationSettings) {
+	if settings != nil && settings.IsEmpty() {
+		settings = nil
+	}
+
+	c.getFromMap(pubkey).setDelegationSettings(settings)
+}
+
+// IsDelegatorAllowed determines if given address is allowed to delegate to a candidate by its owner
+func (c *Candidates) IsDelegatorAllowed(address types.Address, pubkey types.Pubkey) bool {
+	settings := c.GetDelegationSettings(pubkey)
+	if settings == nil {
+		return true
+	}
+
+	return settings.IsAllowed(address)
+}
+
+// IsDelegationWithinLimits determines if given stake fits min delegation amount and max total stake of a candidate
+func (c *Candidates) IsDelegationWithinLimits(pubkey types.Pubkey, coin types.CoinID, amount *big.Int) (low, b bool) {
+	settings := c.GetDelegationSettings(pubkey)
+	if settings == nil {
+		return false, false
+	}
+
+	stakeValue := c.calculateBipValue(coin, amount, true, true, nil)
+	if settings.MinDelegation.Sign() == 1 && stakeValue.Cmp(settings.MinDelegation) == -1 {
+		return true, false
+	}
+
+	if settings.MaxStake.Sign() == 1 {
+		candidate := c.getFromMap(pubkey)
+		newTotalStake := big.NewInt(0).Add(candidate.GetTotalBipStake(), stakeValue)
+		candidate.lock.RLock()
+		for _, update := range candidate.updates {
+			newTotalStake.Add(newTotalStake, update.BipValue)
+		}
+		candidate.lock.RUnlock()
+
+		if newTotalStake.Cmp(settings.MaxStake) == 1 {
+			return false, true
+		}
+	}
+
+	return false, false
+}
+
+func (c *Candidates) loadDelegationSettings(candidate *Candidate) *DelegationSettings {
+	candidate.lock.RLock()
+	if candidate.isDelegationSettingsLoaded {
+		defer candidate.lock.RUnlock()
+		return candidate.delegationSettings
+	}
+	candidate.lock.RUnlock()
+
+	var settings *DelegationSettings
+	if immutableTree := c.immutableTree(); immutableTree != nil {
+		path := []byte{mainPrefix}
+		path = append(path, candidate.idBytes()...)
+		path = append(path, delegationPrefix)
+		_, enc := immutableTree.Get(path)
+		if len(enc) != 0 {
+			settings = &DelegationSettings{}
+			if err := rlp.DecodeBytes(enc, settings); err != nil {
+				panic(fmt.Sprintf("failed to decode delegation settings: %s", err))
+			}
+		}
+	}
+
+	candidate.lock.Lock()
+	defer candidate.lock.Unlock()
+
+	if !candidate.isDelegationSettingsLoaded {
+		candidate.isDelegationSettingsLoaded = true
+		candidate.delegationSettings = settings
+	}
+
+	return candidate.delegationSettings
+}
+
 // Delegate adds a stake to a candidate
 func (c *Candidates) Delegate(address types.Address, pubkey types.Pubkey, coin types.CoinID, value *big.Int, bipValue *big.Int) {
 	candidate := c.GetCandidate(pubkey)
@@ -747,6 +999,162 @@ func (c *Candidates) Edit(pubkey types.Pubkey, rewardAddress types.Address, owne
 	candidate.setControl(controlAddress)
 }
 
+// AnnounceCommission sets a new commission of a candidate which takes effect at given height
+func (c *Candidates) AnnounceCommission(pubkey types.Pubkey, commission uint32, height uint64, effectiveHeight uint64) {
+	candidate := c.getFromMap(pubkey)
+	candidate.setLastEditCommissionHeight(height)
+
+	c.muPendingCommissions.Lock()
+	c.loadPendingCommissions()
+	c.pendingCommissions[candidate.ID] = &PendingCommission{
+		CandidateID: candidate.ID,
+		Commission:  commission,
+		Height:      effectiveHeight,
+	}
+	c.isPendingCommissionsDirty = true
+	c.muPendingCommissions.Unlock()
+
+	c.bus.Events().AddEvent(&eventsdb.CommissionChangeAnnouncedEvent{
+		CandidatePubKey: pubkey,
+		OldCommission:   candidate.Commission,
+		NewCommission:   commission,
+		EffectiveHeight: effectiveHeight,
+	})
+}
+
+// GetPendingCommission returns an announced commission of a candidate which has not taken effect yet
+func (c *Candidates) GetPendingCommission(pubkey types.Pubkey) *PendingCommission {
+	id := c.ID(pubkey)
+	if id == 0 {
+		return nil
+	}
+
+	c.muPendingCommissions.Lock()
+	defer c.muPendingCommissions.Unlock()
+
+	c.loadPendingCommissions()
+	pending, ok := c.pendingCommissions[id]
+	if !ok {
+		return nil
+	}
+
+	result := *pending
+	return &result
+}
+
+// ApplyPendingCommissions sets announced commissions which take effect at given height
+func (c *Candidates) ApplyPendingCommissions(height uint64) {
+	c.muPendingCommissions.Lock()
+	c.loadPendingCommissions()
+	var ready []*PendingCommission
+	for id, pending := range c.pendingCommissions {
+		if pending.Height > height {
+			continue
+		}
+		ready = append(ready, pending)
+		delete(c.pendingCommissions, id)
+		c.isPendingCommissionsDirty = true
+	}
+	c.muPendingCommissions.Unlock()
+
+	sort.Slice(ready, func(i, j int) bool {
+		return ready[i].CandidateID < ready[j].CandidateID
+	})
+
+	for _, pending := range ready {
+		c.lock.RLock()
+		candidate, ok := c.list[pending.CandidateID]
+		c.lock.RUnlock()
+		if !ok {
+			continue
+		}
+
+		oldCommission := candidate.Commission
+		candidate.setCommission(pending.Commission, candidate.LastEditCommissionHeight)
+
+		c.bus.Events().AddEvent(&eventsdb.CommissionChangedEvent{
+			CandidatePubKey: candidate.PubKey,
+			OldCommission:   oldCommission,
+			NewCommission:   pending.Commission,
+		})
+	}
+}
+
+// SetPendingCommission sets an announced commission of a candidate, used in import
+func (c *Candidates) SetPendingCommission(pubkey types.Pubkey, commission uint32, effectiveHeight uint64) {
+	id := c.ID(pubkey)
+
+	c.muPendingCommissions.Lock()
+	defer c.muPendingCommissions.Unlock()
+
+	c.loadPendingCommissions()
+	c.pendingCommissions[id] = &PendingCommission{
+		CandidateID: id,
+		Commission:  commission,
+		Height:      effectiveHeight,
+	}
+	c.isPendingCommissionsDirty = true
+}
+
+// loadPendingCommissions should be called under the pending commissions lock
+func (c *Candidates) loadPendingCommissions() {
+	if c.isPendingCommissionsLoaded {
+		return
+	}
+	c.isPendingCommissionsLoaded = true
+	c.pendingCommissions = map[uint32]*PendingCommission{}
+
+	immutableTree := c.immutableTree()
+	if immutableTree == nil {
+		return
+	}
+
+	_, enc := immutableTree.Get([]byte{pendingCommissionsKey})
+	if len(enc) == 0 {
+		return
+	}
+
+	var list []*PendingCommission
+	if err := rlp.DecodeBytes(enc, &list); err != nil {
+		panic(fmt.Sprintf("failed to decode pending commissions: %s", err))
+	}
+
+	for _, pending := range list {
+		c.pendingCommissions[pending.CandidateID] = pending
+	}
+}
+
+func (c *Candidates) commitPendingCommissions(db tree.MutableTree) error {
+	c.muPendingCommissions.Lock()
+	defer c.muPendingCommissions.Unlock()
+
+	if !c.isPendingCommissionsDirty {
+		return nil
+	}
+	c.isPendingCommissionsDirty = false
+
+	if len(c.pendingCommissions) == 0 {
+		db.Remove([]byte{pendingCommissionsKey})
+		return nil
+	}
+
+	list := make([]*PendingCommission, 0, len(c.pendingCommissions))
+	for _, pending := range c.pendingCommissions {
+		list = append(list, pending)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].CandidateID < list[j].CandidateID
+	})
+
+	data, err := rlp.EncodeToBytes(list)
+	if err != nil {
+		return fmt.Errorf("can't encode pending commissions: %v", err)
+	}
+	db.Set([]byte{pendingCommissionsKey}, data)
+
+	return nil
+}
+
 // EditCommission edits a candidate commission
 func (c *Candidates) EditCommission(pubkey types.Pubkey, commission uint32, height uint64) {
 	candidate := c.getFromMap(pubkey)
@@ -816,6 +1224,24 @@ func (c *Candidates) GetStakes(pubkey types.Pubkey) []*stake {
 	return stakes
 }
 
+// GetUpdates returns pending delegations of a candidate which become stakes at the next recalculation
+func (c *Candidates) GetUpdates(pubkey types.Pubkey) []*stake {
+	candidate := c.GetCandidate(pubkey)
+
+	candidate.lock.RLock()
+	defer candidate.lock.RUnlock()
+
+	updates := make([]*stake, 0, len(candidate.updates))
+	for _, update := range candidate.updates {
+		if update == nil {
+			continue
+		}
+		updates = append(updates, update)
+	}
+
+	return updates
+}
+
 // GetStakeOfAddress returns stake of address in given candidate and in given coin
 func (c *Candidates) GetStakeOfAddress(pubkey types.Pubkey, address types.Address, coin types.CoinID) *stake {
 	candidate := c.GetCandidate(pubkey)
@@ -1079,6 +1505,7 @@ func (c *Candidates) SetStakes(pubkey types.Pubkey, stakes []types.Stake, update
 		}
 		c.bus.Checker().AddCoin(coin, value)
 		candidate.stakes[i].markDirty(i)
+		candidate.addDirtyDelegator(s.Owner)
 	}
 }
 
@@ -1124,6 +1551,8 @@ func (c *Candidates) Export(state *types.AppState) {
 			Stakes:                   stakes,
 			JailedUntil:              candidate.JailedUntil,
 			LastEditCommissionHeight: candidate.LastEditCommissionHeight,
+			DelegationSettings:       exportDelegationSettings(c.loadDelegationSettings(candidate)),
+			PendingCommission:        exportPendingCommission(c.GetPendingCommission(candidate.PubKey)),
 		})
 	}
 
@@ -1149,6 +1578,29 @@ func (c *Candidates) Export(state *types.AppState) {
 	})
 }
 
+func exportDelegationSettings(settings *DelegationSettings) *types.DelegationSettings {
+	if settings == nil {
+		return nil
+	}
+
+	return &types.DelegationSettings{
+		MaxStake:      settings.MaxStake.String(),
+		MinDelegation: settings.MinDelegation.String(),
+		AllowList:     settings.AllowList,
+	}
+}
+
+func exportPendingCommission(pending *PendingCommission) *types.PendingCommission {
+	if pending == nil {
+		return nil
+	}
+
+	return &types.PendingCommission{
+		Commission: uint64(pending.Commission),
+		Height:     pending.Height,
+	}
+}
+
 func (c *Candidates) DeletedCandidates() (result []*deletedID) {
 	c.muDeletedCandidates.Lock()
 	defer c.muDeletedCandidates.Unlock()
@@ -1402,6 +1854,101 @@ func (c *Candidates) ID(pubKey types.Pubkey) uint32 {
 	return c.id(pubKey)
 }
 
+// GetDelegatorCandidateIDs returns ids of candidates which have stakes of given address
+func (c *Candidates) GetDelegatorCandidateIDs(address types.Address) []uint32 {
+	c.muDelegators.RLock()
+	ids, ok := c.delegators[address]
+	c.muDelegators.RUnlock()
+
+	if !ok {
+		return c.loadDelegatorCandidateIDs(address)
+	}
+
+	result := make([]uint32, len(ids))
+	copy(result, ids)
+
+	return result
+}
+
+// EnableDelegatorsIndex makes Commit keep the delegator to candidates index and the delegation settings of candidates.
+// Both exist from the V350 upgrade on, the index is filled for the earlier stakes by ReindexDelegators
+func (c *Candidates) EnableDelegatorsIndex() {
+	c.muDelegators.Lock()
+	defer c.muDelegators.Unlock()
+
+	c.delegatorsIndex = true
+}
+
+func (c *Candidates) isDelegatorsIndexEnabled() bool {
+	c.muDelegators.RLock()
+	defer c.muDelegators.RUnlock()
+
+	return c.delegatorsIndex
+}
+
+// ReindexDelegators adds all stakes and pending delegations of candidates to the delegator to candidates index.
+// It is used once at the upgrade to fill the index with stakes made before it existed, stakes must be loaded
+func (c *Candidates) ReindexDelegators() {
+	for _, candidate := range c.GetCandidates() {
+		for address := range candidate.getDelegators() {
+			c.setDelegatorCandidate(address, candidate.ID, true)
+		}
+	}
+}
+
+func (c *Candidates) loadDelegatorCandidateIDs(address types.Address) []uint32 {
+	immutableTree := c.immutableTree()
+	if immutableTree == nil {
+		return nil
+	}
+
+	_, enc := immutableTree.Get(append([]byte{delegatorsPrefix}, address.Bytes()...))
+	if len(enc) == 0 {
+		return nil
+	}
+
+	var ids []uint32
+	if err := rlp.DecodeBytes(enc, &ids); err != nil {
+		panic(fmt.Sprintf("failed to decode candidates of delegator: %s", err))
+	}
+
+	return ids
+}
+
+func (c *Candidates) setDelegatorCandidate(address types.Address, id uint32, exists bool) {
+	c.muDelegators.Lock()
+	defer c.muDelegators.Unlock()
+
+	if !c.delegatorsIndex {
+		return
+	}
+
+	ids, ok := c.delegators[address]
+	if !ok {
+		ids = c.loadDelegatorCandidateIDs(address)
+	}
+
+	index := sort.Search(len(ids), func(i int) bool {
+		return ids[i] >= id
+	})
+	found := index < len(ids) && ids[index] == id
+	if found == exists {
+		return
+	}
+
+	newIDs := make([]uint32, 0, len(ids)+1)
+	newIDs = append(newIDs, ids[:index]...)
+	if exists {
+		newIDs = append(newIDs, id)
+		newIDs = append(newIDs, ids[index:]...)
+	} else {
+		newIDs = append(newIDs, ids[index+1:]...)
+	}
+
+	c.delegators[address] = newIDs
+	c.dirtyDelegators[address] = struct{}{}
+}
+
 const invalidIDCandidate = "Mp0e11415ef24919557dcea4890d9e8aa26dc31ef8e77c6343114e1180ebeccde3"
 
 // PubKey returns a public key of candidate by it's ID
@@ -1469,6 +2016,8 @@ func (c *Candidates) DeleteCandidate(height uint64, candidate *Candidate) {
 		return
 	}
 
+	delegators := candidate.getDelegators()
+
 	c.AddToBlockPubKey(candidate.PubKey)
 	c.bus.Events().AddEvent(&eventsdb.RemoveCandidateEvent{CandidatePubKey: candidate.PubKey})
 
@@ -1482,7 +2031,7 @@ func (c *Candidates) DeleteCandidate(height uint64, candidate *Candidate) {
 			Coin:            uint64(s.Coin),
 			ValidatorPubKey: &candidate.PubKey,
 		})
-		c.bus.FrozenFunds().AddFrozenFund(height+types.GetUnbondPeriod(), s.Owner, &candidate.PubKey, candidate.ID, s.Coin, s.Value)
+		c.bus.FrozenFunds().AddFrozenFund(height+c.unbondPeriod(), s.Owner, &candidate.PubKey, candidate.ID, s.Coin, s.Value)
 		c.bus.Checker().AddCoin(s.Coin, big.NewInt(0).Neg(s.Value))
 		s.setValue(big.NewInt(0))
 	}
@@ -1496,11 +2045,15 @@ func (c *Candidates) DeleteCandidate(height uint64, candidate *Candidate) {
 			Coin:            uint64(u.Coin),
 			ValidatorPubKey: &candidate.PubKey,
 		})
-		c.bus.FrozenFunds().AddFrozenFund(height+types.GetUnbondPeriod(), u.Owner, &candidate.PubKey, candidate.ID, u.Coin, u.Value)
+		c.bus.FrozenFunds().AddFrozenFund(height+c.unbondPeriod(), u.Owner, &candidate.PubKey, candidate.ID, u.Coin, u.Value)
 		c.bus.Checker().AddCoin(u.Coin, big.NewInt(0).Neg(u.Value))
 		u.setValue(big.NewInt(0))
 	}
 
+	for address := range delegators {
+		c.setDelegatorCandidate(address, candidate.ID, false)
+	}
+
 	c.lock.Lock()
 	c.deleteCandidateFromList(candidate)
 	c.totalStakes.Sub(c.totalStakes, candidate.totalBipStake)
@@ -28,6 +28,12 @@ type Candidate struct {
 	isUpdatesDirty    bool
 	dirtyStakes       [MaxDelegatorsPerCandidate]bool
 
+	delegationSettings         *DelegationSettings
+	isDelegationSettingsLoaded bool
+	isDelegationSettingsDirty  bool
+
+	dirtyDelegators map[types.Address]struct{} // addresses whose set of delegated candidates may have changed
+
 	PubKey                   types.Pubkey
 	RewardAddress            types.Address
 	OwnerAddress             types.Address
@@ -39,6 +45,40 @@ type Candidate struct {
 	JailedUntil              uint64
 }
 
+// PendingCommission represents an announced commission of a candidate which takes effect at given height
+type PendingCommission struct {
+	CandidateID uint32
+	Commission  uint32
+	Height      uint64
+}
+
+// DelegationSettings represents restrictions for delegators set by a candidate owner
+type DelegationSettings struct {
+	MaxStake      *big.Int // max total bip stake of a candidate, 0 means no limit
+	MinDelegation *big.Int // min bip value of a single delegation, 0 means no limit
+	AllowList     []types.Address
+}
+
+// IsEmpty returns true if settings don't restrict anything
+func (s *DelegationSettings) IsEmpty() bool {
+	return s.MaxStake.Sign() == 0 && s.MinDelegation.Sign() == 0 && len(s.AllowList) == 0
+}
+
+// IsAllowed returns true if given address is allowed to delegate to a candidate
+func (s *DelegationSettings) IsAllowed(address types.Address) bool {
+	if len(s.AllowList) == 0 {
+		return true
+	}
+
+	for _, allowed := range s.AllowList {
+		if allowed == address {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (candidate *Candidate) idBytes() []byte {
 	return idBytes(candidate.ID)
 }
@@ -74,6 +114,14 @@ func (candidate *Candidate) setCommission(commission uint32, height uint64) {
 	candidate.LastEditCommissionHeight = height
 }
 
+func (candidate *Candidate) setLastEditCommissionHeight(height uint64) {
+	candidate.lock.Lock()
+	defer candidate.lock.Unlock()
+
+	candidate.isDirty = true
+	candidate.LastEditCommissionHeight = height
+}
+
 func (candidate *Candidate) jainUntil(height uint64) {
 	candidate.lock.Lock()
 	defer candidate.lock.Unlock()
@@ -118,6 +166,7 @@ func (candidate *Candidate) addUpdate(stake *stake) {
 		candidate.isUpdatesDirty = true
 	}
 	candidate.updates = append(candidate.updates, stake)
+	candidate.addDirtyDelegator(stake.Owner)
 }
 
 func (candidate *Candidate) clearUpdates() {
@@ -128,6 +177,10 @@ func (candidate *Candidate) clearUpdates() {
 		candidate.isUpdatesDirty = true
 	}
 
+	for _, update := range candidate.updates {
+		candidate.addDirtyDelegator(update.Owner)
+	}
+
 	candidate.updates = nil
 }
 
@@ -142,6 +195,64 @@ func (candidate *Candidate) setTotalBipStake(totalBipValue *big.Int) {
 	candidate.totalBipStake.Set(totalBipValue)
 }
 
+func (candidate *Candidate) setDelegationSettings(settings *DelegationSettings) {
+	candidate.lock.Lock()
+	defer candidate.lock.Unlock()
+
+	candidate.isDelegationSettingsLoaded = true
+	candidate.isDelegationSettingsDirty = true
+	candidate.delegationSettings = settings
+}
+
+// addDirtyDelegator should be called under the candidate lock
+func (candidate *Candidate) addDirtyDelegator(address types.Address) {
+	if candidate.dirtyDelegators == nil {
+		candidate.dirtyDelegators = map[types.Address]struct{}{}
+	}
+	candidate.dirtyDelegators[address] = struct{}{}
+}
+
+// popDirtyDelegators returns and resets addresses whose set of delegated candidates may have changed
+func (candidate *Candidate) popDirtyDelegators() []types.Address {
+	candidate.lock.Lock()
+	defer candidate.lock.Unlock()
+
+	addresses := make([]types.Address, 0, len(candidate.dirtyDelegators))
+	for address := range candidate.dirtyDelegators {
+		addresses = append(addresses, address)
+	}
+	candidate.dirtyDelegators = nil
+
+	return addresses
+}
+
+// getDelegators returns addresses which have non-zero stakes or updates in a candidate
+func (candidate *Candidate) getDelegators() map[types.Address]struct{} {
+	candidate.lock.RLock()
+	defer candidate.lock.RUnlock()
+
+	delegators := map[types.Address]struct{}{}
+	for _, stake := range candidate.stakes {
+		if stake == nil {
+			continue
+		}
+		stake.lock.RLock()
+		if stake.Value.Sign() == 1 {
+			delegators[stake.Owner] = struct{}{}
+		}
+		stake.lock.RUnlock()
+	}
+	for _, update := range candidate.updates {
+		update.lock.RLock()
+		if update.Value.Sign() == 1 {
+			delegators[update.Owner] = struct{}{}
+		}
+		update.lock.RUnlock()
+	}
+
+	return delegators
+}
+
 // GetTmAddress returns tendermint-address of a candidate
 func (candidate *Candidate) GetTmAddress() types.TmAddress {
 	candidate.lock.RLock()
@@ -247,6 +358,12 @@ func (candidate *Candidate) setStakeAtIndex(index int, stake *stake, isDirty boo
 	stake.index = index
 
 	candidate.lock.Lock()
+	if isDirty {
+		if old := candidate.stakes[index]; old != nil {
+			candidate.addDirtyDelegator(old.Owner)
+		}
+		candidate.addDirtyDelegator(stake.Owner)
+	}
 	candidate.stakes[index] = stake
 	candidate.lock.Unlock()
 
@@ -3,51 +3,52 @@ package checks
 import (
 	"bytes"
 	"fmt"
+	"math/big"
 	"sort"
 	"sync"
-	"sync/atomic"
 
 	"github.com/MinterTeam/minter-go-node/coreV2/check"
 	"github.com/MinterTeam/minter-go-node/coreV2/types"
-	"github.com/cosmos/iavl"
+	"github.com/MinterTeam/minter-go-node/tree"
 )
 
 const mainPrefix = byte('t')
 
+const redeemedPrefix = byte('r')
+
 type RChecks interface {
 	Export(state *types.AppState)
 	IsCheckUsed(check *check.Check) bool
+	IsCheckHashUsed(hash types.Hash) bool
+	GetRedeemedValue(hash types.Hash) *big.Int
 }
 
 type Checks struct {
-	usedChecks map[types.Hash]struct{}
+	usedChecks     map[types.Hash]struct{}
+	redeemedValues map[types.Hash]*big.Int
 
-	db atomic.Value
+	db tree.AtomicTree
 
 	lock sync.RWMutex
 }
 
-func NewChecks(db *iavl.ImmutableTree) *Checks {
-	immutableTree := atomic.Value{}
+func NewChecks(db tree.ImmutableTree) *Checks {
+	immutableTree := tree.AtomicTree{}
 	if db != nil {
 		immutableTree.Store(db)
 	}
-	return &Checks{db: immutableTree, usedChecks: map[types.Hash]struct{}{}}
+	return &Checks{db: immutableTree, usedChecks: map[types.Hash]struct{}{}, redeemedValues: map[types.Hash]*big.Int{}}
 }
 
-func (c *Checks) immutableTree() *iavl.ImmutableTree {
-	db := c.db.Load()
-	if db == nil {
-		return nil
-	}
-	return db.(*iavl.ImmutableTree)
+func (c *Checks) immutableTree() tree.ImmutableTree {
+	return c.db.Load()
 }
 
-func (c *Checks) SetImmutableTree(immutableTree *iavl.ImmutableTree) {
+func (c *Checks) SetImmutableTree(immutableTree tree.ImmutableTree) {
 	c.db.Store(immutableTree)
 }
 
-func (c *Checks) Commit(db *iavl.MutableTree, version int64) error {
+func (c *Checks) Commit(db tree.MutableTree, version int64) error {
 	hashes := c.getOrderedHashes()
 	for _, hash := range hashes {
 		c.lock.Lock()
@@ -58,22 +59,62 @@ func (c *Checks) Commit(db *iavl.MutableTree, version int64) error {
 		db.Set(trieHash, []byte{0x1})
 	}
 
+	for _, hash := range c.getOrderedRedeemedHashes() {
+		c.lock.Lock()
+		value := c.redeemedValues[hash]
+		delete(c.redeemedValues, hash)
+		c.lock.Unlock()
+
+		path := append([]byte{mainPrefix, redeemedPrefix}, hash.Bytes()...)
+		if value.Sign() == 0 {
+			db.Remove(path)
+			continue
+		}
+		db.Set(path, value.Bytes())
+	}
+
 	return nil
 }
 
 func (c *Checks) IsCheckUsed(check *check.Check) bool {
+	return c.IsCheckHashUsed(check.Hash())
+}
+
+func (c *Checks) IsCheckHashUsed(hash types.Hash) bool {
 	c.lock.RLock()
 	defer c.lock.RUnlock()
 
-	if _, has := c.usedChecks[check.Hash()]; has {
+	if _, has := c.usedChecks[hash]; has {
 		return true
 	}
 
-	_, data := c.immutableTree().Get(append([]byte{mainPrefix}, check.Hash().Bytes()...))
+	_, data := c.immutableTree().Get(append([]byte{mainPrefix}, hash.Bytes()...))
 
 	return len(data) != 0
 }
 
+// GetRedeemedValue returns already redeemed value of the partially redeemed check
+func (c *Checks) GetRedeemedValue(hash types.Hash) *big.Int {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	if value, has := c.redeemedValues[hash]; has {
+		return big.NewInt(0).Set(value)
+	}
+
+	_, data := c.immutableTree().Get(append([]byte{mainPrefix, redeemedPrefix}, hash.Bytes()...))
+
+	return big.NewInt(0).SetBytes(data)
+}
+
+// SetRedeemedValue sets already redeemed value of the partially redeemed check, zero value removes the record
+func (c *Checks) SetRedeemedValue(hash types.Hash, value *big.Int) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.redeemedValues[hash] = big.NewInt(0).Set(value)
+}
+
 func (c *Checks) UseCheck(check *check.Check) {
 	c.UseCheckHash(check.Hash())
 }
@@ -87,9 +128,23 @@ func (c *Checks) UseCheckHash(hash types.Hash) {
 
 func (c *Checks) Export(state *types.AppState) {
 	c.immutableTree().IterateRange([]byte{mainPrefix}, []byte{mainPrefix + 1}, true, func(key []byte, value []byte) bool {
+		if len(key) != 1+types.HashLength {
+			return false
+		}
 		state.UsedChecks = append(state.UsedChecks, types.UsedCheck(fmt.Sprintf("%x", key[1:])))
 		return false
 	})
+
+	c.immutableTree().IterateRange([]byte{mainPrefix, redeemedPrefix}, []byte{mainPrefix, redeemedPrefix + 1}, true, func(key []byte, value []byte) bool {
+		if len(key) != 2+types.HashLength {
+			return false
+		}
+		state.RedeemedChecks = append(state.RedeemedChecks, types.RedeemedCheck{
+			Hash:  fmt.Sprintf("%x", key[2:]),
+			Value: big.NewInt(0).SetBytes(value).String(),
+		})
+		return false
+	})
 }
 
 func (c *Checks) getOrderedHashes() []types.Hash {
@@ -107,3 +162,19 @@ func (c *Checks) getOrderedHashes() []types.Hash {
 
 	return keys
 }
+
+func (c *Checks) getOrderedRedeemedHashes() []types.Hash {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	var keys []types.Hash
+	for hash := range c.redeemedValues {
+		keys = append(keys, hash)
+	}
+
+	sort.SliceStable(keys, func(i, j int) bool {
+		return bytes.Compare(keys[i].Bytes(), keys[j].Bytes()) == 1
+	})
+
+	return keys
+}
@@ -38,3 +38,39 @@ func (b *Bus) SubCoinVolume(id types.CoinID, amount *big.Int) {
 func (b *Bus) SubCoinReserve(id types.CoinID, amount *big.Int) {
 	b.coins.SubReserve(id, amount)
 }
+
+func (b *Bus) IsCoinPaused(id types.CoinID) bool {
+	return b.coins.IsPaused(id)
+}
+
+func (b *Bus) IsCoinFrozen(id types.CoinID, address types.Address) bool {
+	return b.coins.IsFrozen(id, address)
+}
+
+// PayTransferFee withholds the transfer fee of the coin from the value sent by the sender and returns the fee,
+// the fee is paid to the owner of the coin or burned, transfers of the owner are exempt
+func (b *Bus) PayTransferFee(id types.CoinID, sender types.Address, value *big.Int) *big.Int {
+	fee := b.coins.GetTransferFee(id)
+	if fee.Bps == 0 {
+		return big.NewInt(0)
+	}
+
+	var owner *types.Address
+	if coin := b.coins.GetCoin(id); coin != nil {
+		if info := b.coins.GetSymbolInfo(coin.Symbol()); info != nil {
+			owner = info.OwnerAddress()
+		}
+	}
+	if owner != nil && *owner == sender {
+		return big.NewInt(0)
+	}
+
+	value = fee.Of(value)
+	if owner != nil && !fee.Burn {
+		b.coins.bus.Accounts().AddBalance(*owner, id, value)
+	} else {
+		b.coins.SubVolume(id, value)
+	}
+
+	return value
+}
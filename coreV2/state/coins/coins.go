@@ -1,23 +1,28 @@
 package coins
 
 import (
+	"bytes"
 	"fmt"
 	"math/big"
 	"sort"
 	"sync"
-	"sync/atomic"
 
+	eventsdb "github.com/MinterTeam/minter-go-node/coreV2/events"
 	"github.com/MinterTeam/minter-go-node/coreV2/state/bus"
 	"github.com/MinterTeam/minter-go-node/coreV2/types"
 	"github.com/MinterTeam/minter-go-node/helpers"
 	"github.com/MinterTeam/minter-go-node/rlp"
-	"github.com/cosmos/iavl"
+	"github.com/MinterTeam/minter-go-node/tree"
 )
 
 const (
-	mainPrefix   = byte('q')
-	infoPrefix   = byte('i')
-	symbolPrefix = byte('s')
+	mainPrefix     = byte('q')
+	infoPrefix     = byte('i')
+	symbolPrefix   = byte('s')
+	metadataPrefix = byte('m')
+	freezePrefix   = byte('f')
+	frozenPrefix   = byte('z')
+	feePrefix      = byte('t')
 
 	BaseVersion types.CoinVersion = 0
 )
@@ -38,6 +43,11 @@ type RCoins interface {
 	GetCoin(id types.CoinID) *Model
 	GetCoinBySymbol(symbol types.CoinSymbol, version types.CoinVersion) *Model
 	GetSymbolInfo(symbol types.CoinSymbol) *SymbolInfo
+	GetMetadata(id types.CoinID) *Metadata
+	IsFreezable(id types.CoinID) bool
+	IsPaused(id types.CoinID) bool
+	IsFrozen(id types.CoinID, address types.Address) bool
+	GetTransferFee(id types.CoinID) TransferFee
 }
 
 // Coins represents coins state in blockchain.
@@ -86,13 +96,13 @@ type Coins struct {
 	symbolsInfoList map[types.CoinSymbol]*SymbolInfo
 
 	bus *bus.Bus
-	db  atomic.Value
+	db  tree.AtomicTree
 
 	lock sync.RWMutex
 }
 
-func NewCoins(stateBus *bus.Bus, db *iavl.ImmutableTree) *Coins {
-	immutableTree := atomic.Value{}
+func NewCoins(stateBus *bus.Bus, db tree.ImmutableTree) *Coins {
+	immutableTree := tree.AtomicTree{}
 	if db != nil {
 		immutableTree.Store(db)
 	}
@@ -109,19 +119,15 @@ func NewCoins(stateBus *bus.Bus, db *iavl.ImmutableTree) *Coins {
 	return coins
 }
 
-func (c *Coins) immutableTree() *iavl.ImmutableTree {
-	db := c.db.Load()
-	if db == nil {
-		return nil
-	}
-	return db.(*iavl.ImmutableTree)
+func (c *Coins) immutableTree() tree.ImmutableTree {
+	return c.db.Load()
 }
 
-func (c *Coins) SetImmutableTree(immutableTree *iavl.ImmutableTree) {
+func (c *Coins) SetImmutableTree(immutableTree tree.ImmutableTree) {
 	c.db.Store(immutableTree)
 }
 
-func (c *Coins) Commit(db *iavl.MutableTree, version int64) error {
+func (c *Coins) Commit(db tree.MutableTree, version int64) error {
 	coins := c.getOrderedDirtyCoins()
 	for _, id := range coins {
 		coin := c.getFromMap(id)
@@ -184,6 +190,76 @@ func (c *Coins) Commit(db *iavl.MutableTree, version int64) error {
 
 			db.Set(getSymbolInfoPath(coin.Symbol()), data)
 		}
+
+		if coin.IsMetadataDirty() {
+			coin.lock.Lock()
+			coin.metadata.isDirty = false
+			metadata := *coin.metadata
+			coin.lock.Unlock()
+
+			if metadata.IsEmpty() {
+				db.Remove(getCoinMetadataPath(id))
+			} else {
+				data, err := rlp.EncodeToBytes(&metadata)
+				if err != nil {
+					return fmt.Errorf("can't encode object at %d: %v", id, err)
+				}
+
+				db.Set(getCoinMetadataPath(id), data)
+			}
+		}
+
+		if coin.IsFreezeDirty() {
+			coin.lock.Lock()
+			coin.freeze.isDirty = false
+			data, err := rlp.EncodeToBytes(coin.freeze)
+			coin.lock.Unlock()
+
+			if err != nil {
+				return fmt.Errorf("can't encode object at %d: %v", id, err)
+			}
+
+			db.Set(getCoinFreezePath(id), data)
+		}
+
+		if coin.IsTransferFeeDirty() {
+			coin.lock.Lock()
+			coin.transferFee.isDirty = false
+			fee := *coin.transferFee
+			coin.lock.Unlock()
+
+			if fee.Bps == 0 {
+				db.Remove(getCoinTransferFeePath(id))
+			} else {
+				data, err := rlp.EncodeToBytes(&fee)
+				if err != nil {
+					return fmt.Errorf("can't encode object at %d: %v", id, err)
+				}
+
+				db.Set(getCoinTransferFeePath(id), data)
+			}
+		}
+
+		if coin.IsFrozenDirty() {
+			coin.lock.Lock()
+			addresses := make([]types.Address, 0, len(coin.dirtyFrozen))
+			for address := range coin.dirtyFrozen {
+				addresses = append(addresses, address)
+			}
+			sort.SliceStable(addresses, func(i, j int) bool {
+				return bytes.Compare(addresses[i].Bytes(), addresses[j].Bytes()) == -1
+			})
+
+			for _, address := range addresses {
+				if coin.frozen[address] {
+					db.Set(getCoinFrozenPath(id, address), []byte{1})
+				} else {
+					db.Remove(getCoinFrozenPath(id, address))
+				}
+			}
+			coin.dirtyFrozen = nil
+			coin.lock.Unlock()
+		}
 	}
 
 	return nil
@@ -197,6 +273,239 @@ func (c *Coins) GetSymbolInfo(symbol types.CoinSymbol) *SymbolInfo {
 	return c.getSymbolInfo(symbol)
 }
 
+// GetMetadata returns the metadata of the coin or nil if it is not set
+func (c *Coins) GetMetadata(id types.CoinID) *Metadata {
+	coin := c.get(id)
+	if coin == nil || id.IsBaseCoin() {
+		return nil
+	}
+
+	coin.lock.Lock()
+	defer coin.lock.Unlock()
+
+	if !coin.isMetadataLoaded {
+		coin.isMetadataLoaded = true
+
+		_, enc := c.immutableTree().Get(getCoinMetadataPath(id))
+		if len(enc) != 0 {
+			coin.metadata = &Metadata{}
+			if err := rlp.DecodeBytes(enc, coin.metadata); err != nil {
+				panic(fmt.Sprintf("failed to decode coin metadata %d: %s", id, err))
+			}
+		}
+	}
+
+	if coin.metadata == nil || coin.metadata.IsEmpty() {
+		return nil
+	}
+
+	metadata := *coin.metadata
+	return &metadata
+}
+
+// SetMetadata replaces the metadata of the coin, the empty metadata removes it
+func (c *Coins) SetMetadata(id types.CoinID, metadata Metadata) {
+	coin := c.get(id)
+
+	coin.lock.Lock()
+	metadata.isDirty = true
+	coin.metadata = &metadata
+	coin.isMetadataLoaded = true
+	coin.lock.Unlock()
+
+	c.markDirty(id)
+}
+
+// IsFreezable returns true if the owner of the token is able to pause it and freeze addresses
+func (c *Coins) IsFreezable(id types.CoinID) bool {
+	return c.getFreeze(id).Freezable
+}
+
+// IsPaused returns true if all transfers of the coin are paused by its owner
+func (c *Coins) IsPaused(id types.CoinID) bool {
+	return c.getFreeze(id).Paused
+}
+
+// IsFrozen returns true if the address is not allowed to transfer or receive the coin
+func (c *Coins) IsFrozen(id types.CoinID, address types.Address) bool {
+	if id.IsBaseCoin() {
+		return false
+	}
+
+	coin := c.get(id)
+	if coin == nil {
+		return false
+	}
+
+	coin.lock.Lock()
+	defer coin.lock.Unlock()
+
+	if frozen, ok := coin.frozen[address]; ok {
+		return frozen
+	}
+
+	_, enc := c.immutableTree().Get(getCoinFrozenPath(id, address))
+	frozen := len(enc) != 0
+
+	if coin.frozen == nil {
+		coin.frozen = map[types.Address]bool{}
+	}
+	coin.frozen[address] = frozen
+
+	return frozen
+}
+
+// SetFreezable marks the token as freezable, it is done once at the creation of the token
+func (c *Coins) SetFreezable(id types.CoinID) {
+	c.setFreeze(id, FreezeInfo{Freezable: true})
+}
+
+func (c *Coins) Pause(id types.CoinID) {
+	c.setFreeze(id, FreezeInfo{Freezable: true, Paused: true})
+	c.bus.Events().AddEvent(&eventsdb.TokenPausedEvent{Coin: uint64(id)})
+}
+
+func (c *Coins) Unpause(id types.CoinID) {
+	c.setFreeze(id, FreezeInfo{Freezable: true})
+	c.bus.Events().AddEvent(&eventsdb.TokenUnpausedEvent{Coin: uint64(id)})
+}
+
+func (c *Coins) Freeze(id types.CoinID, address types.Address) {
+	c.setFrozen(id, address, true)
+	c.bus.Events().AddEvent(&eventsdb.AddressFrozenEvent{Coin: uint64(id), Address: address})
+}
+
+func (c *Coins) Unfreeze(id types.CoinID, address types.Address) {
+	c.setFrozen(id, address, false)
+	c.bus.Events().AddEvent(&eventsdb.AddressUnfrozenEvent{Coin: uint64(id), Address: address})
+}
+
+// ImportFreeze restores the freeze state of the token from genesis without emitting events
+func (c *Coins) ImportFreeze(id types.CoinID, paused bool, frozen []types.Address) {
+	c.setFreeze(id, FreezeInfo{Freezable: true, Paused: paused})
+	for _, address := range frozen {
+		c.setFrozen(id, address, true)
+	}
+}
+
+// GetTransferFee returns the transfer fee of the token, the zero fee is returned if it is not set
+func (c *Coins) GetTransferFee(id types.CoinID) TransferFee {
+	if id.IsBaseCoin() {
+		return TransferFee{}
+	}
+
+	coin := c.get(id)
+	if coin == nil {
+		return TransferFee{}
+	}
+
+	coin.lock.Lock()
+	defer coin.lock.Unlock()
+
+	if !coin.isTransferFeeLoaded {
+		coin.isTransferFeeLoaded = true
+
+		_, enc := c.immutableTree().Get(getCoinTransferFeePath(id))
+		if len(enc) != 0 {
+			coin.transferFee = &TransferFee{}
+			if err := rlp.DecodeBytes(enc, coin.transferFee); err != nil {
+				panic(fmt.Sprintf("failed to decode coin transfer fee %d: %s", id, err))
+			}
+		}
+	}
+
+	if coin.transferFee == nil {
+		return TransferFee{}
+	}
+
+	return TransferFee{Bps: coin.transferFee.Bps, Burn: coin.transferFee.Burn}
+}
+
+// SetTransferFee replaces the transfer fee of the token, the zero fee removes it
+func (c *Coins) SetTransferFee(id types.CoinID, fee TransferFee) {
+	coin := c.get(id)
+
+	coin.lock.Lock()
+	fee.isDirty = true
+	coin.transferFee = &fee
+	coin.isTransferFeeLoaded = true
+	coin.lock.Unlock()
+
+	c.markDirty(id)
+}
+
+func (c *Coins) getFreeze(id types.CoinID) FreezeInfo {
+	if id.IsBaseCoin() {
+		return FreezeInfo{}
+	}
+
+	coin := c.get(id)
+	if coin == nil {
+		return FreezeInfo{}
+	}
+
+	coin.lock.Lock()
+	defer coin.lock.Unlock()
+
+	if !coin.isFreezeLoaded {
+		coin.isFreezeLoaded = true
+
+		_, enc := c.immutableTree().Get(getCoinFreezePath(id))
+		if len(enc) != 0 {
+			coin.freeze = &FreezeInfo{}
+			if err := rlp.DecodeBytes(enc, coin.freeze); err != nil {
+				panic(fmt.Sprintf("failed to decode coin freeze info %d: %s", id, err))
+			}
+		}
+	}
+
+	if coin.freeze == nil {
+		return FreezeInfo{}
+	}
+
+	return FreezeInfo{Freezable: coin.freeze.Freezable, Paused: coin.freeze.Paused}
+}
+
+func (c *Coins) setFreeze(id types.CoinID, freeze FreezeInfo) {
+	coin := c.get(id)
+
+	coin.lock.Lock()
+	freeze.isDirty = true
+	coin.freeze = &freeze
+	coin.isFreezeLoaded = true
+	coin.lock.Unlock()
+
+	c.markDirty(id)
+}
+
+func (c *Coins) setFrozen(id types.CoinID, address types.Address, frozen bool) {
+	coin := c.get(id)
+
+	coin.lock.Lock()
+	if coin.frozen == nil {
+		coin.frozen = map[types.Address]bool{}
+	}
+	if coin.dirtyFrozen == nil {
+		coin.dirtyFrozen = map[types.Address]struct{}{}
+	}
+	coin.frozen[address] = frozen
+	coin.dirtyFrozen[address] = struct{}{}
+	coin.lock.Unlock()
+
+	c.markDirty(id)
+}
+
+func (c *Coins) getFrozenAddresses(id types.CoinID) []types.Address {
+	var addresses []types.Address
+	start, end := append(getCoinPath(id), frozenPrefix), append(getCoinPath(id), frozenPrefix+1)
+	c.immutableTree().IterateRange(start, end, true, func(key []byte, value []byte) bool {
+		addresses = append(addresses, types.BytesToAddress(key[len(start):]))
+		return false
+	})
+
+	return addresses
+}
+
 func (c *Coins) Exists(id types.CoinID) bool {
 	if id.IsBaseCoin() {
 		return true
@@ -483,6 +792,25 @@ func (c *Coins) RecreateToken(newID types.CoinID, name string, symbol types.Coin
 	c.CreateToken(newID, recreateCoin.Symbol(), name, mintable, burnable, initialAmount, maxSupply, nil)
 }
 
+// MigrateToToken releases the reserve of the coin and turns it into a token keeping its ID and balances of holders,
+// the max supply is raised to the given value if it is lower
+func (c *Coins) MigrateToToken(id types.CoinID, mintable, burnable bool, maxSupply *big.Int) {
+	coin := c.get(id)
+	c.SubReserve(id, coin.Reserve())
+
+	coin.lock.Lock()
+	coin.CCrr = 0
+	coin.Mintable = mintable
+	coin.Burnable = burnable
+	if coin.CMaxSupply.Cmp(maxSupply) == -1 {
+		coin.CMaxSupply = big.NewInt(0).Set(maxSupply)
+	}
+	coin.isDirty = true
+	coin.lock.Unlock()
+
+	c.markDirty(id)
+}
+
 func (c *Coins) ChangeOwner(symbol types.CoinSymbol, owner types.Address) {
 	info := c.getSymbolInfo(symbol)
 	info.setOwnerAddress(owner)
@@ -626,6 +954,32 @@ func (c *Coins) Export(state *types.AppState) {
 			owner = info.OwnerAddress()
 		}
 
+		var metadata *types.CoinMetadata
+		if m := c.GetMetadata(coinID); m != nil {
+			metadata = &types.CoinMetadata{
+				Description: m.Description,
+				URI:         m.URI,
+				IconHash:    m.IconHash,
+				Decimals:    uint64(m.Decimals),
+			}
+		}
+
+		var freeze *types.CoinFreeze
+		if f := c.getFreeze(coinID); f.Freezable {
+			freeze = &types.CoinFreeze{
+				Paused:          f.Paused,
+				FrozenAddresses: c.getFrozenAddresses(coinID),
+			}
+		}
+
+		var transferFee *types.CoinTransferFee
+		if f := c.GetTransferFee(coinID); f.Bps != 0 {
+			transferFee = &types.CoinTransferFee{
+				Bps:  uint64(f.Bps),
+				Burn: f.Burn,
+			}
+		}
+
 		state.Coins = append(state.Coins, types.Coin{
 			ID:           uint64(coin.ID()),
 			Name:         coin.Name(),
@@ -638,6 +992,9 @@ func (c *Coins) Export(state *types.AppState) {
 			Mintable:     coin.Mintable,
 			Burnable:     coin.Burnable,
 			OwnerAddress: owner,
+			Metadata:     metadata,
+			Freeze:       freeze,
+			TransferFee:  transferFee,
 		})
 
 		return false
@@ -707,6 +1064,22 @@ func getCoinPath(id types.CoinID) []byte {
 	return append([]byte{mainPrefix}, id.Bytes()...)
 }
 
+func getCoinMetadataPath(id types.CoinID) []byte {
+	return append(getCoinPath(id), metadataPrefix)
+}
+
+func getCoinFreezePath(id types.CoinID) []byte {
+	return append(getCoinPath(id), freezePrefix)
+}
+
+func getCoinTransferFeePath(id types.CoinID) []byte {
+	return append(getCoinPath(id), feePrefix)
+}
+
+func getCoinFrozenPath(id types.CoinID, address types.Address) []byte {
+	return append(append(getCoinPath(id), frozenPrefix), address.Bytes()...)
+}
+
 func getCoinInfoPath(id types.CoinID) []byte {
 	return append(getCoinPath(id), infoPrefix)
 }
@@ -24,6 +24,17 @@ type Model struct {
 	info       *Info
 	symbolInfo *SymbolInfo
 
+	metadata         *Metadata
+	isMetadataLoaded bool
+
+	freeze         *FreezeInfo
+	isFreezeLoaded bool
+	frozen         map[types.Address]bool
+	dirtyFrozen    map[types.Address]struct{}
+
+	transferFee         *TransferFee
+	isTransferFeeLoaded bool
+
 	markDirty func(symbol types.CoinID)
 	lock      sync.RWMutex
 
@@ -235,6 +246,34 @@ func (m *Model) IsInfoDirty() bool {
 	return m.info.isDirty
 }
 
+func (m *Model) IsMetadataDirty() bool {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	return m.metadata != nil && m.metadata.isDirty
+}
+
+func (m *Model) IsFreezeDirty() bool {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	return m.freeze != nil && m.freeze.isDirty
+}
+
+func (m *Model) IsTransferFeeDirty() bool {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	return m.transferFee != nil && m.transferFee.isDirty
+}
+
+func (m *Model) IsFrozenDirty() bool {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	return len(m.dirtyFrozen) != 0
+}
+
 func (m *Model) IsSymbolInfoDirty() bool {
 	m.lock.RLock()
 	defer m.lock.RUnlock()
@@ -286,6 +325,64 @@ type Info struct {
 	lock    sync.RWMutex
 }
 
+const (
+	MaxMetadataDescriptionLength = 512
+	MaxMetadataURILength         = 256
+	MaxMetadataDecimals          = 18
+)
+
+// Metadata is the owner-editable description of the coin for wallets and explorers
+type Metadata struct {
+	Description string
+	URI         string
+	IconHash    types.Hash
+	Decimals    uint32
+
+	isDirty bool
+}
+
+// IsEmpty returns true if no field of the metadata is set
+func (m Metadata) IsEmpty() bool {
+	return m.Description == "" && m.URI == "" && m.IconHash == (types.Hash{}) && m.Decimals == 0
+}
+
+// FreezeInfo holds the freeze capability of the token and whether its transfers are paused
+type FreezeInfo struct {
+	Freezable bool
+	Paused    bool
+
+	isDirty bool
+}
+
+// MaxTransferFeeBps is the upper bound of the transfer fee of a token in basis points
+const MaxTransferFeeBps = 1000
+
+// TransferFee is the share of transferred tokens withheld by the issuer, it is paid to the owner of the token or burned
+type TransferFee struct {
+	Bps  uint32
+	Burn bool
+
+	isDirty bool
+}
+
+// Of returns the fee withheld from the value
+func (f TransferFee) Of(value *big.Int) *big.Int {
+	fee := big.NewInt(0).Mul(value, big.NewInt(int64(f.Bps)))
+	return fee.Quo(fee, big.NewInt(10000))
+}
+
+// Gross returns the value which leaves at least the given value after the fee is withheld
+func (f TransferFee) Gross(value *big.Int) *big.Int {
+	if f.Bps == 0 {
+		return big.NewInt(0).Set(value)
+	}
+
+	rest := big.NewInt(int64(10000 - f.Bps))
+	gross := big.NewInt(0).Mul(value, big.NewInt(10000))
+	gross.Add(gross, big.NewInt(0).Sub(rest, big.NewInt(1)))
+	return gross.Quo(gross, rest)
+}
+
 type SymbolInfo struct {
 	COwnerAddress *types.Address
 
@@ -6,12 +6,11 @@ import (
 	"math/big"
 	"sort"
 	"sync"
-	"sync/atomic"
 
 	"github.com/MinterTeam/minter-go-node/coreV2/types"
 	"github.com/MinterTeam/minter-go-node/helpers"
 	"github.com/MinterTeam/minter-go-node/rlp"
-	"github.com/cosmos/iavl"
+	"github.com/MinterTeam/minter-go-node/tree"
 )
 
 const mainPrefix = byte('p')
@@ -23,6 +22,7 @@ type RCommission interface {
 	Export(state *types.AppState)
 	GetVotes(height uint64) []*Model
 	GetCommissions() *Price
+	GetHeights() []uint64
 	IsVoteExists(height uint64, pubkey types.Pubkey) bool
 }
 
@@ -34,12 +34,12 @@ type Commission struct {
 	currentPrice *Price
 	dirtyCurrent bool
 
-	db   atomic.Value
+	db   tree.AtomicTree
 	lock sync.RWMutex
 }
 
-func NewCommission(db *iavl.ImmutableTree) *Commission {
-	immutableTree := atomic.Value{}
+func NewCommission(db tree.ImmutableTree) *Commission {
+	immutableTree := tree.AtomicTree{}
 	if db != nil {
 		immutableTree.Store(db)
 	}
@@ -53,15 +53,11 @@ func NewCommission(db *iavl.ImmutableTree) *Commission {
 	return halts
 }
 
-func (c *Commission) immutableTree() *iavl.ImmutableTree {
-	db := c.db.Load()
-	if db == nil {
-		return nil
-	}
-	return db.(*iavl.ImmutableTree)
+func (c *Commission) immutableTree() tree.ImmutableTree {
+	return c.db.Load()
 }
 
-func (c *Commission) SetImmutableTree(immutableTree *iavl.ImmutableTree) {
+func (c *Commission) SetImmutableTree(immutableTree tree.ImmutableTree) {
 	c.db.Store(immutableTree)
 }
 
@@ -289,7 +285,7 @@ func (c *Commission) ExportV1(state *types.AppState, id types.CoinID) {
 	}
 }
 
-func (c *Commission) Commit(db *iavl.MutableTree, version int64) error {
+func (c *Commission) Commit(db tree.MutableTree, version int64) error {
 	c.lock.Lock()
 	if c.dirtyCurrent {
 		c.dirtyCurrent = false
@@ -328,6 +324,26 @@ func (c *Commission) GetVotes(height uint64) []*Model {
 	return c.get(height)
 }
 
+// GetHeights returns sorted heights which have votes
+func (c *Commission) GetHeights() []uint64 {
+	var heights []uint64
+	c.immutableTree().IterateRange([]byte{mainPrefix}, []byte{mainPrefix + 1}, true, func(key []byte, value []byte) bool {
+		if len(key) < 8 {
+			return false
+		}
+
+		heights = append(heights, binary.LittleEndian.Uint64(key[1:]))
+
+		return false
+	})
+
+	sort.Slice(heights, func(i, j int) bool {
+		return heights[i] < heights[j]
+	})
+
+	return heights
+}
+
 func (c *Commission) GetCommissions() *Price {
 	c.lock.Lock()
 	defer c.lock.Unlock()
@@ -115,6 +115,195 @@ func (d *Price) Encode() []byte {
 //	return d.Send
 //}
 
+func (d *Price) EditCandidateDelegationPrice() *big.Int {
+	if len(d.More) > 0 {
+		return d.More[0]
+	}
+	return d.EditCandidate
+}
+
+func (d *Price) SubmitMultisigProposalPrice() *big.Int {
+	if len(d.More) > 1 {
+		return d.More[1]
+	}
+	return d.EditMultisig
+}
+
+func (d *Price) ApproveMultisigProposalPrice() *big.Int {
+	if len(d.More) > 2 {
+		return d.More[2]
+	}
+	return d.Send
+}
+
+func (d *Price) CreateVestingPrice() *big.Int {
+	if len(d.More) > 3 {
+		return d.More[3]
+	}
+	return d.Lock
+}
+
+func (d *Price) RevokeCheckPrice() *big.Int {
+	if len(d.More) > 4 {
+		return d.More[4]
+	}
+	return d.RedeemCheck
+}
+
+func (d *Price) CreateStandingOrderPrice() *big.Int {
+	if len(d.More) > 5 {
+		return d.More[5]
+	}
+	return d.AddLimitOrder
+}
+
+func (d *Price) CancelStandingOrderPrice() *big.Int {
+	if len(d.More) > 6 {
+		return d.More[6]
+	}
+	return d.RemoveLimitOrder
+}
+
+func (d *Price) CreateHTLCPrice() *big.Int {
+	if len(d.More) > 7 {
+		return d.More[7]
+	}
+	return d.Lock
+}
+
+func (d *Price) ClaimHTLCPrice() *big.Int {
+	if len(d.More) > 8 {
+		return d.More[8]
+	}
+	return d.RedeemCheck
+}
+
+func (d *Price) RefundHTLCPrice() *big.Int {
+	if len(d.More) > 9 {
+		return d.More[9]
+	}
+	return d.Send
+}
+
+func (d *Price) RotateKeyPrice() *big.Int {
+	if len(d.More) > 10 {
+		return d.More[10]
+	}
+	return d.EditMultisig
+}
+
+func (d *Price) SetKeyGuardianPrice() *big.Int {
+	if len(d.More) > 11 {
+		return d.More[11]
+	}
+	return d.EditMultisig
+}
+
+func (d *Price) SetAccountPolicyPrice() *big.Int {
+	if len(d.More) > 12 {
+		return d.More[12]
+	}
+	return d.EditMultisig
+}
+
+func (d *Price) EditCoinMetadataPrice() *big.Int {
+	if len(d.More) > 13 {
+		return d.More[13]
+	}
+	return d.EditMultisig
+}
+
+func (d *Price) PauseTokenPrice() *big.Int {
+	if len(d.More) > 14 {
+		return d.More[14]
+	}
+	return d.EditMultisig
+}
+
+func (d *Price) UnpauseTokenPrice() *big.Int {
+	if len(d.More) > 15 {
+		return d.More[15]
+	}
+	return d.EditMultisig
+}
+
+func (d *Price) FreezeAddressPrice() *big.Int {
+	if len(d.More) > 16 {
+		return d.More[16]
+	}
+	return d.EditMultisig
+}
+
+func (d *Price) UnfreezeAddressPrice() *big.Int {
+	if len(d.More) > 17 {
+		return d.More[17]
+	}
+	return d.EditMultisig
+}
+
+func (d *Price) SetTokenTransferFeePrice() *big.Int {
+	if len(d.More) > 18 {
+		return d.More[18]
+	}
+	return d.EditMultisig
+}
+
+func (d *Price) DistributeToHoldersPrice() *big.Int {
+	if len(d.More) > 19 {
+		return d.More[19]
+	}
+	return big.NewInt(0).Add(d.MultisendBase, big.NewInt(0).Mul(big.NewInt(99), d.MultisendDelta))
+}
+
+func (d *Price) MigrateCoinToTokenPrice() *big.Int {
+	if len(d.More) > 20 {
+		return d.More[20]
+	}
+	return d.CreateSwapPool
+}
+
+func (d *Price) CreateParamProposalPrice() *big.Int {
+	if len(d.More) > 21 {
+		return d.More[21]
+	}
+	return d.SetHaltBlock
+}
+
+func (d *Price) VoteParamProposalPrice() *big.Int {
+	if len(d.More) > 22 {
+		return d.More[22]
+	}
+	return d.VoteCommission
+}
+
+func (d *Price) CreateTreasuryProposalPrice() *big.Int {
+	if len(d.More) > 23 {
+		return d.More[23]
+	}
+	return d.SetHaltBlock
+}
+
+func (d *Price) VoteTreasuryProposalPrice() *big.Int {
+	if len(d.More) > 24 {
+		return d.More[24]
+	}
+	return d.VoteCommission
+}
+
+func (d *Price) VoteAsDelegatorPrice() *big.Int {
+	if len(d.More) > 25 {
+		return d.More[25]
+	}
+	return d.VoteCommission
+}
+
+func (d *Price) BatchPrice() *big.Int {
+	if len(d.More) > 26 {
+		return d.More[26]
+	}
+	return d.Send
+}
+
 func Decode(s string) *Price {
 	var p Price
 	err := rlp.DecodeBytes([]byte(s), &p)
@@ -0,0 +1,305 @@
+package delegatorvotes
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/state/bus"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/rlp"
+	"github.com/MinterTeam/minter-go-node/tree"
+)
+
+const mainPrefix = byte('k')
+
+// Kinds of validator votings which can be overridden by delegators
+const (
+	KindCommission byte = iota + 1
+	KindUpdate
+	KindHalt
+)
+
+// IsValidKind returns true if delegators can vote in the voting of the kind
+func IsValidKind(kind byte) bool {
+	return kind >= KindCommission && kind <= KindHalt
+}
+
+type RDelegatorVotes interface {
+	Export(state *types.AppState)
+	GetVotes(kind byte, height uint64) []Vote
+	GetVote(kind byte, height uint64, address types.Address) *Vote
+	IsVoteExists(kind byte, height uint64, address types.Address) bool
+	Tally(kind byte, height uint64, options [][]types.Pubkey, powers map[types.Pubkey]*big.Int) []*big.Int
+}
+
+type key struct {
+	kind   byte
+	height uint64
+}
+
+type DelegatorVotes struct {
+	list  map[key]*Model
+	dirty map[key]struct{}
+
+	bus *bus.Bus
+	db  tree.AtomicTree
+
+	lock sync.RWMutex
+}
+
+func NewDelegatorVotes(stateBus *bus.Bus, db tree.ImmutableTree) *DelegatorVotes {
+	immutableTree := tree.AtomicTree{}
+	if db != nil {
+		immutableTree.Store(db)
+	}
+
+	return &DelegatorVotes{
+		bus:   stateBus,
+		db:    immutableTree,
+		list:  map[key]*Model{},
+		dirty: map[key]struct{}{},
+	}
+}
+
+func (dv *DelegatorVotes) immutableTree() tree.ImmutableTree {
+	return dv.db.Load()
+}
+
+func (dv *DelegatorVotes) SetImmutableTree(immutableTree tree.ImmutableTree) {
+	dv.db.Store(immutableTree)
+}
+
+func (dv *DelegatorVotes) Commit(db tree.MutableTree, version int64) error {
+	for _, k := range dv.getOrderedDirty() {
+		model := dv.getFromMap(k)
+		path := getPath(k.kind, k.height)
+
+		dv.lock.Lock()
+		delete(dv.dirty, k)
+		if model.isDeleted() {
+			delete(dv.list, k)
+			db.Remove(path)
+		} else {
+			data, err := rlp.EncodeToBytes(model)
+			if err != nil {
+				dv.lock.Unlock()
+				return fmt.Errorf("can't encode delegator votes %d at %d: %v", k.kind, k.height, err)
+			}
+
+			db.Set(path, data)
+		}
+		dv.lock.Unlock()
+	}
+
+	return nil
+}
+
+// GetVotes returns votes of delegators in the voting of the kind at the height
+func (dv *DelegatorVotes) GetVotes(kind byte, height uint64) []Vote {
+	model := dv.get(kind, height)
+	if model == nil {
+		return nil
+	}
+
+	return model.GetVotes()
+}
+
+// GetVote returns the vote of the delegator, nil if the delegator has not voted
+func (dv *DelegatorVotes) GetVote(kind byte, height uint64, address types.Address) *Vote {
+	model := dv.get(kind, height)
+	if model == nil {
+		return nil
+	}
+
+	return model.getVote(address)
+}
+
+// IsVoteExists returns true if the delegator has already voted
+func (dv *DelegatorVotes) IsVoteExists(kind byte, height uint64, address types.Address) bool {
+	return dv.GetVote(kind, height, address) != nil
+}
+
+// AddVote stores the vote of the delegator in the voting of the kind at the height
+func (dv *DelegatorVotes) AddVote(kind byte, height uint64, address types.Address, option uint32, abstain bool) {
+	dv.getOrNew(kind, height).addVote(Vote{
+		Address: address,
+		Option:  option,
+		Abstain: abstain,
+	})
+}
+
+// Delete removes votes of delegators in the voting of the kind at the height
+func (dv *DelegatorVotes) Delete(kind byte, height uint64) {
+	model := dv.get(kind, height)
+	if model == nil {
+		return
+	}
+
+	model.delete()
+}
+
+// Tally returns the voting power of each option. Options are lists of validators voted for them,
+// powers are voting powers of the validators. The stake of the delegator who has voted is moved
+// from the option of its validator to the option chosen by the delegator
+func (dv *DelegatorVotes) Tally(kind byte, height uint64, options [][]types.Pubkey, powers map[types.Pubkey]*big.Int) []*big.Int {
+	result := make([]*big.Int, len(options))
+	for i := range result {
+		result[i] = big.NewInt(0)
+	}
+
+	votes := map[types.Address]Vote{}
+	for _, vote := range dv.GetVotes(kind, height) {
+		votes[vote.Address] = vote
+	}
+
+	validatorOptions := map[types.Pubkey]int{}
+	for i, option := range options {
+		for _, pubkey := range option {
+			if _, ok := validatorOptions[pubkey]; !ok {
+				validatorOptions[pubkey] = i
+			}
+		}
+	}
+
+	for pubkey, power := range powers {
+		option, voted := validatorOptions[pubkey]
+		if !voted && len(votes) == 0 {
+			continue
+		}
+
+		remaining := big.NewInt(0).Set(power)
+		if len(votes) != 0 {
+			for _, stake := range dv.bus.Candidates().GetStakes(pubkey) {
+				vote, ok := votes[stake.Owner]
+				if !ok || stake.BipValue == nil {
+					continue
+				}
+
+				value := big.NewInt(0).Set(stake.BipValue)
+				if value.Cmp(remaining) == 1 {
+					value.Set(remaining)
+				}
+				remaining.Sub(remaining, value)
+
+				if !vote.Abstain && int(vote.Option) < len(options) {
+					result[vote.Option].Add(result[vote.Option], value)
+				}
+			}
+		}
+
+		if voted {
+			result[option].Add(result[option], remaining)
+		}
+	}
+
+	return result
+}
+
+func (dv *DelegatorVotes) Export(state *types.AppState) {
+	dv.immutableTree().IterateRange([]byte{mainPrefix}, []byte{mainPrefix + 1}, true, func(k []byte, value []byte) bool {
+		if len(k) != 10 {
+			return false
+		}
+
+		kind, height := k[1], binary.LittleEndian.Uint64(k[2:])
+		for _, vote := range dv.GetVotes(kind, height) {
+			state.DelegatorVotes = append(state.DelegatorVotes, types.DelegatorVote{
+				Kind:    uint64(kind),
+				Height:  height,
+				Address: vote.Address,
+				Option:  uint64(vote.Option),
+				Abstain: vote.Abstain,
+			})
+		}
+
+		return false
+	})
+}
+
+func (dv *DelegatorVotes) getOrNew(kind byte, height uint64) *Model {
+	model := dv.get(kind, height)
+	if model == nil {
+		model = &Model{
+			kind:      kind,
+			height:    height,
+			markDirty: dv.markDirty,
+		}
+		dv.setToMap(key{kind: kind, height: height}, model)
+	}
+
+	return model
+}
+
+func (dv *DelegatorVotes) get(kind byte, height uint64) *Model {
+	k := key{kind: kind, height: height}
+	if model := dv.getFromMap(k); model != nil {
+		return model
+	}
+
+	_, enc := dv.immutableTree().Get(getPath(kind, height))
+	if len(enc) == 0 {
+		return nil
+	}
+
+	model := &Model{}
+	if err := rlp.DecodeBytes(enc, model); err != nil {
+		panic(fmt.Sprintf("failed to decode delegator votes %d at height %d: %s", kind, height, err))
+	}
+
+	model.kind = kind
+	model.height = height
+	model.markDirty = dv.markDirty
+
+	dv.setToMap(k, model)
+
+	return model
+}
+
+func (dv *DelegatorVotes) markDirty(kind byte, height uint64) {
+	dv.lock.Lock()
+	defer dv.lock.Unlock()
+
+	dv.dirty[key{kind: kind, height: height}] = struct{}{}
+}
+
+func (dv *DelegatorVotes) getOrderedDirty() []key {
+	dv.lock.RLock()
+	keys := make([]key, 0, len(dv.dirty))
+	for k := range dv.dirty {
+		keys = append(keys, k)
+	}
+	dv.lock.RUnlock()
+
+	sort.SliceStable(keys, func(i, j int) bool {
+		if keys[i].height == keys[j].height {
+			return keys[i].kind < keys[j].kind
+		}
+		return keys[i].height < keys[j].height
+	})
+
+	return keys
+}
+
+func (dv *DelegatorVotes) getFromMap(k key) *Model {
+	dv.lock.RLock()
+	defer dv.lock.RUnlock()
+
+	return dv.list[k]
+}
+
+func (dv *DelegatorVotes) setToMap(k key, model *Model) {
+	dv.lock.Lock()
+	defer dv.lock.Unlock()
+
+	dv.list[k] = model
+}
+
+func getPath(kind byte, height uint64) []byte {
+	b := make([]byte, 8)
+	binary.LittleEndian.PutUint64(b, height)
+
+	return append([]byte{mainPrefix, kind}, b...)
+}
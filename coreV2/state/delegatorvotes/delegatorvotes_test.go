@@ -0,0 +1,71 @@
+package delegatorvotes
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/state/bus"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/tree"
+	db "github.com/tendermint/tm-db"
+)
+
+type candidatesMock struct {
+	bus.Candidates
+	stakes map[types.Pubkey][]*bus.Stake
+}
+
+func (c *candidatesMock) GetStakes(pubkey types.Pubkey) []*bus.Stake {
+	return c.stakes[pubkey]
+}
+
+func TestDelegatorVotesTally(t *testing.T) {
+	t.Parallel()
+	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024, 0)
+	b := bus.NewBus()
+
+	validatorA, validatorB := types.Pubkey{1}, types.Pubkey{2}
+	delegatorA, delegatorB := types.Address{1}, types.Address{2}
+	b.SetCandidates(&candidatesMock{stakes: map[types.Pubkey][]*bus.Stake{
+		validatorA: {{Owner: delegatorA, Value: big.NewInt(40), BipValue: big.NewInt(40)}},
+		validatorB: {{Owner: delegatorB, Value: big.NewInt(10), BipValue: big.NewInt(10)}},
+	}})
+
+	dv := NewDelegatorVotes(b, mutableTree.GetLastImmutable())
+	dv.AddVote(KindCommission, 10, delegatorA, 1, false)
+	dv.AddVote(KindCommission, 10, delegatorB, 0, true)
+
+	_, _, err := mutableTree.Commit(dv)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dv = NewDelegatorVotes(b, mutableTree.GetLastImmutable())
+	if !dv.IsVoteExists(KindCommission, 10, delegatorA) || dv.IsVoteExists(KindUpdate, 10, delegatorA) {
+		t.Fatal("Delegator votes are not stored")
+	}
+
+	options := [][]types.Pubkey{{validatorA}, {validatorB}}
+	powers := map[types.Pubkey]*big.Int{validatorA: big.NewInt(100), validatorB: big.NewInt(50)}
+
+	result := dv.Tally(KindCommission, 10, options, powers)
+	if result[0].Cmp(big.NewInt(60)) != 0 || result[1].Cmp(big.NewInt(80)) != 0 {
+		t.Fatalf("Tally is not correct. Expected [60 80], got %v", result)
+	}
+
+	result = dv.Tally(KindUpdate, 10, options, powers)
+	if result[0].Cmp(big.NewInt(100)) != 0 || result[1].Cmp(big.NewInt(50)) != 0 {
+		t.Fatalf("Tally without delegator votes is not correct. Expected [100 50], got %v", result)
+	}
+
+	dv.Delete(KindCommission, 10)
+	_, _, err = mutableTree.Commit(dv)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dv = NewDelegatorVotes(b, mutableTree.GetLastImmutable())
+	if len(dv.GetVotes(KindCommission, 10)) != 0 {
+		t.Fatal("Delegator votes are not deleted")
+	}
+}
@@ -0,0 +1,83 @@
+package delegatorvotes
+
+import (
+	"sync"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+)
+
+// Vote is a choice of the delegator which overrides the vote of its validators for the delegated stake.
+// Option is an index of the voted option at the height, Abstain excludes the stake from all options
+type Vote struct {
+	Address types.Address
+	Option  uint32
+	Abstain bool
+}
+
+type Model struct {
+	Votes []Vote
+
+	kind      byte
+	height    uint64
+	deleted   bool
+	markDirty func(kind byte, height uint64)
+
+	lock sync.RWMutex
+}
+
+// Kind returns the kind of the voting
+func (m *Model) Kind() byte {
+	return m.kind
+}
+
+// Height returns the height of the voting
+func (m *Model) Height() uint64 {
+	return m.height
+}
+
+// GetVotes returns votes of delegators
+func (m *Model) GetVotes() []Vote {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	votes := make([]Vote, len(m.Votes))
+	copy(votes, m.Votes)
+
+	return votes
+}
+
+func (m *Model) getVote(address types.Address) *Vote {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	for _, vote := range m.Votes {
+		if vote.Address == address {
+			return &Vote{Address: vote.Address, Option: vote.Option, Abstain: vote.Abstain}
+		}
+	}
+
+	return nil
+}
+
+func (m *Model) addVote(vote Vote) {
+	m.lock.Lock()
+	m.Votes = append(m.Votes, vote)
+	m.lock.Unlock()
+
+	m.markDirty(m.kind, m.height)
+}
+
+func (m *Model) delete() {
+	m.lock.Lock()
+	m.deleted = true
+	m.lock.Unlock()
+
+	m.markDirty(m.kind, m.height)
+}
+
+func (m *Model) isDeleted() bool {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	return m.deleted
+}
@@ -0,0 +1,472 @@
+package distributions
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+
+	eventsdb "github.com/MinterTeam/minter-go-node/coreV2/events"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/bus"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/rlp"
+	"github.com/MinterTeam/minter-go-node/tree"
+)
+
+const mainPrefix = byte('g')
+
+const (
+	distributionPrefix = byte('d')
+	sharePrefix        = byte('h')
+	nextIDPrefix       = byte('n')
+)
+
+// MaxHolders limits the number of holders snapshotted by a distribution
+const MaxHolders = 100000
+
+type RDistributions interface {
+	Export(state *types.AppState)
+	GetDistribution(id uint64) *Distribution
+	GetShare(id uint64, index uint64) *Share
+}
+
+type shareKey struct {
+	id    uint64
+	index uint64
+}
+
+// Distributions is a store of airdrops, coins of the distributions are held by the store until they are paid to holders.
+// Snapshotted holders are kept by the ID of the distribution and their index, paid holders are removed.
+type Distributions struct {
+	list  map[uint64]*Distribution
+	dirty map[uint64]struct{}
+
+	shares map[shareKey]*Share
+
+	nextID        uint64
+	isDirtyNextID bool
+
+	bus *bus.Bus
+	db  tree.AtomicTree
+
+	lock sync.RWMutex
+}
+
+func NewDistributions(stateBus *bus.Bus, db tree.ImmutableTree) *Distributions {
+	immutableTree := tree.AtomicTree{}
+	if db != nil {
+		immutableTree.Store(db)
+	}
+	return &Distributions{
+		bus:    stateBus,
+		db:     immutableTree,
+		list:   map[uint64]*Distribution{},
+		dirty:  map[uint64]struct{}{},
+		shares: map[shareKey]*Share{},
+	}
+}
+
+func (d *Distributions) immutableTree() tree.ImmutableTree {
+	return d.db.Load()
+}
+
+func (d *Distributions) SetImmutableTree(immutableTree tree.ImmutableTree) {
+	d.db.Store(immutableTree)
+}
+
+func (d *Distributions) Commit(db tree.MutableTree, version int64) error {
+	for _, id := range d.getOrderedDirty() {
+		distribution := d.getFromMap(id)
+		path := getDistributionPath(id)
+
+		d.lock.Lock()
+		delete(d.dirty, id)
+		d.lock.Unlock()
+
+		distribution.lock.RLock()
+		if distribution.deleted {
+			d.lock.Lock()
+			delete(d.list, id)
+			d.lock.Unlock()
+
+			db.Remove(path)
+		} else {
+			data, err := rlp.EncodeToBytes(distribution)
+			if err != nil {
+				distribution.lock.RUnlock()
+				return fmt.Errorf("can't encode distribution %d: %v", id, err)
+			}
+
+			db.Set(path, data)
+		}
+		distribution.lock.RUnlock()
+	}
+
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	keys := make([]shareKey, 0, len(d.shares))
+	for key := range d.shares {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].id == keys[j].id {
+			return keys[i].index < keys[j].index
+		}
+		return keys[i].id < keys[j].id
+	})
+
+	for _, key := range keys {
+		share := d.shares[key]
+		delete(d.shares, key)
+
+		path := getSharePath(key.id, key.index)
+		if share == nil {
+			db.Remove(path)
+			continue
+		}
+
+		data, err := rlp.EncodeToBytes(share)
+		if err != nil {
+			return fmt.Errorf("can't encode share %d of distribution %d: %v", key.index, key.id, err)
+		}
+
+		db.Set(path, data)
+	}
+
+	if d.isDirtyNextID {
+		d.isDirtyNextID = false
+
+		data, err := rlp.EncodeToBytes(d.nextID)
+		if err != nil {
+			return fmt.Errorf("can't encode next distribution id: %v", err)
+		}
+
+		db.Set([]byte{mainPrefix, nextIDPrefix}, data)
+	}
+
+	return nil
+}
+
+// GetDistribution returns an active distribution by its ID
+func (d *Distributions) GetDistribution(id uint64) *Distribution {
+	distribution := d.get(id)
+	if distribution == nil || distribution.isDeleted() {
+		return nil
+	}
+
+	return distribution
+}
+
+// GetShare returns the snapshotted balance of the holder at given index, nil if the holder is already paid
+func (d *Distributions) GetShare(id uint64, index uint64) *Share {
+	key := shareKey{id: id, index: index}
+
+	d.lock.RLock()
+	share, ok := d.shares[key]
+	d.lock.RUnlock()
+	if ok {
+		return share
+	}
+
+	immutableTree := d.immutableTree()
+	if immutableTree == nil {
+		return nil
+	}
+
+	_, enc := immutableTree.Get(getSharePath(id, index))
+	if len(enc) == 0 {
+		return nil
+	}
+
+	share = &Share{}
+	if err := rlp.DecodeBytes(enc, share); err != nil {
+		panic(fmt.Sprintf("failed to decode share %d of distribution %d: %s", index, id, err))
+	}
+
+	return share
+}
+
+// Create starts a new distribution of the amount among the shares and returns its ID, coins should be subtracted from the owner balance
+func (d *Distributions) Create(owner types.Address, coin types.CoinID, amount *big.Int, holderCoin types.CoinID, shares []Share) uint64 {
+	id := d.getNextID()
+	d.setNextID(id + 1)
+
+	d.SetDistribution(id, owner, coin, amount, holderCoin, big.NewInt(0), shares)
+
+	return id
+}
+
+// SetDistribution puts a distribution with given ID and its unpaid shares to the store
+func (d *Distributions) SetDistribution(id uint64, owner types.Address, coin types.CoinID, amount *big.Int, holderCoin types.CoinID, paid *big.Int, shares []Share) {
+	total := big.NewInt(0)
+
+	d.lock.Lock()
+	for i, share := range shares {
+		total.Add(total, share.Balance)
+		d.shares[shareKey{id: id, index: uint64(i)}] = &Share{
+			Address: share.Address,
+			Balance: big.NewInt(0).Set(share.Balance),
+		}
+	}
+	d.lock.Unlock()
+
+	distribution := &Distribution{
+		Owner:      owner,
+		Coin:       coin,
+		Amount:     big.NewInt(0).Set(amount),
+		HolderCoin: holderCoin,
+		Total:      total,
+		Holders:    uint64(len(shares)),
+		Paid:       big.NewInt(0).Set(paid),
+		id:         id,
+		markDirty:  d.markDirty,
+	}
+	d.setToMap(id, distribution)
+	distribution.markDirty(id)
+
+	d.bus.Checker().AddCoin(coin, big.NewInt(0).Sub(amount, paid))
+}
+
+// SetNextID sets the ID of the next created distribution
+func (d *Distributions) SetNextID(id uint64) {
+	d.setNextID(id)
+}
+
+// Process pays at most limit holders of active distributions in order of their IDs.
+// Distributions of paused coins are postponed, shares of frozen holders are refunded to the owner along with the rounding rest.
+func (d *Distributions) Process(limit int) {
+	for _, id := range d.getActiveIDs() {
+		if limit <= 0 {
+			return
+		}
+
+		distribution := d.GetDistribution(id)
+		if distribution == nil || d.bus.Coins().IsCoinPaused(distribution.Coin) {
+			continue
+		}
+
+		hasNext := distribution.GetCursor() < distribution.Holders
+		for ; hasNext && limit > 0; limit-- {
+			index := distribution.GetCursor()
+			value := big.NewInt(0)
+			if share := d.GetShare(id, index); share != nil && !d.bus.Coins().IsCoinFrozen(distribution.Coin, share.Address) {
+				value = distribution.shareOf(share.Balance)
+				if value.Sign() == 1 {
+					d.bus.Checker().AddCoin(distribution.Coin, big.NewInt(0).Neg(value))
+					d.bus.Accounts().AddBalance(share.Address, distribution.Coin, value)
+					d.bus.Events().AddEvent(&eventsdb.DistributionPaymentEvent{
+						ID:        id,
+						Recipient: share.Address,
+						Coin:      uint64(distribution.Coin),
+						Amount:    value.String(),
+					})
+				}
+			}
+
+			d.lock.Lock()
+			d.shares[shareKey{id: id, index: index}] = nil
+			d.lock.Unlock()
+
+			hasNext = distribution.pay(value)
+		}
+
+		if !hasNext {
+			d.complete(distribution)
+		}
+	}
+}
+
+// complete refunds the undistributed rest to the owner and deletes the distribution
+func (d *Distributions) complete(distribution *Distribution) {
+	distribution.delete()
+
+	paid := distribution.GetPaid()
+	refund := big.NewInt(0).Sub(distribution.Amount, paid)
+	if refund.Sign() == 1 {
+		d.bus.Checker().AddCoin(distribution.Coin, big.NewInt(0).Neg(refund))
+		d.bus.Accounts().AddBalance(distribution.Owner, distribution.Coin, refund)
+	}
+
+	d.bus.Events().AddEvent(&eventsdb.DistributionCompletedEvent{
+		ID:     distribution.id,
+		Owner:  distribution.Owner,
+		Coin:   uint64(distribution.Coin),
+		Paid:   paid.String(),
+		Refund: refund.String(),
+	})
+}
+
+func (d *Distributions) Export(state *types.AppState) {
+	for _, id := range d.getActiveIDs() {
+		distribution := d.GetDistribution(id)
+		if distribution == nil {
+			continue
+		}
+
+		var shares []types.DistributionShare
+		for index := distribution.GetCursor(); index < distribution.Holders; index++ {
+			share := d.GetShare(id, index)
+			if share == nil {
+				continue
+			}
+
+			shares = append(shares, types.DistributionShare{
+				Address: share.Address,
+				Balance: share.Balance.String(),
+			})
+		}
+
+		state.Distributions = append(state.Distributions, types.Distribution{
+			ID:         id,
+			Owner:      distribution.Owner,
+			Coin:       uint64(distribution.Coin),
+			Amount:     distribution.Amount.String(),
+			HolderCoin: uint64(distribution.HolderCoin),
+			Paid:       distribution.GetPaid().String(),
+			Shares:     shares,
+		})
+	}
+
+	state.NextDistributionID = d.getNextID()
+}
+
+// getActiveIDs returns IDs of stored and created distributions which are not completed, ordered by ID
+func (d *Distributions) getActiveIDs() []uint64 {
+	ids := map[uint64]struct{}{}
+
+	if immutableTree := d.immutableTree(); immutableTree != nil {
+		immutableTree.IterateRange([]byte{mainPrefix, distributionPrefix}, []byte{mainPrefix, distributionPrefix + 1}, true, func(key []byte, value []byte) bool {
+			ids[binary.BigEndian.Uint64(key[2:])] = struct{}{}
+			return false
+		})
+	}
+
+	d.lock.RLock()
+	for id := range d.list {
+		ids[id] = struct{}{}
+	}
+	d.lock.RUnlock()
+
+	sorted := make([]uint64, 0, len(ids))
+	for id := range ids {
+		if distribution := d.get(id); distribution == nil || distribution.isDeleted() {
+			continue
+		}
+		sorted = append(sorted, id)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+
+	return sorted
+}
+
+func (d *Distributions) get(id uint64) *Distribution {
+	if distribution := d.getFromMap(id); distribution != nil {
+		return distribution
+	}
+
+	immutableTree := d.immutableTree()
+	if immutableTree == nil {
+		return nil
+	}
+
+	_, enc := immutableTree.Get(getDistributionPath(id))
+	if len(enc) == 0 {
+		return nil
+	}
+
+	distribution := &Distribution{}
+	if err := rlp.DecodeBytes(enc, distribution); err != nil {
+		panic(fmt.Sprintf("failed to decode distribution %d: %s", id, err))
+	}
+
+	distribution.id = id
+	distribution.markDirty = d.markDirty
+
+	d.setToMap(id, distribution)
+
+	return distribution
+}
+
+func (d *Distributions) getNextID() uint64 {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if d.nextID != 0 {
+		return d.nextID
+	}
+
+	d.nextID = 1
+	if immutableTree := d.immutableTree(); immutableTree != nil {
+		_, enc := immutableTree.Get([]byte{mainPrefix, nextIDPrefix})
+		if len(enc) != 0 {
+			if err := rlp.DecodeBytes(enc, &d.nextID); err != nil {
+				panic(fmt.Sprintf("failed to decode next distribution id: %s", err))
+			}
+		}
+	}
+
+	return d.nextID
+}
+
+func (d *Distributions) setNextID(id uint64) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	d.nextID = id
+	d.isDirtyNextID = true
+}
+
+func (d *Distributions) markDirty(id uint64) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	d.dirty[id] = struct{}{}
+}
+
+func (d *Distributions) getOrderedDirty() []uint64 {
+	d.lock.Lock()
+	keys := make([]uint64, 0, len(d.dirty))
+	for k := range d.dirty {
+		keys = append(keys, k)
+	}
+	d.lock.Unlock()
+
+	sort.SliceStable(keys, func(i, j int) bool {
+		return keys[i] < keys[j]
+	})
+
+	return keys
+}
+
+func (d *Distributions) getFromMap(id uint64) *Distribution {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+
+	return d.list[id]
+}
+
+func (d *Distributions) setToMap(id uint64, distribution *Distribution) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	d.list[id] = distribution
+}
+
+func getDistributionPath(id uint64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, id)
+
+	return append([]byte{mainPrefix, distributionPrefix}, b...)
+}
+
+func getSharePath(id uint64, index uint64) []byte {
+	b := make([]byte, 16)
+	binary.BigEndian.PutUint64(b, id)
+	binary.BigEndian.PutUint64(b[8:], index)
+
+	return append([]byte{mainPrefix, sharePrefix}, b...)
+}
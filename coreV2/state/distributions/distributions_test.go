@@ -0,0 +1,140 @@
+package distributions
+
+import (
+	"math/big"
+	"testing"
+
+	eventsdb "github.com/MinterTeam/minter-go-node/coreV2/events"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/accounts"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/bus"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/checker"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/coins"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/tree"
+	db "github.com/tendermint/tm-db"
+)
+
+func TestDistributionsToCommitAndLoad(t *testing.T) {
+	t.Parallel()
+	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024, 0)
+	b := bus.NewBus()
+	b.SetChecker(checker.NewChecker(b))
+	b.SetEvents(&eventsdb.MockEvents{})
+	coins.NewCoins(b, mutableTree.GetLastImmutable())
+	d := NewDistributions(b, mutableTree.GetLastImmutable())
+
+	coin, holderCoin := types.GetBaseCoinID(), types.CoinID(1)
+	owner := types.Address{1}
+	shares := []Share{
+		{Address: types.Address{2}, Balance: big.NewInt(1)},
+		{Address: types.Address{3}, Balance: big.NewInt(3)},
+	}
+
+	id := d.Create(owner, coin, big.NewInt(100), holderCoin, shares)
+
+	_, _, err := mutableTree.Commit(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d = NewDistributions(b, mutableTree.GetLastImmutable())
+	distribution := d.GetDistribution(id)
+	if distribution == nil {
+		t.Fatal("Distribution not found")
+	}
+
+	if distribution.ID() != id || distribution.Owner != owner || distribution.Coin != coin || distribution.HolderCoin != holderCoin ||
+		distribution.Amount.Cmp(big.NewInt(100)) != 0 || distribution.Total.Cmp(big.NewInt(4)) != 0 ||
+		distribution.Holders != 2 || distribution.GetCursor() != 0 || distribution.GetPaid().Sign() != 0 {
+		t.Fatalf("Distribution is not correct: %+v", distribution)
+	}
+
+	for i, share := range shares {
+		loaded := d.GetShare(id, uint64(i))
+		if loaded == nil || loaded.Address != share.Address || loaded.Balance.Cmp(share.Balance) != 0 {
+			t.Fatalf("Share %d is not correct: %+v", i, loaded)
+		}
+	}
+
+	if d.GetShare(id, 2) != nil {
+		t.Fatal("Not snapshotted share is found")
+	}
+
+	if nextID := d.getNextID(); nextID != id+1 {
+		t.Fatalf("Next ID is not correct. Expected %d, got %d", id+1, nextID)
+	}
+}
+
+func TestDistributionsToProcessAfterLoad(t *testing.T) {
+	t.Parallel()
+	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024, 0)
+	b := bus.NewBus()
+	b.SetChecker(checker.NewChecker(b))
+	b.SetEvents(&eventsdb.MockEvents{})
+	coins.NewCoins(b, mutableTree.GetLastImmutable())
+	acc := accounts.NewAccounts(b, mutableTree.GetLastImmutable())
+	d := NewDistributions(b, mutableTree.GetLastImmutable())
+
+	coin := types.GetBaseCoinID()
+	owner := types.Address{1}
+	id := d.Create(owner, coin, big.NewInt(100), types.CoinID(1), []Share{
+		{Address: types.Address{2}, Balance: big.NewInt(1)},
+		{Address: types.Address{3}, Balance: big.NewInt(1)},
+		{Address: types.Address{4}, Balance: big.NewInt(1)},
+	})
+
+	_, _, err := mutableTree.Commit(acc, d)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d = NewDistributions(b, mutableTree.GetLastImmutable())
+	d.Process(2)
+
+	_, _, err = mutableTree.Commit(acc, d)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d = NewDistributions(b, mutableTree.GetLastImmutable())
+	distribution := d.GetDistribution(id)
+	if distribution == nil {
+		t.Fatal("Distribution not found")
+	}
+
+	if distribution.GetCursor() != 2 || distribution.GetPaid().Cmp(big.NewInt(66)) != 0 {
+		t.Fatalf("Distribution is not correct: cursor %d, paid %s", distribution.GetCursor(), distribution.GetPaid())
+	}
+
+	if d.GetShare(id, 0) != nil || d.GetShare(id, 1) != nil || d.GetShare(id, 2) == nil {
+		t.Fatal("Paid shares are not deleted")
+	}
+
+	d.Process(2)
+
+	_, _, err = mutableTree.Commit(acc, d)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d = NewDistributions(b, mutableTree.GetLastImmutable())
+	if d.GetDistribution(id) != nil {
+		t.Fatal("Distribution is not deleted after the last payment")
+	}
+
+	for _, address := range []types.Address{{2}, {3}, {4}} {
+		if balance := acc.GetBalance(address, coin); balance.Cmp(big.NewInt(33)) != 0 {
+			t.Fatalf("Holder balance is not correct. Expected %d, got %s", 33, balance)
+		}
+	}
+
+	if balance := acc.GetBalance(owner, coin); balance.Cmp(big.NewInt(1)) != 0 {
+		t.Fatalf("Owner refund is not correct. Expected %d, got %s", 1, balance)
+	}
+
+	appState := &types.AppState{}
+	d.Export(appState)
+	if len(appState.Distributions) != 0 || appState.NextDistributionID != id+1 {
+		t.Fatalf("Exported state is not correct: %d distributions, next ID %d", len(appState.Distributions), appState.NextDistributionID)
+	}
+}
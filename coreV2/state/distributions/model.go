@@ -0,0 +1,87 @@
+package distributions
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+)
+
+// Distribution is an airdrop of Amount of Coin funded by Owner, which is paid pro-rata to holders of HolderCoin
+// snapshotted at creation. Holders are paid in batches starting from Cursor, the undistributed rest is refunded to Owner.
+type Distribution struct {
+	Owner      types.Address
+	Coin       types.CoinID
+	Amount     *big.Int
+	HolderCoin types.CoinID
+	Total      *big.Int
+	Holders    uint64
+	Cursor     uint64
+	Paid       *big.Int
+
+	id        uint64
+	deleted   bool
+	markDirty func(id uint64)
+	lock      sync.RWMutex
+}
+
+// Share is a snapshotted balance of a holder
+type Share struct {
+	Address types.Address
+	Balance *big.Int
+}
+
+// ID returns the identifier of the distribution
+func (d *Distribution) ID() uint64 {
+	return d.id
+}
+
+// GetCursor returns the index of the next holder to be paid
+func (d *Distribution) GetCursor() uint64 {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+
+	return d.Cursor
+}
+
+// GetPaid returns the amount paid to holders so far
+func (d *Distribution) GetPaid() *big.Int {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+
+	return big.NewInt(0).Set(d.Paid)
+}
+
+// shareOf returns the part of the amount for the snapshotted balance
+func (d *Distribution) shareOf(balance *big.Int) *big.Int {
+	value := big.NewInt(0).Mul(d.Amount, balance)
+	return value.Div(value, d.Total)
+}
+
+// pay moves the cursor to the next holder and returns true if there are holders left
+func (d *Distribution) pay(value *big.Int) bool {
+	d.lock.Lock()
+	d.Paid.Add(d.Paid, value)
+	d.Cursor++
+	hasNext := d.Cursor < d.Holders
+	d.lock.Unlock()
+
+	d.markDirty(d.id)
+
+	return hasNext
+}
+
+func (d *Distribution) delete() {
+	d.lock.Lock()
+	d.deleted = true
+	d.lock.Unlock()
+
+	d.markDirty(d.id)
+}
+
+func (d *Distribution) isDeleted() bool {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+
+	return d.deleted
+}
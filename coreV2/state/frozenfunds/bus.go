@@ -13,6 +13,10 @@ func (b *Bus) AddFrozenFund(height uint64, address types.Address, pubkey *types.
 	b.frozenfunds.AddFund(height, address, pubkey, candidateID, coin, big.NewInt(0).Set(value), 0)
 }
 
+func (b *Bus) SlashFrozenFund(fromHeight, toHeight uint64, address types.Address, candidateID uint32, coin types.CoinID, value *big.Int, punishedPubKey types.Pubkey) *big.Int {
+	return b.frozenfunds.SlashFund(fromHeight, toHeight, address, candidateID, coin, value, punishedPubKey)
+}
+
 func NewBus(frozenfunds *FrozenFunds) *Bus {
 	return &Bus{frozenfunds: frozenfunds}
 }
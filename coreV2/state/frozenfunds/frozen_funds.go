@@ -8,14 +8,13 @@ import (
 	"math/big"
 	"sort"
 	"sync"
-	"sync/atomic"
 
 	eventsdb "github.com/MinterTeam/minter-go-node/coreV2/events"
 	"github.com/MinterTeam/minter-go-node/coreV2/state/bus"
 	"github.com/MinterTeam/minter-go-node/coreV2/types"
 	"github.com/MinterTeam/minter-go-node/formula"
 	"github.com/MinterTeam/minter-go-node/rlp"
-	"github.com/cosmos/iavl"
+	"github.com/MinterTeam/minter-go-node/tree"
 )
 
 const mainPrefix = byte('f')
@@ -31,13 +30,13 @@ type FrozenFunds struct {
 	dirty map[uint64]interface{}
 
 	bus *bus.Bus
-	db  atomic.Value
+	db  tree.AtomicTree
 
 	lock sync.RWMutex
 }
 
-func NewFrozenFunds(stateBus *bus.Bus, db *iavl.ImmutableTree) *FrozenFunds {
-	immutableTree := atomic.Value{}
+func NewFrozenFunds(stateBus *bus.Bus, db tree.ImmutableTree) *FrozenFunds {
+	immutableTree := tree.AtomicTree{}
 	if db != nil {
 		immutableTree.Store(db)
 	}
@@ -47,18 +46,14 @@ func NewFrozenFunds(stateBus *bus.Bus, db *iavl.ImmutableTree) *FrozenFunds {
 	return frozenFunds
 }
 
-func (f *FrozenFunds) immutableTree() *iavl.ImmutableTree {
-	db := f.db.Load()
-	if db == nil {
-		return nil
-	}
-	return db.(*iavl.ImmutableTree)
+func (f *FrozenFunds) immutableTree() tree.ImmutableTree {
+	return f.db.Load()
 }
 
-func (f *FrozenFunds) SetImmutableTree(immutableTree *iavl.ImmutableTree) {
+func (f *FrozenFunds) SetImmutableTree(immutableTree tree.ImmutableTree) {
 	f.db.Store(immutableTree)
 }
-func (f *FrozenFunds) Commit(db *iavl.MutableTree, version int64) error {
+func (f *FrozenFunds) Commit(db tree.MutableTree, version int64) error {
 	dirty := f.getOrderedDirty()
 	for _, height := range dirty {
 		ff := f.getFromMap(height)
@@ -115,24 +110,7 @@ func (f *FrozenFunds) PunishFrozenFundsWithID(fromHeight uint64, toHeight uint64
 				slashed := big.NewInt(0).Set(item.Value)
 				slashed.Sub(slashed, newValue)
 
-				if !item.Coin.IsBaseCoin() {
-					coin := f.bus.Coins().GetCoin(item.Coin)
-					ret := formula.CalculateSaleReturn(coin.Volume, coin.Reserve, coin.Crr, slashed)
-					f.bus.Coins().SubCoinVolume(item.Coin, slashed)
-					f.bus.Coins().SubCoinReserve(item.Coin, ret)
-					f.bus.App().AddTotalSlashed(ret)
-				} else {
-					f.bus.App().AddTotalSlashed(slashed)
-				}
-
-				f.bus.Checker().AddCoin(item.Coin, new(big.Int).Neg(slashed))
-
-				f.bus.Events().AddEvent(&eventsdb.SlashEvent{
-					Address:         item.Address,
-					Amount:          slashed.String(),
-					Coin:            uint64(item.Coin),
-					ValidatorPubKey: *item.CandidateKey,
-				})
+				f.slash(item.Address, item.Coin, slashed, *item.CandidateKey)
 
 				item.Value = newValue
 			}
@@ -146,6 +124,68 @@ func (f *FrozenFunds) PunishFrozenFundsWithID(fromHeight uint64, toHeight uint64
 	}
 }
 
+// SlashFund slashes up to given value from funds of the address frozen at the candidate with given ID from fromHeight to toHeight
+// and returns the slashed value. It is used when a stake to slash has already been unbonded or moved from the candidate
+func (f *FrozenFunds) SlashFund(fromHeight uint64, toHeight uint64, address types.Address, candidateID uint32, coin types.CoinID, value *big.Int, punishedPubKey types.Pubkey) *big.Int {
+	slashed := big.NewInt(0)
+	for cBlock := fromHeight; cBlock <= toHeight && slashed.Cmp(value) == -1; cBlock++ {
+		ff := f.get(cBlock)
+		if ff == nil {
+			continue
+		}
+
+		ff.lock.Lock()
+		changed := false
+		for i, item := range ff.List {
+			if item.Address != address || item.CandidateID != candidateID || item.Coin != coin {
+				continue
+			}
+
+			itemSlashed := big.NewInt(0).Sub(value, slashed)
+			if itemSlashed.Cmp(item.Value) == 1 {
+				itemSlashed.Set(item.Value)
+			}
+			if itemSlashed.Sign() != 1 {
+				continue
+			}
+
+			f.slash(item.Address, item.Coin, itemSlashed, punishedPubKey)
+
+			ff.List[i].Value = big.NewInt(0).Sub(item.Value, itemSlashed)
+			slashed.Add(slashed, itemSlashed)
+			changed = true
+		}
+		ff.lock.Unlock()
+
+		if changed {
+			f.markDirty(cBlock)
+		}
+	}
+
+	return slashed
+}
+
+func (f *FrozenFunds) slash(address types.Address, coinID types.CoinID, slashed *big.Int, pubKey types.Pubkey) {
+	if !coinID.IsBaseCoin() {
+		coin := f.bus.Coins().GetCoin(coinID)
+		ret := formula.CalculateSaleReturn(coin.Volume, coin.Reserve, coin.Crr, slashed)
+		f.bus.Coins().SubCoinVolume(coinID, slashed)
+		f.bus.Coins().SubCoinReserve(coinID, ret)
+		f.bus.App().AddTotalSlashed(ret)
+	} else {
+		f.bus.App().AddTotalSlashed(slashed)
+	}
+
+	f.bus.Checker().AddCoin(coinID, new(big.Int).Neg(slashed))
+
+	f.bus.Events().AddEvent(&eventsdb.SlashEvent{
+		Address:         address,
+		Amount:          slashed.String(),
+		Coin:            uint64(coinID),
+		ValidatorPubKey: pubKey,
+	})
+}
+
 func (f *FrozenFunds) GetOrNew(height uint64) *Model {
 	ff := f.get(height)
 	if ff == nil {
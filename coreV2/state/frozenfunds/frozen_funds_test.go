@@ -1,6 +1,8 @@
 package frozenfunds
 
 import (
+	eventsdb "github.com/MinterTeam/minter-go-node/coreV2/events"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/app"
 	"github.com/MinterTeam/minter-go-node/coreV2/state/bus"
 	"github.com/MinterTeam/minter-go-node/coreV2/state/checker"
 	"github.com/MinterTeam/minter-go-node/coreV2/state/coins"
@@ -95,3 +97,61 @@ func TestFrozenFundsToDeleteNotExistingFund(t *testing.T) {
 
 	ff.Delete(0)
 }
+
+func TestFrozenFundsSlashFund(t *testing.T) {
+	t.Parallel()
+	b := bus.NewBus()
+	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024, 0)
+	ff := NewFrozenFunds(b, mutableTree.GetLastImmutable())
+
+	b.SetEvents(eventsdb.NewEventsStore(db.NewMemDB()))
+	appState := app.NewApp(b, mutableTree.GetLastImmutable())
+	b.SetApp(appState)
+	b.SetChecker(checker.NewChecker(b))
+	coinsState := coins.NewCoins(b, mutableTree.GetLastImmutable())
+
+	b.SetCoins(coins.NewBus(coinsState))
+
+	addr, pubkey, coin := types.Address{0}, types.Pubkey{0}, types.GetBaseCoinID()
+
+	ff.AddFund(10, addr, &pubkey, 1, coin, big.NewInt(100), 0)
+	ff.AddFund(20, addr, &pubkey, 1, coin, big.NewInt(100), 0)
+	ff.AddFund(20, addr, &pubkey, 2, coin, big.NewInt(100), 0)
+	ff.AddFund(30, addr, &pubkey, 1, coin, big.NewInt(100), 0)
+
+	_, _, err := mutableTree.Commit(ff)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	slashed := ff.SlashFund(1, 25, addr, 1, coin, big.NewInt(150), pubkey)
+	if slashed.Cmp(big.NewInt(150)) != 0 {
+		t.Fatalf("slashed %s, want 150", slashed)
+	}
+
+	_, _, err = mutableTree.Commit(ff)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for height, want := range map[uint64][]int64{10: {0}, 20: {50, 100}, 30: {100}} {
+		funds := ff.GetFrozenFunds(height)
+		if funds == nil || len(funds.List) != len(want) {
+			t.Fatalf("invalid funds at height %d", height)
+		}
+		for i, value := range want {
+			if funds.List[i].Value.Cmp(big.NewInt(value)) != 0 {
+				t.Errorf("fund %d at height %d is %s, want %d", i, height, funds.List[i].Value, value)
+			}
+		}
+	}
+
+	if appState.GetTotalSlashed().Cmp(big.NewInt(150)) != 0 {
+		t.Errorf("total slashed is %s, want 150", appState.GetTotalSlashed())
+	}
+
+	slashed = ff.SlashFund(1, 25, addr, 1, coin, big.NewInt(100), pubkey)
+	if slashed.Cmp(big.NewInt(50)) != 0 {
+		t.Fatalf("slashed %s, want 50", slashed)
+	}
+}
@@ -0,0 +1,29 @@
+package governance
+
+type Bus struct {
+	governance *Governance
+}
+
+func NewBus(governance *Governance) *Bus {
+	return &Bus{governance: governance}
+}
+
+func (b *Bus) UnbondPeriod() uint64 {
+	return b.governance.UnbondPeriod()
+}
+
+func (b *Bus) ValidatorMaxAbsentTimes() uint64 {
+	return b.governance.ValidatorMaxAbsentTimes()
+}
+
+func (b *Bus) ValidatorMaxAbsentWindow() uint64 {
+	return b.governance.ValidatorMaxAbsentWindow()
+}
+
+func (b *Bus) MaxDelegatorsPerCandidate() uint64 {
+	return b.governance.MaxDelegatorsPerCandidate()
+}
+
+func (b *Bus) DAOCommission() uint64 {
+	return b.governance.DAOCommission()
+}
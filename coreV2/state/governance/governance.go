@@ -0,0 +1,661 @@
+package governance
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/dao"
+	eventsdb "github.com/MinterTeam/minter-go-node/coreV2/events"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/bus"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/candidates"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/validators"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/rlp"
+	"github.com/MinterTeam/minter-go-node/tree"
+)
+
+const mainPrefix = byte('j')
+
+const (
+	paramPrefix    = byte('p')
+	proposalPrefix = byte('o')
+	nextIDPrefix   = byte('n')
+)
+
+// Keys of the parameters which can be changed by proposals
+const (
+	ParamMaxGas                    = "max_gas"
+	ParamUnbondPeriod              = "unbond_period"
+	ParamValidatorMaxAbsentTimes   = "validator_max_absent_times"
+	ParamValidatorMaxAbsentWindow  = "validator_max_absent_window"
+	ParamMaxDelegatorsPerCandidate = "max_delegators_per_candidate"
+	ParamDAOCommission             = "dao_commission"
+	ParamVotingPeriod              = "voting_period"
+	ParamMinDeposit                = "min_deposit"
+)
+
+const (
+	DefaultMaxGas       = 100000
+	MinMaxGas           = 5000
+	maxMaxGas           = 1000000
+	defaultVotingPeriod = 120960 // 1w
+	defaultMinDeposit   = 10000  // in BIP
+)
+
+type param struct {
+	defaultValue func() uint64
+	minValue     func() uint64
+	max          uint64
+}
+
+func constant(value uint64) func() uint64 {
+	return func() uint64 { return value }
+}
+
+var params = map[string]param{
+	ParamMaxGas: {
+		defaultValue: constant(DefaultMaxGas),
+		minValue:     constant(MinMaxGas),
+		max:          maxMaxGas,
+	},
+	ParamUnbondPeriod: {
+		defaultValue: types.GetUnbondPeriod,
+		minValue:     types.GetMovePeriod, // moved stakes and redelegations are completed within the unbond period
+		max:          10 * types.GetUnbondPeriodWithChain(types.ChainMainnet),
+	},
+	ParamValidatorMaxAbsentTimes: {
+		defaultValue: constant(validators.ValidatorMaxAbsentTimes),
+		minValue:     constant(1),
+		max:          validators.ValidatorMaxAbsentWindow - 1,
+	},
+	ParamValidatorMaxAbsentWindow: {
+		defaultValue: constant(validators.ValidatorMaxAbsentWindow),
+		minValue:     constant(2),
+		max:          validators.ValidatorMaxAbsentWindow,
+	},
+	ParamMaxDelegatorsPerCandidate: {
+		defaultValue: constant(candidates.MaxDelegatorsPerCandidate),
+		minValue:     constant(1),
+		max:          candidates.MaxDelegatorsPerCandidate,
+	},
+	ParamDAOCommission: {
+		defaultValue: constant(uint64(dao.Commission)),
+		minValue:     constant(0),
+		max:          50,
+	},
+	ParamVotingPeriod: {
+		defaultValue: constant(defaultVotingPeriod),
+		minValue:     constant(1),
+		max:          10 * defaultVotingPeriod,
+	},
+	ParamMinDeposit: {
+		defaultValue: constant(defaultMinDeposit),
+		minValue:     constant(1),
+		max:          1000000000,
+	},
+}
+
+type RGovernance interface {
+	Export(state *types.AppState)
+	GetParam(key string) uint64
+	GetParams() map[string]uint64
+	CheckParam(key string, value uint64) error
+	GetProposal(id uint64) *Proposal
+	GetProposals() []*Proposal
+	MaxGas() uint64
+	UnbondPeriod() uint64
+	VotingPeriod() uint64
+	MinDeposit() *big.Int
+	DAOCommission() uint64
+}
+
+// Governance keeps parameters of the network changed by validators and proposals of their changes.
+// Deposits of proposals are held by the store until the end of voting
+type Governance struct {
+	list  map[uint64]*Proposal
+	dirty map[uint64]struct{}
+
+	params      map[string]uint64
+	dirtyParams map[string]struct{}
+	enabled     bool
+
+	nextID        uint64
+	isDirtyNextID bool
+
+	bus *bus.Bus
+	db  tree.AtomicTree
+
+	lock sync.RWMutex
+}
+
+func NewGovernance(stateBus *bus.Bus, db tree.ImmutableTree) *Governance {
+	immutableTree := tree.AtomicTree{}
+	if db != nil {
+		immutableTree.Store(db)
+	}
+	governance := &Governance{
+		bus:         stateBus,
+		db:          immutableTree,
+		list:        map[uint64]*Proposal{},
+		dirty:       map[uint64]struct{}{},
+		params:      map[string]uint64{},
+		dirtyParams: map[string]struct{}{},
+	}
+	governance.bus.SetGovernance(NewBus(governance))
+
+	return governance
+}
+
+func (g *Governance) immutableTree() tree.ImmutableTree {
+	return g.db.Load()
+}
+
+func (g *Governance) SetImmutableTree(immutableTree tree.ImmutableTree) {
+	g.db.Store(immutableTree)
+}
+
+func (g *Governance) Commit(db tree.MutableTree, version int64) error {
+	for _, id := range g.getOrderedDirty() {
+		proposal := g.getFromMap(id)
+		path := getProposalPath(id)
+
+		g.lock.Lock()
+		delete(g.dirty, id)
+		g.lock.Unlock()
+
+		proposal.lock.RLock()
+		if proposal.deleted {
+			g.lock.Lock()
+			delete(g.list, id)
+			g.lock.Unlock()
+
+			db.Remove(path)
+		} else {
+			data, err := rlp.EncodeToBytes(proposal)
+			if err != nil {
+				proposal.lock.RUnlock()
+				return fmt.Errorf("can't encode proposal %d: %v", id, err)
+			}
+
+			db.Set(path, data)
+		}
+		proposal.lock.RUnlock()
+	}
+
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	keys := make([]string, 0, len(g.dirtyParams))
+	for key := range g.dirtyParams {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		delete(g.dirtyParams, key)
+
+		data, err := rlp.EncodeToBytes(g.params[key])
+		if err != nil {
+			return fmt.Errorf("can't encode param %s: %v", key, err)
+		}
+
+		db.Set(getParamPath(key), data)
+	}
+
+	if g.isDirtyNextID {
+		g.isDirtyNextID = false
+
+		data, err := rlp.EncodeToBytes(g.nextID)
+		if err != nil {
+			return fmt.Errorf("can't encode next proposal id: %v", err)
+		}
+
+		db.Set([]byte{mainPrefix, nextIDPrefix}, data)
+	}
+
+	return nil
+}
+
+// CheckParam returns an error if the parameter does not exist or the value is out of its bounds
+func (g *Governance) CheckParam(key string, value uint64) error {
+	p, ok := params[key]
+	if !ok {
+		return fmt.Errorf("unknown param %s", key)
+	}
+
+	if min := p.minValue(); value < min || value > p.max {
+		return fmt.Errorf("value of %s should be between %d and %d", key, min, p.max)
+	}
+
+	switch key {
+	case ParamValidatorMaxAbsentTimes:
+		if window := g.GetParam(ParamValidatorMaxAbsentWindow); value >= window {
+			return fmt.Errorf("value of %s should be less than %s %d", key, ParamValidatorMaxAbsentWindow, window)
+		}
+	case ParamValidatorMaxAbsentWindow:
+		if times := g.GetParam(ParamValidatorMaxAbsentTimes); value <= times {
+			return fmt.Errorf("value of %s should be greater than %s %d", key, ParamValidatorMaxAbsentTimes, times)
+		}
+	}
+
+	return nil
+}
+
+// GetParam returns the current value of the parameter, the default one if it has not been changed
+func (g *Governance) GetParam(key string) uint64 {
+	g.lock.RLock()
+	value, ok := g.params[key]
+	g.lock.RUnlock()
+	if ok {
+		return value
+	}
+
+	p, ok := params[key]
+	if !ok {
+		panic(fmt.Sprintf("unknown param %s", key))
+	}
+
+	value = p.defaultValue()
+	if immutableTree := g.immutableTree(); immutableTree != nil {
+		_, enc := immutableTree.Get(getParamPath(key))
+		if len(enc) != 0 {
+			if err := rlp.DecodeBytes(enc, &value); err != nil {
+				panic(fmt.Sprintf("failed to decode param %s: %s", key, err))
+			}
+
+			g.lock.Lock()
+			g.params[key] = value
+			g.lock.Unlock()
+		}
+	}
+
+	return value
+}
+
+// GetParams returns current values of all parameters
+func (g *Governance) GetParams() map[string]uint64 {
+	values := make(map[string]uint64, len(params))
+	for key := range params {
+		values[key] = g.GetParam(key)
+	}
+
+	return values
+}
+
+// SetParam changes the value of the parameter
+func (g *Governance) SetParam(key string, value uint64) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	g.params[key] = value
+	g.dirtyParams[key] = struct{}{}
+}
+
+// Enable makes the parameters changed by proposals effective, until the network upgrade the default values are used
+func (g *Governance) Enable() {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	g.enabled = true
+}
+
+func (g *Governance) isEnabled() bool {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+
+	return g.enabled
+}
+
+// value returns the effective value of the parameter
+func (g *Governance) value(key string) uint64 {
+	if !g.isEnabled() {
+		return params[key].defaultValue()
+	}
+
+	return g.GetParam(key)
+}
+
+func (g *Governance) MaxGas() uint64 {
+	return g.value(ParamMaxGas)
+}
+
+func (g *Governance) UnbondPeriod() uint64 {
+	return g.value(ParamUnbondPeriod)
+}
+
+func (g *Governance) ValidatorMaxAbsentTimes() uint64 {
+	return g.value(ParamValidatorMaxAbsentTimes)
+}
+
+func (g *Governance) ValidatorMaxAbsentWindow() uint64 {
+	return g.value(ParamValidatorMaxAbsentWindow)
+}
+
+func (g *Governance) MaxDelegatorsPerCandidate() uint64 {
+	return g.value(ParamMaxDelegatorsPerCandidate)
+}
+
+func (g *Governance) DAOCommission() uint64 {
+	return g.value(ParamDAOCommission)
+}
+
+func (g *Governance) VotingPeriod() uint64 {
+	return g.value(ParamVotingPeriod)
+}
+
+// MinDeposit returns the minimal deposit of a proposal in the base coin
+func (g *Governance) MinDeposit() *big.Int {
+	return big.NewInt(0).Mul(big.NewInt(0).SetUint64(g.value(ParamMinDeposit)), big.NewInt(1e18))
+}
+
+// GetProposal returns an active proposal by its ID
+func (g *Governance) GetProposal(id uint64) *Proposal {
+	proposal := g.get(id)
+	if proposal == nil || proposal.isDeleted() {
+		return nil
+	}
+
+	return proposal
+}
+
+// GetProposals returns active proposals ordered by ID
+func (g *Governance) GetProposals() []*Proposal {
+	var proposals []*Proposal
+	for _, id := range g.getActiveIDs() {
+		if proposal := g.GetProposal(id); proposal != nil {
+			proposals = append(proposals, proposal)
+		}
+	}
+
+	return proposals
+}
+
+// Create puts a new proposal to the store and returns its ID, the deposit should be subtracted from the proposer balance
+func (g *Governance) Create(proposer types.Address, key string, value uint64, deposit *big.Int, votingEnd uint64, targetHeight uint64) uint64 {
+	id := g.getNextID()
+	g.setNextID(id + 1)
+
+	g.SetProposal(id, proposer, key, value, deposit, votingEnd, targetHeight, nil, false)
+
+	return id
+}
+
+// SetProposal puts a proposal with given ID to the store
+func (g *Governance) SetProposal(id uint64, proposer types.Address, key string, value uint64, deposit *big.Int, votingEnd uint64, targetHeight uint64, votes []types.Pubkey, passed bool) {
+	proposal := &Proposal{
+		Proposer:     proposer,
+		Key:          key,
+		Value:        value,
+		Deposit:      big.NewInt(0).Set(deposit),
+		VotingEnd:    votingEnd,
+		TargetHeight: targetHeight,
+		Votes:        votes,
+		Passed:       passed,
+		id:           id,
+		markDirty:    g.markDirty,
+	}
+	g.setToMap(id, proposal)
+	proposal.markDirty(id)
+
+	g.bus.Checker().AddCoin(types.GetBaseCoinID(), deposit)
+}
+
+// SetNextID sets the ID of the next created proposal
+func (g *Governance) SetNextID(id uint64) {
+	g.setNextID(id)
+}
+
+// AddVote adds a vote of the validator for the proposal
+func (g *Governance) AddVote(id uint64, pubkey types.Pubkey) {
+	proposal := g.GetProposal(id)
+	if proposal == nil {
+		return
+	}
+
+	proposal.addVote(pubkey)
+}
+
+// Tally counts votes of proposals which voting ends at the height.
+// The proposal is passed if validators with more than 2/3 of the total power voted for it, the deposit is refunded to the proposer.
+// The deposit of a rejected proposal goes to the DAO treasury
+func (g *Governance) Tally(height uint64, totalPower *big.Int, powerOf func(pubkey types.Pubkey) *big.Int) {
+	for _, proposal := range g.GetProposals() {
+		if proposal.VotingEnd != height || proposal.IsPassed() {
+			continue
+		}
+
+		votedPower := big.NewInt(0)
+		for _, vote := range proposal.GetVotes() {
+			if power := powerOf(vote); power != nil {
+				votedPower.Add(votedPower, power)
+			}
+		}
+
+		passed := totalPower.Sign() == 1 &&
+			big.NewInt(0).Mul(votedPower, big.NewInt(3)).Cmp(big.NewInt(0).Mul(totalPower, big.NewInt(2))) == 1
+
+		var deposit *big.Int
+		if passed {
+			deposit = proposal.pass()
+		} else {
+			deposit = proposal.GetDeposit()
+			proposal.delete()
+		}
+
+		if deposit.Sign() == 1 {
+			g.bus.Checker().AddCoin(types.GetBaseCoinID(), big.NewInt(0).Neg(deposit))
+			if passed {
+				g.bus.Accounts().AddBalance(proposal.Proposer, types.GetBaseCoinID(), deposit)
+			} else {
+				g.bus.Treasury().AddIncome(types.GetBaseCoinID(), deposit)
+			}
+		}
+
+		g.bus.Events().AddEvent(&eventsdb.ParamProposalTalliedEvent{
+			ID:           proposal.id,
+			Key:          proposal.Key,
+			Value:        proposal.Value,
+			TargetHeight: proposal.TargetHeight,
+			VotedPower:   votedPower.String(),
+			TotalPower:   totalPower.String(),
+			Passed:       passed,
+		})
+	}
+}
+
+// Apply changes parameters of passed proposals which target height is reached
+func (g *Governance) Apply(height uint64) {
+	for _, proposal := range g.GetProposals() {
+		if !proposal.IsPassed() || proposal.TargetHeight > height {
+			continue
+		}
+
+		g.SetParam(proposal.Key, proposal.Value)
+		proposal.delete()
+
+		g.bus.Events().AddEvent(&eventsdb.ParamChangedEvent{
+			ID:    proposal.id,
+			Key:   proposal.Key,
+			Value: proposal.Value,
+		})
+	}
+}
+
+func (g *Governance) Export(state *types.AppState) {
+	g.loadParams()
+
+	g.lock.RLock()
+	keys := make([]string, 0, len(g.params))
+	for key := range g.params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		state.Params = append(state.Params, types.Param{
+			Key:   key,
+			Value: g.params[key],
+		})
+	}
+	g.lock.RUnlock()
+
+	for _, proposal := range g.GetProposals() {
+		state.ParamProposals = append(state.ParamProposals, types.ParamProposal{
+			ID:           proposal.id,
+			Proposer:     proposal.Proposer,
+			Key:          proposal.Key,
+			Value:        proposal.Value,
+			Deposit:      proposal.GetDeposit().String(),
+			VotingEnd:    proposal.VotingEnd,
+			TargetHeight: proposal.TargetHeight,
+			Votes:        proposal.GetVotes(),
+			Passed:       proposal.IsPassed(),
+		})
+	}
+
+	state.NextParamProposalID = g.getNextID()
+}
+
+// loadParams reads changed parameters from the tree
+func (g *Governance) loadParams() {
+	for key := range params {
+		g.GetParam(key)
+	}
+}
+
+// getActiveIDs returns IDs of stored and created proposals which are not deleted, ordered by ID
+func (g *Governance) getActiveIDs() []uint64 {
+	ids := map[uint64]struct{}{}
+
+	if immutableTree := g.immutableTree(); immutableTree != nil {
+		immutableTree.IterateRange([]byte{mainPrefix, proposalPrefix}, []byte{mainPrefix, proposalPrefix + 1}, true, func(key []byte, value []byte) bool {
+			ids[binary.BigEndian.Uint64(key[2:])] = struct{}{}
+			return false
+		})
+	}
+
+	g.lock.RLock()
+	for id := range g.list {
+		ids[id] = struct{}{}
+	}
+	g.lock.RUnlock()
+
+	sorted := make([]uint64, 0, len(ids))
+	for id := range ids {
+		if proposal := g.get(id); proposal == nil || proposal.isDeleted() {
+			continue
+		}
+		sorted = append(sorted, id)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+
+	return sorted
+}
+
+func (g *Governance) get(id uint64) *Proposal {
+	if proposal := g.getFromMap(id); proposal != nil {
+		return proposal
+	}
+
+	immutableTree := g.immutableTree()
+	if immutableTree == nil {
+		return nil
+	}
+
+	_, enc := immutableTree.Get(getProposalPath(id))
+	if len(enc) == 0 {
+		return nil
+	}
+
+	proposal := &Proposal{}
+	if err := rlp.DecodeBytes(enc, proposal); err != nil {
+		panic(fmt.Sprintf("failed to decode proposal %d: %s", id, err))
+	}
+
+	proposal.id = id
+	proposal.markDirty = g.markDirty
+
+	g.setToMap(id, proposal)
+
+	return proposal
+}
+
+func (g *Governance) getNextID() uint64 {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	if g.nextID != 0 {
+		return g.nextID
+	}
+
+	g.nextID = 1
+	if immutableTree := g.immutableTree(); immutableTree != nil {
+		_, enc := immutableTree.Get([]byte{mainPrefix, nextIDPrefix})
+		if len(enc) != 0 {
+			if err := rlp.DecodeBytes(enc, &g.nextID); err != nil {
+				panic(fmt.Sprintf("failed to decode next proposal id: %s", err))
+			}
+		}
+	}
+
+	return g.nextID
+}
+
+func (g *Governance) setNextID(id uint64) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	g.nextID = id
+	g.isDirtyNextID = true
+}
+
+func (g *Governance) markDirty(id uint64) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	g.dirty[id] = struct{}{}
+}
+
+func (g *Governance) getOrderedDirty() []uint64 {
+	g.lock.Lock()
+	keys := make([]uint64, 0, len(g.dirty))
+	for k := range g.dirty {
+		keys = append(keys, k)
+	}
+	g.lock.Unlock()
+
+	sort.SliceStable(keys, func(i, j int) bool {
+		return keys[i] < keys[j]
+	})
+
+	return keys
+}
+
+func (g *Governance) getFromMap(id uint64) *Proposal {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+
+	return g.list[id]
+}
+
+func (g *Governance) setToMap(id uint64, proposal *Proposal) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	g.list[id] = proposal
+}
+
+func getProposalPath(id uint64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, id)
+
+	return append([]byte{mainPrefix, proposalPrefix}, b...)
+}
+
+func getParamPath(key string) []byte {
+	return append([]byte{mainPrefix, paramPrefix}, []byte(key)...)
+}
@@ -0,0 +1,134 @@
+package governance
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/dao"
+	eventsdb "github.com/MinterTeam/minter-go-node/coreV2/events"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/accounts"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/bus"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/candidates"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/checker"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/coins"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/treasury"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/validators"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/tree"
+	db "github.com/tendermint/tm-db"
+)
+
+func TestGovernanceTallyAndApply(t *testing.T) {
+	t.Parallel()
+	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024, 0)
+	b := bus.NewBus()
+	b.SetChecker(checker.NewChecker(b))
+	b.SetEvents(&eventsdb.MockEvents{})
+	coins.NewCoins(b, mutableTree.GetLastImmutable())
+	acc := accounts.NewAccounts(b, mutableTree.GetLastImmutable())
+	tr := treasury.NewTreasury(b, mutableTree.GetLastImmutable())
+	g := NewGovernance(b, mutableTree.GetLastImmutable())
+
+	proposer := types.Address{1}
+	passedID := g.Create(proposer, ParamUnbondPeriod, 100, big.NewInt(1000), 10, 20)
+	rejectedID := g.Create(proposer, ParamDAOCommission, 20, big.NewInt(500), 10, 20)
+
+	voter, other := types.Pubkey{1}, types.Pubkey{2}
+	g.AddVote(passedID, voter)
+	g.AddVote(rejectedID, other)
+
+	_, _, err := mutableTree.Commit(acc, g)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	g = NewGovernance(b, mutableTree.GetLastImmutable())
+	if !g.GetProposal(passedID).HasVote(voter) {
+		t.Fatal("Vote is not stored")
+	}
+
+	powers := map[types.Pubkey]*big.Int{voter: big.NewInt(70), other: big.NewInt(30)}
+	g.Tally(10, big.NewInt(100), func(pubkey types.Pubkey) *big.Int { return powers[pubkey] })
+
+	if proposal := g.GetProposal(passedID); proposal == nil || !proposal.IsPassed() {
+		t.Fatal("Proposal is not passed")
+	}
+	if g.GetProposal(rejectedID) != nil {
+		t.Fatal("Rejected proposal is not deleted")
+	}
+	if balance := acc.GetBalance(proposer, types.GetBaseCoinID()); balance.Cmp(big.NewInt(1000)) != 0 {
+		t.Fatalf("Deposit is not refunded. Expected %d, got %s", 1000, balance)
+	}
+	if balance := tr.GetBalance(types.GetBaseCoinID()); balance.Cmp(big.NewInt(500)) != 0 {
+		t.Fatalf("Deposit is not sent to DAO treasury. Expected %d, got %s", 500, balance)
+	}
+
+	g.Apply(19)
+	if g.GetParam(ParamUnbondPeriod) != types.GetUnbondPeriod() {
+		t.Fatal("Param is changed before the target height")
+	}
+
+	g.Apply(20)
+	if g.GetParam(ParamUnbondPeriod) != 100 {
+		t.Fatalf("Param is not changed. Expected %d, got %d", 100, g.GetParam(ParamUnbondPeriod))
+	}
+	if g.UnbondPeriod() != types.GetUnbondPeriod() {
+		t.Fatal("Param is effective before the governance is enabled")
+	}
+
+	g.Enable()
+	if g.UnbondPeriod() != 100 {
+		t.Fatalf("Param is not effective. Expected %d, got %d", 100, g.UnbondPeriod())
+	}
+	if g.GetProposal(passedID) != nil {
+		t.Fatal("Applied proposal is not deleted")
+	}
+
+	_, _, err = mutableTree.Commit(acc, g)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	g = NewGovernance(b, mutableTree.GetLastImmutable())
+	g.Enable()
+	if g.UnbondPeriod() != 100 {
+		t.Fatalf("Param is not stored. Expected %d, got %d", 100, g.UnbondPeriod())
+	}
+	if g.DAOCommission() != uint64(dao.Commission) {
+		t.Fatalf("Param is not default. Expected %d, got %d", dao.Commission, g.DAOCommission())
+	}
+}
+
+func TestGovernanceCheckParam(t *testing.T) {
+	t.Parallel()
+	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024, 0)
+	b := bus.NewBus()
+	g := NewGovernance(b, mutableTree.GetLastImmutable())
+
+	if err := g.CheckParam("unknown", 1); err == nil {
+		t.Fatal("Unknown param is allowed")
+	}
+	if err := g.CheckParam(ParamUnbondPeriod, types.GetMovePeriod()-1); err == nil {
+		t.Fatal("Unbond period less than the move period is allowed")
+	}
+	if err := g.CheckParam(ParamUnbondPeriod, types.GetMovePeriod()); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.CheckParam(ParamMaxDelegatorsPerCandidate, candidates.MaxDelegatorsPerCandidate+1); err == nil {
+		t.Fatal("Max delegators over the stakes capacity of a candidate is allowed")
+	}
+	if err := g.CheckParam(ParamValidatorMaxAbsentWindow, validators.ValidatorMaxAbsentTimes); err == nil {
+		t.Fatal("Absent window not greater than max absent times is allowed")
+	}
+	if err := g.CheckParam(ParamValidatorMaxAbsentWindow, validators.ValidatorMaxAbsentTimes+1); err != nil {
+		t.Fatal(err)
+	}
+
+	g.SetParam(ParamValidatorMaxAbsentWindow, 16)
+	if err := g.CheckParam(ParamValidatorMaxAbsentTimes, 16); err == nil {
+		t.Fatal("Max absent times not less than the absent window is allowed")
+	}
+	if err := g.CheckParam(ParamValidatorMaxAbsentTimes, 15); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,108 @@
+package governance
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+)
+
+// Proposal is a change of the parameter Key to Value proposed by Proposer with a deposit in the base coin.
+// Validators vote for the proposal until VotingEnd, the passed proposal is applied at TargetHeight
+type Proposal struct {
+	Proposer     types.Address
+	Key          string
+	Value        uint64
+	Deposit      *big.Int
+	VotingEnd    uint64
+	TargetHeight uint64
+	Votes        []types.Pubkey
+	Passed       bool
+
+	id        uint64
+	deleted   bool
+	markDirty func(id uint64)
+	lock      sync.RWMutex
+}
+
+// ID returns the identifier of the proposal
+func (p *Proposal) ID() uint64 {
+	return p.id
+}
+
+// GetVotes returns public keys of validators voted for the proposal
+func (p *Proposal) GetVotes() []types.Pubkey {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	votes := make([]types.Pubkey, len(p.Votes))
+	copy(votes, p.Votes)
+
+	return votes
+}
+
+// GetDeposit returns the deposit held by the proposal
+func (p *Proposal) GetDeposit() *big.Int {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	return big.NewInt(0).Set(p.Deposit)
+}
+
+// IsPassed returns true if the proposal is passed and waits for the target height
+func (p *Proposal) IsPassed() bool {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	return p.Passed
+}
+
+// HasVote returns true if the validator has already voted for the proposal
+func (p *Proposal) HasVote(pubkey types.Pubkey) bool {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	for _, vote := range p.Votes {
+		if vote == pubkey {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p *Proposal) addVote(pubkey types.Pubkey) {
+	p.lock.Lock()
+	p.Votes = append(p.Votes, pubkey)
+	p.lock.Unlock()
+
+	p.markDirty(p.id)
+}
+
+// pass marks the proposal as passed and returns its deposit
+func (p *Proposal) pass() *big.Int {
+	p.lock.Lock()
+	deposit := p.Deposit
+	p.Deposit = big.NewInt(0)
+	p.Passed = true
+	p.lock.Unlock()
+
+	p.markDirty(p.id)
+
+	return deposit
+}
+
+func (p *Proposal) delete() {
+	p.lock.Lock()
+	p.deleted = true
+	p.lock.Unlock()
+
+	p.markDirty(p.id)
+}
+
+func (p *Proposal) isDeleted() bool {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	return p.deleted
+}
@@ -5,12 +5,11 @@ import (
 	"fmt"
 	"sort"
 	"sync"
-	"sync/atomic"
 
 	"github.com/MinterTeam/minter-go-node/coreV2/state/bus"
 	"github.com/MinterTeam/minter-go-node/coreV2/types"
 	"github.com/MinterTeam/minter-go-node/rlp"
-	"github.com/cosmos/iavl"
+	"github.com/MinterTeam/minter-go-node/tree"
 )
 
 const mainPrefix = byte('h')
@@ -18,6 +17,7 @@ const mainPrefix = byte('h')
 type RHalts interface {
 	Export(state *types.AppState)
 	GetHaltBlocks(height uint64) *Model
+	GetHeights() []uint64
 	IsHaltExists(height uint64, pubkey types.Pubkey) bool
 }
 
@@ -26,13 +26,13 @@ type HaltBlocks struct {
 	dirty map[uint64]struct{}
 
 	bus *bus.Bus
-	db  atomic.Value
+	db  tree.AtomicTree
 
 	lock sync.RWMutex
 }
 
-func NewHalts(stateBus *bus.Bus, db *iavl.ImmutableTree) *HaltBlocks {
-	immutableTree := atomic.Value{}
+func NewHalts(stateBus *bus.Bus, db tree.ImmutableTree) *HaltBlocks {
+	immutableTree := tree.AtomicTree{}
 	if db != nil {
 		immutableTree.Store(db)
 	}
@@ -48,19 +48,15 @@ func NewHalts(stateBus *bus.Bus, db *iavl.ImmutableTree) *HaltBlocks {
 	return halts
 }
 
-func (hb *HaltBlocks) immutableTree() *iavl.ImmutableTree {
-	db := hb.db.Load()
-	if db == nil {
-		return nil
-	}
-	return db.(*iavl.ImmutableTree)
+func (hb *HaltBlocks) immutableTree() tree.ImmutableTree {
+	return hb.db.Load()
 }
 
-func (hb *HaltBlocks) SetImmutableTree(immutableTree *iavl.ImmutableTree) {
+func (hb *HaltBlocks) SetImmutableTree(immutableTree tree.ImmutableTree) {
 	hb.db.Store(immutableTree)
 }
 
-func (hb *HaltBlocks) Commit(db *iavl.MutableTree, version int64) error {
+func (hb *HaltBlocks) Commit(db tree.MutableTree, version int64) error {
 	dirty := hb.getOrderedDirty()
 	for _, height := range dirty {
 		haltBlock := hb.getFromMap(height)
@@ -89,6 +85,26 @@ func (hb *HaltBlocks) GetHaltBlocks(height uint64) *Model {
 	return hb.get(height)
 }
 
+// GetHeights returns sorted heights which have votes
+func (hb *HaltBlocks) GetHeights() []uint64 {
+	var heights []uint64
+	hb.immutableTree().IterateRange([]byte{mainPrefix}, []byte{mainPrefix + 1}, true, func(key []byte, value []byte) bool {
+		if len(key) < 8 {
+			return false
+		}
+
+		heights = append(heights, binary.LittleEndian.Uint64(key[1:]))
+
+		return false
+	})
+
+	sort.Slice(heights, func(i, j int) bool {
+		return heights[i] < heights[j]
+	})
+
+	return heights
+}
+
 func (hb *HaltBlocks) GetOrNew(height uint64) *Model {
 	haltBlock := hb.get(height)
 	if haltBlock == nil {
@@ -76,3 +76,24 @@ func TestBusToAddHaltBlock(t *testing.T) {
 		t.Fatalf("Invalid public key %s. Expected %s", hbPubKey.String(), pubkey.String())
 	}
 }
+
+func TestHaltsGetHeights(t *testing.T) {
+	t.Parallel()
+	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024, 0)
+	b := bus.NewBus()
+	b.SetChecker(checker.NewChecker(b))
+	h := NewHalts(b, mutableTree.GetLastImmutable())
+
+	h.AddHaltBlock(256, types.Pubkey{0})
+	h.AddHaltBlock(10, types.Pubkey{1})
+
+	_, _, err := mutableTree.Commit(h)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	heights := h.GetHeights()
+	if len(heights) != 2 || heights[0] != 10 || heights[1] != 256 {
+		t.Fatalf("Heights are not correct: %v", heights)
+	}
+}
@@ -0,0 +1,335 @@
+package htlcs
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+
+	eventsdb "github.com/MinterTeam/minter-go-node/coreV2/events"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/bus"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/rlp"
+	"github.com/MinterTeam/minter-go-node/tree"
+)
+
+const mainPrefix = byte('l')
+
+const (
+	htlcPrefix   = byte('h')
+	nextIDPrefix = byte('n')
+)
+
+type RHTLCs interface {
+	Export(state *types.AppState)
+	GetHTLC(id uint64) *HTLC
+}
+
+// HTLCs is a store of hash time-locked contracts, coins of the contracts are held by the store
+type HTLCs struct {
+	list  map[uint64]*HTLC
+	dirty map[uint64]struct{}
+
+	nextID        uint64
+	isDirtyNextID bool
+
+	bus *bus.Bus
+	db  tree.AtomicTree
+
+	lock sync.RWMutex
+}
+
+func NewHTLCs(stateBus *bus.Bus, db tree.ImmutableTree) *HTLCs {
+	immutableTree := tree.AtomicTree{}
+	if db != nil {
+		immutableTree.Store(db)
+	}
+	return &HTLCs{
+		bus:   stateBus,
+		db:    immutableTree,
+		list:  map[uint64]*HTLC{},
+		dirty: map[uint64]struct{}{},
+	}
+}
+
+func (h *HTLCs) immutableTree() tree.ImmutableTree {
+	return h.db.Load()
+}
+
+func (h *HTLCs) SetImmutableTree(immutableTree tree.ImmutableTree) {
+	h.db.Store(immutableTree)
+}
+
+func (h *HTLCs) Commit(db tree.MutableTree, version int64) error {
+	for _, id := range h.getOrderedDirty() {
+		htlc := h.getFromMap(id)
+		path := getHTLCPath(id)
+
+		h.lock.Lock()
+		delete(h.dirty, id)
+		h.lock.Unlock()
+
+		htlc.lock.RLock()
+		if htlc.deleted {
+			h.lock.Lock()
+			delete(h.list, id)
+			h.lock.Unlock()
+
+			db.Remove(path)
+		} else {
+			data, err := rlp.EncodeToBytes(htlc)
+			if err != nil {
+				htlc.lock.RUnlock()
+				return fmt.Errorf("can't encode htlc %d: %v", id, err)
+			}
+
+			db.Set(path, data)
+		}
+		htlc.lock.RUnlock()
+	}
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if h.isDirtyNextID {
+		h.isDirtyNextID = false
+
+		data, err := rlp.EncodeToBytes(h.nextID)
+		if err != nil {
+			return fmt.Errorf("can't encode next htlc id: %v", err)
+		}
+
+		db.Set([]byte{mainPrefix, nextIDPrefix}, data)
+	}
+
+	return nil
+}
+
+// GetHTLC returns an active contract by its ID
+func (h *HTLCs) GetHTLC(id uint64) *HTLC {
+	htlc := h.get(id)
+	if htlc == nil || htlc.isDeleted() {
+		return nil
+	}
+
+	return htlc
+}
+
+// Create locks coins in a new contract and returns its ID, coins should be subtracted from the sender balance
+func (h *HTLCs) Create(sender, recipient types.Address, coin types.CoinID, amount *big.Int, hashlock types.Hash, timeout uint64) uint64 {
+	id := h.getNextID()
+	h.setNextID(id + 1)
+
+	h.SetHTLC(id, sender, recipient, coin, amount, hashlock, timeout)
+
+	return id
+}
+
+// SetHTLC puts a contract with given ID to the store
+func (h *HTLCs) SetHTLC(id uint64, sender, recipient types.Address, coin types.CoinID, amount *big.Int, hashlock types.Hash, timeout uint64) {
+	htlc := &HTLC{
+		Sender:    sender,
+		Recipient: recipient,
+		Coin:      coin,
+		Amount:    big.NewInt(0).Set(amount),
+		Hashlock:  hashlock,
+		Timeout:   timeout,
+		id:        id,
+		markDirty: h.markDirty,
+	}
+	h.setToMap(id, htlc)
+	htlc.markDirty(id)
+
+	h.bus.Checker().AddCoin(coin, amount)
+}
+
+// SetNextID sets the ID of the next created contract
+func (h *HTLCs) SetNextID(id uint64) {
+	h.setNextID(id)
+}
+
+// Claim pays coins of the contract to its recipient and publishes the preimage in the event
+func (h *HTLCs) Claim(id uint64, preimage []byte) {
+	htlc := h.GetHTLC(id)
+	if htlc == nil {
+		return
+	}
+
+	htlc.delete()
+	h.bus.Checker().AddCoin(htlc.Coin, big.NewInt(0).Neg(htlc.Amount))
+	h.bus.Accounts().AddBalance(htlc.Recipient, htlc.Coin, htlc.Amount)
+	h.bus.Events().AddEvent(&eventsdb.HTLCClaimedEvent{
+		ID:        id,
+		Hashlock:  hex.EncodeToString(htlc.Hashlock[:]),
+		Preimage:  hex.EncodeToString(preimage),
+		Sender:    htlc.Sender,
+		Recipient: htlc.Recipient,
+		Coin:      uint64(htlc.Coin),
+		Amount:    htlc.Amount.String(),
+	})
+}
+
+// Refund returns coins of the contract to its sender
+func (h *HTLCs) Refund(id uint64) {
+	htlc := h.GetHTLC(id)
+	if htlc == nil {
+		return
+	}
+
+	htlc.delete()
+	h.bus.Checker().AddCoin(htlc.Coin, big.NewInt(0).Neg(htlc.Amount))
+	h.bus.Accounts().AddBalance(htlc.Sender, htlc.Coin, htlc.Amount)
+	h.bus.Events().AddEvent(&eventsdb.HTLCRefundedEvent{
+		ID:       id,
+		Hashlock: hex.EncodeToString(htlc.Hashlock[:]),
+		Sender:   htlc.Sender,
+		Coin:     uint64(htlc.Coin),
+		Amount:   htlc.Amount.String(),
+	})
+}
+
+func (h *HTLCs) Export(state *types.AppState) {
+	ids := map[uint64]struct{}{}
+
+	if immutableTree := h.immutableTree(); immutableTree != nil {
+		immutableTree.IterateRange([]byte{mainPrefix, htlcPrefix}, []byte{mainPrefix, htlcPrefix + 1}, true, func(key []byte, value []byte) bool {
+			ids[binary.BigEndian.Uint64(key[2:])] = struct{}{}
+			return false
+		})
+	}
+
+	h.lock.RLock()
+	for id := range h.list {
+		ids[id] = struct{}{}
+	}
+	h.lock.RUnlock()
+
+	sorted := make([]uint64, 0, len(ids))
+	for id := range ids {
+		sorted = append(sorted, id)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+
+	for _, id := range sorted {
+		htlc := h.GetHTLC(id)
+		if htlc == nil {
+			continue
+		}
+
+		state.HTLCs = append(state.HTLCs, types.HTLC{
+			ID:        id,
+			Sender:    htlc.Sender,
+			Recipient: htlc.Recipient,
+			Coin:      uint64(htlc.Coin),
+			Amount:    htlc.Amount.String(),
+			Hashlock:  hex.EncodeToString(htlc.Hashlock[:]),
+			Timeout:   htlc.Timeout,
+		})
+	}
+
+	state.NextHTLCID = h.getNextID()
+}
+
+func (h *HTLCs) get(id uint64) *HTLC {
+	if htlc := h.getFromMap(id); htlc != nil {
+		return htlc
+	}
+
+	immutableTree := h.immutableTree()
+	if immutableTree == nil {
+		return nil
+	}
+
+	_, enc := immutableTree.Get(getHTLCPath(id))
+	if len(enc) == 0 {
+		return nil
+	}
+
+	htlc := &HTLC{}
+	if err := rlp.DecodeBytes(enc, htlc); err != nil {
+		panic(fmt.Sprintf("failed to decode htlc %d: %s", id, err))
+	}
+
+	htlc.id = id
+	htlc.markDirty = h.markDirty
+
+	h.setToMap(id, htlc)
+
+	return htlc
+}
+
+func (h *HTLCs) getNextID() uint64 {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if h.nextID != 0 {
+		return h.nextID
+	}
+
+	h.nextID = 1
+	if immutableTree := h.immutableTree(); immutableTree != nil {
+		_, enc := immutableTree.Get([]byte{mainPrefix, nextIDPrefix})
+		if len(enc) != 0 {
+			if err := rlp.DecodeBytes(enc, &h.nextID); err != nil {
+				panic(fmt.Sprintf("failed to decode next htlc id: %s", err))
+			}
+		}
+	}
+
+	return h.nextID
+}
+
+func (h *HTLCs) setNextID(id uint64) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.nextID = id
+	h.isDirtyNextID = true
+}
+
+func (h *HTLCs) markDirty(id uint64) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.dirty[id] = struct{}{}
+}
+
+func (h *HTLCs) getOrderedDirty() []uint64 {
+	h.lock.Lock()
+	keys := make([]uint64, 0, len(h.dirty))
+	for k := range h.dirty {
+		keys = append(keys, k)
+	}
+	h.lock.Unlock()
+
+	sort.SliceStable(keys, func(i, j int) bool {
+		return keys[i] < keys[j]
+	})
+
+	return keys
+}
+
+func (h *HTLCs) getFromMap(id uint64) *HTLC {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+
+	return h.list[id]
+}
+
+func (h *HTLCs) setToMap(id uint64, htlc *HTLC) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.list[id] = htlc
+}
+
+func getHTLCPath(id uint64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, id)
+
+	return append([]byte{mainPrefix, htlcPrefix}, b...)
+}
@@ -0,0 +1,115 @@
+package htlcs
+
+import (
+	"math/big"
+	"testing"
+
+	eventsdb "github.com/MinterTeam/minter-go-node/coreV2/events"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/accounts"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/bus"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/checker"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/coins"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/tree"
+	db "github.com/tendermint/tm-db"
+)
+
+func TestHTLCsToCommitAndLoad(t *testing.T) {
+	t.Parallel()
+	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024, 0)
+	b := bus.NewBus()
+	b.SetChecker(checker.NewChecker(b))
+	b.SetEvents(&eventsdb.MockEvents{})
+	coins.NewCoins(b, mutableTree.GetLastImmutable())
+	h := NewHTLCs(b, mutableTree.GetLastImmutable())
+
+	coin := types.GetBaseCoinID()
+	sender, recipient := types.Address{1}, types.Address{2}
+	hashlock := types.Hash{3}
+
+	first := h.Create(sender, recipient, coin, big.NewInt(100), hashlock, 10)
+	second := h.Create(sender, recipient, coin, big.NewInt(200), hashlock, 20)
+
+	_, _, err := mutableTree.Commit(h)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h = NewHTLCs(b, mutableTree.GetLastImmutable())
+	for _, item := range []struct {
+		id      uint64
+		amount  int64
+		timeout uint64
+	}{
+		{id: first, amount: 100, timeout: 10},
+		{id: second, amount: 200, timeout: 20},
+	} {
+		htlc := h.GetHTLC(item.id)
+		if htlc == nil {
+			t.Fatalf("HTLC %d not found", item.id)
+		}
+
+		if htlc.ID() != item.id || htlc.Sender != sender || htlc.Recipient != recipient || htlc.Coin != coin ||
+			htlc.Amount.Cmp(big.NewInt(item.amount)) != 0 || htlc.Hashlock != hashlock || htlc.Timeout != item.timeout {
+			t.Fatalf("HTLC %d is not correct: %+v", item.id, htlc)
+		}
+	}
+
+	if id := h.getNextID(); id != second+1 {
+		t.Fatalf("Next ID is not correct. Expected %d, got %d", second+1, id)
+	}
+
+	if h.GetHTLC(second+1) != nil {
+		t.Fatal("Not created HTLC is found")
+	}
+}
+
+func TestHTLCsToDeleteClaimedAndRefunded(t *testing.T) {
+	t.Parallel()
+	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024, 0)
+	b := bus.NewBus()
+	b.SetChecker(checker.NewChecker(b))
+	b.SetEvents(&eventsdb.MockEvents{})
+	coins.NewCoins(b, mutableTree.GetLastImmutable())
+	acc := accounts.NewAccounts(b, mutableTree.GetLastImmutable())
+	h := NewHTLCs(b, mutableTree.GetLastImmutable())
+
+	coin := types.GetBaseCoinID()
+	sender, recipient := types.Address{1}, types.Address{2}
+
+	claimed := h.Create(sender, recipient, coin, big.NewInt(100), types.Hash{3}, 10)
+	refunded := h.Create(sender, recipient, coin, big.NewInt(200), types.Hash{4}, 10)
+
+	_, _, err := mutableTree.Commit(acc, h)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h = NewHTLCs(b, mutableTree.GetLastImmutable())
+	h.Claim(claimed, []byte{1})
+	h.Refund(refunded)
+
+	_, _, err = mutableTree.Commit(acc, h)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h = NewHTLCs(b, mutableTree.GetLastImmutable())
+	if h.GetHTLC(claimed) != nil || h.GetHTLC(refunded) != nil {
+		t.Fatal("HTLC is not deleted")
+	}
+
+	if balance := acc.GetBalance(recipient, coin); balance.Cmp(big.NewInt(100)) != 0 {
+		t.Fatalf("Recipient balance is not correct. Expected %d, got %s", 100, balance)
+	}
+
+	if balance := acc.GetBalance(sender, coin); balance.Cmp(big.NewInt(200)) != 0 {
+		t.Fatalf("Sender balance is not correct. Expected %d, got %s", 200, balance)
+	}
+
+	appState := &types.AppState{}
+	h.Export(appState)
+	if len(appState.HTLCs) != 0 || appState.NextHTLCID != refunded+1 {
+		t.Fatalf("Exported state is not correct: %d HTLCs, next ID %d", len(appState.HTLCs), appState.NextHTLCID)
+	}
+}
@@ -0,0 +1,44 @@
+package htlcs
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+)
+
+// HTLC is a hash time-locked contract: Amount of coins is locked until it is claimed to Recipient
+// with a preimage of Hashlock before Timeout height or refunded to Sender after it
+type HTLC struct {
+	Sender    types.Address
+	Recipient types.Address
+	Coin      types.CoinID
+	Amount    *big.Int
+	Hashlock  types.Hash
+	Timeout   uint64
+
+	id        uint64
+	deleted   bool
+	markDirty func(id uint64)
+	lock      sync.RWMutex
+}
+
+// ID returns the identifier of the contract
+func (h *HTLC) ID() uint64 {
+	return h.id
+}
+
+func (h *HTLC) delete() {
+	h.lock.Lock()
+	h.deleted = true
+	h.lock.Unlock()
+
+	h.markDirty(h.id)
+}
+
+func (h *HTLC) isDeleted() bool {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+
+	return h.deleted
+}
@@ -0,0 +1,76 @@
+package multisigproposals
+
+import (
+	"sync"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+)
+
+// Proposal is a transaction proposed on behalf of a multisig address, which collects approvals of its members
+type Proposal struct {
+	Multisig     types.Address
+	Proposer     types.Address
+	GasCoin      types.CoinID
+	TxType       byte
+	TxData       []byte
+	ExpireHeight uint64
+	Approvals    []types.Address
+
+	id        uint64
+	deleted   bool
+	markDirty func(id uint64)
+	lock      sync.RWMutex
+}
+
+// ID returns the identifier of the proposal
+func (p *Proposal) ID() uint64 {
+	return p.id
+}
+
+// IsApprovedBy checks if the address has already approved the proposal
+func (p *Proposal) IsApprovedBy(address types.Address) bool {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	for _, approval := range p.Approvals {
+		if approval == address {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetApprovals returns addresses which approved the proposal
+func (p *Proposal) GetApprovals() []types.Address {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	approvals := make([]types.Address, len(p.Approvals))
+	copy(approvals, p.Approvals)
+
+	return approvals
+}
+
+func (p *Proposal) addApproval(address types.Address) {
+	p.lock.Lock()
+	p.Approvals = append(p.Approvals, address)
+	p.lock.Unlock()
+
+	p.markDirty(p.id)
+}
+
+func (p *Proposal) delete() {
+	p.lock.Lock()
+	p.deleted = true
+	p.lock.Unlock()
+
+	p.markDirty(p.id)
+}
+
+func (p *Proposal) isDeleted() bool {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	return p.deleted
+}
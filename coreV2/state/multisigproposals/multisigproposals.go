@@ -0,0 +1,409 @@
+package multisigproposals
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/state/bus"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/rlp"
+	"github.com/MinterTeam/minter-go-node/tree"
+)
+
+const mainPrefix = byte('m')
+
+const (
+	proposalPrefix   = byte('p')
+	expirationPrefix = byte('e')
+	nextIDPrefix     = byte('n')
+)
+
+type RMultisigProposals interface {
+	Export(state *types.AppState)
+	GetProposal(id uint64) *Proposal
+	GetExpiringProposalIDs(height uint64) []uint64
+}
+
+// MultisigProposals is a store of transactions proposed on behalf of multisig addresses.
+// Proposals are kept by their IDs and are indexed by their expiration heights.
+type MultisigProposals struct {
+	list  map[uint64]*Proposal
+	dirty map[uint64]struct{}
+
+	expirations      map[uint64][]uint64
+	dirtyExpirations map[uint64]struct{}
+
+	nextID        uint64
+	isDirtyNextID bool
+
+	bus *bus.Bus
+	db  tree.AtomicTree
+
+	lock sync.RWMutex
+}
+
+func NewMultisigProposals(stateBus *bus.Bus, db tree.ImmutableTree) *MultisigProposals {
+	immutableTree := tree.AtomicTree{}
+	if db != nil {
+		immutableTree.Store(db)
+	}
+	return &MultisigProposals{
+		bus:              stateBus,
+		db:               immutableTree,
+		list:             map[uint64]*Proposal{},
+		dirty:            map[uint64]struct{}{},
+		expirations:      map[uint64][]uint64{},
+		dirtyExpirations: map[uint64]struct{}{},
+	}
+}
+
+func (mp *MultisigProposals) immutableTree() tree.ImmutableTree {
+	return mp.db.Load()
+}
+
+func (mp *MultisigProposals) SetImmutableTree(immutableTree tree.ImmutableTree) {
+	mp.db.Store(immutableTree)
+}
+
+func (mp *MultisigProposals) Commit(db tree.MutableTree, version int64) error {
+	for _, id := range mp.getOrderedDirty() {
+		proposal := mp.getFromMap(id)
+		path := getProposalPath(id)
+
+		mp.lock.Lock()
+		delete(mp.dirty, id)
+		mp.lock.Unlock()
+
+		proposal.lock.RLock()
+		if proposal.deleted {
+			mp.lock.Lock()
+			delete(mp.list, id)
+			mp.lock.Unlock()
+
+			db.Remove(path)
+		} else {
+			data, err := rlp.EncodeToBytes(proposal)
+			if err != nil {
+				proposal.lock.RUnlock()
+				return fmt.Errorf("can't encode multisig proposal %d: %v", id, err)
+			}
+
+			db.Set(path, data)
+		}
+		proposal.lock.RUnlock()
+	}
+
+	mp.lock.Lock()
+	defer mp.lock.Unlock()
+
+	heights := make([]uint64, 0, len(mp.dirtyExpirations))
+	for height := range mp.dirtyExpirations {
+		heights = append(heights, height)
+	}
+	sort.Slice(heights, func(i, j int) bool {
+		return heights[i] < heights[j]
+	})
+
+	for _, height := range heights {
+		delete(mp.dirtyExpirations, height)
+
+		ids := mp.expirations[height]
+		path := getExpirationPath(height)
+		if len(ids) == 0 {
+			delete(mp.expirations, height)
+			db.Remove(path)
+			continue
+		}
+
+		data, err := rlp.EncodeToBytes(ids)
+		if err != nil {
+			return fmt.Errorf("can't encode multisig proposals expiring at %d: %v", height, err)
+		}
+
+		db.Set(path, data)
+	}
+
+	if mp.isDirtyNextID {
+		mp.isDirtyNextID = false
+
+		data, err := rlp.EncodeToBytes(mp.nextID)
+		if err != nil {
+			return fmt.Errorf("can't encode next multisig proposal id: %v", err)
+		}
+
+		db.Set([]byte{mainPrefix, nextIDPrefix}, data)
+	}
+
+	return nil
+}
+
+// GetProposal returns an active proposal by its ID
+func (mp *MultisigProposals) GetProposal(id uint64) *Proposal {
+	proposal := mp.get(id)
+	if proposal == nil || proposal.isDeleted() {
+		return nil
+	}
+
+	return proposal
+}
+
+// GetExpiringProposalIDs returns IDs of proposals which expire at given height
+func (mp *MultisigProposals) GetExpiringProposalIDs(height uint64) []uint64 {
+	mp.lock.Lock()
+	defer mp.lock.Unlock()
+
+	ids := mp.loadExpiration(height)
+	res := make([]uint64, len(ids))
+	copy(res, ids)
+
+	return res
+}
+
+// CreateProposal adds a new proposal approved by its proposer and returns its ID
+func (mp *MultisigProposals) CreateProposal(multisig, proposer types.Address, gasCoin types.CoinID, txType byte, txData []byte, expireHeight uint64) uint64 {
+	id := mp.getNextID()
+	mp.setNextID(id + 1)
+
+	mp.SetProposal(id, multisig, proposer, gasCoin, txType, txData, expireHeight, []types.Address{proposer})
+
+	return id
+}
+
+// SetProposal puts a proposal with given ID and approvals to the store
+func (mp *MultisigProposals) SetProposal(id uint64, multisig, proposer types.Address, gasCoin types.CoinID, txType byte, txData []byte, expireHeight uint64, approvals []types.Address) {
+	proposal := &Proposal{
+		Multisig:     multisig,
+		Proposer:     proposer,
+		GasCoin:      gasCoin,
+		TxType:       txType,
+		TxData:       txData,
+		ExpireHeight: expireHeight,
+		Approvals:    approvals,
+		id:           id,
+		markDirty:    mp.markDirty,
+	}
+	mp.setToMap(id, proposal)
+	proposal.markDirty(id)
+
+	mp.lock.Lock()
+	mp.expirations[expireHeight] = append(mp.loadExpiration(expireHeight), id)
+	mp.dirtyExpirations[expireHeight] = struct{}{}
+	mp.lock.Unlock()
+}
+
+// SetNextID sets the ID of the next created proposal
+func (mp *MultisigProposals) SetNextID(id uint64) {
+	mp.setNextID(id)
+}
+
+// Approve adds an approval of the address to the proposal
+func (mp *MultisigProposals) Approve(id uint64, address types.Address) {
+	proposal := mp.GetProposal(id)
+	if proposal == nil {
+		return
+	}
+
+	proposal.addApproval(address)
+}
+
+// Delete deletes the proposal, e.g. after its execution
+func (mp *MultisigProposals) Delete(id uint64) {
+	proposal := mp.GetProposal(id)
+	if proposal == nil {
+		return
+	}
+
+	proposal.delete()
+}
+
+// DeleteExpired deletes proposals which expire at given height
+func (mp *MultisigProposals) DeleteExpired(height uint64) {
+	for _, id := range mp.GetExpiringProposalIDs(height) {
+		mp.Delete(id)
+	}
+
+	mp.lock.Lock()
+	defer mp.lock.Unlock()
+
+	if len(mp.loadExpiration(height)) == 0 {
+		return
+	}
+
+	mp.expirations[height] = nil
+	mp.dirtyExpirations[height] = struct{}{}
+}
+
+func (mp *MultisigProposals) Export(state *types.AppState) {
+	ids := map[uint64]struct{}{}
+
+	if immutableTree := mp.immutableTree(); immutableTree != nil {
+		immutableTree.IterateRange([]byte{mainPrefix, proposalPrefix}, []byte{mainPrefix, proposalPrefix + 1}, true, func(key []byte, value []byte) bool {
+			ids[binary.BigEndian.Uint64(key[2:])] = struct{}{}
+			return false
+		})
+	}
+
+	mp.lock.RLock()
+	for id := range mp.list {
+		ids[id] = struct{}{}
+	}
+	mp.lock.RUnlock()
+
+	sorted := make([]uint64, 0, len(ids))
+	for id := range ids {
+		sorted = append(sorted, id)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+
+	for _, id := range sorted {
+		proposal := mp.GetProposal(id)
+		if proposal == nil {
+			continue
+		}
+
+		state.MultisigProposals = append(state.MultisigProposals, types.MultisigProposal{
+			ID:           id,
+			Multisig:     proposal.Multisig,
+			Proposer:     proposal.Proposer,
+			GasCoin:      uint64(proposal.GasCoin),
+			TxType:       uint64(proposal.TxType),
+			TxData:       fmt.Sprintf("%x", proposal.TxData),
+			ExpireHeight: proposal.ExpireHeight,
+			Approvals:    proposal.GetApprovals(),
+		})
+	}
+
+	state.NextMultisigProposalID = mp.getNextID()
+}
+
+func (mp *MultisigProposals) get(id uint64) *Proposal {
+	if proposal := mp.getFromMap(id); proposal != nil {
+		return proposal
+	}
+
+	immutableTree := mp.immutableTree()
+	if immutableTree == nil {
+		return nil
+	}
+
+	_, enc := immutableTree.Get(getProposalPath(id))
+	if len(enc) == 0 {
+		return nil
+	}
+
+	proposal := &Proposal{}
+	if err := rlp.DecodeBytes(enc, proposal); err != nil {
+		panic(fmt.Sprintf("failed to decode multisig proposal %d: %s", id, err))
+	}
+
+	proposal.id = id
+	proposal.markDirty = mp.markDirty
+
+	mp.setToMap(id, proposal)
+
+	return proposal
+}
+
+// loadExpiration returns IDs of proposals expiring at given height, should be called under the lock
+func (mp *MultisigProposals) loadExpiration(height uint64) []uint64 {
+	if ids, ok := mp.expirations[height]; ok {
+		return ids
+	}
+
+	var ids []uint64
+	if immutableTree := mp.immutableTree(); immutableTree != nil {
+		_, enc := immutableTree.Get(getExpirationPath(height))
+		if len(enc) != 0 {
+			if err := rlp.DecodeBytes(enc, &ids); err != nil {
+				panic(fmt.Sprintf("failed to decode multisig proposals expiring at %d: %s", height, err))
+			}
+		}
+	}
+
+	mp.expirations[height] = ids
+
+	return ids
+}
+
+func (mp *MultisigProposals) getNextID() uint64 {
+	mp.lock.Lock()
+	defer mp.lock.Unlock()
+
+	if mp.nextID != 0 {
+		return mp.nextID
+	}
+
+	mp.nextID = 1
+	if immutableTree := mp.immutableTree(); immutableTree != nil {
+		_, enc := immutableTree.Get([]byte{mainPrefix, nextIDPrefix})
+		if len(enc) != 0 {
+			if err := rlp.DecodeBytes(enc, &mp.nextID); err != nil {
+				panic(fmt.Sprintf("failed to decode next multisig proposal id: %s", err))
+			}
+		}
+	}
+
+	return mp.nextID
+}
+
+func (mp *MultisigProposals) setNextID(id uint64) {
+	mp.lock.Lock()
+	defer mp.lock.Unlock()
+
+	mp.nextID = id
+	mp.isDirtyNextID = true
+}
+
+func (mp *MultisigProposals) markDirty(id uint64) {
+	mp.lock.Lock()
+	defer mp.lock.Unlock()
+
+	mp.dirty[id] = struct{}{}
+}
+
+func (mp *MultisigProposals) getOrderedDirty() []uint64 {
+	mp.lock.Lock()
+	keys := make([]uint64, 0, len(mp.dirty))
+	for k := range mp.dirty {
+		keys = append(keys, k)
+	}
+	mp.lock.Unlock()
+
+	sort.SliceStable(keys, func(i, j int) bool {
+		return keys[i] < keys[j]
+	})
+
+	return keys
+}
+
+func (mp *MultisigProposals) getFromMap(id uint64) *Proposal {
+	mp.lock.RLock()
+	defer mp.lock.RUnlock()
+
+	return mp.list[id]
+}
+
+func (mp *MultisigProposals) setToMap(id uint64, proposal *Proposal) {
+	mp.lock.Lock()
+	defer mp.lock.Unlock()
+
+	mp.list[id] = proposal
+}
+
+func getProposalPath(id uint64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, id)
+
+	return append([]byte{mainPrefix, proposalPrefix}, b...)
+}
+
+func getExpirationPath(height uint64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, height)
+
+	return append([]byte{mainPrefix, expirationPrefix}, b...)
+}
@@ -0,0 +1,111 @@
+package multisigproposals
+
+import (
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/state/bus"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/checker"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/tree"
+	db "github.com/tendermint/tm-db"
+)
+
+func TestMultisigProposalsToCreateAndApprove(t *testing.T) {
+	t.Parallel()
+	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024, 0)
+	b := bus.NewBus()
+	b.SetChecker(checker.NewChecker(b))
+	mp := NewMultisigProposals(b, mutableTree.GetLastImmutable())
+
+	multisig, proposer, approver := types.Address{1}, types.Address{2}, types.Address{3}
+
+	id := mp.CreateProposal(multisig, proposer, types.GetBaseCoinID(), 1, []byte{1, 2, 3}, 100)
+	if id != 1 {
+		t.Fatalf("Proposal id is not correct. Expected %d, got %d", 1, id)
+	}
+
+	_, _, err := mutableTree.Commit(mp)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mp = NewMultisigProposals(b, mutableTree.GetLastImmutable())
+
+	if nextID := mp.CreateProposal(multisig, proposer, types.GetBaseCoinID(), 1, []byte{1}, 100); nextID != 2 {
+		t.Fatalf("Proposal id is not correct. Expected %d, got %d", 2, nextID)
+	}
+
+	mp.Approve(id, approver)
+
+	_, _, err = mutableTree.Commit(mp)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	proposal := NewMultisigProposals(b, mutableTree.GetLastImmutable()).GetProposal(id)
+	if proposal == nil {
+		t.Fatal("Proposal not found")
+	}
+
+	if !proposal.IsApprovedBy(proposer) || !proposal.IsApprovedBy(approver) {
+		t.Fatal("Proposal approvals are not correct")
+	}
+
+	if proposal.Multisig != multisig || proposal.ExpireHeight != 100 || len(proposal.TxData) != 3 {
+		t.Fatal("Proposal is not correct")
+	}
+
+	if ids := mp.GetExpiringProposalIDs(100); len(ids) != 2 {
+		t.Fatalf("Expiring proposals count is not correct. Expected %d, got %d", 2, len(ids))
+	}
+}
+
+func TestMultisigProposalsToDeleteExpired(t *testing.T) {
+	t.Parallel()
+	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024, 0)
+	b := bus.NewBus()
+	b.SetChecker(checker.NewChecker(b))
+	mp := NewMultisigProposals(b, mutableTree.GetLastImmutable())
+
+	multisig, proposer := types.Address{1}, types.Address{2}
+
+	expiredID := mp.CreateProposal(multisig, proposer, types.GetBaseCoinID(), 1, []byte{1}, 100)
+	activeID := mp.CreateProposal(multisig, proposer, types.GetBaseCoinID(), 1, []byte{1}, 200)
+
+	_, _, err := mutableTree.Commit(mp)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mp.DeleteExpired(100)
+
+	_, _, err = mutableTree.Commit(mp)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mp = NewMultisigProposals(b, mutableTree.GetLastImmutable())
+
+	if mp.GetProposal(expiredID) != nil {
+		t.Fatal("Expired proposal not deleted")
+	}
+
+	if mp.GetProposal(activeID) == nil {
+		t.Fatal("Active proposal deleted")
+	}
+
+	if ids := mp.GetExpiringProposalIDs(100); len(ids) != 0 {
+		t.Fatal("Expiration index not deleted")
+	}
+
+	appState := &types.AppState{}
+	mp.Export(appState)
+
+	if len(appState.MultisigProposals) != 1 || appState.MultisigProposals[0].ID != activeID {
+		t.Fatal("Exported proposals are not correct")
+	}
+
+	if appState.NextMultisigProposalID != activeID+1 {
+		t.Fatalf("Exported next proposal id is not correct. Expected %d, got %d", activeID+1, appState.NextMultisigProposalID)
+	}
+}
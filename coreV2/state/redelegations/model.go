@@ -0,0 +1,54 @@
+package redelegations
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+)
+
+type Item struct {
+	Address         types.Address
+	FromCandidateID uint32
+	ToCandidateID   uint32
+	Coin            types.CoinID
+	Value           *big.Int
+	DelegateHeight  uint64 // height at which funds are delegated to the destination candidate
+}
+
+type Model struct {
+	List []Item
+
+	height    uint64
+	deleted   bool
+	markDirty func(height uint64)
+	lock      sync.RWMutex
+}
+
+func (m *Model) delete() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.deleted = true
+	m.markDirty(m.height)
+}
+
+func (m *Model) addRedelegation(address types.Address, fromCandidateID, toCandidateID uint32, coin types.CoinID, value *big.Int, delegateHeight uint64) {
+	m.lock.Lock()
+	m.List = append(m.List, Item{
+		Address:         address,
+		FromCandidateID: fromCandidateID,
+		ToCandidateID:   toCandidateID,
+		Coin:            coin,
+		Value:           value,
+		DelegateHeight:  delegateHeight,
+	})
+	m.lock.Unlock()
+
+	m.markDirty(m.height)
+}
+
+// Height returns the completion height of redelegations
+func (m *Model) Height() uint64 {
+	return m.height
+}
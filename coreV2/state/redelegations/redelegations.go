@@ -0,0 +1,299 @@
+package redelegations
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/state/bus"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/rlp"
+	"github.com/MinterTeam/minter-go-node/tree"
+)
+
+const mainPrefix = byte('r')
+
+type RRedelegations interface {
+	Export(state *types.AppState, height uint64)
+	GetRedelegations(height uint64) *Model
+	GetActiveRedelegations(height uint64) []*Model
+	GetActiveValue(address types.Address, candidateID uint32, coin types.CoinID, height uint64) *big.Int
+}
+
+// Redelegations is a store of stake moves, which are still liable for misbehaviour of the source candidate.
+// Redelegations are kept by their completion height.
+type Redelegations struct {
+	list  map[uint64]*Model
+	dirty map[uint64]struct{}
+
+	bus *bus.Bus
+	db  tree.AtomicTree
+
+	lock sync.RWMutex
+}
+
+func NewRedelegations(stateBus *bus.Bus, db tree.ImmutableTree) *Redelegations {
+	immutableTree := tree.AtomicTree{}
+	if db != nil {
+		immutableTree.Store(db)
+	}
+	return &Redelegations{bus: stateBus, db: immutableTree, list: map[uint64]*Model{}, dirty: map[uint64]struct{}{}}
+}
+
+func (r *Redelegations) immutableTree() tree.ImmutableTree {
+	return r.db.Load()
+}
+
+func (r *Redelegations) SetImmutableTree(immutableTree tree.ImmutableTree) {
+	r.db.Store(immutableTree)
+}
+
+func (r *Redelegations) Commit(db tree.MutableTree, version int64) error {
+	dirty := r.getOrderedDirty()
+	for _, height := range dirty {
+		model := r.getFromMap(height)
+		path := getPath(height)
+
+		r.lock.Lock()
+		delete(r.dirty, height)
+		r.lock.Unlock()
+
+		model.lock.RLock()
+		if model.deleted {
+			r.lock.Lock()
+			delete(r.list, height)
+			r.lock.Unlock()
+
+			db.Remove(path)
+		} else {
+			data, err := rlp.EncodeToBytes(model)
+			if err != nil {
+				return fmt.Errorf("can't encode object at %d: %v", height, err)
+			}
+
+			db.Set(path, data)
+		}
+		model.lock.RUnlock()
+	}
+
+	return nil
+}
+
+// GetRedelegations returns redelegations which complete at given height
+func (r *Redelegations) GetRedelegations(height uint64) *Model {
+	return r.get(height)
+}
+
+// GetActiveRedelegations returns redelegations which complete after given height
+func (r *Redelegations) GetActiveRedelegations(height uint64) []*Model {
+	heights := map[uint64]struct{}{}
+
+	if immutableTree := r.immutableTree(); immutableTree != nil {
+		immutableTree.IterateRange(getPath(height+1), []byte{mainPrefix + 1}, true, func(key []byte, value []byte) bool {
+			heights[binary.BigEndian.Uint64(key[1:])] = struct{}{}
+			return false
+		})
+	}
+
+	r.lock.RLock()
+	for h := range r.list {
+		if h > height {
+			heights[h] = struct{}{}
+		}
+	}
+	r.lock.RUnlock()
+
+	sorted := make([]uint64, 0, len(heights))
+	for h := range heights {
+		sorted = append(sorted, h)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+
+	var res []*Model
+	for _, h := range sorted {
+		model := r.get(h)
+		if model == nil {
+			continue
+		}
+
+		model.lock.RLock()
+		deleted := model.deleted
+		model.lock.RUnlock()
+		if deleted {
+			continue
+		}
+
+		res = append(res, model)
+	}
+
+	return res
+}
+
+// GetActiveValue returns the amount of coin which is moved to a candidate by an address, is already delegated to it
+// and is still in an active redelegation. Funds which are still frozen are not in the stake of the candidate and are not counted
+func (r *Redelegations) GetActiveValue(address types.Address, candidateID uint32, coin types.CoinID, height uint64) *big.Int {
+	value := big.NewInt(0)
+	for _, model := range r.GetActiveRedelegations(height) {
+		model.lock.RLock()
+		for _, item := range model.List {
+			if item.Address == address && item.ToCandidateID == candidateID && item.Coin == coin && item.DelegateHeight <= height {
+				value.Add(value, item.Value)
+			}
+		}
+		model.lock.RUnlock()
+	}
+
+	return value
+}
+
+// AddRedelegation adds a record about a stake move which completes at given height
+func (r *Redelegations) AddRedelegation(height uint64, address types.Address, fromCandidateID, toCandidateID uint32, coin types.CoinID, value *big.Int, delegateHeight uint64) {
+	r.getOrNew(height).addRedelegation(address, fromCandidateID, toCandidateID, coin, big.NewInt(0).Set(value), delegateHeight)
+}
+
+// PunishRedelegationsWithID slashes funds which were moved from the punished candidate and have already been delegated to the destination candidate.
+// Funds which are still frozen are slashed with frozen funds. The part which is missing in the stake at the destination candidate
+// is slashed from funds unbonded or moved from it and frozen up to toHeight
+func (r *Redelegations) PunishRedelegationsWithID(height uint64, toHeight uint64, candidateID uint32, pubKey types.Pubkey) {
+	for _, model := range r.GetActiveRedelegations(height) {
+		model.lock.Lock()
+		punished := false
+		for i, item := range model.List {
+			if item.FromCandidateID != candidateID || item.DelegateHeight > height {
+				continue
+			}
+
+			newValue := big.NewInt(0).Set(item.Value)
+			newValue.Mul(newValue, big.NewInt(95))
+			newValue.Div(newValue, big.NewInt(100))
+
+			slashed := big.NewInt(0).Sub(item.Value, newValue)
+			slashedStake := r.bus.Candidates().SlashStake(item.Address, item.ToCandidateID, item.Coin, slashed, pubKey)
+			if rest := big.NewInt(0).Sub(slashed, slashedStake); rest.Sign() == 1 {
+				r.bus.FrozenFunds().SlashFrozenFund(height, toHeight, item.Address, item.ToCandidateID, item.Coin, rest, pubKey)
+			}
+
+			model.List[i].Value = newValue
+			punished = true
+		}
+		model.lock.Unlock()
+
+		if punished {
+			model.markDirty(model.height)
+		}
+	}
+}
+
+// Delete deletes redelegations which complete at given height
+func (r *Redelegations) Delete(height uint64) {
+	model := r.get(height)
+	if model == nil {
+		return
+	}
+
+	model.delete()
+}
+
+func (r *Redelegations) Export(state *types.AppState, height uint64) {
+	for _, model := range r.GetActiveRedelegations(height) {
+		for _, item := range model.List {
+			state.Redelegations = append(state.Redelegations, types.Redelegation{
+				Height:          model.height,
+				Address:         item.Address,
+				FromCandidateID: uint64(item.FromCandidateID),
+				ToCandidateID:   uint64(item.ToCandidateID),
+				Coin:            uint64(item.Coin),
+				Value:           item.Value.String(),
+				DelegateHeight:  item.DelegateHeight,
+			})
+		}
+	}
+}
+
+func (r *Redelegations) getOrNew(height uint64) *Model {
+	model := r.get(height)
+	if model == nil {
+		model = &Model{
+			height:    height,
+			markDirty: r.markDirty,
+		}
+		r.setToMap(height, model)
+	}
+
+	return model
+}
+
+func (r *Redelegations) get(height uint64) *Model {
+	if model := r.getFromMap(height); model != nil {
+		return model
+	}
+
+	immutableTree := r.immutableTree()
+	if immutableTree == nil {
+		return nil
+	}
+
+	_, enc := immutableTree.Get(getPath(height))
+	if len(enc) == 0 {
+		return nil
+	}
+
+	model := &Model{}
+	if err := rlp.DecodeBytes(enc, model); err != nil {
+		panic(fmt.Sprintf("failed to decode redelegations at height %d: %s", height, err))
+	}
+
+	model.height = height
+	model.markDirty = r.markDirty
+
+	r.setToMap(height, model)
+
+	return model
+}
+
+func (r *Redelegations) markDirty(height uint64) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.dirty[height] = struct{}{}
+}
+
+func (r *Redelegations) getOrderedDirty() []uint64 {
+	r.lock.Lock()
+	keys := make([]uint64, 0, len(r.dirty))
+	for k := range r.dirty {
+		keys = append(keys, k)
+	}
+	r.lock.Unlock()
+
+	sort.SliceStable(keys, func(i, j int) bool {
+		return keys[i] < keys[j]
+	})
+
+	return keys
+}
+
+func (r *Redelegations) getFromMap(height uint64) *Model {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	return r.list[height]
+}
+
+func (r *Redelegations) setToMap(height uint64, model *Model) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.list[height] = model
+}
+
+func getPath(height uint64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, height)
+
+	return append([]byte{mainPrefix}, b...)
+}
@@ -0,0 +1,87 @@
+package redelegations
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/state/bus"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/checker"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/tree"
+	db "github.com/tendermint/tm-db"
+)
+
+func TestRedelegationsToAddAndDelete(t *testing.T) {
+	t.Parallel()
+	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024, 0)
+	b := bus.NewBus()
+	b.SetChecker(checker.NewChecker(b))
+	r := NewRedelegations(b, mutableTree.GetLastImmutable())
+
+	address, coin, height := types.Address{1}, types.GetBaseCoinID(), uint64(100)
+
+	r.AddRedelegation(height, address, 1, 2, coin, big.NewInt(100), 10)
+	r.AddRedelegation(height, address, 1, 3, coin, big.NewInt(50), 10)
+
+	_, _, err := mutableTree.Commit(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if value := r.GetActiveValue(address, 2, coin, 9); value.Sign() != 0 {
+		t.Fatalf("Frozen value is counted as active. Expected %d, got %s", 0, value)
+	}
+
+	if value := r.GetActiveValue(address, 2, coin, height-1); value.Cmp(big.NewInt(100)) != 0 {
+		t.Fatalf("Active value is not correct. Expected %d, got %s", 100, value)
+	}
+
+	if value := r.GetActiveValue(address, 2, coin, height); value.Sign() != 0 {
+		t.Fatalf("Active value is not correct. Expected %d, got %s", 0, value)
+	}
+
+	r.Delete(height)
+
+	_, _, err = mutableTree.Commit(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if r.GetRedelegations(height) != nil {
+		t.Fatal("Redelegations not deleted")
+	}
+
+	if len(r.GetActiveRedelegations(0)) != 0 {
+		t.Fatal("Active redelegations not deleted")
+	}
+}
+
+func TestRedelegationsToExport(t *testing.T) {
+	t.Parallel()
+	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024, 0)
+	b := bus.NewBus()
+	b.SetChecker(checker.NewChecker(b))
+	r := NewRedelegations(b, mutableTree.GetLastImmutable())
+
+	address, coin := types.Address{1}, types.GetBaseCoinID()
+
+	r.AddRedelegation(50, address, 1, 2, coin, big.NewInt(100), 10)
+	r.AddRedelegation(150, address, 2, 3, coin, big.NewInt(50), 110)
+
+	_, _, err := mutableTree.Commit(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	state := new(types.AppState)
+	r.Export(state, 100)
+
+	if len(state.Redelegations) != 1 {
+		t.Fatalf("Wrong count of redelegations. Expected %d, got %d", 1, len(state.Redelegations))
+	}
+
+	item := state.Redelegations[0]
+	if item.Height != 150 || item.FromCandidateID != 2 || item.ToCandidateID != 3 || item.Value != "50" || item.DelegateHeight != 110 {
+		t.Fatalf("Wrong exported redelegation: %+v", item)
+	}
+}
@@ -0,0 +1,85 @@
+package standingorders
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+)
+
+// Order is a recurring payment of the owner, which is executed every Interval blocks until Remaining payments are done
+type Order struct {
+	Owner      types.Address
+	Recipient  types.Address
+	Coin       types.CoinID
+	Amount     *big.Int
+	Interval   uint64
+	NextHeight uint64
+	Remaining  uint64
+	Failures   uint64
+
+	id        uint64
+	deleted   bool
+	markDirty func(id uint64)
+	lock      sync.RWMutex
+}
+
+// ID returns the identifier of the order
+func (o *Order) ID() uint64 {
+	return o.id
+}
+
+// GetNextHeight returns the height of the next payment
+func (o *Order) GetNextHeight() uint64 {
+	o.lock.RLock()
+	defer o.lock.RUnlock()
+
+	return o.NextHeight
+}
+
+// GetRemaining returns the number of payments left
+func (o *Order) GetRemaining() uint64 {
+	o.lock.RLock()
+	defer o.lock.RUnlock()
+
+	return o.Remaining
+}
+
+// GetFailures returns the number of payments skipped due to insufficient balance of the owner
+func (o *Order) GetFailures() uint64 {
+	o.lock.RLock()
+	defer o.lock.RUnlock()
+
+	return o.Failures
+}
+
+// pay moves the order to the next payment and returns true if there are remaining payments
+func (o *Order) pay(failed bool) bool {
+	o.lock.Lock()
+	if failed {
+		o.Failures++
+	}
+	o.Remaining--
+	o.NextHeight += o.Interval
+	hasNext := o.Remaining > 0
+	o.lock.Unlock()
+
+	o.markDirty(o.id)
+
+	return hasNext
+}
+
+func (o *Order) delete() {
+	o.lock.Lock()
+	o.deleted = true
+	o.lock.Unlock()
+
+	o.markDirty(o.id)
+}
+
+func (o *Order) isDeleted() bool {
+	o.lock.RLock()
+	defer o.lock.RUnlock()
+
+	return o.deleted
+}
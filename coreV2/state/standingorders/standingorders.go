@@ -0,0 +1,460 @@
+package standingorders
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+
+	eventsdb "github.com/MinterTeam/minter-go-node/coreV2/events"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/bus"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/rlp"
+	"github.com/MinterTeam/minter-go-node/tree"
+)
+
+const mainPrefix = byte('o')
+
+const (
+	orderPrefix    = byte('o')
+	schedulePrefix = byte('s')
+	nextIDPrefix   = byte('n')
+)
+
+type RStandingOrders interface {
+	Export(state *types.AppState)
+	GetOrder(id uint64) *Order
+	GetScheduledOrderIDs(height uint64) []uint64
+}
+
+// StandingOrders is a store of recurring payments.
+// Orders are kept by their IDs and are indexed by the heights of their next payments.
+type StandingOrders struct {
+	list  map[uint64]*Order
+	dirty map[uint64]struct{}
+
+	schedule      map[uint64][]uint64
+	dirtySchedule map[uint64]struct{}
+
+	nextID        uint64
+	isDirtyNextID bool
+
+	bus *bus.Bus
+	db  tree.AtomicTree
+
+	lock sync.RWMutex
+}
+
+func NewStandingOrders(stateBus *bus.Bus, db tree.ImmutableTree) *StandingOrders {
+	immutableTree := tree.AtomicTree{}
+	if db != nil {
+		immutableTree.Store(db)
+	}
+	return &StandingOrders{
+		bus:           stateBus,
+		db:            immutableTree,
+		list:          map[uint64]*Order{},
+		dirty:         map[uint64]struct{}{},
+		schedule:      map[uint64][]uint64{},
+		dirtySchedule: map[uint64]struct{}{},
+	}
+}
+
+func (so *StandingOrders) immutableTree() tree.ImmutableTree {
+	return so.db.Load()
+}
+
+func (so *StandingOrders) SetImmutableTree(immutableTree tree.ImmutableTree) {
+	so.db.Store(immutableTree)
+}
+
+func (so *StandingOrders) Commit(db tree.MutableTree, version int64) error {
+	for _, id := range so.getOrderedDirty() {
+		order := so.getFromMap(id)
+		path := getOrderPath(id)
+
+		so.lock.Lock()
+		delete(so.dirty, id)
+		so.lock.Unlock()
+
+		order.lock.RLock()
+		if order.deleted {
+			so.lock.Lock()
+			delete(so.list, id)
+			so.lock.Unlock()
+
+			db.Remove(path)
+		} else {
+			data, err := rlp.EncodeToBytes(order)
+			if err != nil {
+				order.lock.RUnlock()
+				return fmt.Errorf("can't encode standing order %d: %v", id, err)
+			}
+
+			db.Set(path, data)
+		}
+		order.lock.RUnlock()
+	}
+
+	so.lock.Lock()
+	defer so.lock.Unlock()
+
+	heights := make([]uint64, 0, len(so.dirtySchedule))
+	for height := range so.dirtySchedule {
+		heights = append(heights, height)
+	}
+	sort.Slice(heights, func(i, j int) bool {
+		return heights[i] < heights[j]
+	})
+
+	for _, height := range heights {
+		delete(so.dirtySchedule, height)
+
+		ids := so.schedule[height]
+		path := getSchedulePath(height)
+		if len(ids) == 0 {
+			delete(so.schedule, height)
+			db.Remove(path)
+			continue
+		}
+
+		data, err := rlp.EncodeToBytes(ids)
+		if err != nil {
+			return fmt.Errorf("can't encode standing orders scheduled at %d: %v", height, err)
+		}
+
+		db.Set(path, data)
+	}
+
+	if so.isDirtyNextID {
+		so.isDirtyNextID = false
+
+		data, err := rlp.EncodeToBytes(so.nextID)
+		if err != nil {
+			return fmt.Errorf("can't encode next standing order id: %v", err)
+		}
+
+		db.Set([]byte{mainPrefix, nextIDPrefix}, data)
+	}
+
+	return nil
+}
+
+// GetOrder returns an active order by its ID
+func (so *StandingOrders) GetOrder(id uint64) *Order {
+	order := so.get(id)
+	if order == nil || order.isDeleted() {
+		return nil
+	}
+
+	return order
+}
+
+// GetScheduledOrderIDs returns IDs of orders which are due at given height, cancelled orders may be included
+func (so *StandingOrders) GetScheduledOrderIDs(height uint64) []uint64 {
+	so.lock.Lock()
+	defer so.lock.Unlock()
+
+	ids := so.loadSchedule(height)
+	res := make([]uint64, len(ids))
+	copy(res, ids)
+
+	return res
+}
+
+// CreateOrder adds a new order and returns its ID
+func (so *StandingOrders) CreateOrder(owner, recipient types.Address, coin types.CoinID, amount *big.Int, interval, nextHeight, count uint64) uint64 {
+	id := so.getNextID()
+	so.setNextID(id + 1)
+
+	so.SetOrder(id, owner, recipient, coin, amount, interval, nextHeight, count, 0)
+
+	return id
+}
+
+// SetOrder puts an order with given ID to the store
+func (so *StandingOrders) SetOrder(id uint64, owner, recipient types.Address, coin types.CoinID, amount *big.Int, interval, nextHeight, remaining, failures uint64) {
+	order := &Order{
+		Owner:      owner,
+		Recipient:  recipient,
+		Coin:       coin,
+		Amount:     big.NewInt(0).Set(amount),
+		Interval:   interval,
+		NextHeight: nextHeight,
+		Remaining:  remaining,
+		Failures:   failures,
+		id:         id,
+		markDirty:  so.markDirty,
+	}
+	so.setToMap(id, order)
+	order.markDirty(id)
+
+	so.addToSchedule(nextHeight, id)
+}
+
+// SetNextID sets the ID of the next created order
+func (so *StandingOrders) SetNextID(id uint64) {
+	so.setNextID(id)
+}
+
+// Cancel deletes the order, its scheduled payment is skipped
+func (so *StandingOrders) Cancel(id uint64) {
+	order := so.GetOrder(id)
+	if order == nil {
+		return
+	}
+
+	order.delete()
+}
+
+// Execute makes payments of orders which are due at given height.
+// At most limit orders are processed, the rest are moved to the next height.
+// A payment is skipped and counted as failed if the owner has not enough spendable balance or the payment is not allowed by the policy of the owner,
+// the transfer fee of the coin is withheld from the amount received by the recipient.
+func (so *StandingOrders) Execute(height uint64, limit int) {
+	ids := so.GetScheduledOrderIDs(height)
+	if len(ids) == 0 {
+		return
+	}
+
+	so.lock.Lock()
+	so.schedule[height] = nil
+	so.dirtySchedule[height] = struct{}{}
+	so.lock.Unlock()
+
+	// orders over the limit are postponed to the next block, their schedule is kept from the original next height
+	if len(ids) > limit {
+		for _, id := range ids[limit:] {
+			if so.GetOrder(id) == nil {
+				continue
+			}
+
+			so.addToSchedule(height+1, id)
+		}
+		ids = ids[:limit]
+	}
+
+	for _, id := range ids {
+		order := so.GetOrder(id)
+		if order == nil {
+			continue
+		}
+
+		failed := so.bus.Accounts().GetSpendableBalance(order.Owner, order.Coin).Cmp(order.Amount) < 0 ||
+			so.bus.Coins().IsCoinPaused(order.Coin) ||
+			so.bus.Coins().IsCoinFrozen(order.Coin, order.Owner) ||
+			so.bus.Coins().IsCoinFrozen(order.Coin, order.Recipient) ||
+			!so.bus.Accounts().IsAllowedByPolicy(order.Owner, order.Recipient, order.Coin, order.Amount)
+		if !failed {
+			so.bus.Accounts().SubBalance(order.Owner, order.Coin, order.Amount)
+			so.bus.Accounts().AddPolicySpent(order.Owner, order.Coin, order.Amount)
+			transferFee := so.bus.Coins().PayTransferFee(order.Coin, order.Owner, order.Amount)
+			so.bus.Accounts().AddBalance(order.Recipient, order.Coin, big.NewInt(0).Sub(order.Amount, transferFee))
+			event := &eventsdb.StandingOrderPaymentEvent{
+				ID:        id,
+				Owner:     order.Owner,
+				Recipient: order.Recipient,
+				Coin:      uint64(order.Coin),
+				Amount:    order.Amount.String(),
+			}
+			if transferFee.Sign() != 0 {
+				event.TransferFee = transferFee.String()
+			}
+			so.bus.Events().AddEvent(event)
+		}
+
+		if order.pay(failed) {
+			// the order which is late by a whole interval is paid in the next block to catch up the schedule
+			nextHeight := order.GetNextHeight()
+			if nextHeight <= height {
+				nextHeight = height + 1
+			}
+			so.addToSchedule(nextHeight, id)
+		} else {
+			order.delete()
+		}
+	}
+}
+
+func (so *StandingOrders) Export(state *types.AppState) {
+	ids := map[uint64]struct{}{}
+
+	if immutableTree := so.immutableTree(); immutableTree != nil {
+		immutableTree.IterateRange([]byte{mainPrefix, orderPrefix}, []byte{mainPrefix, orderPrefix + 1}, true, func(key []byte, value []byte) bool {
+			ids[binary.BigEndian.Uint64(key[2:])] = struct{}{}
+			return false
+		})
+	}
+
+	so.lock.RLock()
+	for id := range so.list {
+		ids[id] = struct{}{}
+	}
+	so.lock.RUnlock()
+
+	sorted := make([]uint64, 0, len(ids))
+	for id := range ids {
+		sorted = append(sorted, id)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+
+	for _, id := range sorted {
+		order := so.GetOrder(id)
+		if order == nil {
+			continue
+		}
+
+		state.StandingOrders = append(state.StandingOrders, types.StandingOrder{
+			ID:         id,
+			Owner:      order.Owner,
+			Recipient:  order.Recipient,
+			Coin:       uint64(order.Coin),
+			Amount:     order.Amount.String(),
+			Interval:   order.Interval,
+			NextHeight: order.GetNextHeight(),
+			Remaining:  order.GetRemaining(),
+			Failures:   order.GetFailures(),
+		})
+	}
+
+	state.NextStandingOrderID = so.getNextID()
+}
+
+func (so *StandingOrders) get(id uint64) *Order {
+	if order := so.getFromMap(id); order != nil {
+		return order
+	}
+
+	immutableTree := so.immutableTree()
+	if immutableTree == nil {
+		return nil
+	}
+
+	_, enc := immutableTree.Get(getOrderPath(id))
+	if len(enc) == 0 {
+		return nil
+	}
+
+	order := &Order{}
+	if err := rlp.DecodeBytes(enc, order); err != nil {
+		panic(fmt.Sprintf("failed to decode standing order %d: %s", id, err))
+	}
+
+	order.id = id
+	order.markDirty = so.markDirty
+
+	so.setToMap(id, order)
+
+	return order
+}
+
+func (so *StandingOrders) addToSchedule(height uint64, id uint64) {
+	so.lock.Lock()
+	defer so.lock.Unlock()
+
+	so.schedule[height] = append(so.loadSchedule(height), id)
+	so.dirtySchedule[height] = struct{}{}
+}
+
+// loadSchedule returns IDs of orders scheduled at given height, should be called under the lock
+func (so *StandingOrders) loadSchedule(height uint64) []uint64 {
+	if ids, ok := so.schedule[height]; ok {
+		return ids
+	}
+
+	var ids []uint64
+	if immutableTree := so.immutableTree(); immutableTree != nil {
+		_, enc := immutableTree.Get(getSchedulePath(height))
+		if len(enc) != 0 {
+			if err := rlp.DecodeBytes(enc, &ids); err != nil {
+				panic(fmt.Sprintf("failed to decode standing orders scheduled at %d: %s", height, err))
+			}
+		}
+	}
+
+	so.schedule[height] = ids
+
+	return ids
+}
+
+func (so *StandingOrders) getNextID() uint64 {
+	so.lock.Lock()
+	defer so.lock.Unlock()
+
+	if so.nextID != 0 {
+		return so.nextID
+	}
+
+	so.nextID = 1
+	if immutableTree := so.immutableTree(); immutableTree != nil {
+		_, enc := immutableTree.Get([]byte{mainPrefix, nextIDPrefix})
+		if len(enc) != 0 {
+			if err := rlp.DecodeBytes(enc, &so.nextID); err != nil {
+				panic(fmt.Sprintf("failed to decode next standing order id: %s", err))
+			}
+		}
+	}
+
+	return so.nextID
+}
+
+func (so *StandingOrders) setNextID(id uint64) {
+	so.lock.Lock()
+	defer so.lock.Unlock()
+
+	so.nextID = id
+	so.isDirtyNextID = true
+}
+
+func (so *StandingOrders) markDirty(id uint64) {
+	so.lock.Lock()
+	defer so.lock.Unlock()
+
+	so.dirty[id] = struct{}{}
+}
+
+func (so *StandingOrders) getOrderedDirty() []uint64 {
+	so.lock.Lock()
+	keys := make([]uint64, 0, len(so.dirty))
+	for k := range so.dirty {
+		keys = append(keys, k)
+	}
+	so.lock.Unlock()
+
+	sort.SliceStable(keys, func(i, j int) bool {
+		return keys[i] < keys[j]
+	})
+
+	return keys
+}
+
+func (so *StandingOrders) getFromMap(id uint64) *Order {
+	so.lock.RLock()
+	defer so.lock.RUnlock()
+
+	return so.list[id]
+}
+
+func (so *StandingOrders) setToMap(id uint64, order *Order) {
+	so.lock.Lock()
+	defer so.lock.Unlock()
+
+	so.list[id] = order
+}
+
+func getOrderPath(id uint64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, id)
+
+	return append([]byte{mainPrefix, orderPrefix}, b...)
+}
+
+func getSchedulePath(height uint64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, height)
+
+	return append([]byte{mainPrefix, schedulePrefix}, b...)
+}
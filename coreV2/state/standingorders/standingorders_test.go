@@ -0,0 +1,130 @@
+package standingorders
+
+import (
+	"math/big"
+	"testing"
+
+	eventsdb "github.com/MinterTeam/minter-go-node/coreV2/events"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/accounts"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/bus"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/checker"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/coins"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/tree"
+	db "github.com/tendermint/tm-db"
+)
+
+func TestStandingOrdersToExecute(t *testing.T) {
+	t.Parallel()
+	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024, 0)
+	b := bus.NewBus()
+	b.SetChecker(checker.NewChecker(b))
+	events := &eventsdb.MockEvents{}
+	b.SetEvents(events)
+	coins.NewCoins(b, mutableTree.GetLastImmutable())
+	acc := accounts.NewAccounts(b, mutableTree.GetLastImmutable())
+	so := NewStandingOrders(b, mutableTree.GetLastImmutable())
+
+	coin := types.GetBaseCoinID()
+	owner, recipient := types.Address{1}, types.Address{2}
+	acc.AddBalance(owner, coin, big.NewInt(250))
+
+	id := so.CreateOrder(owner, recipient, coin, big.NewInt(100), 10, 10, 3)
+
+	_, _, err := mutableTree.Commit(acc, so)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	so = NewStandingOrders(b, mutableTree.GetLastImmutable())
+	for _, height := range []uint64{10, 20, 30} {
+		if ids := so.GetScheduledOrderIDs(height); len(ids) != 1 || ids[0] != id {
+			t.Fatalf("Scheduled orders at %d are not correct: %v", height, ids)
+		}
+		so.Execute(height, 10)
+	}
+
+	if balance := acc.GetBalance(recipient, coin); balance.Cmp(big.NewInt(200)) != 0 {
+		t.Fatalf("Recipient balance is not correct. Expected %d, got %s", 200, balance)
+	}
+
+	if balance := acc.GetBalance(owner, coin); balance.Cmp(big.NewInt(50)) != 0 {
+		t.Fatalf("Owner balance is not correct. Expected %d, got %s", 50, balance)
+	}
+
+	if count := len(events.LoadEvents(0)); count != 2 {
+		t.Fatalf("Events count is not correct. Expected %d, got %d", 2, count)
+	}
+
+	if so.GetOrder(id) != nil {
+		t.Fatal("Order is not deleted after the last payment")
+	}
+
+	_, _, err = mutableTree.Commit(acc, so)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if NewStandingOrders(b, mutableTree.GetLastImmutable()).GetOrder(id) != nil {
+		t.Fatal("Order is not deleted after the last payment")
+	}
+}
+
+func TestStandingOrdersToPostponeOverLimit(t *testing.T) {
+	t.Parallel()
+	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024, 0)
+	b := bus.NewBus()
+	b.SetChecker(checker.NewChecker(b))
+	b.SetEvents(&eventsdb.MockEvents{})
+	coins.NewCoins(b, mutableTree.GetLastImmutable())
+	acc := accounts.NewAccounts(b, mutableTree.GetLastImmutable())
+	so := NewStandingOrders(b, mutableTree.GetLastImmutable())
+
+	coin := types.GetBaseCoinID()
+	owner := types.Address{1}
+	acc.AddBalance(owner, coin, big.NewInt(1000))
+
+	first := so.CreateOrder(owner, types.Address{2}, coin, big.NewInt(1), 5, 5, 2)
+	second := so.CreateOrder(owner, types.Address{3}, coin, big.NewInt(1), 5, 5, 2)
+	cancelled := so.CreateOrder(owner, types.Address{4}, coin, big.NewInt(1), 5, 5, 2)
+	so.Cancel(cancelled)
+
+	so.Execute(5, 1)
+
+	if order := so.GetOrder(first); order.GetNextHeight() != 10 || order.GetRemaining() != 1 {
+		t.Fatalf("First order is not paid")
+	}
+
+	if order := so.GetOrder(second); order.GetNextHeight() != 5 || order.GetRemaining() != 2 {
+		t.Fatalf("Second order is not postponed")
+	}
+
+	if ids := so.GetScheduledOrderIDs(6); len(ids) != 1 || ids[0] != second {
+		t.Fatalf("Second order is not scheduled to the next block: %v", ids)
+	}
+
+	so.Execute(6, 1)
+
+	if order := so.GetOrder(second); order.GetNextHeight() != 10 || order.GetRemaining() != 1 {
+		t.Fatalf("Second order is not paid by the original schedule, next height %d", order.GetNextHeight())
+	}
+
+	if ids := so.GetScheduledOrderIDs(10); len(ids) != 2 {
+		t.Fatalf("Orders are not scheduled by the original interval: %v", ids)
+	}
+
+	if balance := acc.GetBalance(types.Address{4}, coin); balance.Sign() != 0 {
+		t.Fatalf("Cancelled order is paid")
+	}
+
+	_, _, err := mutableTree.Commit(acc, so)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	appState := new(types.AppState)
+	NewStandingOrders(b, mutableTree.GetLastImmutable()).Export(appState)
+	if len(appState.StandingOrders) != 2 || appState.NextStandingOrderID != 4 {
+		t.Fatalf("Exported standing orders are not correct: %+v", appState.StandingOrders)
+	}
+}
@@ -15,16 +15,23 @@ import (
 	"github.com/MinterTeam/minter-go-node/coreV2/state/checks"
 	"github.com/MinterTeam/minter-go-node/coreV2/state/coins"
 	"github.com/MinterTeam/minter-go-node/coreV2/state/commission"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/delegatorvotes"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/distributions"
 	"github.com/MinterTeam/minter-go-node/coreV2/state/frozenfunds"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/governance"
 	"github.com/MinterTeam/minter-go-node/coreV2/state/halts"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/htlcs"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/multisigproposals"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/redelegations"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/standingorders"
 	"github.com/MinterTeam/minter-go-node/coreV2/state/swap"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/treasury"
 	"github.com/MinterTeam/minter-go-node/coreV2/state/update"
 	"github.com/MinterTeam/minter-go-node/coreV2/state/validators"
 	"github.com/MinterTeam/minter-go-node/coreV2/state/waitlist"
 	"github.com/MinterTeam/minter-go-node/coreV2/types"
 	"github.com/MinterTeam/minter-go-node/helpers"
 	"github.com/MinterTeam/minter-go-node/tree"
-	"github.com/cosmos/iavl"
 	db "github.com/tendermint/tm-db"
 )
 
@@ -49,6 +56,14 @@ func (cs *CheckState) Export() types.AppState {
 	cs.Candidates().Export(appState)
 	cs.WaitList().Export(appState)
 	cs.FrozenFunds().Export(appState, uint64(cs.state.height))
+	cs.Redelegations().Export(appState, uint64(cs.state.height))
+	cs.MultisigProposals().Export(appState)
+	cs.StandingOrders().Export(appState)
+	cs.HTLCs().Export(appState)
+	cs.Distributions().Export(appState)
+	cs.Governance().Export(appState)
+	cs.Treasury().Export(appState)
+	cs.DelegatorVotes().Export(appState)
 	cs.Accounts().Export(appState)
 	cs.Coins().Export(appState)
 	cs.Checks().Export(appState)
@@ -75,6 +90,30 @@ func (cs *CheckState) Candidates() candidates.RCandidates {
 func (cs *CheckState) FrozenFunds() frozenfunds.RFrozenFunds {
 	return cs.state.FrozenFunds
 }
+func (cs *CheckState) Redelegations() redelegations.RRedelegations {
+	return cs.state.Redelegations
+}
+func (cs *CheckState) MultisigProposals() multisigproposals.RMultisigProposals {
+	return cs.state.MultisigProposals
+}
+func (cs *CheckState) StandingOrders() standingorders.RStandingOrders {
+	return cs.state.StandingOrders
+}
+func (cs *CheckState) HTLCs() htlcs.RHTLCs {
+	return cs.state.HTLCs
+}
+func (cs *CheckState) Distributions() distributions.RDistributions {
+	return cs.state.Distributions
+}
+func (cs *CheckState) Governance() governance.RGovernance {
+	return cs.state.Governance
+}
+func (cs *CheckState) Treasury() treasury.RTreasury {
+	return cs.state.Treasury
+}
+func (cs *CheckState) DelegatorVotes() delegatorvotes.RDelegatorVotes {
+	return cs.state.DelegatorVotes
+}
 func (cs *CheckState) InitialHeight() int64 {
 	return cs.state.InitialVersion
 }
@@ -106,24 +145,34 @@ func (cs *CheckState) Commission() commission.RCommission {
 }
 
 type State struct {
-	App         *app.App
-	Validators  *validators.Validators
-	Candidates  *candidates.Candidates
-	FrozenFunds *frozenfunds.FrozenFunds
-	Halts       *halts.HaltBlocks
-	Accounts    *accounts.Accounts
-	Coins       *coins.Coins
-	Checks      *checks.Checks
-	Checker     *checker.Checker
-	Waitlist    *waitlist.WaitList
-	Swap        *swap.Swap
-	SwapV2      *swap.SwapV2
-	Commission  *commission.Commission
-	Updates     *update.Update
+	App           *app.App
+	Validators    *validators.Validators
+	Candidates    *candidates.Candidates
+	FrozenFunds   *frozenfunds.FrozenFunds
+	Halts         *halts.HaltBlocks
+	Redelegations *redelegations.Redelegations
+	Accounts      *accounts.Accounts
+	Coins         *coins.Coins
+	Checks        *checks.Checks
+	Checker       *checker.Checker
+	Waitlist      *waitlist.WaitList
+	Swap          *swap.Swap
+	SwapV2        *swap.SwapV2
+	Commission    *commission.Commission
+	Updates       *update.Update
+
+	MultisigProposals *multisigproposals.MultisigProposals
+	StandingOrders    *standingorders.StandingOrders
+	HTLCs             *htlcs.HTLCs
+	Distributions     *distributions.Distributions
+	Governance        *governance.Governance
+	Treasury          *treasury.Treasury
+	DelegatorVotes    *delegatorvotes.DelegatorVotes
 
 	db     db.DB
 	events eventsdb.IEventsDB
 	tree   tree.MTree
+	cache  *tree.Cache
 
 	keepLastStates int64
 	bus            *bus.Bus
@@ -206,8 +255,8 @@ func (s *State) Lock() {
 }
 
 func (s *State) GetSwap() interface {
-	Commit(db *iavl.MutableTree, version int64) error
-	SetImmutableTree(immutableTree *iavl.ImmutableTree)
+	Commit(db tree.MutableTree, version int64) error
+	SetImmutableTree(immutableTree tree.ImmutableTree)
 } {
 	if s.SwapV2 != nil {
 		return s.SwapV2
@@ -255,7 +304,35 @@ func (s *State) Check() error {
 func (s *State) Commit() ([]byte, error) {
 	s.Checker.Reset()
 
-	hash, version, err := s.tree.Commit(
+	if err := s.commitCache(); err != nil {
+		return nil, err
+	}
+
+	hash, version, err := s.tree.Commit(s.cache)
+	if err != nil {
+		return hash, err
+	}
+
+	s.height = version
+
+	versionToDelete := version - s.keepLastStates - 1
+	if versionToDelete < s.InitialVersion {
+		return hash, nil
+	}
+
+	if err := s.tree.DeleteVersion(versionToDelete); err != nil {
+		log.Printf("DeleteVersion %d error: %s\n", versionToDelete, err)
+	}
+
+	return hash, nil
+}
+
+// commitCache writes changes of the modules to the cache, the modules read them from it until they are committed to the tree
+func (s *State) commitCache() error {
+	version := s.tree.Version()
+	for _, module := range []interface {
+		Commit(db tree.MutableTree, version int64) error
+	}{
 		s.Accounts,
 		s.App,
 		s.Coins,
@@ -268,23 +345,54 @@ func (s *State) Commit() ([]byte, error) {
 		s.GetSwap(),
 		s.Commission,
 		s.Updates,
-	)
-	if err != nil {
-		return hash, err
+		s.Redelegations,
+		s.MultisigProposals,
+		s.StandingOrders,
+		s.HTLCs,
+		s.Distributions,
+		s.Governance,
+		s.Treasury,
+		s.DelegatorVotes,
+	} {
+		if err := module.Commit(s.cache, version); err != nil {
+			return err
+		}
 	}
 
-	s.height = version
+	return nil
+}
 
-	versionToDelete := version - s.keepLastStates - 1
-	if versionToDelete < s.InitialVersion {
-		return hash, nil
+// Overlay returns a state reading the cache of the state, changes made in the overlay are kept in memory and never committed.
+// Changes of the current block are written to the cache before, it costs as much as the changes since the previous write
+func (s *State) Overlay() *State {
+	if err := s.commitCache(); err != nil {
+		log.Panicf("Write state at height %d to cache failed: %s", s.height, err)
 	}
 
-	if err := s.tree.DeleteVersion(versionToDelete); err != nil {
-		log.Printf("DeleteVersion %d error: %s\n", versionToDelete, err)
+	return s.overlay()
+}
+
+// Overlay returns a state reading the cache of the deliver state, changes made in the overlay are kept in memory and never committed.
+// The check state does not write changes of the deliver state to the cache, between blocks the overlay sees the committed state like the check state does
+func (cs *CheckState) Overlay() *State {
+	return cs.state.overlay()
+}
+
+func (s *State) overlay() *State {
+	newStateForTreeFunc := newStateForTree
+	if s.SwapV2 != nil {
+		newStateForTreeFunc = newStateForTreeV2
 	}
 
-	return hash, nil
+	overlay, err := newStateForTreeFunc(s.cache, eventsdb.NewEventsStore(db.NewMemDB()), s.db, 0)
+	if err != nil {
+		log.Panicf("Overlay state at height %d failed: %s", s.height, err)
+	}
+
+	overlay.Candidates.LoadCandidatesDeliver()
+	overlay.Validators.LoadValidators()
+
+	return overlay
 }
 
 func (s *State) Import(state types.AppState, version string) error {
@@ -311,11 +419,40 @@ func (s *State) Import(state types.AppState, version string) error {
 		//if a.LockStakeUntilBlock > 0 {
 		s.Accounts.SetLockStakeUntilBlock(a.Address, a.LockStakeUntilBlock)
 		//}
+		if a.AuthorizedKey != nil {
+			s.Accounts.SetAuthorizedKey(a.Address, *a.AuthorizedKey)
+		}
+		if a.Guardian != nil {
+			s.Accounts.SetGuardian(a.Address, *a.Guardian)
+		}
+		if a.Policy != nil {
+			policy := accounts.PolicyState{
+				Policy:      importPolicy(a.Policy),
+				PeriodStart: a.Policy.PeriodStart,
+			}
+			if a.Policy.Pending != nil {
+				policy.Pending = importPolicy(a.Policy.Pending)
+				policy.PendingHeight = a.Policy.PendingHeight
+			}
+			for _, spent := range a.Policy.Spent {
+				policy.Spent = append(policy.Spent, accounts.PolicyLimit{Coin: types.CoinID(spent.Coin), Value: helpers.StringToBigInt(spent.Value)})
+			}
+			s.Accounts.SetPolicyState(a.Address, policy)
+		}
 		for _, b := range a.Balance {
 			balance := helpers.StringToBigInt(b.Value)
 			coinID := types.CoinID(b.Coin)
 			s.Accounts.SetBalance(a.Address, coinID, balance)
 		}
+		for _, v := range a.Vestings {
+			s.Accounts.AddVesting(a.Address, accounts.Vesting{
+				Coin:        types.CoinID(v.Coin),
+				Value:       helpers.StringToBigInt(v.Value),
+				StartHeight: v.StartHeight,
+				CliffHeight: v.CliffHeight,
+				EndHeight:   v.EndHeight,
+			})
+		}
 	}
 
 	for _, c := range state.Coins {
@@ -328,6 +465,23 @@ func (s *State) Import(state types.AppState, version string) error {
 			reserve := helpers.StringToBigInt(c.Reserve)
 			s.Coins.ImportCoin(coinID, c.Symbol, c.Name, volume, uint32(c.Crr), reserve, maxSupply, c.OwnerAddress, c.Version)
 		}
+		if c.Metadata != nil {
+			s.Coins.SetMetadata(coinID, coins.Metadata{
+				Description: c.Metadata.Description,
+				URI:         c.Metadata.URI,
+				IconHash:    c.Metadata.IconHash,
+				Decimals:    uint32(c.Metadata.Decimals),
+			})
+		}
+		if c.Freeze != nil {
+			s.Coins.ImportFreeze(coinID, c.Freeze.Paused, c.Freeze.FrozenAddresses)
+		}
+		if c.TransferFee != nil {
+			s.Coins.SetTransferFee(coinID, coins.TransferFee{
+				Bps:  uint32(c.TransferFee.Bps),
+				Burn: c.TransferFee.Burn,
+			})
+		}
 	}
 
 	var vals []*validators.Validator
@@ -356,6 +510,16 @@ func (s *State) Import(state types.AppState, version string) error {
 
 		s.Candidates.SetTotalStake(c.PubKey, helpers.StringToBigInt(c.TotalBipStake))
 		s.Candidates.SetStakes(c.PubKey, c.Stakes, c.Updates)
+		if c.DelegationSettings != nil {
+			s.Candidates.SetDelegationSettings(c.PubKey, &candidates.DelegationSettings{
+				MaxStake:      helpers.StringToBigInt(c.DelegationSettings.MaxStake),
+				MinDelegation: helpers.StringToBigInt(c.DelegationSettings.MinDelegation),
+				AllowList:     c.DelegationSettings.AllowList,
+			})
+		}
+		if c.PendingCommission != nil {
+			s.Candidates.SetPendingCommission(c.PubKey, uint32(c.PendingCommission.Commission), c.PendingCommission.Height)
+		}
 	}
 
 	if len(state.DeletedCandidates) > 0 {
@@ -377,12 +541,87 @@ func (s *State) Import(state types.AppState, version string) error {
 		s.Checks.UseCheckHash(hash)
 	}
 
+	for _, redeemed := range state.RedeemedChecks {
+		bytes, _ := hex.DecodeString(redeemed.Hash)
+		var hash types.Hash
+		copy(hash[:], bytes)
+		s.Checks.SetRedeemedValue(hash, helpers.StringToBigInt(redeemed.Value))
+	}
+
 	for _, ff := range state.FrozenFunds {
 		coinID := types.CoinID(ff.Coin)
 		value := helpers.StringToBigInt(ff.Value)
 		s.FrozenFunds.AddFund(ff.Height, ff.Address, ff.CandidateKey, uint32(ff.CandidateID), coinID, value, uint32(ff.MoveToCandidateID))
 	}
 
+	for _, r := range state.Redelegations {
+		s.Redelegations.AddRedelegation(r.Height, r.Address, uint32(r.FromCandidateID), uint32(r.ToCandidateID), types.CoinID(r.Coin), helpers.StringToBigInt(r.Value), r.DelegateHeight)
+	}
+
+	for _, p := range state.MultisigProposals {
+		txData, err := hex.DecodeString(p.TxData)
+		if err != nil {
+			return err
+		}
+		s.MultisigProposals.SetProposal(p.ID, p.Multisig, p.Proposer, types.CoinID(p.GasCoin), byte(p.TxType), txData, p.ExpireHeight, p.Approvals)
+	}
+	if state.NextMultisigProposalID != 0 {
+		s.MultisigProposals.SetNextID(state.NextMultisigProposalID)
+	}
+
+	for _, o := range state.StandingOrders {
+		s.StandingOrders.SetOrder(o.ID, o.Owner, o.Recipient, types.CoinID(o.Coin), helpers.StringToBigInt(o.Amount), o.Interval, o.NextHeight, o.Remaining, o.Failures)
+	}
+	if state.NextStandingOrderID != 0 {
+		s.StandingOrders.SetNextID(state.NextStandingOrderID)
+	}
+
+	for _, h := range state.HTLCs {
+		hashlock, err := hex.DecodeString(h.Hashlock)
+		if err != nil {
+			return err
+		}
+		s.HTLCs.SetHTLC(h.ID, h.Sender, h.Recipient, types.CoinID(h.Coin), helpers.StringToBigInt(h.Amount), types.BytesToHash(hashlock), h.Timeout)
+	}
+	if state.NextHTLCID != 0 {
+		s.HTLCs.SetNextID(state.NextHTLCID)
+	}
+
+	for _, d := range state.Distributions {
+		shares := make([]distributions.Share, 0, len(d.Shares))
+		for _, share := range d.Shares {
+			shares = append(shares, distributions.Share{Address: share.Address, Balance: helpers.StringToBigInt(share.Balance)})
+		}
+		s.Distributions.SetDistribution(d.ID, d.Owner, types.CoinID(d.Coin), helpers.StringToBigInt(d.Amount), types.CoinID(d.HolderCoin), helpers.StringToBigInt(d.Paid), shares)
+	}
+	if state.NextDistributionID != 0 {
+		s.Distributions.SetNextID(state.NextDistributionID)
+	}
+
+	for _, p := range state.Params {
+		s.Governance.SetParam(p.Key, p.Value)
+	}
+	for _, p := range state.ParamProposals {
+		s.Governance.SetProposal(p.ID, p.Proposer, p.Key, p.Value, helpers.StringToBigInt(p.Deposit), p.VotingEnd, p.TargetHeight, p.Votes, p.Passed)
+	}
+	if state.NextParamProposalID != 0 {
+		s.Governance.SetNextID(state.NextParamProposalID)
+	}
+
+	for _, balance := range state.Treasury {
+		s.Treasury.SetBalance(types.CoinID(balance.Coin), helpers.StringToBigInt(balance.Value))
+	}
+	for _, p := range state.TreasuryProposals {
+		s.Treasury.SetProposal(p.ID, p.Proposer, p.Recipient, types.CoinID(p.Coin), helpers.StringToBigInt(p.Amount), p.Description, p.VotingEnd, p.Votes)
+	}
+	if state.NextTreasuryProposalID != 0 {
+		s.Treasury.SetNextID(state.NextTreasuryProposalID)
+	}
+
+	for _, v := range state.DelegatorVotes {
+		s.DelegatorVotes.AddVote(byte(v.Kind), v.Height, v.Address, uint32(v.Option), v.Abstain)
+	}
+
 	s.Swapper().Import(&state)
 
 	c := state.Commission
@@ -525,6 +764,20 @@ func (s *State) Import(state types.AppState, version string) error {
 	return nil
 }
 
+func importPolicy(policy *types.Policy) accounts.Policy {
+	imported := accounts.Policy{
+		Recipients: policy.Recipients,
+	}
+	for _, limit := range policy.Limits {
+		imported.Limits = append(imported.Limits, accounts.PolicyLimit{Coin: types.CoinID(limit.Coin), Value: helpers.StringToBigInt(limit.Value)})
+	}
+	for _, txType := range policy.ForbiddenTypes {
+		imported.ForbiddenTypes = append(imported.ForbiddenTypes, byte(txType))
+	}
+
+	return imported
+}
+
 func (s *State) Export() types.AppState {
 	state, err := NewCheckStateAtHeightV3(uint64(s.tree.Version()), s.db)
 	if err != nil {
@@ -544,7 +797,7 @@ func (s *State) ReloadFromDiskAndExport() types.AppState {
 	return state.Export()
 }
 
-func newCheckStateForTree(immutableTree *iavl.ImmutableTree, events eventsdb.IEventsDB, db db.DB, keepLastStates int64) (*CheckState, error) {
+func newCheckStateForTree(immutableTree tree.ImmutableTree, events eventsdb.IEventsDB, db db.DB, keepLastStates int64) (*CheckState, error) {
 	stateForTree, err := newStateForTree(immutableTree, events, db, keepLastStates)
 	if err != nil {
 		return nil, err
@@ -553,7 +806,7 @@ func newCheckStateForTree(immutableTree *iavl.ImmutableTree, events eventsdb.IEv
 	return NewCheckState(stateForTree), nil
 }
 
-func newCheckStateForTreeV2(immutableTree *iavl.ImmutableTree, events eventsdb.IEventsDB, db db.DB, keepLastStates int64) (*CheckState, error) {
+func newCheckStateForTreeV2(immutableTree tree.ImmutableTree, events eventsdb.IEventsDB, db db.DB, keepLastStates int64) (*CheckState, error) {
 	stateForTree, err := newStateForTreeV2(immutableTree, events, db, keepLastStates)
 	if err != nil {
 		return nil, err
@@ -562,52 +815,80 @@ func newCheckStateForTreeV2(immutableTree *iavl.ImmutableTree, events eventsdb.I
 	return NewCheckState(stateForTree), nil
 }
 
-func newStateForTree(immutableTree *iavl.ImmutableTree, events eventsdb.IEventsDB, db db.DB, keepLastStates int64) (*State, error) {
+func newStateForTree(immutableTree tree.ImmutableTree, events eventsdb.IEventsDB, db db.DB, keepLastStates int64) (*State, error) {
 	stateBus := bus.NewBus()
 	stateBus.SetEvents(events)
 
 	stateChecker := checker.NewChecker(stateBus)
 
-	candidatesState := candidates.NewCandidates(stateBus, immutableTree)
+	cache := tree.NewCache(immutableTree)
+
+	candidatesState := candidates.NewCandidates(stateBus, cache)
+
+	validatorsState := validators.NewValidators(stateBus, cache)
+
+	appState := app.NewApp(stateBus, cache)
+
+	frozenFundsState := frozenfunds.NewFrozenFunds(stateBus, cache)
 
-	validatorsState := validators.NewValidators(stateBus, immutableTree)
+	accountsState := accounts.NewAccounts(stateBus, cache)
 
-	appState := app.NewApp(stateBus, immutableTree)
+	coinsState := coins.NewCoins(stateBus, cache)
 
-	frozenFundsState := frozenfunds.NewFrozenFunds(stateBus, immutableTree)
+	checksState := checks.NewChecks(cache)
 
-	accountsState := accounts.NewAccounts(stateBus, immutableTree)
+	haltsState := halts.NewHalts(stateBus, cache)
 
-	coinsState := coins.NewCoins(stateBus, immutableTree)
+	redelegationsState := redelegations.NewRedelegations(stateBus, cache)
 
-	checksState := checks.NewChecks(immutableTree)
+	multisigProposalsState := multisigproposals.NewMultisigProposals(stateBus, cache)
 
-	haltsState := halts.NewHalts(stateBus, immutableTree)
+	standingOrdersState := standingorders.NewStandingOrders(stateBus, cache)
 
-	waitlistState := waitlist.NewWaitList(stateBus, immutableTree)
+	htlcsState := htlcs.NewHTLCs(stateBus, cache)
 
-	pool := swap.New(stateBus, immutableTree)
+	distributionsState := distributions.NewDistributions(stateBus, cache)
 
-	commission := commission.NewCommission(immutableTree)
+	governanceState := governance.NewGovernance(stateBus, cache)
 
-	update := update.New(immutableTree)
+	treasuryState := treasury.NewTreasury(stateBus, cache)
+
+	delegatorVotesState := delegatorvotes.NewDelegatorVotes(stateBus, cache)
+
+	waitlistState := waitlist.NewWaitList(stateBus, cache)
+
+	pool := swap.New(stateBus, cache)
+
+	commission := commission.NewCommission(cache)
+
+	update := update.New(cache)
 
 	state := &State{
-		Validators:  validatorsState,
-		App:         appState,
-		Candidates:  candidatesState,
-		FrozenFunds: frozenFundsState,
-		Accounts:    accountsState,
-		Coins:       coinsState,
-		Checks:      checksState,
-		Checker:     stateChecker,
-		Halts:       haltsState,
-		Waitlist:    waitlistState,
-		Swap:        pool,
-		Commission:  commission,
-		Updates:     update,
+		Validators:    validatorsState,
+		App:           appState,
+		Candidates:    candidatesState,
+		FrozenFunds:   frozenFundsState,
+		Accounts:      accountsState,
+		Coins:         coinsState,
+		Checks:        checksState,
+		Checker:       stateChecker,
+		Halts:         haltsState,
+		Redelegations: redelegationsState,
+		Waitlist:      waitlistState,
+		Swap:          pool,
+		Commission:    commission,
+		Updates:       update,
+
+		MultisigProposals: multisigProposalsState,
+		StandingOrders:    standingOrdersState,
+		HTLCs:             htlcsState,
+		Distributions:     distributionsState,
+		Governance:        governanceState,
+		Treasury:          treasuryState,
+		DelegatorVotes:    delegatorVotesState,
 
 		height:         immutableTree.Version(),
+		cache:          cache,
 		bus:            stateBus,
 		db:             db,
 		events:         events,
@@ -616,52 +897,80 @@ func newStateForTree(immutableTree *iavl.ImmutableTree, events eventsdb.IEventsD
 
 	return state, nil
 }
-func newStateForTreeV2(immutableTree *iavl.ImmutableTree, events eventsdb.IEventsDB, db db.DB, keepLastStates int64) (*State, error) {
+func newStateForTreeV2(immutableTree tree.ImmutableTree, events eventsdb.IEventsDB, db db.DB, keepLastStates int64) (*State, error) {
 	stateBus := bus.NewBus()
 	stateBus.SetEvents(events)
 
 	stateChecker := checker.NewChecker(stateBus)
 
-	candidatesState := candidates.NewCandidates(stateBus, immutableTree)
+	cache := tree.NewCache(immutableTree)
+
+	candidatesState := candidates.NewCandidates(stateBus, cache)
+
+	validatorsState := validators.NewValidators(stateBus, cache)
+
+	appState := app.NewApp(stateBus, cache)
+
+	frozenFundsState := frozenfunds.NewFrozenFunds(stateBus, cache)
+
+	accountsState := accounts.NewAccounts(stateBus, cache)
+
+	coinsState := coins.NewCoins(stateBus, cache)
+
+	checksState := checks.NewChecks(cache)
+
+	haltsState := halts.NewHalts(stateBus, cache)
+
+	redelegationsState := redelegations.NewRedelegations(stateBus, cache)
 
-	validatorsState := validators.NewValidators(stateBus, immutableTree)
+	multisigProposalsState := multisigproposals.NewMultisigProposals(stateBus, cache)
 
-	appState := app.NewApp(stateBus, immutableTree)
+	standingOrdersState := standingorders.NewStandingOrders(stateBus, cache)
 
-	frozenFundsState := frozenfunds.NewFrozenFunds(stateBus, immutableTree)
+	htlcsState := htlcs.NewHTLCs(stateBus, cache)
 
-	accountsState := accounts.NewAccounts(stateBus, immutableTree)
+	distributionsState := distributions.NewDistributions(stateBus, cache)
 
-	coinsState := coins.NewCoins(stateBus, immutableTree)
+	governanceState := governance.NewGovernance(stateBus, cache)
 
-	checksState := checks.NewChecks(immutableTree)
+	treasuryState := treasury.NewTreasury(stateBus, cache)
 
-	haltsState := halts.NewHalts(stateBus, immutableTree)
+	delegatorVotesState := delegatorvotes.NewDelegatorVotes(stateBus, cache)
 
-	waitlistState := waitlist.NewWaitList(stateBus, immutableTree)
+	waitlistState := waitlist.NewWaitList(stateBus, cache)
 
-	poolV2 := swap.NewV2(stateBus, immutableTree)
+	poolV2 := swap.NewV2(stateBus, cache)
 
-	commission := commission.NewCommission(immutableTree)
+	commission := commission.NewCommission(cache)
 
-	update := update.New(immutableTree)
+	update := update.New(cache)
 
 	state := &State{
-		Validators:  validatorsState,
-		App:         appState,
-		Candidates:  candidatesState,
-		FrozenFunds: frozenFundsState,
-		Accounts:    accountsState,
-		Coins:       coinsState,
-		Checks:      checksState,
-		Checker:     stateChecker,
-		Halts:       haltsState,
-		Waitlist:    waitlistState,
-		SwapV2:      poolV2,
-		Commission:  commission,
-		Updates:     update,
+		Validators:    validatorsState,
+		App:           appState,
+		Candidates:    candidatesState,
+		FrozenFunds:   frozenFundsState,
+		Accounts:      accountsState,
+		Coins:         coinsState,
+		Checks:        checksState,
+		Checker:       stateChecker,
+		Halts:         haltsState,
+		Redelegations: redelegationsState,
+		Waitlist:      waitlistState,
+		SwapV2:        poolV2,
+		Commission:    commission,
+		Updates:       update,
+
+		MultisigProposals: multisigProposalsState,
+		StandingOrders:    standingOrdersState,
+		HTLCs:             htlcsState,
+		Distributions:     distributionsState,
+		Governance:        governanceState,
+		Treasury:          treasuryState,
+		DelegatorVotes:    delegatorVotesState,
 
 		height:         immutableTree.Version(),
+		cache:          cache,
 		bus:            stateBus,
 		db:             db,
 		events:         events,
@@ -354,3 +354,60 @@ func TestStateExport(t *testing.T) {
 		t.Fatal("Invalid waitlist data")
 	}
 }
+
+func TestStateOverlay(t *testing.T) {
+	t.Parallel()
+	memDB := db.NewMemDB()
+	state, err := NewStateV3(0, memDB, &eventsdb.MockEvents{}, 1, 2, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := state.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	privateKey, _ := crypto.GenerateKey()
+	address := crypto.PubkeyToAddress(privateKey.PublicKey)
+	coin := types.GetBaseCoinID()
+	checkHash := types.Hash{1}
+
+	state.Accounts.AddBalance(address, coin, big.NewInt(100))
+	state.Checks.UseCheckHash(checkHash)
+
+	overlay := state.Overlay()
+	if overlay.Accounts.GetBalance(address, coin).Cmp(big.NewInt(100)) != 0 {
+		t.Fatalf("overlay balance is not correct. Expected %d, got %s", 100, overlay.Accounts.GetBalance(address, coin))
+	}
+	if !overlay.Checks.IsCheckHashUsed(checkHash) {
+		t.Fatal("check is not used in overlay")
+	}
+
+	overlay.Accounts.SubBalance(address, coin, big.NewInt(100))
+	overlay.Checks.UseCheckHash(types.Hash{2})
+	if state.Accounts.GetBalance(address, coin).Cmp(big.NewInt(100)) != 0 {
+		t.Fatalf("balance is changed by overlay. Expected %d, got %s", 100, state.Accounts.GetBalance(address, coin))
+	}
+	if !state.Checks.IsCheckHashUsed(checkHash) || state.Checks.IsCheckHashUsed(types.Hash{2}) {
+		t.Fatal("checks are changed by overlay")
+	}
+
+	if balance := NewCheckState(state).Overlay().Accounts.GetBalance(address, coin); balance.Cmp(big.NewInt(100)) != 0 {
+		t.Fatalf("check state overlay balance is not correct. Expected %d, got %s", 100, balance)
+	}
+
+	state.Accounts.AddBalance(address, coin, big.NewInt(50))
+	if _, err := state.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	checkState, err := NewCheckStateAtHeightV3(2, memDB)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if checkState.Accounts().GetBalance(address, coin).Cmp(big.NewInt(150)) != 0 {
+		t.Fatalf("committed balance is not correct. Expected %d, got %s", 150, checkState.Accounts().GetBalance(address, coin))
+	}
+	if !checkState.Checks().IsCheckHashUsed(checkHash) || checkState.Checks().IsCheckHashUsed(types.Hash{2}) {
+		t.Fatal("committed checks are not correct")
+	}
+}
@@ -13,7 +13,7 @@ import (
 
 	"github.com/MinterTeam/minter-go-node/coreV2/types"
 	"github.com/MinterTeam/minter-go-node/rlp"
-	"github.com/cosmos/iavl"
+	"github.com/MinterTeam/minter-go-node/tree"
 )
 
 const commissionOrder = 2
@@ -1200,7 +1200,7 @@ func (p *Pair) addOrderWithID(wantBuyAmount0, wantSellAmount1 *big.Int, sender t
 	return order
 }
 
-func (p *Pair) loadAllOrders(immutableTree *iavl.ImmutableTree) (orders []*Limit) {
+func (p *Pair) loadAllOrders(immutableTree tree.ImmutableTree) (orders []*Limit) {
 	const countFirstBytes = 10
 
 	startKey := append(append([]byte{mainPrefix}, p.pathOrders()...), byte(0), byte(0))
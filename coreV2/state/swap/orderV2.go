@@ -13,7 +13,7 @@ import (
 
 	"github.com/MinterTeam/minter-go-node/coreV2/types"
 	"github.com/MinterTeam/minter-go-node/rlp"
-	"github.com/cosmos/iavl"
+	"github.com/MinterTeam/minter-go-node/tree"
 )
 
 var burnAddress = types.HexToAddress("Mx00cedde786b34d733d1dc96559253081572df2c6")
@@ -848,7 +848,7 @@ func (p *PairV2) addOrderWithID(wantBuyAmount0, wantSellAmount1 *big.Int, sender
 	return order
 }
 
-func (p *PairV2) loadAllOrders(immutableTree *iavl.ImmutableTree) (orders []*Limit) {
+func (p *PairV2) loadAllOrders(immutableTree tree.ImmutableTree) (orders []*Limit) {
 	const countFirstBytes = 10
 
 	startKey := append(append([]byte{mainPrefix}, p.pathOrders()...), byte(0), byte(0))
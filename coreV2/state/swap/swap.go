@@ -12,7 +12,6 @@ import (
 	"strconv"
 	"strings"
 	"sync"
-	"sync/atomic"
 
 	"github.com/MinterTeam/minter-go-node/coreV2/events"
 
@@ -20,7 +19,7 @@ import (
 	"github.com/MinterTeam/minter-go-node/coreV2/types"
 	"github.com/MinterTeam/minter-go-node/helpers"
 	"github.com/MinterTeam/minter-go-node/rlp"
-	"github.com/cosmos/iavl"
+	"github.com/MinterTeam/minter-go-node/tree"
 )
 
 var Bound = big.NewInt(minimumLiquidity)
@@ -102,7 +101,7 @@ type Swap struct {
 	dirtyNextOrdersID bool
 
 	bus *bus.Bus
-	db  atomic.Value
+	db  tree.AtomicTree
 
 	muLoadPools sync.Mutex
 	loadedPools bool
@@ -293,14 +292,14 @@ func (s *Swap) getOrderedDirtyOrderPairs() []PairKey {
 	return keys
 }
 
-func New(bus *bus.Bus, db *iavl.ImmutableTree) *Swap {
-	immutableTree := atomic.Value{}
+func New(bus *bus.Bus, db tree.ImmutableTree) *Swap {
+	immutableTree := tree.AtomicTree{}
 	immutableTree.Store(db)
 	return &Swap{trader: &traderV2{}, pairs: map[PairKey]*Pair{}, bus: bus, db: immutableTree, dirties: map[PairKey]struct{}{}, dirtiesOrders: map[PairKey]struct{}{}}
 }
 
-func (s *Swap) immutableTree() *iavl.ImmutableTree {
-	return s.db.Load().(*iavl.ImmutableTree)
+func (s *Swap) immutableTree() tree.ImmutableTree {
+	return s.db.Load()
 }
 
 func (s *Swap) Export(state *types.AppState) {
@@ -596,7 +595,7 @@ func pricePath(key PairKey, price *big.Float, id uint32, isSale bool) []byte {
 	return append(append(append(append([]byte{mainPrefix}, key.pathOrders()...), saleByte), pricePath...), byteID...)
 }
 
-func (s *Swap) Commit(db *iavl.MutableTree, version int64) error {
+func (s *Swap) Commit(db tree.MutableTree, version int64) error {
 	basePath := []byte{mainPrefix}
 
 	s.muNextID.Lock()
@@ -726,7 +725,7 @@ func (s *Swap) Commit(db *iavl.MutableTree, version int64) error {
 	return nil
 }
 
-func (s *Swap) SetImmutableTree(immutableTree *iavl.ImmutableTree) {
+func (s *Swap) SetImmutableTree(immutableTree tree.ImmutableTree) {
 	s.db.Store(immutableTree)
 }
 
@@ -10,7 +10,6 @@ import (
 	"sort"
 	"strconv"
 	"sync"
-	"sync/atomic"
 
 	"github.com/MinterTeam/minter-go-node/coreV2/events"
 
@@ -18,7 +17,7 @@ import (
 	"github.com/MinterTeam/minter-go-node/coreV2/types"
 	"github.com/MinterTeam/minter-go-node/helpers"
 	"github.com/MinterTeam/minter-go-node/rlp"
-	"github.com/cosmos/iavl"
+	"github.com/MinterTeam/minter-go-node/tree"
 )
 
 type trader interface {
@@ -45,7 +44,7 @@ type SwapV2 struct {
 	version int
 
 	bus *bus.Bus
-	db  atomic.Value
+	db  tree.AtomicTree
 
 	muLoadPools sync.Mutex
 	loadedPools bool
@@ -309,14 +308,14 @@ func (s *SwapV2) getOrderedDirtyOrderPairs() []PairKey {
 	return keys
 }
 
-func NewV2(bus *bus.Bus, db *iavl.ImmutableTree) *SwapV2 {
-	immutableTree := atomic.Value{}
+func NewV2(bus *bus.Bus, db tree.ImmutableTree) *SwapV2 {
+	immutableTree := tree.AtomicTree{}
 	immutableTree.Store(db)
 	return &SwapV2{trader: &traderV2{}, pairs: map[PairKey]*PairV2{}, bus: bus, db: immutableTree, dirties: map[PairKey]struct{}{}, dirtiesOrders: map[PairKey]struct{}{}}
 }
 
-func (s *SwapV2) immutableTree() *iavl.ImmutableTree {
-	return s.db.Load().(*iavl.ImmutableTree)
+func (s *SwapV2) immutableTree() tree.ImmutableTree {
+	return s.db.Load()
 }
 
 func (s *SwapV2) Export(state *types.AppState) {
@@ -470,7 +469,7 @@ func (p *PairV2) reverse() *PairV2 {
 	}
 }
 
-func (s *SwapV2) Commit(db *iavl.MutableTree, version int64) error {
+func (s *SwapV2) Commit(db tree.MutableTree, version int64) error {
 	basePath := []byte{mainPrefix}
 
 	s.muNextID.Lock()
@@ -601,7 +600,7 @@ func (s *SwapV2) Commit(db *iavl.MutableTree, version int64) error {
 	return nil
 }
 
-func (s *SwapV2) SetImmutableTree(immutableTree *iavl.ImmutableTree) {
+func (s *SwapV2) SetImmutableTree(immutableTree tree.ImmutableTree) {
 	s.db.Store(immutableTree)
 }
 
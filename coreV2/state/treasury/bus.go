@@ -0,0 +1,19 @@
+package treasury
+
+import (
+	"math/big"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+)
+
+type Bus struct {
+	treasury *Treasury
+}
+
+func NewBus(treasury *Treasury) *Bus {
+	return &Bus{treasury: treasury}
+}
+
+func (b *Bus) AddIncome(coin types.CoinID, value *big.Int) {
+	b.treasury.AddIncome(coin, value)
+}
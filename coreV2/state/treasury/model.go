@@ -0,0 +1,78 @@
+package treasury
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+)
+
+// Proposal is a request to spend Amount of Coin from the treasury to Recipient.
+// Validators vote for the proposal until VotingEnd, the approved proposal is paid at the end of voting
+type Proposal struct {
+	Proposer    types.Address
+	Recipient   types.Address
+	Coin        types.CoinID
+	Amount      *big.Int
+	Description string
+	VotingEnd   uint64
+	Votes       []types.Pubkey
+
+	id        uint64
+	deleted   bool
+	markDirty func(id uint64)
+	lock      sync.RWMutex
+}
+
+// ID returns the identifier of the proposal
+func (p *Proposal) ID() uint64 {
+	return p.id
+}
+
+// GetVotes returns public keys of validators voted for the proposal
+func (p *Proposal) GetVotes() []types.Pubkey {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	votes := make([]types.Pubkey, len(p.Votes))
+	copy(votes, p.Votes)
+
+	return votes
+}
+
+// HasVote returns true if the validator has already voted for the proposal
+func (p *Proposal) HasVote(pubkey types.Pubkey) bool {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	for _, vote := range p.Votes {
+		if vote == pubkey {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p *Proposal) addVote(pubkey types.Pubkey) {
+	p.lock.Lock()
+	p.Votes = append(p.Votes, pubkey)
+	p.lock.Unlock()
+
+	p.markDirty(p.id)
+}
+
+func (p *Proposal) delete() {
+	p.lock.Lock()
+	p.deleted = true
+	p.lock.Unlock()
+
+	p.markDirty(p.id)
+}
+
+func (p *Proposal) isDeleted() bool {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	return p.deleted
+}
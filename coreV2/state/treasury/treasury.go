@@ -0,0 +1,507 @@
+package treasury
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+
+	eventsdb "github.com/MinterTeam/minter-go-node/coreV2/events"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/bus"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/rlp"
+	"github.com/MinterTeam/minter-go-node/tree"
+)
+
+const mainPrefix = byte('b')
+
+const (
+	balancePrefix  = byte('c')
+	proposalPrefix = byte('p')
+	nextIDPrefix   = byte('n')
+)
+
+// MaxDescriptionLength limits the description of a spend proposal
+const MaxDescriptionLength = 1024
+
+type RTreasury interface {
+	Export(state *types.AppState)
+	GetBalance(coin types.CoinID) *big.Int
+	GetBalances() map[types.CoinID]*big.Int
+	GetProposal(id uint64) *Proposal
+	GetProposals() []*Proposal
+}
+
+// Treasury holds the income of DAO in the protocol state, it is spent by proposals approved by validators
+type Treasury struct {
+	balances      map[types.CoinID]*big.Int
+	dirtyBalances map[types.CoinID]struct{}
+
+	list  map[uint64]*Proposal
+	dirty map[uint64]struct{}
+
+	nextID        uint64
+	isDirtyNextID bool
+
+	bus *bus.Bus
+	db  tree.AtomicTree
+
+	lock sync.RWMutex
+}
+
+func NewTreasury(stateBus *bus.Bus, db tree.ImmutableTree) *Treasury {
+	immutableTree := tree.AtomicTree{}
+	if db != nil {
+		immutableTree.Store(db)
+	}
+	treasury := &Treasury{
+		bus:           stateBus,
+		db:            immutableTree,
+		balances:      map[types.CoinID]*big.Int{},
+		dirtyBalances: map[types.CoinID]struct{}{},
+		list:          map[uint64]*Proposal{},
+		dirty:         map[uint64]struct{}{},
+	}
+	treasury.bus.SetTreasury(NewBus(treasury))
+
+	return treasury
+}
+
+func (t *Treasury) immutableTree() tree.ImmutableTree {
+	return t.db.Load()
+}
+
+func (t *Treasury) SetImmutableTree(immutableTree tree.ImmutableTree) {
+	t.db.Store(immutableTree)
+}
+
+func (t *Treasury) Commit(db tree.MutableTree, version int64) error {
+	for _, id := range t.getOrderedDirty() {
+		proposal := t.getFromMap(id)
+		path := getProposalPath(id)
+
+		t.lock.Lock()
+		delete(t.dirty, id)
+		t.lock.Unlock()
+
+		proposal.lock.RLock()
+		if proposal.deleted {
+			t.lock.Lock()
+			delete(t.list, id)
+			t.lock.Unlock()
+
+			db.Remove(path)
+		} else {
+			data, err := rlp.EncodeToBytes(proposal)
+			if err != nil {
+				proposal.lock.RUnlock()
+				return fmt.Errorf("can't encode proposal %d: %v", id, err)
+			}
+
+			db.Set(path, data)
+		}
+		proposal.lock.RUnlock()
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	coins := make([]types.CoinID, 0, len(t.dirtyBalances))
+	for coin := range t.dirtyBalances {
+		coins = append(coins, coin)
+	}
+	sort.Slice(coins, func(i, j int) bool {
+		return coins[i] < coins[j]
+	})
+
+	for _, coin := range coins {
+		delete(t.dirtyBalances, coin)
+
+		path := getBalancePath(coin)
+		balance := t.balances[coin]
+		if balance.Sign() == 0 {
+			db.Remove(path)
+			continue
+		}
+
+		data, err := rlp.EncodeToBytes(balance)
+		if err != nil {
+			return fmt.Errorf("can't encode treasury balance of %s: %v", coin, err)
+		}
+
+		db.Set(path, data)
+	}
+
+	if t.isDirtyNextID {
+		t.isDirtyNextID = false
+
+		data, err := rlp.EncodeToBytes(t.nextID)
+		if err != nil {
+			return fmt.Errorf("can't encode next proposal id: %v", err)
+		}
+
+		db.Set([]byte{mainPrefix, nextIDPrefix}, data)
+	}
+
+	return nil
+}
+
+// GetBalance returns the amount of the coin held by the treasury
+func (t *Treasury) GetBalance(coin types.CoinID) *big.Int {
+	t.lock.RLock()
+	balance, ok := t.balances[coin]
+	t.lock.RUnlock()
+	if ok {
+		return big.NewInt(0).Set(balance)
+	}
+
+	balance = big.NewInt(0)
+	if immutableTree := t.immutableTree(); immutableTree != nil {
+		_, enc := immutableTree.Get(getBalancePath(coin))
+		if len(enc) != 0 {
+			if err := rlp.DecodeBytes(enc, balance); err != nil {
+				panic(fmt.Sprintf("failed to decode treasury balance of %s: %s", coin, err))
+			}
+		}
+	}
+
+	t.lock.Lock()
+	if _, ok := t.balances[coin]; !ok {
+		t.balances[coin] = balance
+	}
+	t.lock.Unlock()
+
+	return big.NewInt(0).Set(balance)
+}
+
+// GetBalances returns all non-zero balances of the treasury
+func (t *Treasury) GetBalances() map[types.CoinID]*big.Int {
+	balances := map[types.CoinID]*big.Int{}
+
+	if immutableTree := t.immutableTree(); immutableTree != nil {
+		immutableTree.IterateRange([]byte{mainPrefix, balancePrefix}, []byte{mainPrefix, balancePrefix + 1}, true, func(key []byte, value []byte) bool {
+			balances[types.BytesToCoinID(key[2:])] = nil
+			return false
+		})
+	}
+
+	t.lock.RLock()
+	for coin := range t.balances {
+		balances[coin] = nil
+	}
+	t.lock.RUnlock()
+
+	for coin := range balances {
+		balance := t.GetBalance(coin)
+		if balance.Sign() == 0 {
+			delete(balances, coin)
+			continue
+		}
+		balances[coin] = balance
+	}
+
+	return balances
+}
+
+// AddIncome adds the value to the treasury balance, coins should be subtracted from their holder
+func (t *Treasury) AddIncome(coin types.CoinID, value *big.Int) {
+	if value.Sign() != 1 {
+		return
+	}
+
+	t.setBalance(coin, big.NewInt(0).Add(t.GetBalance(coin), value))
+	t.bus.Checker().AddCoin(coin, value)
+}
+
+// SetBalance sets the treasury balance of the coin
+func (t *Treasury) SetBalance(coin types.CoinID, value *big.Int) {
+	t.bus.Checker().AddCoin(coin, big.NewInt(0).Sub(value, t.GetBalance(coin)))
+	t.setBalance(coin, value)
+}
+
+func (t *Treasury) setBalance(coin types.CoinID, value *big.Int) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.balances[coin] = big.NewInt(0).Set(value)
+	t.dirtyBalances[coin] = struct{}{}
+}
+
+// GetProposal returns an active proposal by its ID
+func (t *Treasury) GetProposal(id uint64) *Proposal {
+	proposal := t.get(id)
+	if proposal == nil || proposal.isDeleted() {
+		return nil
+	}
+
+	return proposal
+}
+
+// GetProposals returns active proposals ordered by ID
+func (t *Treasury) GetProposals() []*Proposal {
+	var proposals []*Proposal
+	for _, id := range t.getActiveIDs() {
+		if proposal := t.GetProposal(id); proposal != nil {
+			proposals = append(proposals, proposal)
+		}
+	}
+
+	return proposals
+}
+
+// Create puts a new spend proposal to the store and returns its ID
+func (t *Treasury) Create(proposer types.Address, recipient types.Address, coin types.CoinID, amount *big.Int, description string, votingEnd uint64) uint64 {
+	id := t.getNextID()
+	t.setNextID(id + 1)
+
+	t.SetProposal(id, proposer, recipient, coin, amount, description, votingEnd, nil)
+
+	return id
+}
+
+// SetProposal puts a spend proposal with given ID to the store
+func (t *Treasury) SetProposal(id uint64, proposer types.Address, recipient types.Address, coin types.CoinID, amount *big.Int, description string, votingEnd uint64, votes []types.Pubkey) {
+	proposal := &Proposal{
+		Proposer:    proposer,
+		Recipient:   recipient,
+		Coin:        coin,
+		Amount:      big.NewInt(0).Set(amount),
+		Description: description,
+		VotingEnd:   votingEnd,
+		Votes:       votes,
+		id:          id,
+		markDirty:   t.markDirty,
+	}
+	t.setToMap(id, proposal)
+	proposal.markDirty(id)
+}
+
+// SetNextID sets the ID of the next created proposal
+func (t *Treasury) SetNextID(id uint64) {
+	t.setNextID(id)
+}
+
+// AddVote adds a vote of the validator for the proposal
+func (t *Treasury) AddVote(id uint64, pubkey types.Pubkey) {
+	proposal := t.GetProposal(id)
+	if proposal == nil {
+		return
+	}
+
+	proposal.addVote(pubkey)
+}
+
+// GetVotes returns proposals which voting ends at the height
+func (t *Treasury) GetVotes(height uint64) []*Proposal {
+	var proposals []*Proposal
+	for _, proposal := range t.GetProposals() {
+		if proposal.VotingEnd == height {
+			proposals = append(proposals, proposal)
+		}
+	}
+
+	return proposals
+}
+
+// Spend pays the approved proposal from the treasury, returns an error if the proposal is not found or the treasury has not enough coins
+func (t *Treasury) Spend(id uint64) error {
+	proposal := t.GetProposal(id)
+	if proposal == nil {
+		return fmt.Errorf("proposal %d not found", id)
+	}
+
+	balance := t.GetBalance(proposal.Coin)
+	if balance.Cmp(proposal.Amount) == -1 {
+		return fmt.Errorf("treasury balance %s of coin %d is less than %s", balance, proposal.Coin, proposal.Amount)
+	}
+
+	t.setBalance(proposal.Coin, balance.Sub(balance, proposal.Amount))
+	t.bus.Checker().AddCoin(proposal.Coin, big.NewInt(0).Neg(proposal.Amount))
+	t.bus.Accounts().AddBalance(proposal.Recipient, proposal.Coin, proposal.Amount)
+
+	t.bus.Events().AddEvent(&eventsdb.TreasurySpendEvent{
+		ID:          id,
+		Recipient:   proposal.Recipient,
+		Coin:        uint64(proposal.Coin),
+		Amount:      proposal.Amount.String(),
+		Description: proposal.Description,
+	})
+
+	return nil
+}
+
+// Delete removes proposals which voting ends at the height
+func (t *Treasury) Delete(height uint64) {
+	for _, proposal := range t.GetVotes(height) {
+		proposal.delete()
+	}
+}
+
+func (t *Treasury) Export(state *types.AppState) {
+	balances := t.GetBalances()
+	coins := make([]types.CoinID, 0, len(balances))
+	for coin := range balances {
+		coins = append(coins, coin)
+	}
+	sort.Slice(coins, func(i, j int) bool {
+		return coins[i] < coins[j]
+	})
+
+	for _, coin := range coins {
+		state.Treasury = append(state.Treasury, types.TreasuryBalance{
+			Coin:  uint64(coin),
+			Value: balances[coin].String(),
+		})
+	}
+
+	for _, proposal := range t.GetProposals() {
+		state.TreasuryProposals = append(state.TreasuryProposals, types.TreasuryProposal{
+			ID:          proposal.id,
+			Proposer:    proposal.Proposer,
+			Recipient:   proposal.Recipient,
+			Coin:        uint64(proposal.Coin),
+			Amount:      proposal.Amount.String(),
+			Description: proposal.Description,
+			VotingEnd:   proposal.VotingEnd,
+			Votes:       proposal.GetVotes(),
+		})
+	}
+
+	state.NextTreasuryProposalID = t.getNextID()
+}
+
+// getActiveIDs returns IDs of stored and created proposals which are not deleted, ordered by ID
+func (t *Treasury) getActiveIDs() []uint64 {
+	ids := map[uint64]struct{}{}
+
+	if immutableTree := t.immutableTree(); immutableTree != nil {
+		immutableTree.IterateRange([]byte{mainPrefix, proposalPrefix}, []byte{mainPrefix, proposalPrefix + 1}, true, func(key []byte, value []byte) bool {
+			ids[binary.BigEndian.Uint64(key[2:])] = struct{}{}
+			return false
+		})
+	}
+
+	t.lock.RLock()
+	for id := range t.list {
+		ids[id] = struct{}{}
+	}
+	t.lock.RUnlock()
+
+	sorted := make([]uint64, 0, len(ids))
+	for id := range ids {
+		if proposal := t.get(id); proposal == nil || proposal.isDeleted() {
+			continue
+		}
+		sorted = append(sorted, id)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+
+	return sorted
+}
+
+func (t *Treasury) get(id uint64) *Proposal {
+	if proposal := t.getFromMap(id); proposal != nil {
+		return proposal
+	}
+
+	immutableTree := t.immutableTree()
+	if immutableTree == nil {
+		return nil
+	}
+
+	_, enc := immutableTree.Get(getProposalPath(id))
+	if len(enc) == 0 {
+		return nil
+	}
+
+	proposal := &Proposal{}
+	if err := rlp.DecodeBytes(enc, proposal); err != nil {
+		panic(fmt.Sprintf("failed to decode proposal %d: %s", id, err))
+	}
+
+	proposal.id = id
+	proposal.markDirty = t.markDirty
+
+	t.setToMap(id, proposal)
+
+	return proposal
+}
+
+func (t *Treasury) getNextID() uint64 {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if t.nextID != 0 {
+		return t.nextID
+	}
+
+	t.nextID = 1
+	if immutableTree := t.immutableTree(); immutableTree != nil {
+		_, enc := immutableTree.Get([]byte{mainPrefix, nextIDPrefix})
+		if len(enc) != 0 {
+			if err := rlp.DecodeBytes(enc, &t.nextID); err != nil {
+				panic(fmt.Sprintf("failed to decode next proposal id: %s", err))
+			}
+		}
+	}
+
+	return t.nextID
+}
+
+func (t *Treasury) setNextID(id uint64) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.nextID = id
+	t.isDirtyNextID = true
+}
+
+func (t *Treasury) markDirty(id uint64) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.dirty[id] = struct{}{}
+}
+
+func (t *Treasury) getOrderedDirty() []uint64 {
+	t.lock.Lock()
+	keys := make([]uint64, 0, len(t.dirty))
+	for k := range t.dirty {
+		keys = append(keys, k)
+	}
+	t.lock.Unlock()
+
+	sort.SliceStable(keys, func(i, j int) bool {
+		return keys[i] < keys[j]
+	})
+
+	return keys
+}
+
+func (t *Treasury) getFromMap(id uint64) *Proposal {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	return t.list[id]
+}
+
+func (t *Treasury) setToMap(id uint64, proposal *Proposal) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.list[id] = proposal
+}
+
+func getProposalPath(id uint64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, id)
+
+	return append([]byte{mainPrefix, proposalPrefix}, b...)
+}
+
+func getBalancePath(coin types.CoinID) []byte {
+	return append([]byte{mainPrefix, balancePrefix}, coin.Bytes()...)
+}
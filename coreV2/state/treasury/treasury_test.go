@@ -0,0 +1,74 @@
+package treasury
+
+import (
+	"math/big"
+	"testing"
+
+	eventsdb "github.com/MinterTeam/minter-go-node/coreV2/events"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/accounts"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/bus"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/checker"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/coins"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/tree"
+	db "github.com/tendermint/tm-db"
+)
+
+func TestTreasurySpend(t *testing.T) {
+	t.Parallel()
+	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024, 0)
+	b := bus.NewBus()
+	b.SetChecker(checker.NewChecker(b))
+	events := &eventsdb.MockEvents{}
+	b.SetEvents(events)
+	coins.NewCoins(b, mutableTree.GetLastImmutable())
+	acc := accounts.NewAccounts(b, mutableTree.GetLastImmutable())
+	tr := NewTreasury(b, mutableTree.GetLastImmutable())
+
+	coin := types.GetBaseCoinID()
+	tr.AddIncome(coin, big.NewInt(1000))
+
+	recipient := types.Address{1}
+	approvedID := tr.Create(types.Address{2}, recipient, coin, big.NewInt(600), "grant", 10)
+	tooLargeID := tr.Create(types.Address{2}, recipient, coin, big.NewInt(600), "another grant", 10)
+	laterID := tr.Create(types.Address{2}, recipient, coin, big.NewInt(100), "later grant", 20)
+
+	voter := types.Pubkey{1}
+	tr.AddVote(approvedID, voter)
+
+	_, _, err := mutableTree.Commit(acc, tr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tr = NewTreasury(b, mutableTree.GetLastImmutable())
+	if balance := tr.GetBalance(coin); balance.Cmp(big.NewInt(1000)) != 0 {
+		t.Fatalf("Treasury balance is not correct. Expected %d, got %s", 1000, balance)
+	}
+
+	proposals := tr.GetVotes(10)
+	if len(proposals) != 2 || !proposals[0].HasVote(voter) {
+		t.Fatalf("Proposals to tally are not correct: %v", proposals)
+	}
+
+	if err := tr.Spend(approvedID); err != nil {
+		t.Fatalf("Proposal is not paid: %s", err)
+	}
+	if err := tr.Spend(tooLargeID); err == nil {
+		t.Fatal("Proposal is paid over the treasury balance")
+	}
+	tr.Delete(10)
+
+	if balance := acc.GetBalance(recipient, coin); balance.Cmp(big.NewInt(600)) != 0 {
+		t.Fatalf("Recipient balance is not correct. Expected %d, got %s", 600, balance)
+	}
+	if balance := tr.GetBalance(coin); balance.Cmp(big.NewInt(400)) != 0 {
+		t.Fatalf("Treasury balance is not correct. Expected %d, got %s", 400, balance)
+	}
+	if tr.GetProposal(approvedID) != nil || tr.GetProposal(tooLargeID) != nil || tr.GetProposal(laterID) == nil {
+		t.Fatal("Proposals are not deleted at the end of voting")
+	}
+	if count := len(events.LoadEvents(0)); count != 1 {
+		t.Fatalf("Events count is not correct. Expected %d, got %d", 1, count)
+	}
+}
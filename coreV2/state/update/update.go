@@ -5,11 +5,10 @@ import (
 	"fmt"
 	"sort"
 	"sync"
-	"sync/atomic"
 
 	"github.com/MinterTeam/minter-go-node/coreV2/types"
 	"github.com/MinterTeam/minter-go-node/rlp"
-	"github.com/cosmos/iavl"
+	"github.com/MinterTeam/minter-go-node/tree"
 )
 
 const mainPrefix = byte('u')
@@ -20,6 +19,7 @@ type RUpdate interface {
 
 	Export(state *types.AppState)
 	GetVotes(height uint64) []*Model
+	GetHeights() []uint64
 	IsVoteExists(height uint64, pubkey types.Pubkey) bool
 }
 
@@ -28,12 +28,12 @@ type Update struct {
 	dirty     map[uint64]struct{}
 	forDelete uint64
 
-	db   atomic.Value
+	db   tree.AtomicTree
 	lock sync.RWMutex
 }
 
-func New(db *iavl.ImmutableTree) *Update {
-	immutableTree := atomic.Value{}
+func New(db tree.ImmutableTree) *Update {
+	immutableTree := tree.AtomicTree{}
 	if db != nil {
 		immutableTree.Store(db)
 	}
@@ -47,15 +47,11 @@ func New(db *iavl.ImmutableTree) *Update {
 	return halts
 }
 
-func (c *Update) immutableTree() *iavl.ImmutableTree {
-	db := c.db.Load()
-	if db == nil {
-		return nil
-	}
-	return db.(*iavl.ImmutableTree)
+func (c *Update) immutableTree() tree.ImmutableTree {
+	return c.db.Load()
 }
 
-func (c *Update) SetImmutableTree(immutableTree *iavl.ImmutableTree) {
+func (c *Update) SetImmutableTree(immutableTree tree.ImmutableTree) {
 	c.db.Store(immutableTree)
 }
 
@@ -88,7 +84,7 @@ func (c *Update) ExportV1(state *types.AppState) {
 	return
 }
 
-func (c *Update) Commit(db *iavl.MutableTree, version int64) error {
+func (c *Update) Commit(db tree.MutableTree, version int64) error {
 	c.lock.RLock()
 	dirties := c.getOrderedDirty()
 	c.lock.RUnlock()
@@ -123,6 +119,26 @@ func (c *Update) GetVotes(height uint64) []*Model {
 	return c.get(height)
 }
 
+// GetHeights returns sorted heights which have votes
+func (c *Update) GetHeights() []uint64 {
+	var heights []uint64
+	c.immutableTree().IterateRange([]byte{mainPrefix}, []byte{mainPrefix + 1}, true, func(key []byte, value []byte) bool {
+		if len(key) < 8 {
+			return false
+		}
+
+		heights = append(heights, binary.LittleEndian.Uint64(key[1:]))
+
+		return false
+	})
+
+	sort.Slice(heights, func(i, j int) bool {
+		return heights[i] < heights[j]
+	})
+
+	return heights
+}
+
 func (c *Update) getOrNew(height uint64, version string) *Model {
 	models := c.get(height)
 
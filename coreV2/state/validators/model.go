@@ -107,9 +107,13 @@ func (v *Validator) AddAccumReward(amount *big.Int) {
 }
 
 func (v *Validator) CountAbsentTimes() int {
+	return v.countAbsentTimes(ValidatorMaxAbsentWindow)
+}
+
+func (v *Validator) countAbsentTimes(window int) int {
 	count := 0
 
-	for i := 0; i < ValidatorMaxAbsentWindow; i++ {
+	for i := 0; i < window; i++ {
 		v.lock.RLock()
 		if v.AbsentTimes.GetIndex(i) {
 			count++
@@ -135,11 +139,16 @@ func (v *Validator) setTmAddress() {
 }
 
 func (v *Validator) SetPresent(height uint64) {
-	index := int(height) % ValidatorMaxAbsentWindow
+	v.setPresent(height, ValidatorMaxAbsentWindow)
+}
+
+func (v *Validator) setPresent(height uint64, window int) {
+	index := int(height) % window
 
 	v.lock.Lock()
 	defer v.lock.Unlock()
 
+	v.clearOutOfWindow(window)
 	if v.AbsentTimes.GetIndex(index) {
 		v.isDirty = true
 	}
@@ -147,13 +156,28 @@ func (v *Validator) SetPresent(height uint64) {
 }
 
 func (v *Validator) SetAbsent(height uint64) {
-	index := int(height) % ValidatorMaxAbsentWindow
+	v.setAbsent(height, ValidatorMaxAbsentWindow)
+}
+
+func (v *Validator) setAbsent(height uint64, window int) {
+	index := int(height) % window
 
 	v.lock.Lock()
 	defer v.lock.Unlock()
 
+	v.clearOutOfWindow(window)
 	if !v.AbsentTimes.GetIndex(index) {
 		v.isDirty = true
 	}
 	v.AbsentTimes.SetIndex(index, true)
 }
+
+// clearOutOfWindow resets missed signs left after the window is decreased, should be called under the lock
+func (v *Validator) clearOutOfWindow(window int) {
+	for i := window; i < ValidatorMaxAbsentWindow; i++ {
+		if v.AbsentTimes.GetIndex(i) {
+			v.AbsentTimes.SetIndex(i, false)
+			v.isDirty = true
+		}
+	}
+}
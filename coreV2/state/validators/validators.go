@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"sort"
 	"sync"
-	"sync/atomic"
 
 	"github.com/MinterTeam/minter-go-node/coreV2/dao"
 	"github.com/MinterTeam/minter-go-node/coreV2/developers"
@@ -14,8 +13,8 @@ import (
 	"github.com/MinterTeam/minter-go-node/coreV2/state/candidates"
 	"github.com/MinterTeam/minter-go-node/coreV2/types"
 	"github.com/MinterTeam/minter-go-node/rlp"
+	"github.com/MinterTeam/minter-go-node/tree"
 	"github.com/MinterTeam/minter-go-node/upgrades"
-	"github.com/cosmos/iavl"
 
 	"math/big"
 )
@@ -28,7 +27,7 @@ const (
 
 const (
 	ValidatorMaxAbsentWindow = 24
-	validatorMaxAbsentTimes  = 12
+	ValidatorMaxAbsentTimes  = 12
 )
 
 // Validators struct is a store of Validators state
@@ -37,7 +36,7 @@ type Validators struct {
 	removed map[types.Pubkey]struct{}
 	loaded  bool
 
-	db   atomic.Value
+	db   tree.AtomicTree
 	bus  *bus.Bus
 	lock sync.RWMutex
 }
@@ -52,8 +51,8 @@ type RValidators interface {
 }
 
 // NewValidators returns newly created Validators state with a given bus and iavl
-func NewValidators(bus *bus.Bus, db *iavl.ImmutableTree) *Validators {
-	immutableTree := atomic.Value{}
+func NewValidators(bus *bus.Bus, db tree.ImmutableTree) *Validators {
+	immutableTree := tree.AtomicTree{}
 	loaded := false
 	if db != nil {
 		immutableTree.Store(db)
@@ -65,15 +64,11 @@ func NewValidators(bus *bus.Bus, db *iavl.ImmutableTree) *Validators {
 	return validators
 }
 
-func (v *Validators) immutableTree() *iavl.ImmutableTree {
-	db := v.db.Load()
-	if db == nil {
-		return nil
-	}
-	return db.(*iavl.ImmutableTree)
+func (v *Validators) immutableTree() tree.ImmutableTree {
+	return v.db.Load()
 }
 
-func (v *Validators) SetImmutableTree(immutableTree *iavl.ImmutableTree) {
+func (v *Validators) SetImmutableTree(immutableTree tree.ImmutableTree) {
 	if v.immutableTree() == nil && v.loaded {
 		v.loaded = false
 	}
@@ -81,7 +76,7 @@ func (v *Validators) SetImmutableTree(immutableTree *iavl.ImmutableTree) {
 }
 
 // Commit writes changes to iavl, may return an error
-func (v *Validators) Commit(db *iavl.MutableTree, version int64) error {
+func (v *Validators) Commit(db tree.MutableTree, version int64) error {
 	if v.hasDirtyValidators() { // todo move check lost to range
 		v.lock.RLock()
 		data, err := rlp.EncodeToBytes(v.list)
@@ -186,20 +181,21 @@ func (v *Validators) SetValidatorPresent(height uint64, address types.TmAddress)
 	if validator == nil {
 		return
 	}
-	validator.SetPresent(height)
+	validator.setPresent(height, v.maxAbsentWindow())
 }
 
 // SetValidatorAbsent marks validator as absent at current height
-// if validator misses signs of more than validatorMaxAbsentTimes, it will receive penalty and will be swithed off
+// if validator misses signs of more than ValidatorMaxAbsentTimes (or the value set by governance), it will receive penalty and will be swithed off
 func (v *Validators) SetValidatorAbsent(height uint64, address types.TmAddress, grace *upgrades.Grace) {
 	validator := v.GetByTmAddress(address)
 	if validator == nil {
 		return
 	}
 
-	validator.SetAbsent(height)
+	window := v.maxAbsentWindow()
+	validator.setAbsent(height, window)
 
-	if validator.CountAbsentTimes() > validatorMaxAbsentTimes {
+	if validator.countAbsentTimes(window) > v.maxAbsentTimes(window) {
 		if !grace.IsGraceBlock(height) {
 			v.punishValidator(height, address)
 		}
@@ -208,6 +204,34 @@ func (v *Validators) SetValidatorAbsent(height uint64, address types.TmAddress,
 	}
 }
 
+// maxAbsentTimes returns the allowed number of missed signs in the window, it can be changed by governance
+func (v *Validators) maxAbsentTimes(window int) int {
+	if v.bus.Governance() == nil {
+		return ValidatorMaxAbsentTimes
+	}
+	times := int(v.bus.Governance().ValidatorMaxAbsentTimes())
+	if times >= window {
+		return window - 1
+	}
+	return times
+}
+
+// maxAbsentWindow returns the number of the last blocks where missed signs are counted, it can be changed by governance
+func (v *Validators) maxAbsentWindow() int {
+	if v.bus.Governance() == nil {
+		return ValidatorMaxAbsentWindow
+	}
+	return int(v.bus.Governance().ValidatorMaxAbsentWindow())
+}
+
+// daoCommission returns the percent of rewards sent to DAO, it can be changed by governance
+func (v *Validators) daoCommission() *big.Int {
+	if v.bus.Governance() == nil {
+		return big.NewInt(int64(dao.Commission))
+	}
+	return big.NewInt(0).SetUint64(v.bus.Governance().DAOCommission())
+}
+
 // GetValidators returns list of validators
 func (v *Validators) GetValidators() []*Validator {
 	v.lock.RLock()
@@ -431,7 +455,200 @@ func (v *Validators) PayRewardsV3(height uint64, period int64) (moreRewards *big
 	return moreRewards
 }
 
-// PayRewardsV5Fix2 distributes accumulated rewards between validator, delegators, DAO and developers addresses
+// PayRewardsV350 distributes accumulated rewards between validator, delegators, DAO treasury and developers addresses
+func (v *Validators) PayRewardsV350(height uint64, period int64) (moreRewards *big.Int) {
+	moreRewards = big.NewInt(0)
+	daoCommission := v.daoCommission()
+
+	vals := v.GetValidators()
+
+	calcReward, safeReward := v.bus.App().Reward()
+	var totalAccumRewards = big.NewInt(0)
+	for _, validator := range vals {
+		totalAccumRewards = totalAccumRewards.Add(totalAccumRewards, validator.GetAccumReward())
+	}
+
+	var totalStakes = big.NewInt(0)
+	if totalAccumRewards.Sign() != 1 {
+		for _, validator := range vals {
+			totalStakes = totalStakes.Add(totalStakes, validator.GetTotalBipStake())
+		}
+	}
+
+	for _, validator := range vals {
+		candidate := v.bus.Candidates().GetCandidate(validator.PubKey)
+
+		totalReward := big.NewInt(0).Set(validator.GetAccumReward())
+		remainder := big.NewInt(0).Set(validator.GetAccumReward())
+
+		// pay commission to DAO
+
+		DAOReward := big.NewInt(0).Set(totalReward)
+		DAOReward.Mul(DAOReward, daoCommission)
+		DAOReward.Div(DAOReward, big.NewInt(100))
+
+		// pay commission to Developers
+
+		DevelopersReward := big.NewInt(0).Set(totalReward)
+		DevelopersReward.Mul(DevelopersReward, big.NewInt(int64(developers.Commission)))
+		DevelopersReward.Div(DevelopersReward, big.NewInt(100))
+
+		totalReward.Sub(totalReward, DevelopersReward)
+		totalReward.Sub(totalReward, DAOReward)
+		remainder.Sub(remainder, DAOReward)
+		remainder.Sub(remainder, DevelopersReward)
+
+		// pay commission to validator
+		validatorReward := big.NewInt(0).Set(totalReward)
+		validatorReward.Mul(validatorReward, big.NewInt(int64(candidate.Commission)))
+		validatorReward.Div(validatorReward, big.NewInt(100))
+		totalReward.Sub(totalReward, validatorReward)
+
+		candidate.AddUpdate(types.GetBaseCoinID(), validatorReward, validatorReward, candidate.RewardAddress)
+		v.bus.Checker().AddCoin(types.GetBaseCoinID(), validatorReward)
+
+		remainder.Sub(remainder, validatorReward)
+		v.bus.Events().AddEvent(&eventsdb.RewardEvent{
+			Role:            eventsdb.RoleValidator.String(),
+			Address:         candidate.RewardAddress,
+			Amount:          validatorReward.String(),
+			ValidatorPubKey: validator.PubKey,
+			ForCoin:         0,
+		})
+
+		stakes := v.bus.Candidates().GetStakes(validator.PubKey)
+		for _, stake := range stakes {
+			if stake.BipValue.Sign() == 0 {
+				continue
+			}
+
+			reward := big.NewInt(0).Set(totalReward)
+			reward.Mul(reward, stake.BipValue)
+
+			reward.Div(reward, validator.GetTotalBipStake())
+
+			remainder.Sub(remainder, reward)
+
+			safeRewardVariable := big.NewInt(0).Set(reward)
+			if validator.bus.Accounts().IsX3Mining(stake.Owner, height) {
+				if totalAccumRewards.Sign() == 1 && validator.GetAccumReward().Sign() == 1 {
+					safeRewards := big.NewInt(0).Mul(safeReward, big.NewInt(period))
+					safeRewards.Mul(safeRewards, stake.BipValue)
+					safeRewards.Mul(safeRewards, big.NewInt(3))
+					safeRewards.Mul(safeRewards, validator.GetAccumReward())
+					safeRewards.Div(safeRewards, validator.GetTotalBipStake())
+					safeRewards.Div(safeRewards, totalAccumRewards)
+
+					taxDAOx3 := big.NewInt(0).Div(big.NewInt(0).Mul(safeRewards, daoCommission), big.NewInt(100))
+					taxDEVx3 := big.NewInt(0).Div(big.NewInt(0).Mul(safeRewards, big.NewInt(int64(developers.Commission))), big.NewInt(100))
+
+					safeRewards.Sub(safeRewards, taxDAOx3)
+					safeRewards.Sub(safeRewards, taxDEVx3)
+					safeRewards.Sub(safeRewards, big.NewInt(0).Div(big.NewInt(0).Mul(safeRewards, big.NewInt(int64(candidate.Commission))), big.NewInt(100)))
+
+					calcRewards := big.NewInt(0).Mul(calcReward, big.NewInt(period))
+					calcRewards.Mul(calcRewards, stake.BipValue)
+					calcRewards.Mul(calcRewards, validator.GetAccumReward())
+					calcRewards.Div(calcRewards, validator.GetTotalBipStake())
+					calcRewards.Div(calcRewards, totalAccumRewards)
+
+					taxDAO := big.NewInt(0).Div(big.NewInt(0).Mul(calcRewards, daoCommission), big.NewInt(100))
+					taxDEV := big.NewInt(0).Div(big.NewInt(0).Mul(calcRewards, big.NewInt(int64(developers.Commission))), big.NewInt(100))
+
+					calcRewards.Sub(calcRewards, taxDAO)
+					calcRewards.Sub(calcRewards, taxDEV)
+					calcRewards.Sub(calcRewards, big.NewInt(0).Div(big.NewInt(0).Mul(calcRewards, big.NewInt(int64(candidate.Commission))), big.NewInt(100)))
+
+					diffDAO := big.NewInt(0).Sub(taxDAOx3, taxDAO)
+					diffDEV := big.NewInt(0).Sub(taxDEVx3, taxDEV)
+					DAOReward.Add(DAOReward, diffDAO)
+					DevelopersReward.Add(DevelopersReward, diffDEV)
+
+					moreRewards.Add(moreRewards, diffDAO)
+					moreRewards.Add(moreRewards, diffDEV)
+
+					feeRewards := big.NewInt(0).Sub(reward, calcRewards)
+					safeRewardVariable.Set(big.NewInt(0).Add(safeRewards, feeRewards))
+				} else if totalAccumRewards.Sign() != 1 && validator.GetAccumReward().Sign() != 1 {
+					safeRewards := big.NewInt(0).Mul(safeReward, big.NewInt(period))
+					safeRewards.Mul(safeRewards, stake.BipValue)
+					safeRewards.Mul(safeRewards, big.NewInt(3))
+					safeRewards.Div(safeRewards, totalStakes)
+
+					taxDAO := big.NewInt(0).Div(big.NewInt(0).Mul(safeRewards, daoCommission), big.NewInt(100))
+					taxDEV := big.NewInt(0).Div(big.NewInt(0).Mul(safeRewards, big.NewInt(int64(developers.Commission))), big.NewInt(100))
+
+					DAOReward.Add(DAOReward, taxDAO)
+					DevelopersReward.Add(DevelopersReward, taxDEV)
+					moreRewards.Add(moreRewards, taxDAO)
+					moreRewards.Add(moreRewards, taxDEV)
+
+					safeRewards.Sub(safeRewards, taxDAO)
+					safeRewards.Sub(safeRewards, taxDEV)
+
+					safeRewards.Sub(safeRewards, big.NewInt(0).Div(big.NewInt(0).Mul(safeRewards, big.NewInt(int64(candidate.Commission))), big.NewInt(100)))
+
+					safeRewardVariable.Set(safeRewards)
+				}
+
+				if safeRewardVariable.Sign() < 1 {
+					continue
+				}
+
+				moreRewards.Add(moreRewards, new(big.Int).Sub(safeRewardVariable, reward))
+			}
+
+			if safeRewardVariable.Sign() < 1 {
+				continue
+			}
+
+			candidate.AddUpdate(types.GetBaseCoinID(), safeRewardVariable, safeRewardVariable, stake.Owner)
+			v.bus.Checker().AddCoin(types.GetBaseCoinID(), safeRewardVariable)
+
+			v.bus.Events().AddEvent(&eventsdb.RewardEvent{
+				Role:            eventsdb.RoleDelegator.String(),
+				Address:         stake.Owner,
+				Amount:          safeRewardVariable.String(),
+				ValidatorPubKey: validator.PubKey,
+				ForCoin:         uint64(stake.Coin),
+			})
+		}
+
+		{
+			v.bus.Treasury().AddIncome(types.GetBaseCoinID(), DAOReward)
+			v.bus.Events().AddEvent(&eventsdb.TreasuryIncomeEvent{
+				Coin:            uint64(types.GetBaseCoinID()),
+				Amount:          DAOReward.String(),
+				ValidatorPubKey: validator.PubKey,
+			})
+		}
+
+		{
+			candidate.AddUpdate(types.GetBaseCoinID(), DevelopersReward, DevelopersReward, developers.Address)
+			v.bus.Checker().AddCoin(types.GetBaseCoinID(), DevelopersReward)
+			v.bus.Events().AddEvent(&eventsdb.RewardEvent{
+				Role:            eventsdb.RoleDevelopers.String(),
+				Address:         developers.Address,
+				Amount:          DevelopersReward.String(),
+				ValidatorPubKey: validator.PubKey,
+				ForCoin:         0,
+			})
+		}
+
+		validator.SetAccumReward(big.NewInt(0))
+
+		if remainder.Sign() != -1 {
+			v.bus.App().AddTotalSlashed(remainder)
+		} else {
+			panic(fmt.Sprintf("Negative remainder: %s", remainder.String()))
+		}
+	}
+
+	return moreRewards
+}
+
+// PayRewardsV5Fix2
+// Deprecated
 func (v *Validators) PayRewardsV5Fix2(height uint64, period int64) (moreRewards *big.Int) {
 	moreRewards = big.NewInt(0)
 
@@ -248,6 +248,80 @@ func TestValidators_PayRewardsStake(t *testing.T) {
 		}
 	}
 }
+
+type testGovernance struct {
+	daoCommission uint64
+}
+
+func (g *testGovernance) UnbondPeriod() uint64              { return types.GetUnbondPeriod() }
+func (g *testGovernance) ValidatorMaxAbsentTimes() uint64   { return ValidatorMaxAbsentTimes }
+func (g *testGovernance) ValidatorMaxAbsentWindow() uint64  { return ValidatorMaxAbsentWindow }
+func (g *testGovernance) MaxDelegatorsPerCandidate() uint64 { return 1000 }
+func (g *testGovernance) DAOCommission() uint64             { return g.daoCommission }
+
+type testTreasury struct {
+	income *big.Int
+}
+
+func (t *testTreasury) AddIncome(coin types.CoinID, value *big.Int) {
+	t.income.Add(t.income, value)
+}
+
+func TestValidators_PayRewardsV350ToX3Mining(t *testing.T) {
+	t.Parallel()
+	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024, 1)
+	b := bus.NewBus()
+	accs := accounts.NewAccounts(b, mutableTree.GetLastImmutable())
+
+	b.SetAccounts(accounts.NewBus(accs))
+	b.SetChecker(checker.NewChecker(b))
+	b.SetEvents(eventsdb.NewEventsStore(db.NewMemDB()))
+	b.SetGovernance(&testGovernance{daoCommission: 20})
+	treasury := &testTreasury{income: big.NewInt(0)}
+	b.SetTreasury(treasury)
+	appBus := app.NewApp(b, mutableTree.GetLastImmutable())
+	appBus.SetReward(big.NewInt(100), big.NewInt(100))
+	b.SetApp(appBus)
+	validators := NewValidators(b, mutableTree.GetLastImmutable())
+	validators.SetValidators([]*Validator{NewValidator(
+		[32]byte{4},
+		types.NewBitArray(ValidatorMaxAbsentWindow),
+		big.NewInt(1000000),
+		big.NewInt(10),
+		true,
+		true,
+		true,
+		b)})
+	validators.GetByPublicKey([32]byte{4}).AddAccumReward(big.NewInt(990))
+	candidatesS := candidates.NewCandidates(b, mutableTree.GetLastImmutable())
+
+	candidatesS.Create([20]byte{1}, [20]byte{2}, [20]byte{3}, [32]byte{4}, 10, 0, 0)
+	candidatesS.SetOnline([32]byte{4})
+	candidatesS.SetStakes([32]byte{4}, []types.Stake{
+		{
+			Owner:    [20]byte{1},
+			Coin:     0,
+			Value:    "1000000000000000000000",
+			BipValue: "1000000000000000000000",
+		},
+	}, nil)
+	candidatesS.RecalculateStakes(1)
+	validators.SetNewValidators(candidatesS.GetNewCandidates(1))
+	accs.SetLockStakeUntilBlock([20]byte{1}, 100)
+
+	validators.PayRewardsV350(0, 1)
+	candidatesS.RecalculateStakesV2(1)
+
+	// 20% of the accumulated reward and of the x3 part of the safe reward
+	if treasury.income.String() != "240" {
+		t.Fatal("dao did not receive its commission", treasury.income)
+	}
+	// 10% of the accumulated reward and of the x3 part of the safe reward
+	if d := candidatesS.GetStakeOfAddress([32]byte{4}, developers.Address, 0).Value.String(); d != "120" {
+		t.Fatal("developers did not receive their commission", d)
+	}
+}
+
 func TestValidators_PayRewardsStakeAndUpdate(t *testing.T) {
 	t.Parallel()
 	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024, 1)
@@ -368,7 +442,7 @@ func TestValidators_SetValidatorAbsent(t *testing.T) {
 	if validator == nil {
 		t.Fatal("validator not found")
 	}
-	for i := uint64(0); i < validatorMaxAbsentTimes+1; i++ {
+	for i := uint64(0); i < ValidatorMaxAbsentTimes+1; i++ {
 		validators.SetValidatorAbsent(i, validator.tmAddress, nil)
 	}
 	if !validator.IsToDrop() {
@@ -7,12 +7,11 @@ import (
 	"math/big"
 	"sort"
 	"sync"
-	"sync/atomic"
 
 	"github.com/MinterTeam/minter-go-node/coreV2/state/bus"
 	"github.com/MinterTeam/minter-go-node/coreV2/types"
 	"github.com/MinterTeam/minter-go-node/rlp"
-	"github.com/cosmos/iavl"
+	"github.com/MinterTeam/minter-go-node/tree"
 )
 
 const mainPrefix = byte('w')
@@ -32,15 +31,15 @@ type WaitList struct {
 	list  map[types.Address]*Model
 	dirty map[types.Address]struct{}
 
-	db atomic.Value
+	db tree.AtomicTree
 
 	bus *bus.Bus
 
 	lock sync.RWMutex
 }
 
-func NewWaitList(stateBus *bus.Bus, db *iavl.ImmutableTree) *WaitList {
-	immutableTree := atomic.Value{}
+func NewWaitList(stateBus *bus.Bus, db tree.ImmutableTree) *WaitList {
+	immutableTree := tree.AtomicTree{}
 	if db != nil {
 		immutableTree.Store(db)
 	}
@@ -55,15 +54,11 @@ func NewWaitList(stateBus *bus.Bus, db *iavl.ImmutableTree) *WaitList {
 	return waitlist
 }
 
-func (wl *WaitList) immutableTree() *iavl.ImmutableTree {
-	db := wl.db.Load()
-	if db == nil {
-		return nil
-	}
-	return db.(*iavl.ImmutableTree)
+func (wl *WaitList) immutableTree() tree.ImmutableTree {
+	return wl.db.Load()
 }
 
-func (wl *WaitList) SetImmutableTree(immutableTree *iavl.ImmutableTree) {
+func (wl *WaitList) SetImmutableTree(immutableTree tree.ImmutableTree) {
 	wl.db.Store(immutableTree)
 }
 
@@ -92,6 +87,14 @@ func (wl *WaitList) Export(state *types.AppState) {
 }
 
 // Deprecated
+// unbondPeriod returns the number of blocks funds are frozen for, it can be changed by governance
+func (wl *WaitList) unbondPeriod() uint64 {
+	if wl.bus.Governance() == nil {
+		return types.GetUnbondPeriod()
+	}
+	return wl.bus.Governance().UnbondPeriod()
+}
+
 func (wl *WaitList) ExportV1(state *types.AppState, droppedIDs []uint32, height uint64) {
 	dropped := map[uint32]struct{}{}
 	for _, d := range droppedIDs {
@@ -105,7 +108,7 @@ func (wl *WaitList) ExportV1(state *types.AppState, droppedIDs []uint32, height
 			for _, w := range model.List {
 				if _, ok := dropped[w.CandidateId]; ok {
 					state.FrozenFunds = append(state.FrozenFunds, types.FrozenFund{
-						Height:       height + types.GetUnbondPeriod(),
+						Height:       height + wl.unbondPeriod(),
 						CandidateID:  0,
 						CandidateKey: nil,
 						Address:      address,
@@ -131,7 +134,7 @@ func (wl *WaitList) ExportV1(state *types.AppState, droppedIDs []uint32, height
 	})
 }
 
-func (wl *WaitList) Commit(db *iavl.MutableTree, version int64) error {
+func (wl *WaitList) Commit(db tree.MutableTree, version int64) error {
 	dirty := wl.getOrderedDirty()
 	for _, address := range dirty {
 		w := wl.getFromMap(address)
@@ -0,0 +1,158 @@
+package transaction
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/accounts"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+)
+
+// policyNeutralTypes are types of transactions allowed by the policy restricting outflows,
+// they do not move value of the sender to other accounts. Send and Multisend are checked against the policy
+var policyNeutralTypes = map[TxType]bool{
+	TypeSend:                    true,
+	TypeMultisend:               true,
+	TypeBatch:                   true,
+	TypeRedeemCheck:             true,
+	TypeRedeemChecks:            true,
+	TypeRedeemCheckV2:           true,
+	TypeRevokeCheck:             true,
+	TypeClaimHTLC:               true,
+	TypeRefundHTLC:              true,
+	TypeCancelStandingOrder:     true,
+	TypeRemoveLimitOrder:        true,
+	TypeApproveMultisigProposal: true,
+	TypeSubmitMultisigProposal:  true,
+	TypeRotateKey:               true,
+	TypeSetKeyGuardian:          true,
+	TypeSetAccountPolicy:        true,
+	TypeVoteCommission:          true,
+	TypeVoteUpdate:              true,
+	TypeVoteParamProposal:       true,
+	TypeVoteTreasuryProposal:    true,
+	TypeVoteAsDelegator:         true,
+}
+
+// policyItems returns the data of the tx and data of its batch items which are checked against the account policy
+func policyItems(tx *Transaction) []Data {
+	items := []Data{tx.decodedData}
+	if batch, ok := tx.decodedData.(*BatchData); ok {
+		batchItems, _ := batch.decodeItems()
+		items = append(items, batchItems...)
+	}
+
+	return items
+}
+
+// policyOutflows returns values of coins sent by Send and Multisend items of the tx and checks their recipients
+func policyOutflows(items []Data, policy accounts.Policy, sender types.Address) ([]accounts.PolicyLimit, *Response) {
+	var outflows []accounts.PolicyLimit
+	add := func(coin types.CoinID, to types.Address, value *big.Int) *Response {
+		if !policy.IsAllowedRecipient(to) {
+			return &Response{
+				Code: code.RecipientNotAllowedByPolicy,
+				Log:  fmt.Sprintf("Recipient %s is not allowed by policy of %s", to.String(), sender.String()),
+				Info: EncodeError(code.NewRecipientNotAllowedByPolicy(sender.String(), to.String())),
+			}
+		}
+
+		for i, outflow := range outflows {
+			if outflow.Coin == coin {
+				outflows[i].Value.Add(outflow.Value, value)
+				return nil
+			}
+		}
+		outflows = append(outflows, accounts.PolicyLimit{Coin: coin, Value: big.NewInt(0).Set(value)})
+		return nil
+	}
+
+	for _, item := range items {
+		switch data := item.(type) {
+		case *SendData:
+			if response := add(data.Coin, data.To, data.Value); response != nil {
+				return nil, response
+			}
+		case *MultisendData:
+			for _, send := range data.List {
+				if response := add(send.Coin, send.To, send.Value); response != nil {
+					return nil, response
+				}
+			}
+		}
+	}
+
+	return outflows, nil
+}
+
+// checkAccountPolicy enforces the policy of the sender before the tx is run and returns coins to be accounted in its outflow
+func checkAccountPolicy(tx *Transaction, context *state.CheckState, sender types.Address) ([]accounts.PolicyLimit, *Response) {
+	policy := context.Accounts().GetPolicy(sender)
+	if policy.IsEmpty() {
+		return nil, nil
+	}
+
+	items := policyItems(tx)
+	for _, item := range items {
+		if policy.IsForbiddenType(byte(item.TxType())) || (policy.IsOutflowRestricted() && !policyNeutralTypes[item.TxType()]) {
+			return nil, &Response{
+				Code: code.TxTypeForbiddenByPolicy,
+				Log:  fmt.Sprintf("Tx type %s is forbidden by policy of %s", item.TxType().String(), sender.String()),
+				Info: EncodeError(code.NewTxTypeForbiddenByPolicy(sender.String(), item.TxType().String())),
+			}
+		}
+	}
+
+	outflows, response := policyOutflows(items, policy, sender)
+	if response != nil {
+		return nil, response
+	}
+
+	for _, outflow := range outflows {
+		limit := policy.GetLimit(outflow.Coin)
+		if limit == nil {
+			continue
+		}
+
+		spent := big.NewInt(0).Add(context.Accounts().GetPolicySpent(sender, outflow.Coin), outflow.Value)
+		if spent.Cmp(limit) == 1 {
+			return nil, &Response{
+				Code: code.PolicyLimitExceeded,
+				Log:  fmt.Sprintf("Outflow limit of coin %s is exceeded by %s. Limit %s, wanted %s", outflow.Coin.String(), sender.String(), limit.String(), spent.String()),
+				Info: EncodeError(code.NewPolicyLimitExceeded(sender.String(), outflow.Coin.String(), limit.String(), spent.String())),
+			}
+		}
+	}
+
+	return outflows, nil
+}
+
+// checkIssuerPolicy checks the value of coin redeemed by the recipient from checks of the issuer against the policy of the issuer
+func checkIssuerPolicy(context *state.CheckState, issuer, recipient types.Address, coin types.CoinID, value *big.Int) *Response {
+	policy := context.Accounts().GetPolicy(issuer)
+	if !policy.IsAllowedRecipient(recipient) {
+		return &Response{
+			Code: code.RecipientNotAllowedByPolicy,
+			Log:  fmt.Sprintf("Recipient %s is not allowed by policy of %s", recipient.String(), issuer.String()),
+			Info: EncodeError(code.NewRecipientNotAllowedByPolicy(issuer.String(), recipient.String())),
+		}
+	}
+
+	limit := policy.GetLimit(coin)
+	if limit == nil {
+		return nil
+	}
+
+	spent := big.NewInt(0).Add(context.Accounts().GetPolicySpent(issuer, coin), value)
+	if spent.Cmp(limit) == 1 {
+		return &Response{
+			Code: code.PolicyLimitExceeded,
+			Log:  fmt.Sprintf("Outflow limit of coin %s is exceeded by %s. Limit %s, wanted %s", coin.String(), issuer.String(), limit.String(), spent.String()),
+			Info: EncodeError(code.NewPolicyLimitExceeded(issuer.String(), coin.String(), limit.String(), spent.String())),
+		}
+	}
+
+	return nil
+}
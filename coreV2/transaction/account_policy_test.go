@@ -0,0 +1,164 @@
+package transaction
+
+import (
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/accounts"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/crypto"
+	"github.com/MinterTeam/minter-go-node/helpers"
+)
+
+func TestAccountPolicyEnforcement(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+	coin := types.GetBaseCoinID()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(1000)))
+
+	cState.Accounts.SetPolicyState(addr, accounts.PolicyState{
+		Policy: accounts.Policy{
+			Limits:         []accounts.PolicyLimit{{Coin: coin, Value: helpers.BipToPip(big.NewInt(10))}},
+			Recipients:     []types.Address{{1}, {2}},
+			ForbiddenTypes: []byte{byte(TypeSellCoin)},
+		},
+	})
+
+	encodedTx, err := makeTestTx(TypeSellCoin, SellCoinData{CoinToSell: coin, ValueToSell: big.NewInt(1), CoinToBuy: 1, MinimumValueToBuy: big.NewInt(0)}, 1, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 0, &sync.Map{}, 0, false)
+	if response.Code != code.TxTypeForbiddenByPolicy {
+		t.Fatalf("Response code is not %d. Error %s", code.TxTypeForbiddenByPolicy, response.Log)
+	}
+
+	encodedTx, err = makeTestTx(TypeSend, SendData{Coin: coin, To: types.Address{3}, Value: big.NewInt(1)}, 1, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response = NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 0, &sync.Map{}, 0, false)
+	if response.Code != code.RecipientNotAllowedByPolicy {
+		t.Fatalf("Response code is not %d. Error %s", code.RecipientNotAllowedByPolicy, response.Log)
+	}
+
+	encodedTx, err = makeTestTx(TypeMultisend, MultisendData{List: []MultisendDataItem{
+		{Coin: coin, To: types.Address{1}, Value: helpers.BipToPip(big.NewInt(4))},
+		{Coin: coin, To: types.Address{2}, Value: helpers.BipToPip(big.NewInt(4))},
+	}}, 1, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response = NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 0, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not %d. Error %s", code.OK, response.Log)
+	}
+
+	if spent := cState.Accounts.GetPolicySpent(addr, coin); spent.Cmp(helpers.BipToPip(big.NewInt(8))) != 0 {
+		t.Fatalf("Spent value is not correct. Expected %s, got %s", helpers.BipToPip(big.NewInt(8)), spent)
+	}
+
+	encodedTx, err = makeTestTx(TypeSend, SendData{Coin: coin, To: types.Address{1}, Value: helpers.BipToPip(big.NewInt(3))}, 2, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response = NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 0, &sync.Map{}, 0, false)
+	if response.Code != code.PolicyLimitExceeded {
+		t.Fatalf("Response code is not %d. Error %s", code.PolicyLimitExceeded, response.Log)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestAccountPolicyToForbidOtherOutflows(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+	coin := types.GetBaseCoinID()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(1000)))
+
+	cState.Accounts.SetPolicyState(addr, accounts.PolicyState{
+		Policy: accounts.Policy{
+			Limits: []accounts.PolicyLimit{{Coin: coin, Value: helpers.BipToPip(big.NewInt(10))}},
+		},
+	})
+
+	encodedTx, err := makeTestTx(TypeCreateStandingOrder, CreateStandingOrderData{
+		Recipient: types.Address{1},
+		Coin:      coin,
+		Amount:    helpers.BipToPip(big.NewInt(100)),
+		Interval:  minStandingOrderInterval,
+		Count:     1,
+	}, 1, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 0, &sync.Map{}, 0, false)
+	if response.Code != code.TxTypeForbiddenByPolicy {
+		t.Fatalf("Response code is not %d. Error %s", code.TxTypeForbiddenByPolicy, response.Log)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestAccountPolicyToRedeemCheck(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+	coin := types.GetBaseCoinID()
+
+	issuerKey, _ := crypto.GenerateKey()
+	issuer := crypto.PubkeyToAddress(issuerKey.PublicKey)
+	cState.Accounts.AddBalance(issuer, coin, helpers.BipToPip(big.NewInt(1000)))
+
+	receiverKey, _ := crypto.GenerateKey()
+	receiver := crypto.PubkeyToAddress(receiverKey.PublicKey)
+
+	for i, item := range []struct {
+		policy accounts.Policy
+		code   uint32
+	}{
+		{policy: accounts.Policy{Recipients: []types.Address{{1}}}, code: code.RecipientNotAllowedByPolicy},
+		{policy: accounts.Policy{Limits: []accounts.PolicyLimit{{Coin: coin, Value: helpers.BipToPip(big.NewInt(5))}}}, code: code.PolicyLimitExceeded},
+		{policy: accounts.Policy{Limits: []accounts.PolicyLimit{{Coin: coin, Value: helpers.BipToPip(big.NewInt(10))}}}, code: code.OK},
+	} {
+		cState.Accounts.SetPolicyState(issuer, accounts.PolicyState{Policy: item.policy})
+
+		encodedTx, err := makeTestTx(TypeRedeemCheck, makeTestCheck(t, issuerKey, receiver, []byte{1}, helpers.BipToPip(big.NewInt(10))), 1, receiverKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		response := NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 0, &sync.Map{}, 0, false)
+		if response.Code != item.code {
+			t.Fatalf("Tx %d: Response code is not %d. Error %s", i, item.code, response.Log)
+		}
+	}
+
+	if spent := cState.Accounts.GetPolicySpent(issuer, coin); spent.Cmp(helpers.BipToPip(big.NewInt(10))) != 0 {
+		t.Fatalf("Spent value is not correct. Expected %s, got %s", helpers.BipToPip(big.NewInt(10)), spent)
+	}
+
+	if balance := cState.Accounts.GetBalance(receiver, coin); balance.Cmp(helpers.BipToPip(big.NewInt(10))) != 0 {
+		t.Fatalf("Receiver balance is not correct. Expected %s, got %s", helpers.BipToPip(big.NewInt(10)), balance)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
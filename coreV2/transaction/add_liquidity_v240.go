@@ -143,7 +143,7 @@ func (data AddLiquidityDataV240) Run(tx *Transaction, context state.Interface, r
 		if tx.GasCoin == data.Coin0 {
 			amount0.Add(amount0, commission)
 		}
-		if checkState.Accounts().GetBalance(sender, data.Coin0).Cmp(amount0) == -1 {
+		if checkState.Accounts().GetSpendableBalance(sender, data.Coin0).Cmp(amount0) == -1 {
 			symbol := checkState.Coins().GetCoin(data.Coin0).GetFullSymbol()
 			return Response{
 				Code: code.InsufficientFunds,
@@ -158,7 +158,7 @@ func (data AddLiquidityDataV240) Run(tx *Transaction, context state.Interface, r
 		if tx.GasCoin == data.Coin1 {
 			maximumVolume1.Add(maximumVolume1, commission)
 		}
-		if checkState.Accounts().GetBalance(sender, data.Coin1).Cmp(maximumVolume1) == -1 {
+		if checkState.Accounts().GetSpendableBalance(sender, data.Coin1).Cmp(maximumVolume1) == -1 {
 			symbol := checkState.Coins().GetCoin(data.Coin1).GetFullSymbol()
 			return Response{
 				Code: code.InsufficientFunds,
@@ -168,7 +168,7 @@ func (data AddLiquidityDataV240) Run(tx *Transaction, context state.Interface, r
 		}
 	}
 
-	if checkState.Accounts().GetBalance(sender, tx.GasCoin).Cmp(commission) == -1 {
+	if checkState.Accounts().GetSpendableBalance(sender, tx.GasCoin).Cmp(commission) == -1 {
 		return Response{
 			Code: code.InsufficientFunds,
 			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), commission.String(), gasCoin.GetFullSymbol()),
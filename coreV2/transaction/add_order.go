@@ -103,7 +103,7 @@ func (data AddLimitOrderData) Run(tx *Transaction, context state.Interface, rewa
 
 	amountSell := new(big.Int).Set(data.ValueToSell)
 	if tx.GasCoin != data.CoinToSell {
-		if checkState.Accounts().GetBalance(sender, tx.GasCoin).Cmp(commission) < 0 {
+		if checkState.Accounts().GetSpendableBalance(sender, tx.GasCoin).Cmp(commission) < 0 {
 			return Response{
 				Code: code.InsufficientFunds,
 				Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), commission.String(), gasCoin.GetFullSymbol()),
@@ -113,7 +113,7 @@ func (data AddLimitOrderData) Run(tx *Transaction, context state.Interface, rewa
 	} else {
 		amountSell.Add(amountSell, commission)
 	}
-	if checkState.Accounts().GetBalance(sender, data.CoinToSell).Cmp(amountSell) < 0 {
+	if checkState.Accounts().GetSpendableBalance(sender, data.CoinToSell).Cmp(amountSell) < 0 {
 		coin := checkState.Coins().GetCoin(data.CoinToSell)
 		return Response{
 			Code: code.InsufficientFunds,
@@ -0,0 +1,157 @@
+package transaction
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/commission"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/multisigproposals"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/swap"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	abcTypes "github.com/tendermint/tendermint/abci/types"
+)
+
+type ApproveMultisigProposalData struct {
+	ID uint64
+}
+
+func (data ApproveMultisigProposalData) Gas() int64 {
+	return gasApproveMultisigProposal
+}
+func (data ApproveMultisigProposalData) TxType() TxType {
+	return TypeApproveMultisigProposal
+}
+
+func (data ApproveMultisigProposalData) basicCheck(tx *Transaction, context *state.CheckState, block uint64) (*multisigproposals.Proposal, *Response) {
+	sender, _ := tx.Sender()
+
+	proposal := context.MultisigProposals().GetProposal(data.ID)
+	if proposal == nil || proposal.ExpireHeight <= block {
+		return nil, &Response{
+			Code: code.MultisigProposalNotExists,
+			Log:  "Multisig proposal does not exists",
+			Info: EncodeError(code.NewMultisigProposalNotExists(strconv.FormatUint(data.ID, 10))),
+		}
+	}
+
+	if response := checkMultisigMember(context, proposal.Multisig, sender); response != nil {
+		return nil, response
+	}
+
+	if proposal.IsApprovedBy(sender) {
+		return nil, &Response{
+			Code: code.MultisigProposalAlreadyApproved,
+			Log:  "Multisig proposal is already approved by sender",
+			Info: EncodeError(code.NewMultisigProposalAlreadyApproved(strconv.FormatUint(data.ID, 10), sender.String())),
+		}
+	}
+
+	return proposal, nil
+}
+
+func (data ApproveMultisigProposalData) String() string {
+	return fmt.Sprintf("APPROVE MULTISIG PROPOSAL id: %d", data.ID)
+}
+
+func (data ApproveMultisigProposalData) CommissionData(price *commission.Price) *big.Int {
+	return price.ApproveMultisigProposalPrice()
+}
+
+func (data ApproveMultisigProposalData) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, price *big.Int) Response {
+	sender, _ := tx.Sender()
+
+	var checkState *state.CheckState
+	var isCheck bool
+	if checkState, isCheck = context.(*state.CheckState); !isCheck {
+		checkState = state.NewCheckState(context.(*state.State))
+	}
+
+	proposal, response := data.basicCheck(tx, checkState, currentBlock)
+	if response != nil {
+		return *response
+	}
+
+	commissionInBaseCoin := price
+	commissionPoolSwapper := checkState.Swap().GetSwapper(tx.GasCoin, types.GetBaseCoinID())
+	gasCoin := checkState.Coins().GetCoin(tx.GasCoin)
+	commission, isGasCommissionFromPoolSwap, errResp := CalculateCommission(checkState, commissionPoolSwapper, gasCoin, commissionInBaseCoin)
+	if errResp != nil {
+		return *errResp
+	}
+
+	if checkState.Accounts().GetSpendableBalance(sender, tx.GasCoin).Cmp(commission) < 0 {
+		return Response{
+			Code: code.InsufficientFunds,
+			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), commission.String(), gasCoin.GetFullSymbol()),
+			Info: EncodeError(code.NewInsufficientFunds(sender.String(), commission.String(), gasCoin.GetFullSymbol(), gasCoin.ID().String())),
+		}
+	}
+
+	multisig := checkState.Accounts().GetAccount(proposal.Multisig).Multisig()
+	weight := multisigProposalWeight(&multisig, append(proposal.GetApprovals(), sender))
+	executed := weight >= multisig.Threshold
+	var proposalResponse Response
+	if executed {
+		proposalResponse = runMultisigProposal(context, proposal.Multisig, proposal.GasCoin, TxType(proposal.TxType), proposal.TxData, rewardPool, currentBlock)
+		if proposalResponse.Code != code.OK {
+			return proposalResponse
+		}
+	}
+
+	var tags []abcTypes.EventAttribute
+	if deliverState, ok := context.(*state.State); ok {
+		var tagsCom *tagPoolChange
+		if isGasCommissionFromPoolSwap {
+			var (
+				poolIDCom  uint32
+				detailsCom *swap.ChangeDetailsWithOrders
+				ownersCom  []*swap.OrderDetail
+			)
+			commission, commissionInBaseCoin, poolIDCom, detailsCom, ownersCom = deliverState.Swapper().PairSellWithOrders(tx.CommissionCoin(), types.GetBaseCoinID(), commission, big.NewInt(0))
+			tagsCom = &tagPoolChange{
+				PoolID:   poolIDCom,
+				CoinIn:   tx.CommissionCoin(),
+				ValueIn:  commission.String(),
+				CoinOut:  types.GetBaseCoinID(),
+				ValueOut: commissionInBaseCoin.String(),
+				Orders:   detailsCom,
+				// Sellers:  ownersCom,
+			}
+			for _, value := range ownersCom {
+				deliverState.Accounts.AddBalance(value.Owner, tx.CommissionCoin(), value.ValueBigInt)
+			}
+		} else if !tx.GasCoin.IsBaseCoin() {
+			deliverState.Coins.SubVolume(tx.CommissionCoin(), commission)
+			deliverState.Coins.SubReserve(tx.CommissionCoin(), commissionInBaseCoin)
+		}
+		deliverState.Accounts.SubBalance(sender, tx.GasCoin, commission)
+		rewardPool.Add(rewardPool, commissionInBaseCoin)
+
+		if executed {
+			deliverState.MultisigProposals.Delete(data.ID)
+		} else {
+			deliverState.MultisigProposals.Approve(data.ID, sender)
+		}
+		deliverState.Accounts.SetNonce(sender, tx.Nonce)
+
+		tags = []abcTypes.EventAttribute{
+			{Key: []byte("tx.commission_in_base_coin"), Value: []byte(commissionInBaseCoin.String())},
+			{Key: []byte("tx.commission_conversion"), Value: []byte(isGasCommissionFromPoolSwap.String()), Index: true},
+			{Key: []byte("tx.commission_amount"), Value: []byte(commission.String())},
+			{Key: []byte("tx.commission_details"), Value: []byte(tagsCom.string())},
+			{Key: []byte("tx.multisig"), Value: []byte(hex.EncodeToString(proposal.Multisig[:])), Index: true},
+			{Key: []byte("tx.multisig_proposal_id"), Value: []byte(strconv.FormatUint(data.ID, 10)), Index: true},
+			{Key: []byte("tx.multisig_proposal_executed"), Value: []byte(strconv.FormatBool(executed))},
+		}
+		tags = append(tags, nestedTags("multisig_proposal_tx", proposalResponse.Tags)...)
+	}
+
+	return Response{
+		Code: code.OK,
+		Tags: tags,
+	}
+}
@@ -0,0 +1,302 @@
+package transaction
+
+import (
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/accounts"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/crypto"
+	"github.com/MinterTeam/minter-go-node/helpers"
+	"github.com/MinterTeam/minter-go-node/rlp"
+)
+
+func TestMultisigProposalTx(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	multisig := types.Address{1}
+	privateKey1, _ := crypto.GenerateKey()
+	addr1 := crypto.PubkeyToAddress(privateKey1.PublicKey)
+	privateKey2, _ := crypto.GenerateKey()
+	addr2 := crypto.PubkeyToAddress(privateKey2.PublicKey)
+	privateKey3, _ := crypto.GenerateKey()
+	addr3 := crypto.PubkeyToAddress(privateKey3.PublicKey)
+	privateKey4, _ := crypto.GenerateKey()
+	addr4 := crypto.PubkeyToAddress(privateKey4.PublicKey)
+
+	cState.Accounts.CreateMultisig([]uint32{1, 2, 3}, []types.Address{addr1, addr2, addr3}, 3, multisig)
+
+	coin := types.GetBaseCoinID()
+	cState.Accounts.AddBalance(multisig, coin, helpers.BipToPip(big.NewInt(1000)))
+	for _, addr := range []types.Address{addr1, addr2, addr3, addr4} {
+		cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(1000)))
+	}
+
+	value := helpers.BipToPip(big.NewInt(10))
+	sendData, err := rlp.EncodeToBytes(SendData{
+		Coin:  coin,
+		To:    addr4,
+		Value: value,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	currentBlock := uint64(1)
+
+	encodedTx, err := makeTestTx(TypeSubmitMultisigProposal, SubmitMultisigProposalData{
+		Multisig:     multisig,
+		Type:         TypeSend,
+		Data:         sendData,
+		GasCoin:      coin,
+		ExpireHeight: currentBlock + 100,
+	}, 1, privateKey1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), currentBlock, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not %d. Error %s", code.OK, response.Log)
+	}
+
+	proposal := cState.MultisigProposals.GetProposal(1)
+	if proposal == nil {
+		t.Fatal("Proposal is not created")
+	}
+
+	if !proposal.IsApprovedBy(addr1) {
+		t.Fatal("Proposal is not approved by proposer")
+	}
+
+	encodedTx, err = makeTestTx(TypeApproveMultisigProposal, ApproveMultisigProposalData{ID: 1}, 2, privateKey1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response = NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), currentBlock, &sync.Map{}, 0, false)
+	if response.Code != code.MultisigProposalAlreadyApproved {
+		t.Fatalf("Response code is not %d. Error %s", code.MultisigProposalAlreadyApproved, response.Log)
+	}
+
+	encodedTx, err = makeTestTx(TypeApproveMultisigProposal, ApproveMultisigProposalData{ID: 1}, 1, privateKey4)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response = NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), currentBlock, &sync.Map{}, 0, false)
+	if response.Code != code.IsNotMultisigMember {
+		t.Fatalf("Response code is not %d. Error %s", code.IsNotMultisigMember, response.Log)
+	}
+
+	expectedBalance := big.NewInt(0).Sub(helpers.BipToPip(big.NewInt(1000)), cState.Commission.GetCommissions().FailedTx)
+	if balance := cState.Accounts.GetBalance(addr4, coin); balance.Cmp(expectedBalance) != 0 {
+		t.Fatalf("Target %s balance is not correct. Expected %s, got %s", addr4.String(), expectedBalance, balance)
+	}
+
+	encodedTx, err = makeTestTx(TypeApproveMultisigProposal, ApproveMultisigProposalData{ID: 1}, 1, privateKey2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response = NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), currentBlock, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not %d. Error %s", code.OK, response.Log)
+	}
+
+	expectedBalance.Add(expectedBalance, value)
+	if balance := cState.Accounts.GetBalance(addr4, coin); balance.Cmp(expectedBalance) != 0 {
+		t.Fatalf("Target %s balance is not correct. Expected %s, got %s", addr4.String(), expectedBalance, balance)
+	}
+
+	if nonce := cState.Accounts.GetNonce(multisig); nonce != 1 {
+		t.Fatalf("Multisig nonce is not correct. Expected %d, got %d", 1, nonce)
+	}
+
+	if cState.MultisigProposals.GetProposal(1) != nil {
+		t.Fatal("Executed proposal is not deleted")
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMultisigProposalTxToExpired(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	multisig := types.Address{1}
+	privateKey1, _ := crypto.GenerateKey()
+	addr1 := crypto.PubkeyToAddress(privateKey1.PublicKey)
+	privateKey2, _ := crypto.GenerateKey()
+	addr2 := crypto.PubkeyToAddress(privateKey2.PublicKey)
+
+	cState.Accounts.CreateMultisig([]uint32{1, 1}, []types.Address{addr1, addr2}, 2, multisig)
+
+	coin := types.GetBaseCoinID()
+	cState.Accounts.AddBalance(addr1, coin, helpers.BipToPip(big.NewInt(1000)))
+	cState.Accounts.AddBalance(addr2, coin, helpers.BipToPip(big.NewInt(1000)))
+
+	sendData, err := rlp.EncodeToBytes(SendData{
+		Coin:  coin,
+		To:    addr2,
+		Value: big.NewInt(1),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	encodedTx, err := makeTestTx(TypeSubmitMultisigProposal, SubmitMultisigProposalData{
+		Multisig:     multisig,
+		Type:         TypeSend,
+		Data:         sendData,
+		GasCoin:      coin,
+		ExpireHeight: 1,
+	}, 1, privateKey1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.WrongMultisigProposalExpireHeight {
+		t.Fatalf("Response code is not %d. Error %s", code.WrongMultisigProposalExpireHeight, response.Log)
+	}
+
+	encodedTx, err = makeTestTx(TypeSubmitMultisigProposal, SubmitMultisigProposalData{
+		Multisig:     multisig,
+		Type:         TypeSend,
+		Data:         sendData,
+		GasCoin:      coin,
+		ExpireHeight: 2,
+	}, 1, privateKey1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response = NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not %d. Error %s", code.OK, response.Log)
+	}
+
+	encodedTx, err = makeTestTx(TypeApproveMultisigProposal, ApproveMultisigProposalData{ID: 1}, 1, privateKey2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response = NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 2, &sync.Map{}, 0, false)
+	if response.Code != code.MultisigProposalNotExists {
+		t.Fatalf("Response code is not %d. Error %s", code.MultisigProposalNotExists, response.Log)
+	}
+
+	// the multisig has no funds, so the proposed tx fails along with the approval
+	response = NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.InsufficientFunds {
+		t.Fatalf("Response code is not %d. Error %s", code.InsufficientFunds, response.Log)
+	}
+
+	if proposal := cState.MultisigProposals.GetProposal(1); proposal == nil || proposal.IsApprovedBy(addr2) {
+		t.Fatal("Proposal is not correct")
+	}
+
+	cState.MultisigProposals.DeleteExpired(2)
+
+	if cState.MultisigProposals.GetProposal(1) != nil {
+		t.Fatal("Expired proposal is not deleted")
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMultisigProposalTxWithPolicyOfMultisig(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	multisig := types.Address{1}
+	privateKey1, _ := crypto.GenerateKey()
+	addr1 := crypto.PubkeyToAddress(privateKey1.PublicKey)
+	privateKey2, _ := crypto.GenerateKey()
+	addr2 := crypto.PubkeyToAddress(privateKey2.PublicKey)
+
+	cState.Accounts.CreateMultisig([]uint32{1, 1}, []types.Address{addr1, addr2}, 1, multisig)
+
+	coin := types.GetBaseCoinID()
+	cState.Accounts.AddBalance(multisig, coin, helpers.BipToPip(big.NewInt(1000)))
+	cState.Accounts.AddBalance(addr1, coin, helpers.BipToPip(big.NewInt(1000)))
+	cState.Accounts.SetPolicyState(multisig, accounts.PolicyState{
+		Policy: accounts.Policy{Recipients: []types.Address{addr2}},
+	})
+
+	sendData, err := rlp.EncodeToBytes(SendData{
+		Coin:  coin,
+		To:    types.Address{3},
+		Value: helpers.BipToPip(big.NewInt(10)),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	encodedTx, err := makeTestTx(TypeSubmitMultisigProposal, SubmitMultisigProposalData{
+		Multisig:     multisig,
+		Type:         TypeSend,
+		Data:         sendData,
+		GasCoin:      coin,
+		ExpireHeight: 100,
+	}, 1, privateKey1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.RecipientNotAllowedByPolicy {
+		t.Fatalf("Response code is not %d. Error %s", code.RecipientNotAllowedByPolicy, response.Log)
+	}
+
+	if balance := cState.Accounts.GetBalance(types.Address{3}, coin); balance.Sign() != 0 {
+		t.Fatalf("Target balance is not correct. Expected %s, got %s", "0", balance)
+	}
+
+	sendData, err = rlp.EncodeToBytes(SendData{
+		Coin:  coin,
+		To:    addr2,
+		Value: helpers.BipToPip(big.NewInt(10)),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	encodedTx, err = makeTestTx(TypeSubmitMultisigProposal, SubmitMultisigProposalData{
+		Multisig:     multisig,
+		Type:         TypeSend,
+		Data:         sendData,
+		GasCoin:      coin,
+		ExpireHeight: 100,
+	}, 1, privateKey1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response = NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not %d. Error %s", code.OK, response.Log)
+	}
+
+	var hasInnerTags bool
+	for _, tag := range response.Tags {
+		if string(tag.Key) == "tx.multisig_proposal_tx.to" {
+			hasInnerTags = true
+		}
+	}
+	if !hasInnerTags {
+		t.Fatal("Tags of the proposed tx are not returned")
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,282 @@
+package transaction
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/commission"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/swap"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/rlp"
+	abcTypes "github.com/tendermint/tendermint/abci/types"
+)
+
+const maxBatchItems = 16
+
+type BatchItem struct {
+	Type TxType
+	Data []byte
+}
+
+// BatchData runs an ordered list of txs of the sender with one nonce and one signature.
+// Items see changes of the preceding ones, the batch is applied only if all of its items succeed
+type BatchData struct {
+	Items []BatchItem
+}
+
+// Gas of the batch includes gas of its items twice, they are run on an overlay of the state and then on the state itself
+func (data BatchData) Gas() int64 {
+	var gas int64 = gasBatch
+	items, _ := data.decodeItems()
+	for _, item := range items {
+		gas += 2 * item.Gas()
+	}
+	return gas
+}
+func (data BatchData) TxType() TxType {
+	return TypeBatch
+}
+
+// decodeItems decodes data of the batch items up to the first incorrect one, nested batches are not allowed
+func (data BatchData) decodeItems() ([]Data, error) {
+	items := make([]Data, 0, len(data.Items))
+	for i, item := range data.Items {
+		if item.Type == TypeBatch {
+			return items, fmt.Errorf("item %d: tx type %s can not be batched", i, item.Type)
+		}
+
+		itemData, ok := GetData(item.Type)
+		if !ok {
+			return items, fmt.Errorf("item %d: tx type %s is not registered", i, item.Type)
+		}
+
+		if err := rlp.DecodeBytes(item.Data, itemData); err != nil {
+			return items, fmt.Errorf("item %d: %s", i, err)
+		}
+
+		items = append(items, itemData)
+	}
+
+	return items, nil
+}
+
+func (data BatchData) basicCheck(tx *Transaction, context *state.CheckState) ([]Data, *Response) {
+	if len(data.Items) == 0 || len(data.Items) > maxBatchItems {
+		return nil, &Response{
+			Code: code.WrongBatch,
+			Log:  fmt.Sprintf("Batch should contain from 1 to %d items", maxBatchItems),
+			Info: EncodeError(code.NewWrongBatch(strconv.Itoa(len(data.Items)), "")),
+		}
+	}
+
+	items, err := data.decodeItems()
+	if err != nil {
+		return nil, &Response{
+			Code: code.WrongBatch,
+			Log:  fmt.Sprintf("Incorrect batch: %s", err),
+			Info: EncodeError(code.NewWrongBatch(strconv.Itoa(len(items)), data.Items[len(items)].Type.String())),
+		}
+	}
+
+	return items, nil
+}
+
+func (data BatchData) String() string {
+	txTypes := make([]string, 0, len(data.Items))
+	for _, item := range data.Items {
+		txTypes = append(txTypes, item.Type.String())
+	}
+	return fmt.Sprintf("BATCH items: %s", strings.Join(txTypes, ","))
+}
+
+// CommissionData returns the price of the batch plus the sum of commissions of its items, the base and payload parts of the commission are paid once
+func (data BatchData) CommissionData(price *commission.Price) *big.Int {
+	total := new(big.Int).Set(price.BatchPrice())
+	items, _ := data.decodeItems()
+	for _, item := range items {
+		total.Add(total, item.CommissionData(price))
+	}
+	return total
+}
+
+func (data BatchData) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, price *big.Int) Response {
+	sender, _ := tx.Sender()
+
+	var checkState *state.CheckState
+	var isCheck bool
+	if checkState, isCheck = context.(*state.CheckState); !isCheck {
+		checkState = state.NewCheckState(context.(*state.State))
+	}
+
+	items, response := data.basicCheck(tx, checkState)
+	if response != nil {
+		return *response
+	}
+
+	commissionInBaseCoin := price
+	commissionPoolSwapper := checkState.Swap().GetSwapper(tx.GasCoin, types.GetBaseCoinID())
+	gasCoin := checkState.Coins().GetCoin(tx.GasCoin)
+	commission, isGasCommissionFromPoolSwap, errResp := CalculateCommission(checkState, commissionPoolSwapper, gasCoin, commissionInBaseCoin)
+	if errResp != nil {
+		return *errResp
+	}
+
+	if checkState.Accounts().GetSpendableBalance(sender, tx.GasCoin).Cmp(commission) < 0 {
+		return Response{
+			Code: code.InsufficientFunds,
+			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), commission.String(), gasCoin.GetFullSymbol()),
+			Info: EncodeError(code.NewInsufficientFunds(sender.String(), commission.String(), gasCoin.GetFullSymbol(), gasCoin.ID().String())),
+		}
+	}
+
+	// items are applied to an overlay of the state first, so every item is checked against results of the preceding ones
+	// and the state is changed only if all of them succeed
+	var overlay *state.State
+	deliverState, isDeliver := context.(*state.State)
+	if isDeliver {
+		overlay = deliverState.Overlay()
+	} else {
+		overlay = checkState.Overlay()
+	}
+	loadBatchStakes(overlay, items)
+	if _, response := data.apply(tx, sender, items, overlay, big.NewInt(0), currentBlock, commission, commissionInBaseCoin, isGasCommissionFromPoolSwap); response != nil {
+		return *response
+	}
+
+	var tags []abcTypes.EventAttribute
+	if isDeliver {
+		var response *Response
+		tags, response = data.apply(tx, sender, items, deliverState, rewardPool, currentBlock, commission, commissionInBaseCoin, isGasCommissionFromPoolSwap)
+		if response != nil {
+			return *response
+		}
+
+		deliverState.Accounts.SetNonce(sender, tx.Nonce)
+	}
+
+	return Response{
+		Code: code.OK,
+		Tags: tags,
+	}
+}
+
+// apply charges the commission of the batch and delivers its items one by one
+func (data BatchData) apply(tx *Transaction, sender types.Address, items []Data, deliverState *state.State, rewardPool *big.Int, currentBlock uint64, commission, commissionInBaseCoin *big.Int, isGasCommissionFromPoolSwap gasMethod) ([]abcTypes.EventAttribute, *Response) {
+	commission, commissionInBaseCoin = new(big.Int).Set(commission), new(big.Int).Set(commissionInBaseCoin)
+
+	var tagsCom *tagPoolChange
+	if isGasCommissionFromPoolSwap {
+		var (
+			poolIDCom  uint32
+			detailsCom *swap.ChangeDetailsWithOrders
+			ownersCom  []*swap.OrderDetail
+		)
+		commission, commissionInBaseCoin, poolIDCom, detailsCom, ownersCom = deliverState.Swapper().PairSellWithOrders(tx.CommissionCoin(), types.GetBaseCoinID(), commission, big.NewInt(0))
+		tagsCom = &tagPoolChange{
+			PoolID:   poolIDCom,
+			CoinIn:   tx.CommissionCoin(),
+			ValueIn:  commission.String(),
+			CoinOut:  types.GetBaseCoinID(),
+			ValueOut: commissionInBaseCoin.String(),
+			Orders:   detailsCom,
+			// Sellers:  ownersCom,
+		}
+		for _, value := range ownersCom {
+			deliverState.Accounts.AddBalance(value.Owner, tx.CommissionCoin(), value.ValueBigInt)
+		}
+	} else if !tx.GasCoin.IsBaseCoin() {
+		deliverState.Coins.SubVolume(tx.CommissionCoin(), commission)
+		deliverState.Coins.SubReserve(tx.CommissionCoin(), commissionInBaseCoin)
+	}
+	deliverState.Accounts.SubBalance(sender, tx.GasCoin, commission)
+	rewardPool.Add(rewardPool, commissionInBaseCoin)
+
+	tags := []abcTypes.EventAttribute{
+		{Key: []byte("tx.commission_in_base_coin"), Value: []byte(commissionInBaseCoin.String())},
+		{Key: []byte("tx.commission_conversion"), Value: []byte(isGasCommissionFromPoolSwap.String()), Index: true},
+		{Key: []byte("tx.commission_amount"), Value: []byte(commission.String())},
+		{Key: []byte("tx.commission_details"), Value: []byte(tagsCom.string())},
+		{Key: []byte("tx.batch_size"), Value: []byte(strconv.Itoa(len(items)))},
+	}
+
+	commissions := deliverState.Commission.GetCommissions()
+	for i, item := range items {
+		itemTx := batchItemTx(tx, data.Items[i], item)
+		response := item.Run(itemTx, deliverState, rewardPool, currentBlock, big.NewInt(0))
+		if response.Code != code.OK {
+			response = batchItemResponse(i, response)
+			return nil, &response
+		}
+		burnTags, errResp := burnForSymbol(deliverState, itemTx, commissions, rewardPool)
+		if errResp != nil {
+			response := batchItemResponse(i, *errResp)
+			return nil, &response
+		}
+		tags = append(tags, batchItemTags(i, append(response.Tags, burnTags...))...)
+	}
+
+	return tags, nil
+}
+
+// loadBatchStakes loads stakes of the candidates used by the batch items, stakes are not loaded in an overlay of the state
+func loadBatchStakes(overlay *state.State, items []Data) {
+	var pubKeys []types.Pubkey
+	for _, item := range items {
+		switch itemData := item.(type) {
+		case *DelegateDataV260:
+			pubKeys = append(pubKeys, itemData.PubKey)
+		case *UnbondDataV3:
+			pubKeys = append(pubKeys, itemData.PubKey)
+		case *MoveStakeData:
+			pubKeys = append(pubKeys, itemData.FromPubKey, itemData.ToPubKey)
+		}
+	}
+	for _, pubKey := range pubKeys {
+		if overlay.Candidates.Exists(pubKey) {
+			overlay.Candidates.LoadStakesOfCandidate(pubKey)
+		}
+	}
+}
+
+// batchItemTx returns a tx of the batch item signed by the sender of the batch
+func batchItemTx(tx *Transaction, item BatchItem, data Data) *Transaction {
+	itemTx := *tx
+	itemTx.Type = item.Type
+	itemTx.Data = item.Data
+	itemTx.decodedData = data
+	return &itemTx
+}
+
+func batchItemResponse(index int, response Response) Response {
+	response.Log = fmt.Sprintf("Batch item %d: %s", index, response.Log)
+	return response
+}
+
+// batchItemTags returns tags of the batch item prefixed with its index, the commission of items is paid by the batch
+func batchItemTags(index int, itemTags []abcTypes.EventAttribute) []abcTypes.EventAttribute {
+	tags := make([]abcTypes.EventAttribute, 0, len(itemTags))
+	for _, tag := range itemTags {
+		if strings.HasPrefix(string(tag.Key), "tx.commission_") {
+			continue
+		}
+		tags = append(tags, tag)
+	}
+	return nestedTags(fmt.Sprintf("batch_%d", index), tags)
+}
+
+// nestedTags returns tags of a tx run inside another tx with keys prefixed
+func nestedTags(prefix string, itemTags []abcTypes.EventAttribute) []abcTypes.EventAttribute {
+	tags := make([]abcTypes.EventAttribute, 0, len(itemTags))
+	for _, tag := range itemTags {
+		tags = append(tags, abcTypes.EventAttribute{
+			Key:   []byte(fmt.Sprintf("tx.%s.%s", prefix, strings.TrimPrefix(string(tag.Key), "tx."))),
+			Value: tag.Value,
+			Index: tag.Index,
+		})
+	}
+	return tags
+}
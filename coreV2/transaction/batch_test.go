@@ -0,0 +1,259 @@
+package transaction
+
+import (
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/crypto"
+	"github.com/MinterTeam/minter-go-node/helpers"
+	"github.com/MinterTeam/minter-go-node/rlp"
+)
+
+func TestBatchTx(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	coin := types.GetBaseCoinID()
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(1000)))
+
+	value := helpers.BipToPip(big.NewInt(10))
+	var items []BatchItem
+	for _, to := range []types.Address{{1}, {2}} {
+		sendData, err := rlp.EncodeToBytes(SendData{
+			Coin:  coin,
+			To:    to,
+			Value: value,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		items = append(items, BatchItem{Type: TypeSend, Data: sendData})
+	}
+
+	encodedTx, err := makeTestTx(TypeBatch, BatchData{Items: items}, 1, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not %d. Error %s", code.OK, response.Log)
+	}
+
+	for _, to := range []types.Address{{1}, {2}} {
+		if balance := cState.Accounts.GetBalance(to, coin); balance.Cmp(value) != 0 {
+			t.Fatalf("Target %s balance is not correct. Expected %s, got %s", to.String(), value, balance)
+		}
+	}
+
+	commissions := cState.Commission.GetCommissions()
+	commission := big.NewInt(0).Mul(commissions.Send, big.NewInt(2))
+	commission.Add(commission, commissions.BatchPrice())
+	expectedBalance := big.NewInt(0).Sub(helpers.BipToPip(big.NewInt(1000)), big.NewInt(0).Mul(value, big.NewInt(2)))
+	expectedBalance.Sub(expectedBalance, commission)
+	if balance := cState.Accounts.GetBalance(addr, coin); balance.Cmp(expectedBalance) != 0 {
+		t.Fatalf("Sender balance is not correct. Expected %s, got %s", expectedBalance, balance)
+	}
+
+	if nonce := cState.Accounts.GetNonce(addr); nonce != 1 {
+		t.Fatalf("Sender nonce is not correct. Expected %d, got %d", 1, nonce)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestBatchTxToFailedItem(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	coin := types.GetBaseCoinID()
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(1000)))
+
+	sendData, err := rlp.EncodeToBytes(SendData{
+		Coin:  coin,
+		To:    types.Address{1},
+		Value: helpers.BipToPip(big.NewInt(10)),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tooBigSendData, err := rlp.EncodeToBytes(SendData{
+		Coin:  coin,
+		To:    types.Address{2},
+		Value: helpers.BipToPip(big.NewInt(10000)),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	encodedTx, err := makeTestTx(TypeBatch, BatchData{Items: []BatchItem{
+		{Type: TypeSend, Data: sendData},
+		{Type: TypeSend, Data: tooBigSendData},
+	}}, 1, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.InsufficientFunds {
+		t.Fatalf("Response code is not %d. Error %s", code.InsufficientFunds, response.Log)
+	}
+
+	if balance := cState.Accounts.GetBalance(types.Address{1}, coin); balance.Sign() != 0 {
+		t.Fatalf("Target balance is not correct. Expected %s, got %s", "0", balance)
+	}
+
+	expectedBalance := big.NewInt(0).Sub(helpers.BipToPip(big.NewInt(1000)), cState.Commission.GetCommissions().FailedTx)
+	if balance := cState.Accounts.GetBalance(addr, coin); balance.Cmp(expectedBalance) != 0 {
+		t.Fatalf("Sender balance is not correct. Expected %s, got %s", expectedBalance, balance)
+	}
+
+	batchData, err := rlp.EncodeToBytes(BatchData{Items: []BatchItem{{Type: TypeSend, Data: sendData}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	encodedTx, err = makeTestTx(TypeBatch, BatchData{Items: []BatchItem{
+		{Type: TypeSend, Data: sendData},
+		{Type: TypeBatch, Data: batchData},
+	}}, 1, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response = NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.WrongBatch {
+		t.Fatalf("Response code is not %d. Error %s", code.WrongBatch, response.Log)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestBatchTxToInsufficientFundsForSequence(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	coin := types.GetBaseCoinID()
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(1000)))
+
+	value := helpers.BipToPip(big.NewInt(600))
+	var items []BatchItem
+	for _, to := range []types.Address{{1}, {2}} {
+		sendData, err := rlp.EncodeToBytes(SendData{
+			Coin:  coin,
+			To:    to,
+			Value: value,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		items = append(items, BatchItem{Type: TypeSend, Data: sendData})
+	}
+
+	encodedTx, err := makeTestTx(TypeBatch, BatchData{Items: items}, 1, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.InsufficientFunds {
+		t.Fatalf("Response code is not %d. Error %s", code.InsufficientFunds, response.Log)
+	}
+
+	for _, to := range []types.Address{{1}, {2}} {
+		if balance := cState.Accounts.GetBalance(to, coin); balance.Sign() != 0 {
+			t.Fatalf("Target %s balance is not correct. Expected %s, got %s", to.String(), "0", balance)
+		}
+	}
+
+	expectedBalance := big.NewInt(0).Sub(helpers.BipToPip(big.NewInt(1000)), cState.Commission.GetCommissions().FailedTx)
+	if balance := cState.Accounts.GetBalance(addr, coin); balance.Cmp(expectedBalance) != 0 {
+		t.Fatalf("Sender balance is not correct. Expected %s, got %s", expectedBalance, balance)
+	}
+
+	if nonce := cState.Accounts.GetNonce(addr); nonce != 0 {
+		t.Fatalf("Sender nonce is not correct. Expected %d, got %d", 0, nonce)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestBatchTxWithDependentItems(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	coin := types.GetBaseCoinID()
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(1000000)))
+
+	symbol := types.StrToCoinSymbol("ABCDEF")
+	amount := helpers.BipToPip(big.NewInt(100))
+	createCoinData, err := rlp.EncodeToBytes(CreateCoinData{
+		Name:                 "My Test Coin",
+		Symbol:               symbol,
+		InitialAmount:        amount,
+		InitialReserve:       helpers.BipToPip(big.NewInt(10000)),
+		ConstantReserveRatio: 50,
+		MaxSupply:            big.NewInt(0).Mul(amount, big.NewInt(10)),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	newCoin := cState.App.GetNextCoinID()
+	value := helpers.BipToPip(big.NewInt(10))
+	sendData, err := rlp.EncodeToBytes(SendData{
+		Coin:  newCoin,
+		To:    types.Address{1},
+		Value: value,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	encodedTx, err := makeTestTx(TypeBatch, BatchData{Items: []BatchItem{
+		{Type: TypeCreateCoin, Data: createCoinData},
+		{Type: TypeSend, Data: sendData},
+	}}, 1, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not %d. Error %s", code.OK, response.Log)
+	}
+
+	if stateCoin := cState.Coins.GetCoinBySymbol(symbol, 0); stateCoin == nil || stateCoin.ID() != newCoin {
+		t.Fatalf("Coin %s is not created", symbol)
+	}
+
+	if balance := cState.Accounts.GetBalance(types.Address{1}, newCoin); balance.Cmp(value) != 0 {
+		t.Fatalf("Target balance is not correct. Expected %s, got %s", value, balance)
+	}
+
+	expectedBalance := big.NewInt(0).Sub(amount, value)
+	if balance := cState.Accounts.GetBalance(addr, newCoin); balance.Cmp(expectedBalance) != 0 {
+		t.Fatalf("Sender balance is not correct. Expected %s, got %s", expectedBalance, balance)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
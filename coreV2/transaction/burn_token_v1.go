@@ -97,7 +97,7 @@ func (data BurnTokenDataV1) Run(tx *Transaction, context state.Interface, reward
 		return *errResp
 	}
 
-	if checkState.Accounts().GetBalance(sender, tx.GasCoin).Cmp(commission) == -1 {
+	if checkState.Accounts().GetSpendableBalance(sender, tx.GasCoin).Cmp(commission) == -1 {
 		return Response{
 			Code: code.InsufficientFunds,
 			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), commission.String(), gasCoin.GetFullSymbol()),
@@ -110,7 +110,7 @@ func (data BurnTokenDataV1) Run(tx *Transaction, context state.Interface, reward
 		value.Add(value, commission)
 	}
 
-	if checkState.Accounts().GetBalance(sender, data.Coin).Cmp(value) == -1 {
+	if checkState.Accounts().GetSpendableBalance(sender, data.Coin).Cmp(value) == -1 {
 		symbol := checkState.Coins().GetCoin(data.Coin).GetFullSymbol()
 		return Response{
 			Code: code.InsufficientFunds,
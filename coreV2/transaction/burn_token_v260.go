@@ -83,7 +83,7 @@ func (data BurnTokenDataV260) Run(tx *Transaction, context state.Interface, rewa
 		return *errResp
 	}
 
-	if checkState.Accounts().GetBalance(sender, tx.GasCoin).Cmp(commission) == -1 {
+	if checkState.Accounts().GetSpendableBalance(sender, tx.GasCoin).Cmp(commission) == -1 {
 		return Response{
 			Code: code.InsufficientFunds,
 			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), commission.String(), gasCoin.GetFullSymbol()),
@@ -96,7 +96,7 @@ func (data BurnTokenDataV260) Run(tx *Transaction, context state.Interface, rewa
 		value.Add(value, commission)
 	}
 
-	if checkState.Accounts().GetBalance(sender, data.Coin).Cmp(value) == -1 {
+	if checkState.Accounts().GetSpendableBalance(sender, data.Coin).Cmp(value) == -1 {
 		symbol := checkState.Coins().GetCoin(data.Coin).GetFullSymbol()
 		return Response{
 			Code: code.InsufficientFunds,
@@ -183,7 +183,7 @@ func (data BuyCoinData) Run(tx *Transaction, context state.Interface, rewardPool
 	if tx.GasCoin == coinToSell {
 		spendInGasCoin.Add(spendInGasCoin, value)
 	} else {
-		if checkState.Accounts().GetBalance(sender, data.CoinToSell).Cmp(value) < 0 {
+		if checkState.Accounts().GetSpendableBalance(sender, data.CoinToSell).Cmp(value) < 0 {
 			return Response{
 				Code: code.InsufficientFunds,
 				Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), value.String(), coinFrom.GetFullSymbol()),
@@ -192,7 +192,7 @@ func (data BuyCoinData) Run(tx *Transaction, context state.Interface, rewardPool
 		}
 	}
 
-	if checkState.Accounts().GetBalance(sender, tx.GasCoin).Cmp(spendInGasCoin) < 0 {
+	if checkState.Accounts().GetSpendableBalance(sender, tx.GasCoin).Cmp(spendInGasCoin) < 0 {
 		return Response{
 			Code: code.InsufficientFunds,
 			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), spendInGasCoin.String(), gasCoin.GetFullSymbol()),
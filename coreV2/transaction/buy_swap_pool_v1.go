@@ -144,7 +144,7 @@ func (data BuySwapPoolDataV1) Run(tx *Transaction, context state.Interface, rewa
 	if tx.GasCoin == coinToSell {
 		amount0.Add(amount0, commission)
 	}
-	if checkState.Accounts().GetBalance(sender, coinToSell).Cmp(amount0) == -1 {
+	if checkState.Accounts().GetSpendableBalance(sender, coinToSell).Cmp(amount0) == -1 {
 		symbol := checkState.Coins().GetCoin(coinToSell).GetFullSymbol()
 		return Response{
 			Code: code.InsufficientFunds,
@@ -153,7 +153,7 @@ func (data BuySwapPoolDataV1) Run(tx *Transaction, context state.Interface, rewa
 		}
 	}
 
-	if checkState.Accounts().GetBalance(sender, tx.GasCoin).Cmp(commission) == -1 {
+	if checkState.Accounts().GetSpendableBalance(sender, tx.GasCoin).Cmp(commission) == -1 {
 		return Response{
 			Code: code.InsufficientFunds,
 			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), commission.String(), gasCoin.GetFullSymbol()),
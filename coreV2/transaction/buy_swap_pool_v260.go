@@ -108,6 +108,7 @@ func (data BuySwapPoolDataV260) Run(tx *Transaction, context state.Interface, re
 	}
 
 	reverseCoinIds(data.Coins)
+	valueToBuyGross := transferFeeGrossOf(checkState, data.Coins[0], sender, data.ValueToBuy)
 
 	var calculatedAmountToSell *big.Int
 	lastIteration := len(data.Coins[1:]) - 1
@@ -115,7 +116,7 @@ func (data BuySwapPoolDataV260) Run(tx *Transaction, context state.Interface, re
 		checkDuplicatePools := map[uint32]struct{}{}
 		coinToBuy := data.Coins[0]
 		coinToBuyModel := checkState.Coins().GetCoin(coinToBuy)
-		valueToBuy := big.NewInt(0).Set(data.ValueToBuy)
+		valueToBuy := big.NewInt(0).Set(valueToBuyGross)
 		valueToSell := maxCoinSupply
 		for i, coinToSell := range data.Coins[1:] {
 			swapper := checkState.Swap().GetSwapper(coinToSell, coinToBuy)
@@ -170,7 +171,7 @@ func (data BuySwapPoolDataV260) Run(tx *Transaction, context state.Interface, re
 	if tx.GasCoin == coinToSell {
 		amount0.Add(amount0, commission)
 	}
-	if checkState.Accounts().GetBalance(sender, coinToSell).Cmp(amount0) == -1 {
+	if checkState.Accounts().GetSpendableBalance(sender, coinToSell).Cmp(amount0) == -1 {
 		symbol := checkState.Coins().GetCoin(coinToSell).GetFullSymbol()
 		return Response{
 			Code: code.InsufficientFunds,
@@ -179,7 +180,7 @@ func (data BuySwapPoolDataV260) Run(tx *Transaction, context state.Interface, re
 		}
 	}
 
-	if checkState.Accounts().GetBalance(sender, tx.GasCoin).Cmp(commission) == -1 {
+	if checkState.Accounts().GetSpendableBalance(sender, tx.GasCoin).Cmp(commission) == -1 {
 		return Response{
 			Code: code.InsufficientFunds,
 			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), commission.String(), gasCoin.GetFullSymbol()),
@@ -216,9 +217,10 @@ func (data BuySwapPoolDataV260) Run(tx *Transaction, context state.Interface, re
 		rewardPool.Add(rewardPool, commissionInBaseCoin)
 
 		coinToBuy := data.Coins[0]
-		valueToBuy := data.ValueToBuy
+		valueToBuy := valueToBuyGross
 
 		var poolIDs tagPoolsChange
+		var transferFee *big.Int
 
 		for i, coinToSell := range data.Coins[1:] {
 			amountIn, amountOut, poolID, details, owners := deliverState.Swapper().PairBuyWithOrders(coinToSell, coinToBuy, maxCoinSupply, valueToBuy)
@@ -239,7 +241,9 @@ func (data BuySwapPoolDataV260) Run(tx *Transaction, context state.Interface, re
 			poolIDs = append(poolIDs, tags)
 
 			if i == 0 {
-				deliverState.Accounts.AddBalance(sender, coinToBuy, amountOut)
+				var received *big.Int
+				received, transferFee = payTransferFee(deliverState, coinToBuy, sender, amountOut)
+				deliverState.Accounts.AddBalance(sender, coinToBuy, received)
 			}
 
 			valueToBuy = amountIn
@@ -264,6 +268,9 @@ func (data BuySwapPoolDataV260) Run(tx *Transaction, context state.Interface, re
 			{Key: []byte("tx.return"), Value: []byte(amountIn.String())},
 			{Key: []byte("tx.pools"), Value: []byte(poolIDs.string())},
 		}
+		if transferFee.Sign() != 0 {
+			tags = append(tags, abcTypes.EventAttribute{Key: []byte("tx.transfer_fee"), Value: []byte(transferFee.String())})
+		}
 	}
 
 	return Response{
@@ -0,0 +1,55 @@
+package transaction
+
+import (
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/crypto"
+	"github.com/MinterTeam/minter-go-node/helpers"
+)
+
+func TestCancelStandingOrderTx(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+	coin := types.GetBaseCoinID()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(1000)))
+
+	otherKey, _ := crypto.GenerateKey()
+	cState.Accounts.AddBalance(crypto.PubkeyToAddress(otherKey.PublicKey), coin, helpers.BipToPip(big.NewInt(1000)))
+
+	id := cState.StandingOrders.CreateOrder(addr, types.Address{1}, coin, helpers.BipToPip(big.NewInt(10)), 10, 10, 2)
+
+	encodedTx, err := makeTestTx(TypeCancelStandingOrder, CancelStandingOrderData{ID: id}, 1, otherKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.IsNotOwnerOfStandingOrder {
+		t.Fatalf("Response code is not %d. Error %s", code.IsNotOwnerOfStandingOrder, response.Log)
+	}
+
+	encodedTx, err = makeTestTx(TypeCancelStandingOrder, CancelStandingOrderData{ID: id}, 1, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response = NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not %d. Error %s", code.OK, response.Log)
+	}
+
+	if cState.StandingOrders.GetOrder(id) != nil {
+		t.Fatal("Standing order is not cancelled")
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
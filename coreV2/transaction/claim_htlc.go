@@ -0,0 +1,158 @@
+package transaction
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/commission"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/htlcs"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/swap"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	abcTypes "github.com/tendermint/tendermint/abci/types"
+)
+
+const maxHTLCPreimageLength = 64
+
+// ClaimHTLCData pays coins of the contract to its recipient, the transaction can be sent by anyone knowing the preimage
+type ClaimHTLCData struct {
+	ID       uint64
+	Preimage []byte
+}
+
+func (data ClaimHTLCData) TxType() TxType {
+	return TypeClaimHTLC
+}
+
+func (data ClaimHTLCData) Gas() int64 {
+	return gasClaimHTLC
+}
+
+func (data ClaimHTLCData) basicCheck(tx *Transaction, context *state.CheckState, block uint64) (*htlcs.HTLC, *Response) {
+	if len(data.Preimage) == 0 || len(data.Preimage) > maxHTLCPreimageLength {
+		return nil, &Response{
+			Code: code.DecodeError,
+			Log:  "Incorrect tx data",
+			Info: EncodeError(code.NewDecodeError()),
+		}
+	}
+
+	htlc := context.HTLCs().GetHTLC(data.ID)
+	if htlc == nil {
+		return nil, &Response{
+			Code: code.HTLCNotExists,
+			Log:  "HTLC does not exists",
+			Info: EncodeError(code.NewHTLCNotExists(strconv.FormatUint(data.ID, 10))),
+		}
+	}
+
+	if htlc.Timeout <= block {
+		return nil, &Response{
+			Code: code.WrongHTLCTimeout,
+			Log:  "HTLC is timed out and can only be refunded",
+			Info: EncodeError(code.NewWrongHTLCTimeout(strconv.FormatUint(htlc.Timeout, 10), strconv.FormatUint(block, 10))),
+		}
+	}
+
+	if sha256.Sum256(data.Preimage) != htlc.Hashlock {
+		return nil, &Response{
+			Code: code.WrongHTLCPreimage,
+			Log:  "Preimage does not match hashlock of HTLC",
+			Info: EncodeError(code.NewWrongHTLCPreimage(hex.EncodeToString(htlc.Hashlock[:]))),
+		}
+	}
+
+	return htlc, nil
+}
+
+func (data ClaimHTLCData) String() string {
+	return fmt.Sprintf("CLAIM HTLC id: %d", data.ID)
+}
+
+func (data ClaimHTLCData) CommissionData(price *commission.Price) *big.Int {
+	return price.ClaimHTLCPrice()
+}
+
+func (data ClaimHTLCData) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, price *big.Int) Response {
+	sender, _ := tx.Sender()
+	var checkState *state.CheckState
+	var isCheck bool
+	if checkState, isCheck = context.(*state.CheckState); !isCheck {
+		checkState = state.NewCheckState(context.(*state.State))
+	}
+
+	htlc, response := data.basicCheck(tx, checkState, currentBlock)
+	if response != nil {
+		return *response
+	}
+
+	commissionInBaseCoin := price
+	commissionPoolSwapper := checkState.Swap().GetSwapper(tx.GasCoin, types.GetBaseCoinID())
+	gasCoin := checkState.Coins().GetCoin(tx.GasCoin)
+	commission, isGasCommissionFromPoolSwap, errResp := CalculateCommission(checkState, commissionPoolSwapper, gasCoin, commissionInBaseCoin)
+	if errResp != nil {
+		return *errResp
+	}
+
+	if checkState.Accounts().GetSpendableBalance(sender, tx.GasCoin).Cmp(commission) < 0 {
+		return Response{
+			Code: code.InsufficientFunds,
+			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), commission.String(), gasCoin.GetFullSymbol()),
+			Info: EncodeError(code.NewInsufficientFunds(sender.String(), commission.String(), gasCoin.GetFullSymbol(), gasCoin.ID().String())),
+		}
+	}
+
+	var tags []abcTypes.EventAttribute
+	if deliverState, ok := context.(*state.State); ok {
+		var tagsCom *tagPoolChange
+		if isGasCommissionFromPoolSwap {
+			var (
+				poolIDCom  uint32
+				detailsCom *swap.ChangeDetailsWithOrders
+				ownersCom  []*swap.OrderDetail
+			)
+			commission, commissionInBaseCoin, poolIDCom, detailsCom, ownersCom = deliverState.Swapper().PairSellWithOrders(tx.CommissionCoin(), types.GetBaseCoinID(), commission, big.NewInt(0))
+			tagsCom = &tagPoolChange{
+				PoolID:   poolIDCom,
+				CoinIn:   tx.CommissionCoin(),
+				ValueIn:  commission.String(),
+				CoinOut:  types.GetBaseCoinID(),
+				ValueOut: commissionInBaseCoin.String(),
+				Orders:   detailsCom,
+				// Sellers:  ownersCom,
+			}
+			for _, value := range ownersCom {
+				deliverState.Accounts.AddBalance(value.Owner, tx.CommissionCoin(), value.ValueBigInt)
+			}
+		} else if !tx.GasCoin.IsBaseCoin() {
+			deliverState.Coins.SubVolume(tx.CommissionCoin(), commission)
+			deliverState.Coins.SubReserve(tx.CommissionCoin(), commissionInBaseCoin)
+		}
+		deliverState.Accounts.SubBalance(sender, tx.GasCoin, commission)
+		rewardPool.Add(rewardPool, commissionInBaseCoin)
+
+		deliverState.HTLCs.Claim(data.ID, data.Preimage)
+		deliverState.Accounts.SetNonce(sender, tx.Nonce)
+
+		tags = []abcTypes.EventAttribute{
+			{Key: []byte("tx.commission_in_base_coin"), Value: []byte(commissionInBaseCoin.String())},
+			{Key: []byte("tx.commission_conversion"), Value: []byte(isGasCommissionFromPoolSwap.String()), Index: true},
+			{Key: []byte("tx.commission_amount"), Value: []byte(commission.String())},
+			{Key: []byte("tx.commission_details"), Value: []byte(tagsCom.string())},
+			{Key: []byte("tx.to"), Value: []byte(hex.EncodeToString(htlc.Recipient[:])), Index: true},
+			{Key: []byte("tx.coin_id"), Value: []byte(htlc.Coin.String()), Index: true},
+			{Key: []byte("tx.htlc_id"), Value: []byte(strconv.FormatUint(data.ID, 10)), Index: true},
+			{Key: []byte("tx.htlc_hashlock"), Value: []byte(hex.EncodeToString(htlc.Hashlock[:])), Index: true},
+			{Key: []byte("tx.htlc_preimage"), Value: []byte(hex.EncodeToString(data.Preimage))},
+		}
+	}
+
+	return Response{
+		Code: code.OK,
+		Tags: tags,
+	}
+}
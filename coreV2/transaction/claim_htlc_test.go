@@ -0,0 +1,103 @@
+package transaction
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	eventsdb "github.com/MinterTeam/minter-go-node/coreV2/events"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/crypto"
+	"github.com/MinterTeam/minter-go-node/helpers"
+)
+
+func createTestHTLC(t *testing.T, cState *state.State, privateKey *ecdsa.PrivateKey, recipient types.Address, value *big.Int, preimage []byte, timeout uint64) {
+	encodedTx, err := makeTestTx(TypeCreateHTLC, CreateHTLCData{
+		Recipient: recipient,
+		Coin:      types.GetBaseCoinID(),
+		Value:     value,
+		Hashlock:  sha256.Sum256(preimage),
+		Timeout:   timeout,
+	}, 1, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not %d. Error %s", code.OK, response.Log)
+	}
+}
+
+func TestClaimHTLCTx(t *testing.T) {
+	t.Parallel()
+	events := &eventsdb.MockEvents{}
+	cState := getState(events)
+	coin := types.GetBaseCoinID()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(1000)))
+
+	recipientKey, _ := crypto.GenerateKey()
+	recipient := crypto.PubkeyToAddress(recipientKey.PublicKey)
+	cState.Accounts.AddBalance(recipient, coin, helpers.BipToPip(big.NewInt(1)))
+
+	value := helpers.BipToPip(big.NewInt(100))
+	preimage := []byte("secret")
+	createTestHTLC(t, cState, privateKey, recipient, value, preimage, 10)
+
+	if err := checkState(cState); err != nil {
+		t.Fatal(err)
+	}
+
+	encodedTx, err := makeTestTx(TypeClaimHTLC, ClaimHTLCData{ID: 1, Preimage: []byte("wrong")}, 1, recipientKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 2, &sync.Map{}, 0, false)
+	if response.Code != code.WrongHTLCPreimage {
+		t.Fatalf("Response code is not %d. Error %s", code.WrongHTLCPreimage, response.Log)
+	}
+
+	encodedTx, err = makeTestTx(TypeClaimHTLC, ClaimHTLCData{ID: 1, Preimage: preimage}, 1, recipientKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response = NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 10, &sync.Map{}, 0, false)
+	if response.Code != code.WrongHTLCTimeout {
+		t.Fatalf("Response code is not %d. Error %s", code.WrongHTLCTimeout, response.Log)
+	}
+
+	response = NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 2, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not %d. Error %s", code.OK, response.Log)
+	}
+
+	commissions := cState.Commission.GetCommissions()
+	expectedBalance := big.NewInt(0).Add(helpers.BipToPip(big.NewInt(1)), big.NewInt(0).Sub(value, commissions.ClaimHTLCPrice()))
+	expectedBalance.Sub(expectedBalance, big.NewInt(0).Mul(commissions.FailedTx, big.NewInt(2)))
+	if balance := cState.Accounts.GetBalance(recipient, coin); balance.Cmp(expectedBalance) != 0 {
+		t.Fatalf("Recipient balance is not correct. Expected %s, got %s", expectedBalance, balance)
+	}
+
+	claimed := false
+	for _, event := range events.LoadEvents(0) {
+		if e, ok := event.(*eventsdb.HTLCClaimedEvent); ok && e.Preimage == "736563726574" {
+			claimed = true
+		}
+	}
+	if !claimed {
+		t.Fatal("Preimage is not published in events")
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
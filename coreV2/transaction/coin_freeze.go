@@ -0,0 +1,159 @@
+package transaction
+
+import (
+	"fmt"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/check"
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+)
+
+// checkFreezableCoinOwner checks that the coin is a freezable token and the sender is the owner of its symbol
+func checkFreezableCoinOwner(context *state.CheckState, coinID types.CoinID, sender types.Address) *Response {
+	coin := context.Coins().GetCoin(coinID)
+	if coin == nil {
+		return &Response{
+			Code: code.CoinNotExists,
+			Log:  fmt.Sprintf("Coin %s not exists", coinID),
+			Info: EncodeError(code.NewCoinNotExists("", coinID.String())),
+		}
+	}
+
+	info := context.Coins().GetSymbolInfo(coin.Symbol())
+	if info == nil || info.OwnerAddress() == nil || *info.OwnerAddress() != sender {
+		var owner *string
+		if info != nil && info.OwnerAddress() != nil {
+			address := info.OwnerAddress().String()
+			owner = &address
+		}
+		return &Response{
+			Code: code.IsNotOwnerOfCoin,
+			Log:  "Sender is not owner of coin",
+			Info: EncodeError(code.NewIsNotOwnerOfCoin(coin.Symbol().String(), owner)),
+		}
+	}
+
+	if !context.Coins().IsFreezable(coinID) {
+		return &Response{
+			Code: code.CoinIsNotFreezable,
+			Log:  fmt.Sprintf("Coin %s is not freezable", coin.GetFullSymbol()),
+			Info: EncodeError(code.NewCoinIsNotFreezable(coinID.String())),
+		}
+	}
+
+	return nil
+}
+
+// checkCoinTransfer checks that transfers of the coin are not paused and none of the addresses is frozen
+func checkCoinTransfer(context *state.CheckState, coin types.CoinID, addresses ...types.Address) *Response {
+	if context.Coins().IsPaused(coin) {
+		return &Response{
+			Code: code.CoinIsPaused,
+			Log:  fmt.Sprintf("Transfers of coin %s are paused", coin.String()),
+			Info: EncodeError(code.NewCoinIsPaused(coin.String())),
+		}
+	}
+
+	for _, address := range addresses {
+		if context.Coins().IsFrozen(coin, address) {
+			return &Response{
+				Code: code.AddressIsFrozen,
+				Log:  fmt.Sprintf("Address %s is frozen for coin %s", address.String(), coin.String()),
+				Info: EncodeError(code.NewAddressIsFrozen(coin.String(), address.String())),
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkFrozenCoins checks coins moved by the tx and its batch items against paused tokens and frozen addresses
+func checkFrozenCoins(tx *Transaction, context *state.CheckState, sender types.Address) *Response {
+	for _, item := range policyItems(tx) {
+		var response *Response
+		switch data := item.(type) {
+		case *SendData:
+			response = checkCoinTransfer(context, data.Coin, sender, data.To)
+		case *MultisendData:
+			for _, send := range data.List {
+				if response = checkCoinTransfer(context, send.Coin, sender, send.To); response != nil {
+					break
+				}
+			}
+		case *SellSwapPoolDataV260:
+			response = checkCoinsTransfer(context, data.Coins, sender)
+		case *BuySwapPoolDataV260:
+			response = checkCoinsTransfer(context, data.Coins, sender)
+		case *SellAllSwapPoolDataV260:
+			response = checkCoinsTransfer(context, data.Coins, sender)
+		case *AddLimitOrderData:
+			response = checkCoinsTransfer(context, []types.CoinID{data.CoinToSell, data.CoinToBuy}, sender)
+		case *DelegateDataV260:
+			response = checkCoinTransfer(context, data.Coin, sender)
+		case *CreateVestingData:
+			response = checkCoinTransfer(context, data.Coin, sender, data.To)
+		case *CreateStandingOrderData:
+			response = checkCoinTransfer(context, data.Coin, sender, data.Recipient)
+		case *CreateHTLCData:
+			response = checkCoinTransfer(context, data.Coin, sender, data.Recipient)
+		case *DistributeToHoldersData:
+			response = checkCoinTransfer(context, data.Coin, sender)
+		case *RedeemCheckData:
+			response = checkRedeemCheckTransfer(context, data.RawCheck, false, sender)
+		case *RedeemCheckV2Data:
+			response = checkRedeemCheckTransfer(context, data.RawCheck, true, sender)
+		case *RedeemChecksData:
+			for _, c := range data.Checks {
+				if response = checkRedeemCheckTransfer(context, c.RawCheck, false, sender); response != nil {
+					break
+				}
+			}
+		}
+
+		if response != nil {
+			return response
+		}
+	}
+
+	return nil
+}
+
+func checkCoinsTransfer(context *state.CheckState, coins []types.CoinID, address types.Address) *Response {
+	for _, coin := range coins {
+		if response := checkCoinTransfer(context, coin, address); response != nil {
+			return response
+		}
+	}
+
+	return nil
+}
+
+// checkRedeemCheckTransfer checks the coin of the check for its issuer and the sender, malformed checks are left to the tx itself
+func checkRedeemCheckTransfer(context *state.CheckState, rawCheck []byte, v2 bool, sender types.Address) *Response {
+	var (
+		coin   types.CoinID
+		issuer types.Address
+		err    error
+	)
+	if v2 {
+		var decodedCheck *check.CheckV2
+		if decodedCheck, err = check.DecodeV2FromBytes(rawCheck); err != nil {
+			return nil
+		}
+		coin = decodedCheck.Coin
+		issuer, err = decodedCheck.Sender()
+	} else {
+		var decodedCheck *check.Check
+		if decodedCheck, err = check.DecodeFromBytes(rawCheck); err != nil {
+			return nil
+		}
+		coin = decodedCheck.Coin
+		issuer, err = decodedCheck.Sender()
+	}
+	if err != nil {
+		return nil
+	}
+
+	return checkCoinTransfer(context, coin, issuer, sender)
+}
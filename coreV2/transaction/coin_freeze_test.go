@@ -0,0 +1,91 @@
+package transaction
+
+import (
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/crypto"
+	"github.com/MinterTeam/minter-go-node/helpers"
+)
+
+func TestCoinFreezeToRejectHTLCToFrozenAddress(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	cState.Accounts.AddBalance(addr, types.GetBaseCoinID(), helpers.BipToPip(big.NewInt(1000)))
+
+	coin := createTestFreezableToken(cState, addr)
+	recipient := types.Address{1}
+	cState.Coins.Freeze(coin, recipient)
+
+	value := helpers.BipToPip(big.NewInt(10))
+	encodedTx, err := makeTestTx(TypeCreateHTLC, CreateHTLCData{Recipient: recipient, Coin: coin, Value: value, Hashlock: types.Hash{1}, Timeout: 100}, 1, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	balance := cState.Accounts.GetBalance(addr, coin)
+	response := NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 0, &sync.Map{}, 0, false)
+	if response.Code != code.AddressIsFrozen {
+		t.Fatalf("Response code is not %d. Error %s", code.AddressIsFrozen, response.Log)
+	}
+
+	if newBalance := cState.Accounts.GetBalance(addr, coin); newBalance.Cmp(balance) != 0 {
+		t.Fatalf("Balance is not correct. Expected %s, got %s", balance, newBalance)
+	}
+
+	cState.Coins.Unfreeze(coin, recipient)
+
+	response = NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 0, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not %d. Error %s", code.OK, response.Log)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCoinFreezeToRejectVestingOfPausedToken(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	cState.Accounts.AddBalance(addr, types.GetBaseCoinID(), helpers.BipToPip(big.NewInt(1000)))
+
+	coin := createTestFreezableToken(cState, addr)
+	cState.Coins.Pause(coin)
+
+	value := helpers.BipToPip(big.NewInt(10))
+	encodedTx, err := makeTestTx(TypeCreateVesting, CreateVestingData{To: types.Address{1}, Coin: coin, Value: value, StartHeight: 1, CliffHeight: 1, EndHeight: 100}, 1, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	balance := cState.Accounts.GetBalance(addr, coin)
+	response := NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 0, &sync.Map{}, 0, false)
+	if response.Code != code.CoinIsPaused {
+		t.Fatalf("Response code is not %d. Error %s", code.CoinIsPaused, response.Log)
+	}
+
+	if newBalance := cState.Accounts.GetBalance(addr, coin); newBalance.Cmp(balance) != 0 {
+		t.Fatalf("Balance is not correct. Expected %s, got %s", balance, newBalance)
+	}
+
+	cState.Coins.Unpause(coin)
+
+	response = NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 0, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not %d. Error %s", code.OK, response.Log)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
@@ -174,7 +174,7 @@ func (data CreateCoinData) Run(tx *Transaction, context state.Interface, rewardP
 		return *errResp
 	}
 
-	if checkState.Accounts().GetBalance(sender, tx.GasCoin).Cmp(commission) == -1 {
+	if checkState.Accounts().GetSpendableBalance(sender, tx.GasCoin).Cmp(commission) == -1 {
 		gasCoin := checkState.Coins().GetCoin(tx.GasCoin)
 		return Response{
 			Code: code.InsufficientFunds,
@@ -187,7 +187,7 @@ func (data CreateCoinData) Run(tx *Transaction, context state.Interface, rewardP
 	if tx.GasCoin == types.GetBaseCoinID() {
 		totalTxCost.Add(totalTxCost, commissionInBaseCoin)
 	}
-	if checkState.Accounts().GetBalance(sender, types.GetBaseCoinID()).Cmp(totalTxCost) == -1 {
+	if checkState.Accounts().GetSpendableBalance(sender, types.GetBaseCoinID()).Cmp(totalTxCost) == -1 {
 		coin := checkState.Coins().GetCoin(types.GetBaseCoinID())
 		return Response{
 			Code: code.InsufficientFunds,
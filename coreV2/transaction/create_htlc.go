@@ -0,0 +1,163 @@
+package transaction
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/commission"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/swap"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	abcTypes "github.com/tendermint/tendermint/abci/types"
+)
+
+// CreateHTLCData locks Value of coins until they are claimed by Recipient with a sha256 preimage of Hashlock
+// before Timeout height or refunded to the sender after it
+type CreateHTLCData struct {
+	Recipient types.Address
+	Coin      types.CoinID
+	Value     *big.Int
+	Hashlock  types.Hash
+	Timeout   uint64
+}
+
+func (data CreateHTLCData) TxType() TxType {
+	return TypeCreateHTLC
+}
+
+func (data CreateHTLCData) Gas() int64 {
+	return gasCreateHTLC
+}
+
+func (data CreateHTLCData) basicCheck(tx *Transaction, context *state.CheckState, block uint64) *Response {
+	if data.Value == nil || data.Value.Sign() != 1 {
+		return &Response{
+			Code: code.DecodeError,
+			Log:  "Incorrect tx data",
+			Info: EncodeError(code.NewDecodeError()),
+		}
+	}
+
+	if data.Timeout <= block {
+		return &Response{
+			Code: code.WrongHTLCTimeout,
+			Log:  "Timeout of htlc should be in the future",
+			Info: EncodeError(code.NewWrongHTLCTimeout(strconv.FormatUint(data.Timeout, 10), strconv.FormatUint(block, 10))),
+		}
+	}
+
+	if !context.Coins().Exists(data.Coin) {
+		return &Response{
+			Code: code.CoinNotExists,
+			Log:  fmt.Sprintf("Coin %s not exists", data.Coin),
+			Info: EncodeError(code.NewCoinNotExists("", data.Coin.String())),
+		}
+	}
+
+	return nil
+}
+
+func (data CreateHTLCData) String() string {
+	return fmt.Sprintf("CREATE HTLC recipient:%s coin:%s value:%s hashlock:%x timeout:%d",
+		data.Recipient.String(), data.Coin.String(), data.Value.String(), data.Hashlock[:], data.Timeout)
+}
+
+func (data CreateHTLCData) CommissionData(price *commission.Price) *big.Int {
+	return price.CreateHTLCPrice()
+}
+
+func (data CreateHTLCData) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, price *big.Int) Response {
+	sender, _ := tx.Sender()
+	var checkState *state.CheckState
+	var isCheck bool
+	if checkState, isCheck = context.(*state.CheckState); !isCheck {
+		checkState = state.NewCheckState(context.(*state.State))
+	}
+
+	response := data.basicCheck(tx, checkState, currentBlock)
+	if response != nil {
+		return *response
+	}
+
+	commissionInBaseCoin := price
+	commissionPoolSwapper := checkState.Swap().GetSwapper(tx.GasCoin, types.GetBaseCoinID())
+	gasCoin := checkState.Coins().GetCoin(tx.GasCoin)
+	commission, isGasCommissionFromPoolSwap, errResp := CalculateCommission(checkState, commissionPoolSwapper, gasCoin, commissionInBaseCoin)
+	if errResp != nil {
+		return *errResp
+	}
+
+	needValue := big.NewInt(0).Set(commission)
+	if tx.GasCoin == data.Coin {
+		needValue.Add(data.Value, needValue)
+	} else {
+		if checkState.Accounts().GetSpendableBalance(sender, data.Coin).Cmp(data.Value) < 0 {
+			coin := checkState.Coins().GetCoin(data.Coin)
+			return Response{
+				Code: code.InsufficientFunds,
+				Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), data.Value.String(), coin.GetFullSymbol()),
+				Info: EncodeError(code.NewInsufficientFunds(sender.String(), data.Value.String(), coin.GetFullSymbol(), coin.ID().String())),
+			}
+		}
+	}
+	if checkState.Accounts().GetSpendableBalance(sender, tx.GasCoin).Cmp(needValue) < 0 {
+		return Response{
+			Code: code.InsufficientFunds,
+			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), needValue.String(), gasCoin.GetFullSymbol()),
+			Info: EncodeError(code.NewInsufficientFunds(sender.String(), needValue.String(), gasCoin.GetFullSymbol(), gasCoin.ID().String())),
+		}
+	}
+
+	var tags []abcTypes.EventAttribute
+	if deliverState, ok := context.(*state.State); ok {
+		var tagsCom *tagPoolChange
+		if isGasCommissionFromPoolSwap {
+			var (
+				poolIDCom  uint32
+				detailsCom *swap.ChangeDetailsWithOrders
+				ownersCom  []*swap.OrderDetail
+			)
+			commission, commissionInBaseCoin, poolIDCom, detailsCom, ownersCom = deliverState.Swapper().PairSellWithOrders(tx.CommissionCoin(), types.GetBaseCoinID(), commission, big.NewInt(0))
+			tagsCom = &tagPoolChange{
+				PoolID:   poolIDCom,
+				CoinIn:   tx.CommissionCoin(),
+				ValueIn:  commission.String(),
+				CoinOut:  types.GetBaseCoinID(),
+				ValueOut: commissionInBaseCoin.String(),
+				Orders:   detailsCom,
+				// Sellers:  ownersCom,
+			}
+			for _, value := range ownersCom {
+				deliverState.Accounts.AddBalance(value.Owner, tx.CommissionCoin(), value.ValueBigInt)
+			}
+		} else if !tx.GasCoin.IsBaseCoin() {
+			deliverState.Coins.SubVolume(tx.CommissionCoin(), commission)
+			deliverState.Coins.SubReserve(tx.CommissionCoin(), commissionInBaseCoin)
+		}
+		deliverState.Accounts.SubBalance(sender, tx.GasCoin, commission)
+		rewardPool.Add(rewardPool, commissionInBaseCoin)
+
+		deliverState.Accounts.SubBalance(sender, data.Coin, data.Value)
+		id := deliverState.HTLCs.Create(sender, data.Recipient, data.Coin, data.Value, data.Hashlock, data.Timeout)
+		deliverState.Accounts.SetNonce(sender, tx.Nonce)
+
+		tags = []abcTypes.EventAttribute{
+			{Key: []byte("tx.commission_in_base_coin"), Value: []byte(commissionInBaseCoin.String())},
+			{Key: []byte("tx.commission_conversion"), Value: []byte(isGasCommissionFromPoolSwap.String()), Index: true},
+			{Key: []byte("tx.commission_amount"), Value: []byte(commission.String())},
+			{Key: []byte("tx.commission_details"), Value: []byte(tagsCom.string())},
+			{Key: []byte("tx.to"), Value: []byte(hex.EncodeToString(data.Recipient[:])), Index: true},
+			{Key: []byte("tx.coin_id"), Value: []byte(data.Coin.String()), Index: true},
+			{Key: []byte("tx.htlc_id"), Value: []byte(strconv.FormatUint(id, 10)), Index: true},
+			{Key: []byte("tx.htlc_hashlock"), Value: []byte(hex.EncodeToString(data.Hashlock[:])), Index: true},
+		}
+	}
+
+	return Response{
+		Code: code.OK,
+		Tags: tags,
+	}
+}
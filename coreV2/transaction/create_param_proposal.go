@@ -0,0 +1,170 @@
+package transaction
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/commission"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/swap"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	abcTypes "github.com/tendermint/tendermint/abci/types"
+)
+
+// CreateParamProposalData proposes to change the network parameter Key to Value at TargetHeight.
+// Deposit in the base coin is held until the end of voting, it is refunded if the proposal is passed and goes to the DAO treasury otherwise
+type CreateParamProposalData struct {
+	Key          string
+	Value        uint64
+	TargetHeight uint64
+	Deposit      *big.Int
+}
+
+func (data CreateParamProposalData) Gas() int64 {
+	return gasCreateParamProposal
+}
+
+func (data CreateParamProposalData) TxType() TxType {
+	return TypeCreateParamProposal
+}
+
+func (data CreateParamProposalData) basicCheck(tx *Transaction, context *state.CheckState, block uint64) *Response {
+	value := strconv.FormatUint(data.Value, 10)
+
+	if err := context.Governance().CheckParam(data.Key, data.Value); err != nil {
+		return &Response{
+			Code: code.WrongParamProposal,
+			Log:  err.Error(),
+			Info: EncodeError(code.NewWrongParamProposal(data.Key, value, err.Error())),
+		}
+	}
+
+	if minDeposit := context.Governance().MinDeposit(); data.Deposit == nil || data.Deposit.Cmp(minDeposit) == -1 {
+		return &Response{
+			Code: code.WrongParamProposal,
+			Log:  fmt.Sprintf("Deposit should be at least %s", minDeposit.String()),
+			Info: EncodeError(code.NewWrongParamProposal(data.Key, value, "deposit is too small")),
+		}
+	}
+
+	if votingEnd := block + context.Governance().VotingPeriod(); data.TargetHeight <= votingEnd {
+		return &Response{
+			Code: code.WrongParamProposal,
+			Log:  fmt.Sprintf("Target height should be greater than the end of voting %d", votingEnd),
+			Info: EncodeError(code.NewWrongParamProposal(data.Key, value, "target height is before the end of voting")),
+		}
+	}
+
+	return nil
+}
+
+func (data CreateParamProposalData) String() string {
+	return fmt.Sprintf("CREATE PARAM PROPOSAL key:%s value:%d target_height:%d", data.Key, data.Value, data.TargetHeight)
+}
+
+func (data CreateParamProposalData) CommissionData(price *commission.Price) *big.Int {
+	return price.CreateParamProposalPrice()
+}
+
+func (data CreateParamProposalData) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, price *big.Int) Response {
+	sender, _ := tx.Sender()
+
+	var checkState *state.CheckState
+	var isCheck bool
+	if checkState, isCheck = context.(*state.CheckState); !isCheck {
+		checkState = state.NewCheckState(context.(*state.State))
+	}
+
+	response := data.basicCheck(tx, checkState, currentBlock)
+	if response != nil {
+		return *response
+	}
+
+	commissionInBaseCoin := price
+	commissionPoolSwapper := checkState.Swap().GetSwapper(tx.GasCoin, types.GetBaseCoinID())
+	gasCoin := checkState.Coins().GetCoin(tx.GasCoin)
+	commission, isGasCommissionFromPoolSwap, errResp := CalculateCommission(checkState, commissionPoolSwapper, gasCoin, commissionInBaseCoin)
+	if errResp != nil {
+		return *errResp
+	}
+
+	baseCoin := checkState.Coins().GetCoin(types.GetBaseCoinID())
+	if checkState.Accounts().GetSpendableBalance(sender, types.GetBaseCoinID()).Cmp(data.Deposit) < 0 {
+		return Response{
+			Code: code.InsufficientFunds,
+			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), data.Deposit.String(), baseCoin.GetFullSymbol()),
+			Info: EncodeError(code.NewInsufficientFunds(sender.String(), data.Deposit.String(), baseCoin.GetFullSymbol(), baseCoin.ID().String())),
+		}
+	}
+
+	if checkState.Accounts().GetSpendableBalance(sender, tx.GasCoin).Cmp(commission) < 0 {
+		return Response{
+			Code: code.InsufficientFunds,
+			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), commission.String(), gasCoin.GetFullSymbol()),
+			Info: EncodeError(code.NewInsufficientFunds(sender.String(), commission.String(), gasCoin.GetFullSymbol(), gasCoin.ID().String())),
+		}
+	}
+
+	if tx.GasCoin.IsBaseCoin() {
+		totalTxCost := big.NewInt(0).Add(data.Deposit, commission)
+		if checkState.Accounts().GetSpendableBalance(sender, tx.GasCoin).Cmp(totalTxCost) < 0 {
+			return Response{
+				Code: code.InsufficientFunds,
+				Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), totalTxCost.String(), gasCoin.GetFullSymbol()),
+				Info: EncodeError(code.NewInsufficientFunds(sender.String(), totalTxCost.String(), gasCoin.GetFullSymbol(), gasCoin.ID().String())),
+			}
+		}
+	}
+
+	var tags []abcTypes.EventAttribute
+	if deliverState, ok := context.(*state.State); ok {
+		var tagsCom *tagPoolChange
+		if isGasCommissionFromPoolSwap {
+			var (
+				poolIDCom  uint32
+				detailsCom *swap.ChangeDetailsWithOrders
+				ownersCom  []*swap.OrderDetail
+			)
+			commission, commissionInBaseCoin, poolIDCom, detailsCom, ownersCom = deliverState.Swapper().PairSellWithOrders(tx.CommissionCoin(), types.GetBaseCoinID(), commission, big.NewInt(0))
+			tagsCom = &tagPoolChange{
+				PoolID:   poolIDCom,
+				CoinIn:   tx.CommissionCoin(),
+				ValueIn:  commission.String(),
+				CoinOut:  types.GetBaseCoinID(),
+				ValueOut: commissionInBaseCoin.String(),
+				Orders:   detailsCom,
+				// Sellers:  ownersCom,
+			}
+			for _, value := range ownersCom {
+				deliverState.Accounts.AddBalance(value.Owner, tx.CommissionCoin(), value.ValueBigInt)
+			}
+		} else if !tx.GasCoin.IsBaseCoin() {
+			deliverState.Coins.SubVolume(tx.CommissionCoin(), commission)
+			deliverState.Coins.SubReserve(tx.CommissionCoin(), commissionInBaseCoin)
+		}
+		deliverState.Accounts.SubBalance(sender, tx.GasCoin, commission)
+		rewardPool.Add(rewardPool, commissionInBaseCoin)
+
+		votingEnd := currentBlock + checkState.Governance().VotingPeriod()
+		deliverState.Accounts.SubBalance(sender, types.GetBaseCoinID(), data.Deposit)
+		id := deliverState.Governance.Create(sender, data.Key, data.Value, data.Deposit, votingEnd, data.TargetHeight)
+		deliverState.Accounts.SetNonce(sender, tx.Nonce)
+
+		tags = []abcTypes.EventAttribute{
+			{Key: []byte("tx.commission_in_base_coin"), Value: []byte(commissionInBaseCoin.String())},
+			{Key: []byte("tx.commission_conversion"), Value: []byte(isGasCommissionFromPoolSwap.String()), Index: true},
+			{Key: []byte("tx.commission_amount"), Value: []byte(commission.String())},
+			{Key: []byte("tx.commission_details"), Value: []byte(tagsCom.string())},
+			{Key: []byte("tx.proposal_id"), Value: []byte(strconv.FormatUint(id, 10)), Index: true},
+			{Key: []byte("tx.param"), Value: []byte(data.Key), Index: true},
+			{Key: []byte("tx.voting_end"), Value: []byte(strconv.FormatUint(votingEnd, 10))},
+		}
+	}
+
+	return Response{
+		Code: code.OK,
+		Tags: tags,
+	}
+}
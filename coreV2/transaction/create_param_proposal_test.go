@@ -0,0 +1,115 @@
+package transaction
+
+import (
+	"math/big"
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/governance"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/crypto"
+	"github.com/MinterTeam/minter-go-node/helpers"
+)
+
+func TestCreateParamProposalTx(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	coin := types.GetBaseCoinID()
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(20000)))
+
+	deposit := cState.Governance.MinDeposit()
+	targetHeight := cState.Governance.VotingPeriod() + 100
+	data := CreateParamProposalData{Key: governance.ParamMaxGas, Value: 50000, TargetHeight: targetHeight, Deposit: deposit}
+	encodedTx, err := makeTestTx(TypeCreateParamProposal, data, 1, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not %d. Error %s", code.OK, response.Log)
+	}
+
+	proposal := cState.Governance.GetProposal(1)
+	if proposal == nil || proposal.Key != governance.ParamMaxGas || proposal.Value != 50000 {
+		t.Fatalf("Proposal is not created: %+v", proposal)
+	}
+
+	maxBalance := big.NewInt(0).Sub(helpers.BipToPip(big.NewInt(20000)), deposit)
+	if balance := cState.Accounts.GetBalance(addr, coin); balance.Cmp(maxBalance) != -1 {
+		t.Fatalf("Deposit and commission are not subtracted. Expected less than %s, got %s", maxBalance, balance)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+
+	pubkey := types.Pubkey{}
+	rand.Read(pubkey[:])
+	cState.Candidates.Create(addr, addr, addr, pubkey, 10, 0, 0)
+
+	encodedTx, err = makeTestTx(TypeVoteParamProposal, VoteParamProposalData{PubKey: pubkey, ID: 1}, 2, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response = NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 2, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not %d. Error %s", code.OK, response.Log)
+	}
+
+	if !cState.Governance.GetProposal(1).HasVote(pubkey) {
+		t.Fatal("Vote is not added")
+	}
+
+	encodedTx, err = makeTestTx(TypeVoteParamProposal, VoteParamProposalData{PubKey: pubkey, ID: 1}, 3, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response = NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 3, &sync.Map{}, 0, false)
+	if response.Code != code.VoteAlreadyExists {
+		t.Fatalf("Response code is not %d. Error %s", code.VoteAlreadyExists, response.Log)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCreateParamProposalTxToWrongParam(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	cState.Accounts.AddBalance(addr, types.GetBaseCoinID(), helpers.BipToPip(big.NewInt(20000)))
+
+	deposit := cState.Governance.MinDeposit()
+	targetHeight := cState.Governance.VotingPeriod() + 100
+	for _, data := range []CreateParamProposalData{
+		{Key: "unknown", Value: 1, TargetHeight: targetHeight, Deposit: deposit},
+		{Key: governance.ParamDAOCommission, Value: 51, TargetHeight: targetHeight, Deposit: deposit},
+		{Key: governance.ParamDAOCommission, Value: 20, TargetHeight: targetHeight, Deposit: big.NewInt(1)},
+		{Key: governance.ParamDAOCommission, Value: 20, TargetHeight: 10, Deposit: deposit},
+	} {
+		encodedTx, err := makeTestTx(TypeCreateParamProposal, data, 1, privateKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		response := NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 1, &sync.Map{}, 0, false)
+		if response.Code != code.WrongParamProposal {
+			t.Fatalf("Response code is not %d. Error %s", code.WrongParamProposal, response.Log)
+		}
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
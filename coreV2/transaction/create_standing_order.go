@@ -0,0 +1,154 @@
+package transaction
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/commission"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/swap"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	abcTypes "github.com/tendermint/tendermint/abci/types"
+)
+
+// minStandingOrderInterval and maxStandingOrderCount limit the number of standing order payments made in EndBlock
+const (
+	minStandingOrderInterval = 120
+	maxStandingOrderCount    = 10000
+)
+
+// CreateStandingOrderData schedules Count payments of Amount to Recipient every Interval blocks,
+// the first payment is made Interval blocks after the transaction
+type CreateStandingOrderData struct {
+	Recipient types.Address
+	Coin      types.CoinID
+	Amount    *big.Int
+	Interval  uint64
+	Count     uint64
+}
+
+func (data CreateStandingOrderData) TxType() TxType {
+	return TypeCreateStandingOrder
+}
+
+func (data CreateStandingOrderData) Gas() int64 {
+	return gasCreateStandingOrder
+}
+
+func (data CreateStandingOrderData) basicCheck(tx *Transaction, context *state.CheckState) *Response {
+	if data.Amount == nil || data.Amount.Sign() != 1 {
+		return &Response{
+			Code: code.DecodeError,
+			Log:  "Incorrect tx data",
+			Info: EncodeError(code.NewDecodeError()),
+		}
+	}
+
+	if data.Interval < minStandingOrderInterval || data.Count == 0 || data.Count > maxStandingOrderCount {
+		return &Response{
+			Code: code.WrongStandingOrderSchedule,
+			Log:  fmt.Sprintf("Interval of standing order payments should be at least %d blocks and count should be from 1 to %d", minStandingOrderInterval, maxStandingOrderCount),
+			Info: EncodeError(code.NewWrongStandingOrderSchedule(strconv.FormatUint(data.Interval, 10), strconv.FormatUint(data.Count, 10))),
+		}
+	}
+
+	if !context.Coins().Exists(data.Coin) {
+		return &Response{
+			Code: code.CoinNotExists,
+			Log:  fmt.Sprintf("Coin %s not exists", data.Coin),
+			Info: EncodeError(code.NewCoinNotExists("", data.Coin.String())),
+		}
+	}
+
+	return nil
+}
+
+func (data CreateStandingOrderData) String() string {
+	return fmt.Sprintf("CREATE STANDING ORDER recipient:%s coin:%s amount:%s interval:%d count:%d",
+		data.Recipient.String(), data.Coin.String(), data.Amount.String(), data.Interval, data.Count)
+}
+
+func (data CreateStandingOrderData) CommissionData(price *commission.Price) *big.Int {
+	return price.CreateStandingOrderPrice()
+}
+
+func (data CreateStandingOrderData) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, price *big.Int) Response {
+	sender, _ := tx.Sender()
+	var checkState *state.CheckState
+	var isCheck bool
+	if checkState, isCheck = context.(*state.CheckState); !isCheck {
+		checkState = state.NewCheckState(context.(*state.State))
+	}
+
+	response := data.basicCheck(tx, checkState)
+	if response != nil {
+		return *response
+	}
+
+	commissionInBaseCoin := price
+	commissionPoolSwapper := checkState.Swap().GetSwapper(tx.GasCoin, types.GetBaseCoinID())
+	gasCoin := checkState.Coins().GetCoin(tx.GasCoin)
+	commission, isGasCommissionFromPoolSwap, errResp := CalculateCommission(checkState, commissionPoolSwapper, gasCoin, commissionInBaseCoin)
+	if errResp != nil {
+		return *errResp
+	}
+
+	if checkState.Accounts().GetSpendableBalance(sender, tx.GasCoin).Cmp(commission) < 0 {
+		return Response{
+			Code: code.InsufficientFunds,
+			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), commission.String(), gasCoin.GetFullSymbol()),
+			Info: EncodeError(code.NewInsufficientFunds(sender.String(), commission.String(), gasCoin.GetFullSymbol(), gasCoin.ID().String())),
+		}
+	}
+
+	var tags []abcTypes.EventAttribute
+	if deliverState, ok := context.(*state.State); ok {
+		var tagsCom *tagPoolChange
+		if isGasCommissionFromPoolSwap {
+			var (
+				poolIDCom  uint32
+				detailsCom *swap.ChangeDetailsWithOrders
+				ownersCom  []*swap.OrderDetail
+			)
+			commission, commissionInBaseCoin, poolIDCom, detailsCom, ownersCom = deliverState.Swapper().PairSellWithOrders(tx.CommissionCoin(), types.GetBaseCoinID(), commission, big.NewInt(0))
+			tagsCom = &tagPoolChange{
+				PoolID:   poolIDCom,
+				CoinIn:   tx.CommissionCoin(),
+				ValueIn:  commission.String(),
+				CoinOut:  types.GetBaseCoinID(),
+				ValueOut: commissionInBaseCoin.String(),
+				Orders:   detailsCom,
+				// Sellers:  ownersCom,
+			}
+			for _, value := range ownersCom {
+				deliverState.Accounts.AddBalance(value.Owner, tx.CommissionCoin(), value.ValueBigInt)
+			}
+		} else if !tx.GasCoin.IsBaseCoin() {
+			deliverState.Coins.SubVolume(tx.CommissionCoin(), commission)
+			deliverState.Coins.SubReserve(tx.CommissionCoin(), commissionInBaseCoin)
+		}
+		deliverState.Accounts.SubBalance(sender, tx.GasCoin, commission)
+		rewardPool.Add(rewardPool, commissionInBaseCoin)
+
+		id := deliverState.StandingOrders.CreateOrder(sender, data.Recipient, data.Coin, data.Amount, data.Interval, currentBlock+data.Interval, data.Count)
+		deliverState.Accounts.SetNonce(sender, tx.Nonce)
+
+		tags = []abcTypes.EventAttribute{
+			{Key: []byte("tx.commission_in_base_coin"), Value: []byte(commissionInBaseCoin.String())},
+			{Key: []byte("tx.commission_conversion"), Value: []byte(isGasCommissionFromPoolSwap.String()), Index: true},
+			{Key: []byte("tx.commission_amount"), Value: []byte(commission.String())},
+			{Key: []byte("tx.commission_details"), Value: []byte(tagsCom.string())},
+			{Key: []byte("tx.to"), Value: []byte(hex.EncodeToString(data.Recipient[:])), Index: true},
+			{Key: []byte("tx.coin_id"), Value: []byte(data.Coin.String()), Index: true},
+			{Key: []byte("tx.standing_order_id"), Value: []byte(strconv.FormatUint(id, 10)), Index: true},
+		}
+	}
+
+	return Response{
+		Code: code.OK,
+		Tags: tags,
+	}
+}
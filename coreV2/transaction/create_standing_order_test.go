@@ -0,0 +1,152 @@
+package transaction
+
+import (
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/accounts"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/coins"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/crypto"
+	"github.com/MinterTeam/minter-go-node/helpers"
+)
+
+func TestCreateStandingOrderTx(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+	coin := types.GetBaseCoinID()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(1000)))
+
+	recipient := types.Address{1}
+	amount := helpers.BipToPip(big.NewInt(10))
+	encodedTx, err := makeTestTx(TypeCreateStandingOrder, CreateStandingOrderData{
+		Recipient: recipient,
+		Coin:      coin,
+		Amount:    amount,
+		Interval:  minStandingOrderInterval,
+		Count:     2,
+	}, 1, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 5, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not %d. Error %s", code.OK, response.Log)
+	}
+
+	order := cState.StandingOrders.GetOrder(1)
+	if order == nil {
+		t.Fatal("Standing order is not created")
+	}
+
+	if order.Owner != addr || order.GetNextHeight() != 5+minStandingOrderInterval || order.GetRemaining() != 2 {
+		t.Fatalf("Standing order is not correct: %+v", order)
+	}
+
+	cState.StandingOrders.Execute(5+minStandingOrderInterval, 10)
+
+	if balance := cState.Accounts.GetBalance(recipient, coin); balance.Cmp(amount) != 0 {
+		t.Fatalf("Recipient balance is not correct. Expected %s, got %s", amount, balance)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCreateStandingOrderTxToWrongSchedule(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+	coin := types.GetBaseCoinID()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(1000)))
+
+	for i, data := range []CreateStandingOrderData{
+		{Recipient: types.Address{1}, Coin: coin, Amount: big.NewInt(1), Interval: minStandingOrderInterval - 1, Count: 1},
+		{Recipient: types.Address{1}, Coin: coin, Amount: big.NewInt(1), Interval: minStandingOrderInterval, Count: 0},
+		{Recipient: types.Address{1}, Coin: coin, Amount: big.NewInt(1), Interval: minStandingOrderInterval, Count: maxStandingOrderCount + 1},
+	} {
+		encodedTx, err := makeTestTx(TypeCreateStandingOrder, data, 1, privateKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		response := NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 5, &sync.Map{}, 0, false)
+		if response.Code != code.WrongStandingOrderSchedule {
+			t.Fatalf("Tx %d: Response code is not %d. Error %s", i, code.WrongStandingOrderSchedule, response.Log)
+		}
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestStandingOrderPaymentWithPolicyAndTransferFee(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	coin := createNonReserveCoin(cState)
+	cState.Coins.SetTransferFee(coin, coins.TransferFee{Bps: 100, Burn: true})
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	cState.Accounts.AddBalance(addr, types.GetBaseCoinID(), helpers.BipToPip(big.NewInt(1000)))
+	cState.Accounts.SubBalance(types.Address{}, coin, helpers.BipToPip(big.NewInt(1000)))
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(1000)))
+
+	recipient := types.Address{1}
+	amount := helpers.BipToPip(big.NewInt(100))
+	encodedTx, err := makeTestTx(TypeCreateStandingOrder, CreateStandingOrderData{
+		Recipient: recipient,
+		Coin:      coin,
+		Amount:    amount,
+		Interval:  minStandingOrderInterval,
+		Count:     2,
+	}, 1, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 5, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not %d. Error %s", code.OK, response.Log)
+	}
+
+	volume := cState.Coins.GetCoin(coin).Volume()
+	cState.StandingOrders.Execute(5+minStandingOrderInterval, 10)
+
+	expectedBalance := helpers.BipToPip(big.NewInt(99))
+	if balance := cState.Accounts.GetBalance(recipient, coin); balance.Cmp(expectedBalance) != 0 {
+		t.Fatalf("Recipient balance is not correct. Expected %s, got %s", expectedBalance, balance)
+	}
+
+	expectedVolume := big.NewInt(0).Sub(volume, helpers.BipToPip(big.NewInt(1)))
+	if volume := cState.Coins.GetCoin(coin).Volume(); volume.Cmp(expectedVolume) != 0 {
+		t.Fatalf("Coin volume is not correct. Expected %s, got %s", expectedVolume, volume)
+	}
+
+	cState.Accounts.SetPolicyState(addr, accounts.PolicyState{Policy: accounts.Policy{Recipients: []types.Address{{2}}}})
+	cState.StandingOrders.Execute(5+2*minStandingOrderInterval, 10)
+
+	if balance := cState.Accounts.GetBalance(recipient, coin); balance.Cmp(expectedBalance) != 0 {
+		t.Fatalf("Recipient balance is not correct. Expected %s, got %s", expectedBalance, balance)
+	}
+
+	expectedBalance = helpers.BipToPip(big.NewInt(900))
+	if balance := cState.Accounts.GetBalance(addr, coin); balance.Cmp(expectedBalance) != 0 {
+		t.Fatalf("Owner balance is not correct. Expected %s, got %s", expectedBalance, balance)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
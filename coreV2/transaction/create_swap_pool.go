@@ -113,7 +113,7 @@ func (data CreateSwapPoolData) Run(tx *Transaction, context state.Interface, rew
 		if tx.GasCoin == data.Coin0 {
 			amount0.Add(amount0, commission)
 		}
-		if checkState.Accounts().GetBalance(sender, data.Coin0).Cmp(amount0) == -1 {
+		if checkState.Accounts().GetSpendableBalance(sender, data.Coin0).Cmp(amount0) == -1 {
 			symbol := checkState.Coins().GetCoin(data.Coin0).GetFullSymbol()
 			return Response{
 				Code: code.InsufficientFunds,
@@ -128,7 +128,7 @@ func (data CreateSwapPoolData) Run(tx *Transaction, context state.Interface, rew
 		if tx.GasCoin == data.Coin1 {
 			totalAmount1.Add(totalAmount1, commission)
 		}
-		if checkState.Accounts().GetBalance(sender, data.Coin1).Cmp(totalAmount1) == -1 {
+		if checkState.Accounts().GetSpendableBalance(sender, data.Coin1).Cmp(totalAmount1) == -1 {
 			symbol := checkState.Coins().GetCoin(data.Coin1).GetFullSymbol()
 			return Response{
 				Code: code.InsufficientFunds,
@@ -138,7 +138,7 @@ func (data CreateSwapPoolData) Run(tx *Transaction, context state.Interface, rew
 		}
 	}
 
-	if checkState.Accounts().GetBalance(sender, tx.GasCoin).Cmp(commission) == -1 {
+	if checkState.Accounts().GetSpendableBalance(sender, tx.GasCoin).Cmp(commission) == -1 {
 		return Response{
 			Code: code.InsufficientFunds,
 			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), commission.String(), gasCoin.GetFullSymbol()),
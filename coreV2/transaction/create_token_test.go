@@ -189,3 +189,44 @@ func TestCreateTokenData_bbb(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestCreateTokenDataFreezable(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	cState.Accounts.AddBalance(addr, types.GetBaseCoinID(), helpers.BipToPip(big.NewInt(1000000)))
+
+	toCreate := types.StrToCoinSymbol("TOKEN1")
+	amount := helpers.BipToPip(big.NewInt(100))
+	data := CreateTokenDataV350{
+		Name:          "My Test Coin",
+		Symbol:        toCreate,
+		InitialAmount: amount,
+		MaxSupply:     amount,
+		Freezable:     []bool{true},
+	}
+	encodedTx, err := makeTestTx(TypeCreateToken, data, 1, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 0, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not %d. Error %s", code.OK, response.Log)
+	}
+
+	stateCoin := cState.Coins.GetCoinBySymbol(toCreate, 0)
+	if stateCoin == nil {
+		t.Fatalf("Coin %s not found in state", toCreate)
+	}
+
+	if !cState.Coins.IsFreezable(stateCoin.ID()) {
+		t.Fatal("Token is not freezable")
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,208 @@
+package transaction
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/state/commission"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/swap"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	abcTypes "github.com/tendermint/tendermint/abci/types"
+)
+
+type CreateTokenDataV350 struct {
+	Name          string
+	Symbol        types.CoinSymbol
+	InitialAmount *big.Int
+	MaxSupply     *big.Int
+	Mintable      bool
+	Burnable      bool
+	Freezable     []bool `rlp:"tail"`
+}
+
+// IsFreezable returns true if the owner of the token is able to pause its transfers and freeze addresses
+func (data CreateTokenDataV350) IsFreezable() bool {
+	return len(data.Freezable) != 0 && data.Freezable[0]
+}
+
+func (data CreateTokenDataV350) Gas() int64 {
+	return gasCreateToken
+}
+func (data CreateTokenDataV350) TxType() TxType {
+	return TypeCreateToken
+}
+
+func (data CreateTokenDataV350) basicCheck(tx *Transaction, context *state.CheckState) *Response {
+	if len(data.Name) > maxCoinNameBytes {
+		return &Response{
+			Code: code.InvalidCoinName,
+			Log:  fmt.Sprintf("Coin name is invalid. Allowed up to %d bytes.", maxCoinNameBytes),
+			Info: EncodeError(code.NewInvalidCoinName(strconv.Itoa(maxCoinNameBytes), strconv.Itoa(len(data.Name)))),
+		}
+	}
+
+	if !checkAllowSymbol(data.Symbol.String()) {
+		return &Response{
+			Code: code.InvalidCoinSymbol,
+			Log:  fmt.Sprintf("Invalid coin symbol. Should be %s and must contain characters", allowedCoinSymbols),
+			Info: EncodeError(code.NewInvalidCoinSymbol(allowedCoinSymbols, data.Symbol.String())),
+		}
+	}
+
+	if context.Coins().ExistsBySymbol(data.Symbol) {
+		return &Response{
+			Code: code.CoinAlreadyExists,
+			Log:  "Coin already exists",
+			Info: EncodeError(code.NewCoinAlreadyExists(types.StrToCoinSymbol(data.Symbol.String()).String(), context.Coins().GetCoinBySymbol(data.Symbol, 0).ID().String())),
+		}
+	}
+
+	if !data.Mintable && data.InitialAmount.Cmp(data.MaxSupply) != 0 {
+		return &Response{
+			Code: code.WrongCoinSupply,
+			Log:  fmt.Sprintf("Maximum supply cannot be more than the initial amount, if the token is not mintable"),
+			Info: EncodeError(code.NewWrongCoinSupply(minTokenSupply.String(), maxCoinSupply.String(), data.MaxSupply.String(), "", "", data.InitialAmount.String())),
+		}
+	}
+
+	if data.InitialAmount.Cmp(minTokenSupply) == -1 || data.InitialAmount.Cmp(data.MaxSupply) == 1 {
+		return &Response{
+			Code: code.WrongCoinSupply,
+			Log:  fmt.Sprintf("Coin amount should be between %s and %s", minTokenSupply.String(), data.MaxSupply.String()),
+			Info: EncodeError(code.NewWrongCoinSupply(minTokenSupply.String(), maxCoinSupply.String(), data.MaxSupply.String(), "", "", data.InitialAmount.String())),
+		}
+	}
+
+	if data.MaxSupply.Cmp(maxCoinSupply) == 1 {
+		return &Response{
+			Code: code.WrongCoinSupply,
+			Log:  fmt.Sprintf("Max coin supply should be less %s", maxCoinSupply.String()),
+			Info: EncodeError(code.NewWrongCoinSupply(minTokenSupply.String(), maxCoinSupply.String(), data.MaxSupply.String(), "", "", data.InitialAmount.String())),
+		}
+	}
+
+	return nil
+}
+
+func (data CreateTokenDataV350) String() string {
+	return fmt.Sprintf("CREATE TOKEN symbol:%s emission:%s",
+		data.Symbol.String(), data.MaxSupply)
+}
+
+func (data CreateTokenDataV350) CommissionData(price *commission.Price) *big.Int {
+	createTickerPrice := data.PayForSymbol(price)
+
+	return big.NewInt(0).Add(createTickerPrice, price.CreateCoin)
+}
+
+func (data CreateTokenDataV350) PayForSymbol(price *commission.Price) *big.Int {
+	switch len(data.Symbol.String()) {
+	case 3:
+		return price.CreateTicker3
+	case 4:
+		return price.CreateTicker4
+	case 5:
+		return price.CreateTicker5
+	case 6:
+		return price.CreateTicker6
+	default:
+		return price.CreateTicker7to10
+	}
+}
+
+func (data CreateTokenDataV350) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, price *big.Int) Response {
+	sender, _ := tx.Sender()
+
+	var checkState *state.CheckState
+	var isCheck bool
+	if checkState, isCheck = context.(*state.CheckState); !isCheck {
+		checkState = state.NewCheckState(context.(*state.State))
+	}
+	response := data.basicCheck(tx, checkState)
+	if response != nil {
+		return *response
+	}
+
+	commissionInBaseCoin := price
+	commissionPoolSwapper := checkState.Swap().GetSwapper(tx.GasCoin, types.GetBaseCoinID())
+	gasCoin := checkState.Coins().GetCoin(tx.GasCoin)
+	commission, isGasCommissionFromPoolSwap, errResp := CalculateCommission(checkState, commissionPoolSwapper, gasCoin, commissionInBaseCoin)
+	if errResp != nil {
+		return *errResp
+	}
+
+	if checkState.Accounts().GetSpendableBalance(sender, tx.GasCoin).Cmp(commission) == -1 {
+		return Response{
+			Code: code.InsufficientFunds,
+			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), commission.String(), gasCoin.GetFullSymbol()),
+			Info: EncodeError(code.NewInsufficientFunds(sender.String(), commission.String(), gasCoin.GetFullSymbol(), gasCoin.ID().String())),
+		}
+	}
+
+	var tags []abcTypes.EventAttribute
+	if deliverState, ok := context.(*state.State); ok {
+		var tagsCom *tagPoolChange
+		if isGasCommissionFromPoolSwap {
+			var (
+				poolIDCom  uint32
+				detailsCom *swap.ChangeDetailsWithOrders
+				ownersCom  []*swap.OrderDetail
+			)
+			commission, commissionInBaseCoin, poolIDCom, detailsCom, ownersCom = deliverState.Swapper().PairSellWithOrders(tx.CommissionCoin(), types.GetBaseCoinID(), commission, big.NewInt(0))
+			tagsCom = &tagPoolChange{
+				PoolID:   poolIDCom,
+				CoinIn:   tx.CommissionCoin(),
+				ValueIn:  commission.String(),
+				CoinOut:  types.GetBaseCoinID(),
+				ValueOut: commissionInBaseCoin.String(),
+				Orders:   detailsCom,
+				// Sellers:  ownersCom,
+			}
+			for _, value := range ownersCom {
+				deliverState.Accounts.AddBalance(value.Owner, tx.CommissionCoin(), value.ValueBigInt)
+			}
+		} else if !tx.GasCoin.IsBaseCoin() {
+			deliverState.Coins.SubVolume(tx.CommissionCoin(), commission)
+			deliverState.Coins.SubReserve(tx.CommissionCoin(), commissionInBaseCoin)
+		}
+		rewardPool.Add(rewardPool, commissionInBaseCoin)
+		deliverState.Accounts.SubBalance(sender, tx.GasCoin, commission)
+
+		coinId := checkState.App().GetNextCoinID()
+		deliverState.Coins.CreateToken(
+			coinId,
+			data.Symbol,
+			data.Name,
+			data.Mintable,
+			data.Burnable,
+			data.InitialAmount,
+			data.MaxSupply,
+			&sender,
+		)
+		if data.IsFreezable() {
+			deliverState.Coins.SetFreezable(coinId)
+		}
+
+		deliverState.App.SetCoinsCount(coinId.Uint32())
+		deliverState.Accounts.AddBalance(sender, coinId, data.InitialAmount)
+		deliverState.Accounts.SetNonce(sender, tx.Nonce)
+
+		tags = []abcTypes.EventAttribute{
+			{Key: []byte("tx.commission_in_base_coin"), Value: []byte(commissionInBaseCoin.String())},
+			{Key: []byte("tx.commission_conversion"), Value: []byte(isGasCommissionFromPoolSwap.String()), Index: true},
+			{Key: []byte("tx.commission_amount"), Value: []byte(commission.String())},
+			{Key: []byte("tx.commission_details"), Value: []byte(tagsCom.string())},
+			{Key: []byte("tx.coin_symbol"), Value: []byte(data.Symbol.String()), Index: true},
+			{Key: []byte("tx.coin_id"), Value: []byte(coinId.String()), Index: true},
+		}
+	}
+
+	return Response{
+		Code: code.OK,
+		Tags: tags,
+	}
+}
@@ -0,0 +1,158 @@
+package transaction
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/commission"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/swap"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/treasury"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	abcTypes "github.com/tendermint/tendermint/abci/types"
+)
+
+// CreateTreasuryProposalData proposes to pay Amount of Coin from the DAO treasury to Recipient.
+// The proposal is paid at the end of voting if validators with more than 2/3 of voting power voted for it
+type CreateTreasuryProposalData struct {
+	Recipient   types.Address
+	Coin        types.CoinID
+	Amount      *big.Int
+	Description string
+}
+
+func (data CreateTreasuryProposalData) Gas() int64 {
+	return gasCreateTreasuryProposal
+}
+
+func (data CreateTreasuryProposalData) TxType() TxType {
+	return TypeCreateTreasuryProposal
+}
+
+func (data CreateTreasuryProposalData) basicCheck(tx *Transaction, context *state.CheckState) *Response {
+	if data.Amount == nil || data.Amount.Sign() != 1 {
+		return &Response{
+			Code: code.DecodeError,
+			Log:  "Incorrect tx data",
+			Info: EncodeError(code.NewDecodeError()),
+		}
+	}
+
+	if !context.Coins().Exists(data.Coin) {
+		return &Response{
+			Code: code.CoinNotExists,
+			Log:  fmt.Sprintf("Coin %s not exists", data.Coin),
+			Info: EncodeError(code.NewCoinNotExists("", data.Coin.String())),
+		}
+	}
+
+	if len(data.Description) > treasury.MaxDescriptionLength {
+		return &Response{
+			Code: code.WrongTreasuryProposal,
+			Log:  fmt.Sprintf("Description should be no longer than %d bytes", treasury.MaxDescriptionLength),
+			Info: EncodeError(code.NewWrongTreasuryProposal("description is too long")),
+		}
+	}
+
+	if balance := context.Treasury().GetBalance(data.Coin); balance.Cmp(data.Amount) == -1 {
+		return &Response{
+			Code: code.WrongTreasuryProposal,
+			Log:  fmt.Sprintf("Treasury has only %s of coin %s", balance.String(), data.Coin),
+			Info: EncodeError(code.NewWrongTreasuryProposal("insufficient treasury funds")),
+		}
+	}
+
+	return nil
+}
+
+func (data CreateTreasuryProposalData) String() string {
+	return fmt.Sprintf("CREATE TREASURY PROPOSAL recipient:%s coin:%s amount:%s", data.Recipient.String(), data.Coin.String(), data.Amount.String())
+}
+
+func (data CreateTreasuryProposalData) CommissionData(price *commission.Price) *big.Int {
+	return price.CreateTreasuryProposalPrice()
+}
+
+func (data CreateTreasuryProposalData) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, price *big.Int) Response {
+	sender, _ := tx.Sender()
+
+	var checkState *state.CheckState
+	var isCheck bool
+	if checkState, isCheck = context.(*state.CheckState); !isCheck {
+		checkState = state.NewCheckState(context.(*state.State))
+	}
+
+	response := data.basicCheck(tx, checkState)
+	if response != nil {
+		return *response
+	}
+
+	commissionInBaseCoin := price
+	commissionPoolSwapper := checkState.Swap().GetSwapper(tx.GasCoin, types.GetBaseCoinID())
+	gasCoin := checkState.Coins().GetCoin(tx.GasCoin)
+	commission, isGasCommissionFromPoolSwap, errResp := CalculateCommission(checkState, commissionPoolSwapper, gasCoin, commissionInBaseCoin)
+	if errResp != nil {
+		return *errResp
+	}
+
+	if checkState.Accounts().GetSpendableBalance(sender, tx.GasCoin).Cmp(commission) < 0 {
+		return Response{
+			Code: code.InsufficientFunds,
+			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), commission.String(), gasCoin.GetFullSymbol()),
+			Info: EncodeError(code.NewInsufficientFunds(sender.String(), commission.String(), gasCoin.GetFullSymbol(), gasCoin.ID().String())),
+		}
+	}
+
+	var tags []abcTypes.EventAttribute
+	if deliverState, ok := context.(*state.State); ok {
+		var tagsCom *tagPoolChange
+		if isGasCommissionFromPoolSwap {
+			var (
+				poolIDCom  uint32
+				detailsCom *swap.ChangeDetailsWithOrders
+				ownersCom  []*swap.OrderDetail
+			)
+			commission, commissionInBaseCoin, poolIDCom, detailsCom, ownersCom = deliverState.Swapper().PairSellWithOrders(tx.CommissionCoin(), types.GetBaseCoinID(), commission, big.NewInt(0))
+			tagsCom = &tagPoolChange{
+				PoolID:   poolIDCom,
+				CoinIn:   tx.CommissionCoin(),
+				ValueIn:  commission.String(),
+				CoinOut:  types.GetBaseCoinID(),
+				ValueOut: commissionInBaseCoin.String(),
+				Orders:   detailsCom,
+				// Sellers:  ownersCom,
+			}
+			for _, value := range ownersCom {
+				deliverState.Accounts.AddBalance(value.Owner, tx.CommissionCoin(), value.ValueBigInt)
+			}
+		} else if !tx.GasCoin.IsBaseCoin() {
+			deliverState.Coins.SubVolume(tx.CommissionCoin(), commission)
+			deliverState.Coins.SubReserve(tx.CommissionCoin(), commissionInBaseCoin)
+		}
+		deliverState.Accounts.SubBalance(sender, tx.GasCoin, commission)
+		rewardPool.Add(rewardPool, commissionInBaseCoin)
+
+		votingEnd := currentBlock + checkState.Governance().VotingPeriod()
+		id := deliverState.Treasury.Create(sender, data.Recipient, data.Coin, data.Amount, data.Description, votingEnd)
+		deliverState.Accounts.SetNonce(sender, tx.Nonce)
+
+		tags = []abcTypes.EventAttribute{
+			{Key: []byte("tx.commission_in_base_coin"), Value: []byte(commissionInBaseCoin.String())},
+			{Key: []byte("tx.commission_conversion"), Value: []byte(isGasCommissionFromPoolSwap.String()), Index: true},
+			{Key: []byte("tx.commission_amount"), Value: []byte(commission.String())},
+			{Key: []byte("tx.commission_details"), Value: []byte(tagsCom.string())},
+			{Key: []byte("tx.proposal_id"), Value: []byte(strconv.FormatUint(id, 10)), Index: true},
+			{Key: []byte("tx.to"), Value: []byte(hex.EncodeToString(data.Recipient[:])), Index: true},
+			{Key: []byte("tx.coin_id"), Value: []byte(data.Coin.String()), Index: true},
+			{Key: []byte("tx.voting_end"), Value: []byte(strconv.FormatUint(votingEnd, 10))},
+		}
+	}
+
+	return Response{
+		Code: code.OK,
+		Tags: tags,
+	}
+}
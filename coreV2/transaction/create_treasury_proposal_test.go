@@ -0,0 +1,79 @@
+package transaction
+
+import (
+	"math/big"
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/crypto"
+	"github.com/MinterTeam/minter-go-node/helpers"
+)
+
+func TestCreateTreasuryProposalTx(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	coin := types.GetBaseCoinID()
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(1000)))
+
+	value := helpers.BipToPip(big.NewInt(100))
+	data := CreateTreasuryProposalData{Recipient: types.Address{1}, Coin: coin, Amount: value, Description: "grant"}
+	encodedTx, err := makeTestTx(TypeCreateTreasuryProposal, data, 1, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.WrongTreasuryProposal {
+		t.Fatalf("Response code is not %d. Error %s", code.WrongTreasuryProposal, response.Log)
+	}
+
+	cState.Treasury.AddIncome(coin, value)
+
+	response = NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not %d. Error %s", code.OK, response.Log)
+	}
+
+	proposal := cState.Treasury.GetProposal(1)
+	if proposal == nil || proposal.Recipient != data.Recipient || proposal.Amount.Cmp(value) != 0 {
+		t.Fatalf("Proposal is not created: %+v", proposal)
+	}
+
+	pubkey := types.Pubkey{}
+	rand.Read(pubkey[:])
+	cState.Candidates.Create(addr, addr, addr, pubkey, 10, 0, 0)
+
+	encodedTx, err = makeTestTx(TypeVoteTreasuryProposal, VoteTreasuryProposalData{PubKey: pubkey, ID: 1}, 2, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response = NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 2, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not %d. Error %s", code.OK, response.Log)
+	}
+
+	if !cState.Treasury.GetProposal(1).HasVote(pubkey) {
+		t.Fatal("Vote is not added")
+	}
+
+	encodedTx, err = makeTestTx(TypeVoteTreasuryProposal, VoteTreasuryProposalData{PubKey: pubkey, ID: 1}, 3, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response = NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), proposal.VotingEnd+1, &sync.Map{}, 0, false)
+	if response.Code != code.VoteExpired {
+		t.Fatalf("Response code is not %d. Error %s", code.VoteExpired, response.Log)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
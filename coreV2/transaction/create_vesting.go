@@ -0,0 +1,168 @@
+package transaction
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/accounts"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/commission"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/swap"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	abcTypes "github.com/tendermint/tendermint/abci/types"
+)
+
+type CreateVestingData struct {
+	To          types.Address
+	Coin        types.CoinID
+	Value       *big.Int
+	StartHeight uint64
+	CliffHeight uint64
+	EndHeight   uint64
+}
+
+func (data CreateVestingData) TxType() TxType {
+	return TypeCreateVesting
+}
+
+func (data CreateVestingData) Gas() int64 {
+	return gasCreateVesting
+}
+
+func (data CreateVestingData) basicCheck(tx *Transaction, context *state.CheckState, block uint64) *Response {
+	if data.Value == nil || data.Value.Sign() != 1 {
+		return &Response{
+			Code: code.DecodeError,
+			Log:  "Incorrect tx data",
+			Info: EncodeError(code.NewDecodeError()),
+		}
+	}
+
+	if data.StartHeight > data.CliffHeight || data.CliffHeight > data.EndHeight || data.StartHeight >= data.EndHeight || data.EndHeight <= block {
+		return &Response{
+			Code: code.WrongVestingSchedule,
+			Log:  "Vesting schedule should satisfy start height <= cliff height <= end height, start height < end height and end in the future",
+			Info: EncodeError(code.NewWrongVestingSchedule(strconv.FormatUint(data.StartHeight, 10), strconv.FormatUint(data.CliffHeight, 10), strconv.FormatUint(data.EndHeight, 10), strconv.FormatUint(block, 10))),
+		}
+	}
+
+	if !context.Coins().Exists(data.Coin) {
+		return &Response{
+			Code: code.CoinNotExists,
+			Log:  fmt.Sprintf("Coin %s not exists", data.Coin),
+			Info: EncodeError(code.NewCoinNotExists("", data.Coin.String())),
+		}
+	}
+
+	return nil
+}
+
+func (data CreateVestingData) String() string {
+	return fmt.Sprintf("CREATE VESTING to:%s coin:%s value:%s",
+		data.To.String(), data.Coin.String(), data.Value.String())
+}
+
+func (data CreateVestingData) CommissionData(price *commission.Price) *big.Int {
+	return price.CreateVestingPrice()
+}
+
+func (data CreateVestingData) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, price *big.Int) Response {
+	sender, _ := tx.Sender()
+	var checkState *state.CheckState
+	var isCheck bool
+	if checkState, isCheck = context.(*state.CheckState); !isCheck {
+		checkState = state.NewCheckState(context.(*state.State))
+	}
+
+	response := data.basicCheck(tx, checkState, currentBlock)
+	if response != nil {
+		return *response
+	}
+
+	commissionInBaseCoin := price
+	commissionPoolSwapper := checkState.Swap().GetSwapper(tx.GasCoin, types.GetBaseCoinID())
+	gasCoin := checkState.Coins().GetCoin(tx.GasCoin)
+	commission, isGasCommissionFromPoolSwap, errResp := CalculateCommission(checkState, commissionPoolSwapper, gasCoin, commissionInBaseCoin)
+	if errResp != nil {
+		return *errResp
+	}
+
+	needValue := big.NewInt(0).Set(commission)
+	if tx.GasCoin == data.Coin {
+		needValue.Add(data.Value, needValue)
+	} else {
+		if checkState.Accounts().GetSpendableBalance(sender, data.Coin).Cmp(data.Value) < 0 {
+			coin := checkState.Coins().GetCoin(data.Coin)
+			return Response{
+				Code: code.InsufficientFunds,
+				Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), data.Value.String(), coin.GetFullSymbol()),
+				Info: EncodeError(code.NewInsufficientFunds(sender.String(), data.Value.String(), coin.GetFullSymbol(), coin.ID().String())),
+			}
+		}
+	}
+	if checkState.Accounts().GetSpendableBalance(sender, tx.GasCoin).Cmp(needValue) < 0 {
+		return Response{
+			Code: code.InsufficientFunds,
+			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), needValue.String(), gasCoin.GetFullSymbol()),
+			Info: EncodeError(code.NewInsufficientFunds(sender.String(), needValue.String(), gasCoin.GetFullSymbol(), gasCoin.ID().String())),
+		}
+	}
+
+	var tags []abcTypes.EventAttribute
+	if deliverState, ok := context.(*state.State); ok {
+		var tagsCom *tagPoolChange
+		if isGasCommissionFromPoolSwap {
+			var (
+				poolIDCom  uint32
+				detailsCom *swap.ChangeDetailsWithOrders
+				ownersCom  []*swap.OrderDetail
+			)
+			commission, commissionInBaseCoin, poolIDCom, detailsCom, ownersCom = deliverState.Swapper().PairSellWithOrders(tx.CommissionCoin(), types.GetBaseCoinID(), commission, big.NewInt(0))
+			tagsCom = &tagPoolChange{
+				PoolID:   poolIDCom,
+				CoinIn:   tx.CommissionCoin(),
+				ValueIn:  commission.String(),
+				CoinOut:  types.GetBaseCoinID(),
+				ValueOut: commissionInBaseCoin.String(),
+				Orders:   detailsCom,
+				// Sellers:  ownersCom,
+			}
+			for _, value := range ownersCom {
+				deliverState.Accounts.AddBalance(value.Owner, tx.CommissionCoin(), value.ValueBigInt)
+			}
+		} else if !tx.GasCoin.IsBaseCoin() {
+			deliverState.Coins.SubVolume(tx.CommissionCoin(), commission)
+			deliverState.Coins.SubReserve(tx.CommissionCoin(), commissionInBaseCoin)
+		}
+		deliverState.Accounts.SubBalance(sender, tx.GasCoin, commission)
+		rewardPool.Add(rewardPool, commissionInBaseCoin)
+
+		deliverState.Accounts.SubBalance(sender, data.Coin, data.Value)
+		deliverState.Accounts.AddBalance(data.To, data.Coin, data.Value)
+		deliverState.Accounts.AddVesting(data.To, accounts.Vesting{
+			Coin:        data.Coin,
+			Value:       data.Value,
+			StartHeight: data.StartHeight,
+			CliffHeight: data.CliffHeight,
+			EndHeight:   data.EndHeight,
+		})
+		deliverState.Accounts.SetNonce(sender, tx.Nonce)
+
+		tags = []abcTypes.EventAttribute{
+			{Key: []byte("tx.commission_in_base_coin"), Value: []byte(commissionInBaseCoin.String())},
+			{Key: []byte("tx.commission_conversion"), Value: []byte(isGasCommissionFromPoolSwap.String()), Index: true},
+			{Key: []byte("tx.commission_amount"), Value: []byte(commission.String())},
+			{Key: []byte("tx.commission_details"), Value: []byte(tagsCom.string())},
+			{Key: []byte("tx.to"), Value: []byte(hex.EncodeToString(data.To[:])), Index: true},
+			{Key: []byte("tx.coin_id"), Value: []byte(data.Coin.String()), Index: true},
+		}
+	}
+
+	return Response{
+		Code: code.OK,
+		Tags: tags,
+	}
+}
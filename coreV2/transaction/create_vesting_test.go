@@ -0,0 +1,117 @@
+package transaction
+
+import (
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/crypto"
+	"github.com/MinterTeam/minter-go-node/helpers"
+)
+
+func TestCreateVestingTx(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	receiverKey, _ := crypto.GenerateKey()
+	receiver := crypto.PubkeyToAddress(receiverKey.PublicKey)
+
+	coin := types.GetBaseCoinID()
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(1000)))
+	cState.Accounts.AddBalance(receiver, coin, helpers.BipToPip(big.NewInt(10)))
+
+	value := helpers.BipToPip(big.NewInt(100))
+	encodedTx, err := makeTestTx(TypeCreateVesting, CreateVestingData{
+		To:          receiver,
+		Coin:        coin,
+		Value:       value,
+		StartHeight: 1,
+		CliffHeight: 50,
+		EndHeight:   101,
+	}, 1, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not %d. Error %s", code.OK, response.Log)
+	}
+
+	expectedBalance := helpers.BipToPip(big.NewInt(110))
+	if balance := cState.Accounts.GetBalance(receiver, coin); balance.Cmp(expectedBalance) != 0 {
+		t.Fatalf("Receiver balance is not correct. Expected %s, got %s", expectedBalance, balance)
+	}
+
+	if unvested := cState.Accounts.GetUnvestedBalance(receiver, coin); unvested.Cmp(value) != 0 {
+		t.Fatalf("Receiver unvested balance is not correct. Expected %s, got %s", value, unvested)
+	}
+
+	encodedTx, err = makeTestTx(TypeSend, SendData{
+		Coin:  coin,
+		To:    addr,
+		Value: helpers.BipToPip(big.NewInt(50)),
+	}, 1, receiverKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response = NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.InsufficientFunds {
+		t.Fatalf("Response code is not %d. Error %s", code.InsufficientFunds, response.Log)
+	}
+
+	encodedTx, err = makeTestTx(TypeSend, SendData{
+		Coin:  coin,
+		To:    addr,
+		Value: helpers.BipToPip(big.NewInt(5)),
+	}, 1, receiverKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response = NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not %d. Error %s", code.OK, response.Log)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCreateVestingTxToWrongSchedule(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+
+	coin := types.GetBaseCoinID()
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(1000)))
+
+	encodedTx, err := makeTestTx(TypeCreateVesting, CreateVestingData{
+		To:          types.Address{1},
+		Coin:        coin,
+		Value:       helpers.BipToPip(big.NewInt(100)),
+		StartHeight: 10,
+		CliffHeight: 5,
+		EndHeight:   101,
+	}, 1, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.WrongVestingSchedule {
+		t.Fatalf("Response code is not %d. Error %s", code.WrongVestingSchedule, response.Log)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
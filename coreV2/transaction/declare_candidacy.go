@@ -121,7 +121,7 @@ func (data DeclareCandidacyData) Run(tx *Transaction, context state.Interface, r
 		return *errResp
 	}
 
-	if checkState.Accounts().GetBalance(sender, data.Coin).Cmp(data.Stake) < 0 {
+	if checkState.Accounts().GetSpendableBalance(sender, data.Coin).Cmp(data.Stake) < 0 {
 		coin := checkState.Coins().GetCoin(data.Coin)
 		return Response{
 			Code: code.InsufficientFunds,
@@ -130,7 +130,7 @@ func (data DeclareCandidacyData) Run(tx *Transaction, context state.Interface, r
 		}
 	}
 
-	if checkState.Accounts().GetBalance(sender, tx.GasCoin).Cmp(commission) < 0 {
+	if checkState.Accounts().GetSpendableBalance(sender, tx.GasCoin).Cmp(commission) < 0 {
 		return Response{
 			Code: code.InsufficientFunds,
 			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), commission, gasCoin.GetFullSymbol()),
@@ -143,7 +143,7 @@ func (data DeclareCandidacyData) Run(tx *Transaction, context state.Interface, r
 		totalTxCost.Add(totalTxCost, data.Stake)
 		totalTxCost.Add(totalTxCost, commission)
 
-		if checkState.Accounts().GetBalance(sender, tx.GasCoin).Cmp(totalTxCost) < 0 {
+		if checkState.Accounts().GetSpendableBalance(sender, tx.GasCoin).Cmp(totalTxCost) < 0 {
 			return Response{
 				Code: code.InsufficientFunds,
 				Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), totalTxCost.String(), gasCoin.GetFullSymbol()),
@@ -79,7 +79,7 @@ func GetDataV1(txType TxType) (Data, bool) {
 }
 
 func GetData(txType TxType) (Data, bool) {
-	return GetDataV3(txType)
+	return GetDataV350(txType)
 }
 
 func GetDataV260(txType TxType) (Data, bool) {
@@ -124,6 +124,77 @@ func GetDataV3(txType TxType) (Data, bool) {
 		return GetDataV260(txType)
 	}
 }
+
+func GetDataV350(txType TxType) (Data, bool) {
+	switch txType {
+	case TypeEditCandidateCommission:
+		return &EditCandidateCommissionV350{}, true
+	case TypeMoveStake:
+		return &MoveStakeDataV350{}, true
+	case TypeCreateToken:
+		return &CreateTokenDataV350{}, true
+	case TypeEditCandidateDelegation:
+		return &EditCandidateDelegationData{}, true
+	case TypeSubmitMultisigProposal:
+		return &SubmitMultisigProposalData{}, true
+	case TypeApproveMultisigProposal:
+		return &ApproveMultisigProposalData{}, true
+	case TypeCreateVesting:
+		return &CreateVestingData{}, true
+	case TypeBatch:
+		return &BatchData{}, true
+	case TypeRevokeCheck:
+		return &RevokeCheckData{}, true
+	case TypeRedeemChecks:
+		return &RedeemChecksData{}, true
+	case TypeRedeemCheckV2:
+		return &RedeemCheckV2Data{}, true
+	case TypeCreateStandingOrder:
+		return &CreateStandingOrderData{}, true
+	case TypeCancelStandingOrder:
+		return &CancelStandingOrderData{}, true
+	case TypeCreateHTLC:
+		return &CreateHTLCData{}, true
+	case TypeClaimHTLC:
+		return &ClaimHTLCData{}, true
+	case TypeRefundHTLC:
+		return &RefundHTLCData{}, true
+	case TypeRotateKey:
+		return &RotateKeyData{}, true
+	case TypeSetKeyGuardian:
+		return &SetKeyGuardianData{}, true
+	case TypeSetAccountPolicy:
+		return &SetAccountPolicyData{}, true
+	case TypeEditCoinMetadata:
+		return &EditCoinMetadataData{}, true
+	case TypePauseToken:
+		return &PauseTokenData{}, true
+	case TypeUnpauseToken:
+		return &UnpauseTokenData{}, true
+	case TypeFreezeAddress:
+		return &FreezeAddressData{}, true
+	case TypeUnfreezeAddress:
+		return &UnfreezeAddressData{}, true
+	case TypeSetTokenTransferFee:
+		return &SetTokenTransferFeeData{}, true
+	case TypeDistributeToHolders:
+		return &DistributeToHoldersData{}, true
+	case TypeMigrateCoinToToken:
+		return &MigrateCoinToTokenData{}, true
+	case TypeCreateParamProposal:
+		return &CreateParamProposalData{}, true
+	case TypeVoteParamProposal:
+		return &VoteParamProposalData{}, true
+	case TypeCreateTreasuryProposal:
+		return &CreateTreasuryProposalData{}, true
+	case TypeVoteTreasuryProposal:
+		return &VoteTreasuryProposalData{}, true
+	case TypeVoteAsDelegator:
+		return &VoteAsDelegatorData{}, true
+	default:
+		return GetDataV3(txType)
+	}
+}
 func GetDataV250(txType TxType) (Data, bool) {
 	switch txType {
 	case TypeVoteCommission:
@@ -197,6 +268,13 @@ func DecodeSig(tx *Transaction) (*Transaction, error) {
 				return nil, err
 			}
 		}
+	case SigTypeAuthorized:
+		{
+			tx.authorized = &SignatureAuthorized{}
+			if err := rlp.DecodeBytes(tx.SignatureData, tx.authorized); err != nil {
+				return nil, err
+			}
+		}
 	default:
 		return nil, errors.New("unknown signature type")
 	}
@@ -216,6 +294,10 @@ func (e *Executor) DecodeFromBytesWithoutSig(buf []byte) (*Transaction, error) {
 		return nil, errors.New("incorrect tx data")
 	}
 
+	if len(tx.Sponsor) > 1 {
+		return nil, errors.New("incorrect tx sponsor")
+	}
+
 	d, ok := e.decodeTxFunc(tx.Type)
 
 	if !ok {
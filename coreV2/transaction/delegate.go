@@ -113,7 +113,7 @@ func (data DelegateData) Run(tx *Transaction, context state.Interface, rewardPoo
 		return *errResp
 	}
 
-	if checkState.Accounts().GetBalance(sender, tx.GasCoin).Cmp(commission) < 0 {
+	if checkState.Accounts().GetSpendableBalance(sender, tx.GasCoin).Cmp(commission) < 0 {
 		return Response{
 			Code: code.InsufficientFunds,
 			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), commission, gasCoin.GetFullSymbol()),
@@ -121,7 +121,7 @@ func (data DelegateData) Run(tx *Transaction, context state.Interface, rewardPoo
 		}
 	}
 
-	if checkState.Accounts().GetBalance(sender, data.Coin).Cmp(data.Value) < 0 {
+	if checkState.Accounts().GetSpendableBalance(sender, data.Coin).Cmp(data.Value) < 0 {
 		coin := checkState.Coins().GetCoin(data.Coin)
 		return Response{
 			Code: code.InsufficientFunds,
@@ -135,7 +135,7 @@ func (data DelegateData) Run(tx *Transaction, context state.Interface, rewardPoo
 		totalTxCost.Add(totalTxCost, data.Value)
 		totalTxCost.Add(totalTxCost, commission)
 
-		if checkState.Accounts().GetBalance(sender, tx.GasCoin).Cmp(totalTxCost) < 0 {
+		if checkState.Accounts().GetSpendableBalance(sender, tx.GasCoin).Cmp(totalTxCost) < 0 {
 			return Response{
 				Code: code.InsufficientFunds,
 				Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), totalTxCost.String(), gasCoin.GetFullSymbol()),
@@ -72,6 +72,10 @@ func (data DelegateDataV260) basicCheck(tx *Transaction, context *state.CheckSta
 		}
 	}
 
+	if errResp := checkDelegationSettings(context, sender, data.PubKey, data.Coin, value); errResp != nil {
+		return errResp
+	}
+
 	low, b := context.Candidates().IsDelegatorStakeAllowed(sender, data.PubKey, data.Coin, value)
 	if low {
 		return &Response{
@@ -122,7 +126,7 @@ func (data DelegateDataV260) Run(tx *Transaction, context state.Interface, rewar
 		return *errResp
 	}
 
-	if checkState.Accounts().GetBalance(sender, tx.GasCoin).Cmp(commission) < 0 {
+	if checkState.Accounts().GetSpendableBalance(sender, tx.GasCoin).Cmp(commission) < 0 {
 		return Response{
 			Code: code.InsufficientFunds,
 			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), commission, gasCoin.GetFullSymbol()),
@@ -130,7 +134,7 @@ func (data DelegateDataV260) Run(tx *Transaction, context state.Interface, rewar
 		}
 	}
 
-	if checkState.Accounts().GetBalance(sender, data.Coin).Cmp(data.Value) < 0 {
+	if checkState.Accounts().GetSpendableBalance(sender, data.Coin).Cmp(data.Value) < 0 {
 		coin := checkState.Coins().GetCoin(data.Coin)
 		return Response{
 			Code: code.InsufficientFunds,
@@ -144,7 +148,7 @@ func (data DelegateDataV260) Run(tx *Transaction, context state.Interface, rewar
 		totalTxCost.Add(totalTxCost, data.Value)
 		totalTxCost.Add(totalTxCost, commission)
 
-		if checkState.Accounts().GetBalance(sender, tx.GasCoin).Cmp(totalTxCost) < 0 {
+		if checkState.Accounts().GetSpendableBalance(sender, tx.GasCoin).Cmp(totalTxCost) < 0 {
 			return Response{
 				Code: code.InsufficientFunds,
 				Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), totalTxCost.String(), gasCoin.GetFullSymbol()),
@@ -0,0 +1,203 @@
+package transaction
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/commission"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/distributions"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/swap"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	abcTypes "github.com/tendermint/tendermint/abci/types"
+)
+
+// holdersPerUnit is the number of holders covered by the gas and the commission of DistributeToHoldersData
+const holdersPerUnit = 100
+
+// DistributeToHoldersData locks Value of Coin to be distributed pro-rata to holders of HolderCoin as of the current height,
+// holders are paid in batches at the end of blocks. The gas and the commission are charged for every holdersPerUnit committed holders
+type DistributeToHoldersData struct {
+	Coin       types.CoinID
+	Value      *big.Int
+	HolderCoin types.CoinID
+}
+
+func (data DistributeToHoldersData) Gas() int64 {
+	return gasDistributeToHolders
+}
+
+// units returns the number of holdersPerUnit batches in the committed holders of HolderCoin, but at least one
+func (data DistributeToHoldersData) units(context *state.CheckState) int64 {
+	holders := int64(context.Accounts().HoldersCount(data.HolderCoin))
+	if holders <= holdersPerUnit {
+		return 1
+	}
+
+	return (holders + holdersPerUnit - 1) / holdersPerUnit
+}
+
+func (data DistributeToHoldersData) TxType() TxType {
+	return TypeDistributeToHolders
+}
+
+func (data DistributeToHoldersData) basicCheck(tx *Transaction, context *state.CheckState) *Response {
+	if data.Value == nil || data.Value.Sign() != 1 {
+		return &Response{
+			Code: code.DecodeError,
+			Log:  "Incorrect tx data",
+			Info: EncodeError(code.NewDecodeError()),
+		}
+	}
+
+	if !context.Coins().Exists(data.Coin) {
+		return &Response{
+			Code: code.CoinNotExists,
+			Log:  fmt.Sprintf("Coin %s not exists", data.Coin),
+			Info: EncodeError(code.NewCoinNotExists("", data.Coin.String())),
+		}
+	}
+
+	if !context.Coins().Exists(data.HolderCoin) {
+		return &Response{
+			Code: code.CoinNotExists,
+			Log:  fmt.Sprintf("Coin %s not exists", data.HolderCoin),
+			Info: EncodeError(code.NewCoinNotExists("", data.HolderCoin.String())),
+		}
+	}
+
+	holders := context.Accounts().HoldersCount(data.HolderCoin)
+	if holders == 0 {
+		return &Response{
+			Code: code.WrongDistribution,
+			Log:  fmt.Sprintf("Coin %s has no holders", data.HolderCoin),
+			Info: EncodeError(code.NewWrongDistribution(data.HolderCoin.String(), "no holders")),
+		}
+	}
+
+	if holders > distributions.MaxHolders {
+		return &Response{
+			Code: code.WrongDistribution,
+			Log:  fmt.Sprintf("Coin %s has more than %d holders", data.HolderCoin, distributions.MaxHolders),
+			Info: EncodeError(code.NewWrongDistribution(data.HolderCoin.String(), "too many holders")),
+		}
+	}
+
+	return nil
+}
+
+func (data DistributeToHoldersData) String() string {
+	return fmt.Sprintf("DISTRIBUTE TO HOLDERS coin:%s value:%s holder_coin:%s", data.Coin.String(), data.Value.String(), data.HolderCoin.String())
+}
+
+func (data DistributeToHoldersData) CommissionData(price *commission.Price) *big.Int {
+	return price.DistributeToHoldersPrice()
+}
+
+func (data DistributeToHoldersData) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, price *big.Int) Response {
+	sender, _ := tx.Sender()
+
+	var checkState *state.CheckState
+	var isCheck bool
+	if checkState, isCheck = context.(*state.CheckState); !isCheck {
+		checkState = state.NewCheckState(context.(*state.State))
+	}
+
+	response := data.basicCheck(tx, checkState)
+	if response != nil {
+		return *response
+	}
+
+	commissionInBaseCoin := price
+	commissionPoolSwapper := checkState.Swap().GetSwapper(tx.GasCoin, types.GetBaseCoinID())
+	gasCoin := checkState.Coins().GetCoin(tx.GasCoin)
+	commission, isGasCommissionFromPoolSwap, errResp := CalculateCommission(checkState, commissionPoolSwapper, gasCoin, commissionInBaseCoin)
+	if errResp != nil {
+		return *errResp
+	}
+
+	coin := checkState.Coins().GetCoin(data.Coin)
+	if checkState.Accounts().GetSpendableBalance(sender, data.Coin).Cmp(data.Value) < 0 {
+		return Response{
+			Code: code.InsufficientFunds,
+			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), data.Value.String(), coin.GetFullSymbol()),
+			Info: EncodeError(code.NewInsufficientFunds(sender.String(), data.Value.String(), coin.GetFullSymbol(), coin.ID().String())),
+		}
+	}
+
+	if checkState.Accounts().GetSpendableBalance(sender, tx.GasCoin).Cmp(commission) < 0 {
+		return Response{
+			Code: code.InsufficientFunds,
+			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), commission.String(), gasCoin.GetFullSymbol()),
+			Info: EncodeError(code.NewInsufficientFunds(sender.String(), commission.String(), gasCoin.GetFullSymbol(), gasCoin.ID().String())),
+		}
+	}
+
+	if data.Coin == tx.GasCoin {
+		totalTxCost := big.NewInt(0).Add(data.Value, commission)
+		if checkState.Accounts().GetSpendableBalance(sender, tx.GasCoin).Cmp(totalTxCost) < 0 {
+			return Response{
+				Code: code.InsufficientFunds,
+				Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), totalTxCost.String(), gasCoin.GetFullSymbol()),
+				Info: EncodeError(code.NewInsufficientFunds(sender.String(), totalTxCost.String(), gasCoin.GetFullSymbol(), gasCoin.ID().String())),
+			}
+		}
+	}
+
+	var tags []abcTypes.EventAttribute
+	if deliverState, ok := context.(*state.State); ok {
+		var tagsCom *tagPoolChange
+		if isGasCommissionFromPoolSwap {
+			var (
+				poolIDCom  uint32
+				detailsCom *swap.ChangeDetailsWithOrders
+				ownersCom  []*swap.OrderDetail
+			)
+			commission, commissionInBaseCoin, poolIDCom, detailsCom, ownersCom = deliverState.Swapper().PairSellWithOrders(tx.CommissionCoin(), types.GetBaseCoinID(), commission, big.NewInt(0))
+			tagsCom = &tagPoolChange{
+				PoolID:   poolIDCom,
+				CoinIn:   tx.CommissionCoin(),
+				ValueIn:  commission.String(),
+				CoinOut:  types.GetBaseCoinID(),
+				ValueOut: commissionInBaseCoin.String(),
+				Orders:   detailsCom,
+				// Sellers:  ownersCom,
+			}
+			for _, value := range ownersCom {
+				deliverState.Accounts.AddBalance(value.Owner, tx.CommissionCoin(), value.ValueBigInt)
+			}
+		} else if !tx.GasCoin.IsBaseCoin() {
+			deliverState.Coins.SubVolume(tx.CommissionCoin(), commission)
+			deliverState.Coins.SubReserve(tx.CommissionCoin(), commissionInBaseCoin)
+		}
+		deliverState.Accounts.SubBalance(sender, tx.GasCoin, commission)
+		rewardPool.Add(rewardPool, commissionInBaseCoin)
+
+		deliverState.Accounts.SubBalance(sender, data.Coin, data.Value)
+
+		holders := deliverState.Accounts.GetHolders(data.HolderCoin)
+		shares := make([]distributions.Share, 0, len(holders))
+		for _, holder := range holders {
+			shares = append(shares, distributions.Share{Address: holder.Address, Balance: holder.Value})
+		}
+		id := deliverState.Distributions.Create(sender, data.Coin, data.Value, data.HolderCoin, shares)
+		deliverState.Accounts.SetNonce(sender, tx.Nonce)
+
+		tags = []abcTypes.EventAttribute{
+			{Key: []byte("tx.commission_in_base_coin"), Value: []byte(commissionInBaseCoin.String())},
+			{Key: []byte("tx.commission_conversion"), Value: []byte(isGasCommissionFromPoolSwap.String()), Index: true},
+			{Key: []byte("tx.commission_amount"), Value: []byte(commission.String())},
+			{Key: []byte("tx.commission_details"), Value: []byte(tagsCom.string())},
+			{Key: []byte("tx.coin_id"), Value: []byte(data.Coin.String()), Index: true},
+			{Key: []byte("tx.distribution_id"), Value: []byte(strconv.FormatUint(id, 10)), Index: true},
+			{Key: []byte("tx.holders"), Value: []byte(strconv.Itoa(len(shares)))},
+		}
+	}
+
+	return Response{
+		Code: code.OK,
+		Tags: tags,
+	}
+}
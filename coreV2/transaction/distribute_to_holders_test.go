@@ -0,0 +1,154 @@
+package transaction
+
+import (
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/crypto"
+	"github.com/MinterTeam/minter-go-node/helpers"
+)
+
+func TestDistributeToHoldersTx(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+	cState.Accounts.EnableHoldersIndex()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	coin := types.GetBaseCoinID()
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(1000)))
+
+	holderCoin := createNonReserveCoin(cState)
+	volume := helpers.BipToPip(big.NewInt(100000))
+	cState.Accounts.SubBalance(types.Address{}, holderCoin, volume)
+	cState.Accounts.AddBalance(types.Address{1}, holderCoin, helpers.BipToPip(big.NewInt(75000)))
+	cState.Accounts.AddBalance(types.Address{2}, holderCoin, helpers.BipToPip(big.NewInt(25000)))
+	if _, err := cState.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	value := big.NewInt(1001)
+	encodedTx, err := makeTestTx(TypeDistributeToHolders, DistributeToHoldersData{Coin: coin, Value: value, HolderCoin: holderCoin}, 1, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 0, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not %d. Error %s", code.OK, response.Log)
+	}
+
+	distribution := cState.Distributions.GetDistribution(1)
+	if distribution == nil || distribution.Holders != 2 {
+		t.Fatalf("Distribution is not created: %+v", distribution)
+	}
+
+	ownerBalance := cState.Accounts.GetBalance(addr, coin)
+
+	cState.Distributions.Process(1)
+	if balance := cState.Accounts.GetBalance(types.Address{1}, coin); balance.Cmp(big.NewInt(750)) != 0 {
+		t.Fatalf("Holder balance is not correct. Expected %d, got %s", 750, balance)
+	}
+	if balance := cState.Accounts.GetBalance(types.Address{2}, coin); balance.Sign() != 0 {
+		t.Fatalf("Holder is paid out of the batch: %s", balance)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+
+	cState.Distributions.Process(10)
+	if balance := cState.Accounts.GetBalance(types.Address{2}, coin); balance.Cmp(big.NewInt(250)) != 0 {
+		t.Fatalf("Holder balance is not correct. Expected %d, got %s", 250, balance)
+	}
+
+	if cState.Distributions.GetDistribution(1) != nil {
+		t.Fatal("Distribution is not deleted after the last payment")
+	}
+
+	if balance := cState.Accounts.GetBalance(addr, coin); big.NewInt(0).Sub(balance, ownerBalance).Cmp(big.NewInt(1)) != 0 {
+		t.Fatalf("Rest is not refunded to the owner. Got %s", big.NewInt(0).Sub(balance, ownerBalance))
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestDistributeToHoldersTxToCoinWithoutHolders(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+	cState.Accounts.EnableHoldersIndex()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	coin := types.GetBaseCoinID()
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(1000)))
+
+	holderCoin := createNonReserveCoin(cState)
+	cState.Accounts.SubBalance(types.Address{}, holderCoin, helpers.BipToPip(big.NewInt(100000)))
+	cState.Coins.SubVolume(holderCoin, helpers.BipToPip(big.NewInt(100000)))
+
+	encodedTx, err := makeTestTx(TypeDistributeToHolders, DistributeToHoldersData{Coin: coin, Value: big.NewInt(1000), HolderCoin: holderCoin}, 1, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 0, &sync.Map{}, 0, false)
+	if response.Code != code.WrongDistribution {
+		t.Fatalf("Response code is not %d. Error %s", code.WrongDistribution, response.Log)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestDistributeToHoldersTxToScaleCommissionWithHolders(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+	cState.Accounts.EnableHoldersIndex()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	coin := types.GetBaseCoinID()
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(1000000)))
+
+	holderCoin := createNonReserveCoin(cState)
+	cState.Accounts.SubBalance(types.Address{}, holderCoin, helpers.BipToPip(big.NewInt(100000)))
+	for i := 1; i <= holdersPerUnit+50; i++ {
+		cState.Accounts.AddBalance(types.BigToAddress(big.NewInt(int64(i))), holderCoin, big.NewInt(1))
+	}
+	cState.Coins.SubVolume(holderCoin, big.NewInt(0).Sub(helpers.BipToPip(big.NewInt(100000)), big.NewInt(holdersPerUnit+50)))
+	if _, err := cState.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	value := big.NewInt(1000)
+	encodedTx, err := makeTestTx(TypeDistributeToHolders, DistributeToHoldersData{Coin: coin, Value: value, HolderCoin: holderCoin}, 1, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 0, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not %d. Error %s", code.OK, response.Log)
+	}
+
+	if gas := int64(gasBase + 2*gasDistributeToHolders); response.GasUsed != gas {
+		t.Fatalf("Gas used is not correct. Expected %d, got %d", gas, response.GasUsed)
+	}
+
+	price := big.NewInt(0).Mul(cState.Commission.GetCommissions().DistributeToHoldersPrice(), big.NewInt(2))
+	spent := big.NewInt(0).Sub(helpers.BipToPip(big.NewInt(1000000)), cState.Accounts.GetBalance(addr, coin))
+	if expected := big.NewInt(0).Add(value, price); spent.Cmp(expected) != 0 {
+		t.Fatalf("Spent value is not correct. Expected %s, got %s", expected, spent)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,73 @@
+package transaction
+
+import (
+	"math/big"
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/crypto"
+	"github.com/MinterTeam/minter-go-node/helpers"
+)
+
+func TestEditCandidateCommissionTx(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	cState.Accounts.AddBalance(addr, types.GetBaseCoinID(), helpers.BipToPip(big.NewInt(1000000)))
+
+	pubkey := types.Pubkey{}
+	rand.Read(pubkey[:])
+	cState.Candidates.Create(addr, addr, addr, pubkey, 10, 0, 0)
+
+	currentBlock := 3*types.GetUnbondPeriod() + 1
+	encodedTx, err := makeTestTx(TypeEditCandidateCommission, EditCandidateCommissionV350{
+		PubKey:     pubkey,
+		Commission: 20,
+	}, 1, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), currentBlock, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not %d. Error %s", code.OK, response.Log)
+	}
+
+	candidate := cState.Candidates.GetCandidate(pubkey)
+	if candidate.Commission != 10 {
+		t.Fatalf("Commission changed before effective height: %d", candidate.Commission)
+	}
+
+	pending := cState.Candidates.GetPendingCommission(pubkey)
+	if pending == nil {
+		t.Fatal("Pending commission not found")
+	}
+
+	effectiveHeight := currentBlock + types.GetUnbondPeriod()
+	if pending.Commission != 20 || pending.Height != effectiveHeight {
+		t.Fatalf("Wrong pending commission: %+v", pending)
+	}
+
+	cState.Candidates.ApplyPendingCommissions(effectiveHeight - 1)
+	if candidate.Commission != 10 {
+		t.Fatalf("Commission changed before effective height: %d", candidate.Commission)
+	}
+
+	cState.Candidates.ApplyPendingCommissions(effectiveHeight)
+	if candidate.Commission != 20 {
+		t.Fatalf("Commission is not changed. Expected %d, got %d", 20, candidate.Commission)
+	}
+
+	if cState.Candidates.GetPendingCommission(pubkey) != nil {
+		t.Fatal("Pending commission is not deleted")
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,193 @@
+package transaction
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/candidates"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/commission"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/swap"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	abcTypes "github.com/tendermint/tendermint/abci/types"
+)
+
+const maxDelegationAllowListLength = 100
+
+type EditCandidateDelegationData struct {
+	PubKey        types.Pubkey
+	MaxStake      *big.Int
+	MinDelegation *big.Int
+	AllowList     []types.Address
+}
+
+func (data EditCandidateDelegationData) Gas() int64 {
+	return gasEditCandidateDelegation
+}
+func (data EditCandidateDelegationData) TxType() TxType {
+	return TypeEditCandidateDelegation
+}
+
+func (data EditCandidateDelegationData) GetPubKey() types.Pubkey {
+	return data.PubKey
+}
+
+func (data EditCandidateDelegationData) basicCheck(tx *Transaction, context *state.CheckState) *Response {
+	if data.MaxStake == nil || data.MinDelegation == nil {
+		return &Response{
+			Code: code.DecodeError,
+			Log:  "Incorrect tx data",
+			Info: EncodeError(code.NewDecodeError()),
+		}
+	}
+
+	if len(data.AllowList) > maxDelegationAllowListLength {
+		return &Response{
+			Code: code.TooLargeAllowList,
+			Log:  fmt.Sprintf("Allow list can not contain more than %d addresses", maxDelegationAllowListLength),
+			Info: EncodeError(code.NewTooLargeAllowList(strconv.Itoa(len(data.AllowList)), strconv.Itoa(maxDelegationAllowListLength))),
+		}
+	}
+
+	usedAddresses := map[types.Address]bool{}
+	for _, address := range data.AllowList {
+		if usedAddresses[address] {
+			return &Response{
+				Code: code.DuplicatedAddresses,
+				Log:  "Duplicated allow list addresses",
+				Info: EncodeError(code.NewDuplicatedAddresses(address.String())),
+			}
+		}
+
+		usedAddresses[address] = true
+	}
+
+	return checkCandidateOwnership(data, tx, context)
+}
+
+func (data EditCandidateDelegationData) String() string {
+	return fmt.Sprintf("EDIT CANDIDATE DELEGATION pubkey: %x",
+		data.PubKey)
+}
+
+func (data EditCandidateDelegationData) CommissionData(price *commission.Price) *big.Int {
+	return price.EditCandidateDelegationPrice()
+}
+
+func (data EditCandidateDelegationData) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, price *big.Int) Response {
+	sender, _ := tx.Sender()
+
+	var checkState *state.CheckState
+	var isCheck bool
+	if checkState, isCheck = context.(*state.CheckState); !isCheck {
+		checkState = state.NewCheckState(context.(*state.State))
+	}
+
+	response := data.basicCheck(tx, checkState)
+	if response != nil {
+		return *response
+	}
+
+	commissionInBaseCoin := price
+	commissionPoolSwapper := checkState.Swap().GetSwapper(tx.GasCoin, types.GetBaseCoinID())
+	gasCoin := checkState.Coins().GetCoin(tx.GasCoin)
+	commission, isGasCommissionFromPoolSwap, errResp := CalculateCommission(checkState, commissionPoolSwapper, gasCoin, commissionInBaseCoin)
+	if errResp != nil {
+		return *errResp
+	}
+
+	if checkState.Accounts().GetSpendableBalance(sender, tx.GasCoin).Cmp(commission) < 0 {
+		return Response{
+			Code: code.InsufficientFunds,
+			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), commission.String(), gasCoin.GetFullSymbol()),
+			Info: EncodeError(code.NewInsufficientFunds(sender.String(), commission.String(), gasCoin.GetFullSymbol(), gasCoin.ID().String())),
+		}
+	}
+
+	var tags []abcTypes.EventAttribute
+	if deliverState, ok := context.(*state.State); ok {
+		var tagsCom *tagPoolChange
+		if isGasCommissionFromPoolSwap {
+			var (
+				poolIDCom  uint32
+				detailsCom *swap.ChangeDetailsWithOrders
+				ownersCom  []*swap.OrderDetail
+			)
+			commission, commissionInBaseCoin, poolIDCom, detailsCom, ownersCom = deliverState.Swapper().PairSellWithOrders(tx.CommissionCoin(), types.GetBaseCoinID(), commission, big.NewInt(0))
+			tagsCom = &tagPoolChange{
+				PoolID:   poolIDCom,
+				CoinIn:   tx.CommissionCoin(),
+				ValueIn:  commission.String(),
+				CoinOut:  types.GetBaseCoinID(),
+				ValueOut: commissionInBaseCoin.String(),
+				Orders:   detailsCom,
+				// Sellers:  ownersCom,
+			}
+			for _, value := range ownersCom {
+				deliverState.Accounts.AddBalance(value.Owner, tx.CommissionCoin(), value.ValueBigInt)
+			}
+		} else if !tx.GasCoin.IsBaseCoin() {
+			deliverState.Coins.SubVolume(tx.CommissionCoin(), commission)
+			deliverState.Coins.SubReserve(tx.CommissionCoin(), commissionInBaseCoin)
+		}
+		deliverState.Accounts.SubBalance(sender, tx.GasCoin, commission)
+		rewardPool.Add(rewardPool, commissionInBaseCoin)
+
+		deliverState.Candidates.SetDelegationSettings(data.PubKey, &candidates.DelegationSettings{
+			MaxStake:      big.NewInt(0).Set(data.MaxStake),
+			MinDelegation: big.NewInt(0).Set(data.MinDelegation),
+			AllowList:     data.AllowList,
+		})
+		deliverState.Accounts.SetNonce(sender, tx.Nonce)
+
+		tags = []abcTypes.EventAttribute{
+			{Key: []byte("tx.commission_in_base_coin"), Value: []byte(commissionInBaseCoin.String())},
+			{Key: []byte("tx.commission_conversion"), Value: []byte(isGasCommissionFromPoolSwap.String()), Index: true},
+			{Key: []byte("tx.commission_amount"), Value: []byte(commission.String())},
+			{Key: []byte("tx.commission_details"), Value: []byte(tagsCom.string())},
+			{Key: []byte("tx.public_key"), Value: []byte(hex.EncodeToString(data.PubKey[:])), Index: true},
+		}
+	}
+
+	return Response{
+		Code: code.OK,
+		Tags: tags,
+	}
+}
+
+// checkDelegationSettings checks a new stake against the delegation restrictions set by the candidate owner
+func checkDelegationSettings(context *state.CheckState, sender types.Address, pubKey types.Pubkey, coinID types.CoinID, value *big.Int) *Response {
+	settings := context.Candidates().GetDelegationSettings(pubKey)
+	if settings == nil {
+		return nil
+	}
+
+	if !settings.IsAllowed(sender) {
+		return &Response{
+			Code: code.DelegatorNotAllowed,
+			Log:  "Sender is not allowed to delegate to this candidate",
+			Info: EncodeError(code.NewDelegatorNotAllowed(sender.String(), pubKey.String())),
+		}
+	}
+
+	low, b := context.Candidates().IsDelegationWithinLimits(pubKey, coinID, value)
+	if low {
+		return &Response{
+			Code: code.TooLowDelegation,
+			Log:  fmt.Sprintf("Stake is less than the minimum delegation of the candidate: %s", settings.MinDelegation),
+			Info: EncodeError(code.NewTooLowDelegation(pubKey.String(), value.String(), coinID.String(), context.Coins().GetCoin(coinID).GetFullSymbol(), settings.MinDelegation.String())),
+		}
+	}
+	if b {
+		return &Response{
+			Code: code.StakeLimitReached,
+			Log:  fmt.Sprintf("Total stake of the candidate would exceed its limit: %s", settings.MaxStake),
+			Info: EncodeError(code.NewStakeLimitReached(pubKey.String(), value.String(), coinID.String(), context.Coins().GetCoin(coinID).GetFullSymbol(), settings.MaxStake.String())),
+		}
+	}
+
+	return nil
+}
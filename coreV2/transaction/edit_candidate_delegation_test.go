@@ -0,0 +1,255 @@
+package transaction
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/crypto"
+	"github.com/MinterTeam/minter-go-node/helpers"
+	"github.com/MinterTeam/minter-go-node/rlp"
+)
+
+func makeTestTx(txType TxType, data interface{}, nonce uint64, privateKey *ecdsa.PrivateKey) ([]byte, error) {
+	encodedData, err := rlp.EncodeToBytes(data)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := Transaction{
+		Nonce:         nonce,
+		GasPrice:      1,
+		ChainID:       types.CurrentChainID,
+		GasCoin:       types.GetBaseCoinID(),
+		Type:          txType,
+		Data:          encodedData,
+		SignatureType: SigTypeSingle,
+	}
+
+	if err := tx.Sign(privateKey); err != nil {
+		return nil, err
+	}
+
+	return rlp.EncodeToBytes(tx)
+}
+
+func createTestCandidateWithDelegationSettings(t *testing.T, cState *state.State, data EditCandidateDelegationData) types.Pubkey {
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	cState.Accounts.AddBalance(addr, types.GetBaseCoinID(), helpers.BipToPip(big.NewInt(1000000)))
+
+	pubkey := types.Pubkey{}
+	rand.Read(pubkey[:])
+	cState.Candidates.Create(addr, addr, addr, pubkey, 10, 0, 0)
+
+	data.PubKey = pubkey
+	encodedTx, err := makeTestTx(TypeEditCandidateDelegation, data, 1, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 0, &sync.Map{}, 0, false)
+	if response.Code != 0 {
+		t.Fatalf("Response code is not 0. Error %s", response.Log)
+	}
+
+	return pubkey
+}
+
+func TestEditCandidateDelegationTx(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	allowed := types.Address{1}
+	pubkey := createTestCandidateWithDelegationSettings(t, cState, EditCandidateDelegationData{
+		MaxStake:      helpers.BipToPip(big.NewInt(1000)),
+		MinDelegation: helpers.BipToPip(big.NewInt(10)),
+		AllowList:     []types.Address{allowed},
+	})
+
+	if err := checkState(cState); err != nil {
+		t.Fatal(err)
+	}
+
+	settings := cState.Candidates.GetDelegationSettings(pubkey)
+	if settings == nil {
+		t.Fatal("Delegation settings not found")
+	}
+
+	if settings.MaxStake.Cmp(helpers.BipToPip(big.NewInt(1000))) != 0 {
+		t.Fatalf("Max stake is not correct. Expected %s, got %s", helpers.BipToPip(big.NewInt(1000)), settings.MaxStake)
+	}
+
+	if !settings.IsAllowed(allowed) || settings.IsAllowed(types.Address{2}) {
+		t.Fatal("Allow list is not correct")
+	}
+}
+
+func TestEditCandidateDelegationTxToNotOwner(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	pubkey := createTestCandidate(cState)
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	cState.Accounts.AddBalance(addr, types.GetBaseCoinID(), helpers.BipToPip(big.NewInt(1000000)))
+
+	encodedTx, err := makeTestTx(TypeEditCandidateDelegation, EditCandidateDelegationData{
+		PubKey:        pubkey,
+		MaxStake:      big.NewInt(0),
+		MinDelegation: big.NewInt(0),
+	}, 1, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 0, &sync.Map{}, 0, false)
+	if response.Code != code.IsNotOwnerOfCandidate {
+		t.Fatalf("Response code is not %d. Error %s", code.IsNotOwnerOfCandidate, response.Log)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestEditCandidateDelegationTxWithDuplicatedAddresses(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	cState.Accounts.AddBalance(addr, types.GetBaseCoinID(), helpers.BipToPip(big.NewInt(1000000)))
+
+	pubkey := types.Pubkey{}
+	rand.Read(pubkey[:])
+	cState.Candidates.Create(addr, addr, addr, pubkey, 10, 0, 0)
+
+	encodedTx, err := makeTestTx(TypeEditCandidateDelegation, EditCandidateDelegationData{
+		PubKey:        pubkey,
+		MaxStake:      big.NewInt(0),
+		MinDelegation: big.NewInt(0),
+		AllowList:     []types.Address{{1}, {1}},
+	}, 1, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 0, &sync.Map{}, 0, false)
+	if response.Code != code.DuplicatedAddresses {
+		t.Fatalf("Response code is not %d. Error %s", code.DuplicatedAddresses, response.Log)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDelegateTxToRestrictedCandidate(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	coin := types.GetBaseCoinID()
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(1000000)))
+
+	pubkey := createTestCandidateWithDelegationSettings(t, cState, EditCandidateDelegationData{
+		MaxStake:      helpers.BipToPip(big.NewInt(1000)),
+		MinDelegation: helpers.BipToPip(big.NewInt(10)),
+		AllowList:     []types.Address{addr},
+	})
+
+	cases := []struct {
+		value *big.Int
+		code  uint32
+	}{
+		{value: helpers.BipToPip(big.NewInt(1)), code: code.TooLowDelegation},
+		{value: helpers.BipToPip(big.NewInt(1001)), code: code.StakeLimitReached},
+		{value: helpers.BipToPip(big.NewInt(100)), code: code.OK},
+	}
+
+	for _, c := range cases {
+		encodedTx, err := makeTestTx(TypeDelegate, DelegateDataV260{
+			PubKey: pubkey,
+			Coin:   coin,
+			Value:  c.value,
+		}, 1, privateKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		response := NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 0, &sync.Map{}, 0, false)
+		if response.Code != c.code {
+			t.Fatalf("Response code is not %d. Error %s", c.code, response.Log)
+		}
+	}
+
+	otherPrivateKey, _ := crypto.GenerateKey()
+	otherAddr := crypto.PubkeyToAddress(otherPrivateKey.PublicKey)
+	cState.Accounts.AddBalance(otherAddr, coin, helpers.BipToPip(big.NewInt(1000000)))
+
+	encodedTx, err := makeTestTx(TypeDelegate, DelegateDataV260{
+		PubKey: pubkey,
+		Coin:   coin,
+		Value:  helpers.BipToPip(big.NewInt(100)),
+	}, 1, otherPrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 0, &sync.Map{}, 0, false)
+	if response.Code != code.DelegatorNotAllowed {
+		t.Fatalf("Response code is not %d. Error %s", code.DelegatorNotAllowed, response.Log)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMoveStakeTxToRestrictedCandidate(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	coin := types.GetBaseCoinID()
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(1000000)))
+
+	from := createTestCandidate(cState)
+	value := helpers.BipToPip(big.NewInt(100))
+	cState.Candidates.Delegate(addr, from, coin, value, value)
+	cState.Candidates.RecalculateStakes(0)
+
+	to := createTestCandidateWithDelegationSettings(t, cState, EditCandidateDelegationData{
+		MaxStake:      big.NewInt(0),
+		MinDelegation: big.NewInt(0),
+		AllowList:     []types.Address{{1}},
+	})
+
+	encodedTx, err := makeTestTx(TypeMoveStake, MoveStakeDataV350{
+		FromPubKey: from,
+		ToPubKey:   to,
+		Coin:       coin,
+		Value:      value,
+	}, 1, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 0, &sync.Map{}, 0, false)
+	if response.Code != code.DelegatorNotAllowed {
+		t.Fatalf("Response code is not %d. Error %s", code.DelegatorNotAllowed, response.Log)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Fatal(err)
+	}
+}
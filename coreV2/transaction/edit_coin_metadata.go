@@ -0,0 +1,174 @@
+package transaction
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/coins"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/commission"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/swap"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	abcTypes "github.com/tendermint/tendermint/abci/types"
+)
+
+// EditCoinMetadataData replaces the metadata of the coin, it can be sent by the owner of the coin symbol only
+type EditCoinMetadataData struct {
+	Coin        types.CoinID
+	Description string
+	URI         string
+	IconHash    types.Hash
+	Decimals    uint32
+}
+
+func (data EditCoinMetadataData) Gas() int64 {
+	return gasEditCoinMetadata
+}
+
+func (data EditCoinMetadataData) TxType() TxType {
+	return TypeEditCoinMetadata
+}
+
+func (data EditCoinMetadataData) basicCheck(tx *Transaction, context *state.CheckState) *Response {
+	sender, _ := tx.Sender()
+
+	coin := context.Coins().GetCoin(data.Coin)
+	if coin == nil {
+		return &Response{
+			Code: code.CoinNotExists,
+			Log:  fmt.Sprintf("Coin %s not exists", data.Coin),
+			Info: EncodeError(code.NewCoinNotExists("", data.Coin.String())),
+		}
+	}
+
+	info := context.Coins().GetSymbolInfo(coin.Symbol())
+	if info == nil || info.OwnerAddress() == nil || *info.OwnerAddress() != sender {
+		var owner *string
+		if info != nil && info.OwnerAddress() != nil {
+			address := info.OwnerAddress().String()
+			owner = &address
+		}
+		return &Response{
+			Code: code.IsNotOwnerOfCoin,
+			Log:  "Sender is not owner of coin",
+			Info: EncodeError(code.NewIsNotOwnerOfCoin(coin.Symbol().String(), owner)),
+		}
+	}
+
+	if len(data.Description) > coins.MaxMetadataDescriptionLength {
+		return &Response{
+			Code: code.WrongCoinMetadata,
+			Log:  fmt.Sprintf("Description of coin is limited to %d bytes", coins.MaxMetadataDescriptionLength),
+			Info: EncodeError(code.NewWrongCoinMetadata(data.Coin.String(), "description is too long")),
+		}
+	}
+
+	if len(data.URI) > coins.MaxMetadataURILength {
+		return &Response{
+			Code: code.WrongCoinMetadata,
+			Log:  fmt.Sprintf("URI of coin is limited to %d bytes", coins.MaxMetadataURILength),
+			Info: EncodeError(code.NewWrongCoinMetadata(data.Coin.String(), "uri is too long")),
+		}
+	}
+
+	if data.Decimals > coins.MaxMetadataDecimals {
+		return &Response{
+			Code: code.WrongCoinMetadata,
+			Log:  fmt.Sprintf("Decimals of coin should be not greater than %d", coins.MaxMetadataDecimals),
+			Info: EncodeError(code.NewWrongCoinMetadata(data.Coin.String(), "decimals are out of range")),
+		}
+	}
+
+	return nil
+}
+
+func (data EditCoinMetadataData) String() string {
+	return fmt.Sprintf("EDIT COIN METADATA coin:%s uri:%s decimals:%d", data.Coin.String(), data.URI, data.Decimals)
+}
+
+func (data EditCoinMetadataData) CommissionData(price *commission.Price) *big.Int {
+	return price.EditCoinMetadataPrice()
+}
+
+func (data EditCoinMetadataData) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, price *big.Int) Response {
+	sender, _ := tx.Sender()
+
+	var checkState *state.CheckState
+	var isCheck bool
+	if checkState, isCheck = context.(*state.CheckState); !isCheck {
+		checkState = state.NewCheckState(context.(*state.State))
+	}
+
+	response := data.basicCheck(tx, checkState)
+	if response != nil {
+		return *response
+	}
+
+	commissionInBaseCoin := price
+	commissionPoolSwapper := checkState.Swap().GetSwapper(tx.GasCoin, types.GetBaseCoinID())
+	gasCoin := checkState.Coins().GetCoin(tx.GasCoin)
+	commission, isGasCommissionFromPoolSwap, errResp := CalculateCommission(checkState, commissionPoolSwapper, gasCoin, commissionInBaseCoin)
+	if errResp != nil {
+		return *errResp
+	}
+
+	if checkState.Accounts().GetSpendableBalance(sender, tx.GasCoin).Cmp(commission) < 0 {
+		return Response{
+			Code: code.InsufficientFunds,
+			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), commission.String(), gasCoin.GetFullSymbol()),
+			Info: EncodeError(code.NewInsufficientFunds(sender.String(), commission.String(), gasCoin.GetFullSymbol(), gasCoin.ID().String())),
+		}
+	}
+
+	var tags []abcTypes.EventAttribute
+	if deliverState, ok := context.(*state.State); ok {
+		var tagsCom *tagPoolChange
+		if isGasCommissionFromPoolSwap {
+			var (
+				poolIDCom  uint32
+				detailsCom *swap.ChangeDetailsWithOrders
+				ownersCom  []*swap.OrderDetail
+			)
+			commission, commissionInBaseCoin, poolIDCom, detailsCom, ownersCom = deliverState.Swapper().PairSellWithOrders(tx.CommissionCoin(), types.GetBaseCoinID(), commission, big.NewInt(0))
+			tagsCom = &tagPoolChange{
+				PoolID:   poolIDCom,
+				CoinIn:   tx.CommissionCoin(),
+				ValueIn:  commission.String(),
+				CoinOut:  types.GetBaseCoinID(),
+				ValueOut: commissionInBaseCoin.String(),
+				Orders:   detailsCom,
+				// Sellers:  ownersCom,
+			}
+			for _, value := range ownersCom {
+				deliverState.Accounts.AddBalance(value.Owner, tx.CommissionCoin(), value.ValueBigInt)
+			}
+		} else if !tx.GasCoin.IsBaseCoin() {
+			deliverState.Coins.SubVolume(tx.CommissionCoin(), commission)
+			deliverState.Coins.SubReserve(tx.CommissionCoin(), commissionInBaseCoin)
+		}
+		deliverState.Accounts.SubBalance(sender, tx.GasCoin, commission)
+		rewardPool.Add(rewardPool, commissionInBaseCoin)
+
+		deliverState.Coins.SetMetadata(data.Coin, coins.Metadata{
+			Description: data.Description,
+			URI:         data.URI,
+			IconHash:    data.IconHash,
+			Decimals:    data.Decimals,
+		})
+		deliverState.Accounts.SetNonce(sender, tx.Nonce)
+
+		tags = []abcTypes.EventAttribute{
+			{Key: []byte("tx.commission_in_base_coin"), Value: []byte(commissionInBaseCoin.String())},
+			{Key: []byte("tx.commission_conversion"), Value: []byte(isGasCommissionFromPoolSwap.String()), Index: true},
+			{Key: []byte("tx.commission_amount"), Value: []byte(commission.String())},
+			{Key: []byte("tx.commission_details"), Value: []byte(tagsCom.string())},
+			{Key: []byte("tx.coin_id"), Value: []byte(data.Coin.String()), Index: true},
+		}
+	}
+
+	return Response{
+		Code: code.OK,
+		Tags: tags,
+	}
+}
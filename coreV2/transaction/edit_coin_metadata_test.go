@@ -0,0 +1,100 @@
+package transaction
+
+import (
+	"math/big"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/crypto"
+	"github.com/MinterTeam/minter-go-node/helpers"
+)
+
+func TestEditCoinMetadataTx(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	cState.Accounts.AddBalance(addr, types.GetBaseCoinID(), helpers.BipToPip(big.NewInt(1000)))
+
+	coin := createTestCoinWithOwner(cState, addr)
+
+	data := EditCoinMetadataData{
+		Coin:        coin,
+		Description: "Test coin",
+		URI:         "https://example.com",
+		IconHash:    types.Hash{1},
+		Decimals:    6,
+	}
+	encodedTx, err := makeTestTx(TypeEditCoinMetadata, data, 1, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 0, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not %d. Error %s", code.OK, response.Log)
+	}
+
+	metadata := cState.Coins.GetMetadata(coin)
+	if metadata == nil || metadata.Description != data.Description || metadata.URI != data.URI || metadata.IconHash != data.IconHash || metadata.Decimals != data.Decimals {
+		t.Fatalf("Metadata is not correct: %+v", metadata)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestEditCoinMetadataTxToNotOwner(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	cState.Accounts.AddBalance(addr, types.GetBaseCoinID(), helpers.BipToPip(big.NewInt(1000)))
+
+	coin := createTestCoinWithOwner(cState, types.Address{1})
+
+	encodedTx, err := makeTestTx(TypeEditCoinMetadata, EditCoinMetadataData{Coin: coin, Decimals: 6}, 1, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 0, &sync.Map{}, 0, false)
+	if response.Code != code.IsNotOwnerOfCoin {
+		t.Fatalf("Response code is not %d. Error %s", code.IsNotOwnerOfCoin, response.Log)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestEditCoinMetadataTxToTooLongDescription(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	cState.Accounts.AddBalance(addr, types.GetBaseCoinID(), helpers.BipToPip(big.NewInt(1000)))
+
+	coin := createTestCoinWithOwner(cState, addr)
+
+	encodedTx, err := makeTestTx(TypeEditCoinMetadata, EditCoinMetadataData{Coin: coin, Description: strings.Repeat("a", 513)}, 1, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 0, &sync.Map{}, 0, false)
+	if response.Code != code.WrongCoinMetadata {
+		t.Fatalf("Response code is not %d. Error %s", code.WrongCoinMetadata, response.Log)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
@@ -86,7 +86,7 @@ func (data EditCoinOwnerData) Run(tx *Transaction, context state.Interface, rewa
 		return *errResp
 	}
 
-	if checkState.Accounts().GetBalance(sender, tx.GasCoin).Cmp(commission) < 0 {
+	if checkState.Accounts().GetSpendableBalance(sender, tx.GasCoin).Cmp(commission) < 0 {
 		gasCoin := checkState.Coins().GetCoin(tx.GasCoin)
 
 		return Response{
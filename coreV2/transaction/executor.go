@@ -123,6 +123,10 @@ func (e *Executor) RunTx(context state.Interface, rawTx []byte, rewardPool *big.
 		}
 	}
 
+	if errResp := checkUnsupportedFeatures(tx); errResp != nil {
+		return *errResp
+	}
+
 	// check multi-signature
 	if tx.SignatureType == SigTypeMulti {
 		multisig := checkState.Accounts().GetAccount(tx.multisig.Multisig)
@@ -251,3 +255,24 @@ func (tx *Transaction) CommissionCoin() types.CoinID {
 	}
 	return tx.GasCoin
 }
+
+// checkUnsupportedFeatures rejects signatures of the authorized keys and sponsored txs, they are supported by ExecutorV350 only
+func checkUnsupportedFeatures(tx *Transaction) *Response {
+	if tx.SignatureType == SigTypeAuthorized {
+		return &Response{
+			Code: code.DecodeError,
+			Log:  "unknown signature type",
+			Info: EncodeError(code.NewDecodeError()),
+		}
+	}
+
+	if tx.IsSponsored() {
+		return &Response{
+			Code: code.DecodeError,
+			Log:  "incorrect tx sponsor",
+			Info: EncodeError(code.NewDecodeError()),
+		}
+	}
+
+	return nil
+}
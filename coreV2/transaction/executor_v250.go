@@ -111,6 +111,10 @@ func (e *ExecutorV250) RunTx(context state.Interface, rawTx []byte, rewardPool *
 		}
 	}
 
+	if errResp := checkUnsupportedFeatures(tx); errResp != nil {
+		return *errResp
+	}
+
 	// check multi-signature
 	if tx.SignatureType == SigTypeMulti {
 		multisig := checkState.Accounts().GetAccount(tx.multisig.Multisig)
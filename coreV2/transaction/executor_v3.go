@@ -101,6 +101,10 @@ func (e *ExecutorV3) RunTx(context state.Interface, rawTx []byte, rewardPool *bi
 		}
 	}
 
+	if errResp := checkUnsupportedFeatures(tx); errResp != nil {
+		return *errResp
+	}
+
 	// check multi-signature
 	if tx.SignatureType == SigTypeMulti {
 		multisig := checkState.Accounts().GetAccount(tx.multisig.Multisig)
@@ -0,0 +1,104 @@
+package transaction
+
+import (
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/crypto"
+	"github.com/MinterTeam/minter-go-node/helpers"
+)
+
+func TestFreezeAddressTx(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	cState.Accounts.AddBalance(addr, types.GetBaseCoinID(), helpers.BipToPip(big.NewInt(1000)))
+
+	coin := createTestFreezableToken(cState, addr)
+	frozen := types.Address{1}
+
+	encodedTx, err := makeTestTx(TypeFreezeAddress, FreezeAddressData{Coin: coin, Address: frozen}, 1, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 0, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not %d. Error %s", code.OK, response.Log)
+	}
+
+	if !cState.Coins.IsFrozen(coin, frozen) {
+		t.Fatal("Address is not frozen")
+	}
+
+	encodedTx, err = makeTestTx(TypeMultisend, MultisendData{List: []MultisendDataItem{
+		{Coin: coin, To: types.Address{2}, Value: big.NewInt(1)},
+		{Coin: coin, To: frozen, Value: big.NewInt(1)},
+	}}, 2, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response = NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 0, &sync.Map{}, 0, false)
+	if response.Code != code.AddressIsFrozen {
+		t.Fatalf("Response code is not %d. Error %s", code.AddressIsFrozen, response.Log)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+
+	encodedTx, err = makeTestTx(TypeUnfreezeAddress, UnfreezeAddressData{Coin: coin, Address: frozen}, 2, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response = NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 0, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not %d. Error %s", code.OK, response.Log)
+	}
+
+	encodedTx, err = makeTestTx(TypeSend, SendData{Coin: coin, To: frozen, Value: big.NewInt(1)}, 3, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response = NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 0, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not %d. Error %s", code.OK, response.Log)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestFreezeAddressTxToNotOwner(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	cState.Accounts.AddBalance(addr, types.GetBaseCoinID(), helpers.BipToPip(big.NewInt(1000)))
+
+	coin := createTestFreezableToken(cState, types.Address{1})
+
+	encodedTx, err := makeTestTx(TypeFreezeAddress, FreezeAddressData{Coin: coin, Address: types.Address{2}}, 1, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 0, &sync.Map{}, 0, false)
+	if response.Code != code.IsNotOwnerOfCoin {
+		t.Fatalf("Response code is not %d. Error %s", code.IsNotOwnerOfCoin, response.Log)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
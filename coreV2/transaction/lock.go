@@ -79,7 +79,7 @@ func (data LockData) Run(tx *Transaction, context state.Interface, rewardPool *b
 	if tx.GasCoin == data.Coin {
 		needValue.Add(data.Value, needValue)
 	} else {
-		if checkState.Accounts().GetBalance(sender, data.Coin).Cmp(data.Value) < 0 {
+		if checkState.Accounts().GetSpendableBalance(sender, data.Coin).Cmp(data.Value) < 0 {
 			coin := checkState.Coins().GetCoin(data.Coin)
 			return Response{
 				Code: code.InsufficientFunds,
@@ -88,7 +88,7 @@ func (data LockData) Run(tx *Transaction, context state.Interface, rewardPool *b
 			}
 		}
 	}
-	if checkState.Accounts().GetBalance(sender, tx.GasCoin).Cmp(needValue) < 0 {
+	if checkState.Accounts().GetSpendableBalance(sender, tx.GasCoin).Cmp(needValue) < 0 {
 		return Response{
 			Code: code.InsufficientFunds,
 			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), needValue.String(), gasCoin.GetFullSymbol()),
@@ -0,0 +1,220 @@
+package transaction
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/coins"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/commission"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/swap"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	abcTypes "github.com/tendermint/tendermint/abci/types"
+)
+
+// MigrateCoinToTokenData turns the coin with reserve into a token keeping its ID and balances of holders,
+// the released reserve and the coin minted at the current bancor price seed a swap pool with the base coin.
+// The liquidity of the pool is locked at the zero address.
+// It can be sent by the owner of the coin symbol only
+type MigrateCoinToTokenData struct {
+	Coin     types.CoinID
+	Mintable bool
+	Burnable bool
+}
+
+func (data MigrateCoinToTokenData) Gas() int64 {
+	return gasMigrateCoinToToken
+}
+
+func (data MigrateCoinToTokenData) TxType() TxType {
+	return TypeMigrateCoinToToken
+}
+
+func (data MigrateCoinToTokenData) basicCheck(tx *Transaction, context *state.CheckState) *Response {
+	sender, _ := tx.Sender()
+
+	coin := context.Coins().GetCoin(data.Coin)
+	if coin == nil {
+		return &Response{
+			Code: code.CoinNotExists,
+			Log:  fmt.Sprintf("Coin %s not exists", data.Coin),
+			Info: EncodeError(code.NewCoinNotExists("", data.Coin.String())),
+		}
+	}
+
+	if owner := coinOwner(context, data.Coin); owner == nil || *owner != sender {
+		var ownerS *string
+		if owner != nil {
+			address := owner.String()
+			ownerS = &address
+		}
+		return &Response{
+			Code: code.IsNotOwnerOfCoin,
+			Log:  "Sender is not owner of coin",
+			Info: EncodeError(code.NewIsNotOwnerOfCoin(coin.Symbol().String(), ownerS)),
+		}
+	}
+
+	if coin.IsToken() {
+		return &Response{
+			Code: code.CoinHasNotReserve,
+			Log:  "Coin has no reserve",
+			Info: EncodeError(code.NewCoinHasNotReserve(coin.GetFullSymbol(), coin.ID().String())),
+		}
+	}
+
+	if context.Swap().SwapPoolExist(types.GetBaseCoinID(), data.Coin) {
+		return &Response{
+			Code: code.PairAlreadyExists,
+			Log:  "swap pool already exist",
+			Info: EncodeError(code.NewPairAlreadyExists(
+				types.GetBaseCoinID().String(),
+				data.Coin.String())),
+		}
+	}
+
+	return nil
+}
+
+func (data MigrateCoinToTokenData) String() string {
+	return fmt.Sprintf("MIGRATE COIN TO TOKEN coin:%s mintable:%t burnable:%t", data.Coin.String(), data.Mintable, data.Burnable)
+}
+
+func (data MigrateCoinToTokenData) CommissionData(price *commission.Price) *big.Int {
+	return price.MigrateCoinToTokenPrice()
+}
+
+// migrationPoolAmounts returns the reserve of the coin and the amount of the coin worth it at the current bancor price
+func migrationPoolAmounts(coin *coins.Model) (*big.Int, *big.Int) {
+	amount := big.NewInt(0).Mul(coin.Volume(), big.NewInt(int64(coin.Crr())))
+	return coin.Reserve(), amount.Div(amount, big.NewInt(100))
+}
+
+func (data MigrateCoinToTokenData) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, price *big.Int) Response {
+	sender, _ := tx.Sender()
+
+	var checkState *state.CheckState
+	var isCheck bool
+	if checkState, isCheck = context.(*state.CheckState); !isCheck {
+		checkState = state.NewCheckState(context.(*state.State))
+	}
+
+	response := data.basicCheck(tx, checkState)
+	if response != nil {
+		return *response
+	}
+
+	commissionInBaseCoin := price
+	commissionPoolSwapper := checkState.Swap().GetSwapper(tx.GasCoin, types.GetBaseCoinID())
+	gasCoin := checkState.Coins().GetCoin(tx.GasCoin)
+	commission, isGasCommissionFromPoolSwap, errResp := CalculateCommission(checkState, commissionPoolSwapper, gasCoin, commissionInBaseCoin)
+	if errResp != nil {
+		return *errResp
+	}
+
+	if checkState.Accounts().GetSpendableBalance(sender, tx.GasCoin).Cmp(commission) < 0 {
+		return Response{
+			Code: code.InsufficientFunds,
+			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), commission.String(), gasCoin.GetFullSymbol()),
+			Info: EncodeError(code.NewInsufficientFunds(sender.String(), commission.String(), gasCoin.GetFullSymbol(), gasCoin.ID().String())),
+		}
+	}
+
+	coin := checkState.Coins().GetCoin(data.Coin)
+	reserve, amount := migrationPoolAmounts(coin)
+	if tx.GasCoin == data.Coin && !isGasCommissionFromPoolSwap {
+		// the commission paid by the migrated coin itself is taken from its volume and reserve
+		reserve = big.NewInt(0).Sub(reserve, commissionInBaseCoin)
+		amount = big.NewInt(0).Mul(big.NewInt(0).Sub(coin.Volume(), commission), big.NewInt(int64(coin.Crr())))
+		amount.Div(amount, big.NewInt(100))
+	}
+	if err := checkState.Swap().GetSwapper(types.GetBaseCoinID(), data.Coin).CheckCreate(reserve, amount); err != nil {
+		if err == swap.ErrorInsufficientLiquidityMinted {
+			return Response{
+				Code: code.InsufficientLiquidityMinted,
+				Log: fmt.Sprintf("You wanted to add less than minimum liquidity, you should add %s %s and %s or more %s",
+					"10", checkState.Coins().GetCoin(types.GetBaseCoinID()).GetFullSymbol(), "10", coin.GetFullSymbol()),
+				Info: EncodeError(code.NewInsufficientLiquidityMinted(types.GetBaseCoinID().String(), "10", data.Coin.String(), "10")),
+			}
+		}
+		return Response{
+			Code: code.SwapPoolUnknown,
+			Log:  err.Error(),
+			Info: EncodeError(code.NewCustomCode(code.SwapPoolUnknown)),
+		}
+	}
+
+	var tags []abcTypes.EventAttribute
+	if deliverState, ok := context.(*state.State); ok {
+		var tagsCom *tagPoolChange
+		if isGasCommissionFromPoolSwap {
+			var (
+				poolIDCom  uint32
+				detailsCom *swap.ChangeDetailsWithOrders
+				ownersCom  []*swap.OrderDetail
+			)
+			commission, commissionInBaseCoin, poolIDCom, detailsCom, ownersCom = deliverState.Swapper().PairSellWithOrders(tx.CommissionCoin(), types.GetBaseCoinID(), commission, big.NewInt(0))
+			tagsCom = &tagPoolChange{
+				PoolID:   poolIDCom,
+				CoinIn:   tx.CommissionCoin(),
+				ValueIn:  commission.String(),
+				CoinOut:  types.GetBaseCoinID(),
+				ValueOut: commissionInBaseCoin.String(),
+				Orders:   detailsCom,
+				// Sellers:  ownersCom,
+			}
+			for _, value := range ownersCom {
+				deliverState.Accounts.AddBalance(value.Owner, tx.CommissionCoin(), value.ValueBigInt)
+			}
+		} else if !tx.GasCoin.IsBaseCoin() {
+			deliverState.Coins.SubVolume(tx.CommissionCoin(), commission)
+			deliverState.Coins.SubReserve(tx.CommissionCoin(), commissionInBaseCoin)
+		}
+		deliverState.Accounts.SubBalance(sender, tx.GasCoin, commission)
+		rewardPool.Add(rewardPool, commissionInBaseCoin)
+
+		// the commission could be paid by the migrated coin itself
+		reserve, amount = migrationPoolAmounts(coin)
+		volume := big.NewInt(0).Add(coin.Volume(), amount)
+
+		deliverState.Coins.MigrateToToken(data.Coin, data.Mintable, data.Burnable, volume)
+		deliverState.Coins.AddVolume(data.Coin, amount)
+
+		_, _, liquidity, id := deliverState.Swapper().PairCreate(types.GetBaseCoinID(), data.Coin, reserve, amount)
+
+		pairCoins := liquidityCoinName(types.GetBaseCoinID(), data.Coin)
+		coinID := checkState.App().GetNextCoinID()
+
+		liquidityCoinSymbol := LiquidityCoinSymbol(id)
+		deliverState.Coins.CreateToken(coinID, liquidityCoinSymbol, "Liquidity Pool "+pairCoins, true, true, big.NewInt(0).Set(liquidity), maxCoinSupply, nil)
+		// the liquidity is locked forever, so the owner can't withdraw the released reserve
+		deliverState.Accounts.AddBalance(types.Address{}, coinID, liquidity)
+
+		deliverState.App.SetCoinsCount(coinID.Uint32())
+
+		deliverState.Accounts.SetNonce(sender, tx.Nonce)
+
+		tags = []abcTypes.EventAttribute{
+			{Key: []byte("tx.commission_in_base_coin"), Value: []byte(commissionInBaseCoin.String())},
+			{Key: []byte("tx.commission_conversion"), Value: []byte(isGasCommissionFromPoolSwap.String()), Index: true},
+			{Key: []byte("tx.commission_amount"), Value: []byte(commission.String())},
+			{Key: []byte("tx.commission_details"), Value: []byte(tagsCom.string())},
+			{Key: []byte("tx.coin_id"), Value: []byte(data.Coin.String()), Index: true},
+			{Key: []byte("tx.holders"), Value: []byte(strconv.FormatUint(checkState.Accounts().HoldersCount(data.Coin), 10))},
+			{Key: []byte("tx.volume0"), Value: []byte(reserve.String())},
+			{Key: []byte("tx.volume1"), Value: []byte(amount.String())},
+			{Key: []byte("tx.liquidity"), Value: []byte(liquidity.String())},
+			{Key: []byte("tx.pool_token"), Value: []byte(liquidityCoinSymbol.String()), Index: true},
+			{Key: []byte("tx.pool_token_id"), Value: []byte(coinID.String()), Index: true},
+			{Key: []byte("tx.pair_ids"), Value: []byte(pairCoins), Index: true},
+			{Key: []byte("tx.pool_id"), Value: []byte(types.CoinID(id).String()), Index: true},
+		}
+	}
+
+	return Response{
+		Code: code.OK,
+		Tags: tags,
+	}
+}
@@ -0,0 +1,131 @@
+package transaction
+
+import (
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/crypto"
+	"github.com/MinterTeam/minter-go-node/helpers"
+)
+
+func TestMigrateCoinToTokenTx(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	cState.Accounts.AddBalance(addr, types.GetBaseCoinID(), helpers.BipToPip(big.NewInt(1000000)))
+
+	coin := createTestCoinWithOwner(cState, addr)
+
+	encodedTx, err := makeTestTx(TypeMigrateCoinToToken, MigrateCoinToTokenData{Coin: coin, Mintable: true, Burnable: false}, 1, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 0, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not %d. Error %s", code.OK, response.Log)
+	}
+
+	model := cState.Coins.GetCoin(coin)
+	if !model.IsToken() || !model.IsMintable() || model.IsBurnable() {
+		t.Fatalf("Coin is not migrated to token: crr %d, mintable %t, burnable %t", model.Crr(), model.IsMintable(), model.IsBurnable())
+	}
+
+	if volume := model.Volume(); volume.Cmp(helpers.BipToPip(big.NewInt(110000))) != 0 {
+		t.Fatalf("Coin volume is not correct. Expected %s, got %s", helpers.BipToPip(big.NewInt(110000)), volume)
+	}
+
+	if balance := cState.Accounts.GetBalance(types.Address{}, coin); balance.Cmp(helpers.BipToPip(big.NewInt(100000))) != 0 {
+		t.Fatalf("Holder balance is changed: %s", balance)
+	}
+
+	reserve0, reserve1, _ := cState.Swapper().SwapPool(types.GetBaseCoinID(), coin)
+	if reserve0.Cmp(helpers.BipToPip(big.NewInt(100000))) != 0 || reserve1.Cmp(helpers.BipToPip(big.NewInt(10000))) != 0 {
+		t.Fatalf("Pool reserves are not correct: %s, %s", reserve0, reserve1)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMigrateCoinToTokenTxToLockLiquidity(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	cState.Accounts.AddBalance(addr, types.GetBaseCoinID(), helpers.BipToPip(big.NewInt(1000000)))
+
+	coin := createTestCoinWithOwner(cState, addr)
+
+	encodedTx, err := makeTestTx(TypeMigrateCoinToToken, MigrateCoinToTokenData{Coin: coin}, 1, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 0, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not %d. Error %s", code.OK, response.Log)
+	}
+
+	swapper := cState.Swapper().GetSwapper(types.GetBaseCoinID(), coin)
+	liquidityCoin := cState.Coins.GetCoinBySymbol(LiquidityCoinSymbol(swapper.GetID()), 0)
+	if balance := cState.Accounts.GetBalance(addr, liquidityCoin.ID()); balance.Sign() != 0 {
+		t.Fatalf("Owner got the liquidity: %s", balance)
+	}
+
+	if balance := cState.Accounts.GetBalance(types.Address{}, liquidityCoin.ID()); balance.Cmp(liquidityCoin.Volume()) != 0 {
+		t.Fatalf("Liquidity is not locked. Expected %s, got %s", liquidityCoin.Volume(), balance)
+	}
+
+	encodedTx, err = makeTestTx(TypeRemoveLiquidity, RemoveLiquidityV240{
+		Coin0:          types.GetBaseCoinID(),
+		Coin1:          coin,
+		Liquidity:      big.NewInt(1000),
+		MinimumVolume0: big.NewInt(0),
+		MinimumVolume1: big.NewInt(0),
+	}, 2, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response = NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 0, &sync.Map{}, 0, false)
+	if response.Code != code.InsufficientFunds {
+		t.Fatalf("Response code is not %d. Error %s", code.InsufficientFunds, response.Log)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMigrateCoinToTokenTxToNotOwner(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	cState.Accounts.AddBalance(addr, types.GetBaseCoinID(), helpers.BipToPip(big.NewInt(1000000)))
+
+	coin := createTestCoinWithOwner(cState, types.Address{1})
+
+	encodedTx, err := makeTestTx(TypeMigrateCoinToToken, MigrateCoinToTokenData{Coin: coin}, 1, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 0, &sync.Map{}, 0, false)
+	if response.Code != code.IsNotOwnerOfCoin {
+		t.Fatalf("Response code is not %d. Error %s", code.IsNotOwnerOfCoin, response.Log)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,132 @@
+package transaction
+
+import (
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/crypto"
+	"github.com/MinterTeam/minter-go-node/helpers"
+)
+
+func TestMoveStakeTxFromRedelegatedStake(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	coin := types.GetBaseCoinID()
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(1000000)))
+
+	from := createTestCandidate(cState)
+	to := createTestCandidate(cState)
+	next := createTestCandidate(cState)
+
+	value := helpers.BipToPip(big.NewInt(100))
+	cState.Candidates.Delegate(addr, from, coin, value, value)
+	cState.Candidates.RecalculateStakes(0)
+
+	encodedTx, err := makeTestTx(TypeMoveStake, MoveStakeDataV350{
+		FromPubKey: from,
+		ToPubKey:   to,
+		Coin:       coin,
+		Value:      value,
+	}, 1, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not %d. Error %s", code.OK, response.Log)
+	}
+
+	delegateHeight := 1 + types.GetMovePeriod()
+	if cState.Redelegations.GetActiveValue(addr, cState.Candidates.ID(to), coin, delegateHeight).Cmp(value) != 0 {
+		t.Fatal("Redelegation not found")
+	}
+
+	// moved funds are delegated to the destination candidate
+	cState.Candidates.Delegate(addr, to, coin, value, value)
+	cState.Candidates.RecalculateStakes(0)
+
+	encodedTx, err = makeTestTx(TypeMoveStake, MoveStakeDataV350{
+		FromPubKey: to,
+		ToPubKey:   next,
+		Coin:       coin,
+		Value:      value,
+	}, 2, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response = NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), delegateHeight, &sync.Map{}, 0, false)
+	if response.Code != code.StakeInRedelegation {
+		t.Fatalf("Response code is not %d. Error %s", code.StakeInRedelegation, response.Log)
+	}
+
+	response = NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), types.GetUnbondPeriod()+1, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not %d. Error %s", code.OK, response.Log)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMoveStakeTxWhileRedelegationIsFrozen(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	coin := types.GetBaseCoinID()
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(1000000)))
+
+	from := createTestCandidate(cState)
+	to := createTestCandidate(cState)
+	next := createTestCandidate(cState)
+
+	value := helpers.BipToPip(big.NewInt(100))
+	cState.Candidates.Delegate(addr, from, coin, value, value)
+	cState.Candidates.Delegate(addr, to, coin, value, value)
+	cState.Candidates.RecalculateStakes(0)
+
+	encodedTx, err := makeTestTx(TypeMoveStake, MoveStakeDataV350{
+		FromPubKey: from,
+		ToPubKey:   to,
+		Coin:       coin,
+		Value:      big.NewInt(0).Div(value, big.NewInt(2)),
+	}, 1, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not %d. Error %s", code.OK, response.Log)
+	}
+
+	// the first move is still frozen, so the whole stake of the destination candidate can be moved
+	encodedTx, err = makeTestTx(TypeMoveStake, MoveStakeDataV350{
+		FromPubKey: to,
+		ToPubKey:   next,
+		Coin:       coin,
+		Value:      value,
+	}, 2, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response = NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 2, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not %d. Error %s", code.OK, response.Log)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -124,9 +124,14 @@ func (data MultisendData) Run(tx *Transaction, context state.Interface, rewardPo
 		}
 		deliverState.Accounts.SubBalance(sender, tx.GasCoin, commission)
 		rewardPool.Add(rewardPool, commissionInBaseCoin)
-		for _, item := range data.List {
+		received := make([]string, len(data.List))
+		hasTransferFee := false
+		for i, item := range data.List {
 			deliverState.Accounts.SubBalance(sender, item.Coin, item.Value)
-			deliverState.Accounts.AddBalance(item.To, item.Coin, item.Value)
+			value, transferFee := payTransferFee(deliverState, item.Coin, sender, item.Value)
+			deliverState.Accounts.AddBalance(item.To, item.Coin, value)
+			received[i] = value.String()
+			hasTransferFee = hasTransferFee || transferFee.Sign() != 0
 		}
 		deliverState.Accounts.SetNonce(sender, tx.Nonce)
 
@@ -142,6 +147,9 @@ func (data MultisendData) Run(tx *Transaction, context state.Interface, rewardPo
 		}
 
 		tags = append(tags, abcTypes.EventAttribute{Key: []byte("tx.to"), Value: []byte(pluckRecipients(data.List))})
+		if hasTransferFee {
+			tags = append(tags, abcTypes.EventAttribute{Key: []byte("tx.received"), Value: []byte(strings.Join(received, ","))})
+		}
 	}
 
 	return Response{
@@ -174,7 +182,7 @@ func checkBalances(context *state.CheckState, sender types.Address, items []Mult
 	for _, coin := range coins {
 		value := total[coin]
 		coinData := context.Coins().GetCoin(coin)
-		if context.Accounts().GetBalance(sender, coin).Cmp(value) < 0 {
+		if context.Accounts().GetSpendableBalance(sender, coin).Cmp(value) < 0 {
 			return &Response{
 				Code: code.InsufficientFunds,
 				Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), value, coinData.GetFullSymbol()),
@@ -0,0 +1,146 @@
+package transaction
+
+import (
+	"log"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/crypto"
+	"github.com/MinterTeam/minter-go-node/helpers"
+)
+
+func createTestFreezableToken(stateDB *state.State, owner types.Address) types.CoinID {
+	volume := helpers.BipToPip(big.NewInt(100000))
+
+	id := stateDB.App.GetNextCoinID()
+
+	stateDB.Coins.CreateToken(id, getTestCoinSymbol(), "TEST TOKEN", true, true, volume,
+		big.NewInt(0).Mul(volume, big.NewInt(10)), &owner)
+	stateDB.Coins.SetFreezable(id)
+	stateDB.App.SetCoinsCount(id.Uint32())
+	stateDB.Accounts.AddBalance(owner, id, volume)
+
+	_, _, err := stateDB.Tree().Commit(stateDB.Coins)
+	if err != nil {
+		log.Fatalf("failed to commit coins: %s", err)
+	}
+
+	return id
+}
+
+func TestPauseTokenTx(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	cState.Accounts.AddBalance(addr, types.GetBaseCoinID(), helpers.BipToPip(big.NewInt(1000)))
+
+	coin := createTestFreezableToken(cState, addr)
+
+	encodedTx, err := makeTestTx(TypePauseToken, PauseTokenData{Coin: coin}, 1, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 0, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not %d. Error %s", code.OK, response.Log)
+	}
+
+	if !cState.Coins.IsPaused(coin) {
+		t.Fatal("Token is not paused")
+	}
+
+	encodedTx, err = makeTestTx(TypeSend, SendData{Coin: coin, To: types.Address{1}, Value: big.NewInt(1)}, 2, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response = NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 0, &sync.Map{}, 0, false)
+	if response.Code != code.CoinIsPaused {
+		t.Fatalf("Response code is not %d. Error %s", code.CoinIsPaused, response.Log)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+
+	encodedTx, err = makeTestTx(TypeUnpauseToken, UnpauseTokenData{Coin: coin}, 2, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response = NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 0, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not %d. Error %s", code.OK, response.Log)
+	}
+
+	encodedTx, err = makeTestTx(TypeSend, SendData{Coin: coin, To: types.Address{1}, Value: big.NewInt(1)}, 3, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response = NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 0, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not %d. Error %s", code.OK, response.Log)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPauseTokenTxToNotFreezable(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	cState.Accounts.AddBalance(addr, types.GetBaseCoinID(), helpers.BipToPip(big.NewInt(1000)))
+
+	coin := createTestCoinWithOwner(cState, addr)
+
+	encodedTx, err := makeTestTx(TypePauseToken, PauseTokenData{Coin: coin}, 1, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 0, &sync.Map{}, 0, false)
+	if response.Code != code.CoinIsNotFreezable {
+		t.Fatalf("Response code is not %d. Error %s", code.CoinIsNotFreezable, response.Log)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPauseTokenTxToNotOwner(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	cState.Accounts.AddBalance(addr, types.GetBaseCoinID(), helpers.BipToPip(big.NewInt(1000)))
+
+	coin := createTestFreezableToken(cState, types.Address{1})
+
+	encodedTx, err := makeTestTx(TypePauseToken, PauseTokenData{Coin: coin}, 1, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 0, &sync.Map{}, 0, false)
+	if response.Code != code.IsNotOwnerOfCoin {
+		t.Fatalf("Response code is not %d. Error %s", code.IsNotOwnerOfCoin, response.Log)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
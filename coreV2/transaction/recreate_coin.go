@@ -139,7 +139,7 @@ func (data RecreateCoinData) Run(tx *Transaction, context state.Interface, rewar
 		return *errResp
 	}
 
-	if checkState.Accounts().GetBalance(sender, tx.GasCoin).Cmp(commission) < 0 {
+	if checkState.Accounts().GetSpendableBalance(sender, tx.GasCoin).Cmp(commission) < 0 {
 		gasCoin := checkState.Coins().GetCoin(tx.GasCoin)
 
 		return Response{
@@ -149,7 +149,7 @@ func (data RecreateCoinData) Run(tx *Transaction, context state.Interface, rewar
 		}
 	}
 
-	if checkState.Accounts().GetBalance(sender, types.GetBaseCoinID()).Cmp(data.InitialReserve) < 0 {
+	if checkState.Accounts().GetSpendableBalance(sender, types.GetBaseCoinID()).Cmp(data.InitialReserve) < 0 {
 		return Response{
 			Code: code.InsufficientFunds,
 			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), data.InitialReserve.String(), types.GetBaseCoin()),
@@ -164,7 +164,7 @@ func (data RecreateCoinData) Run(tx *Transaction, context state.Interface, rewar
 		totalTxCost.Add(totalTxCost, data.InitialReserve)
 		totalTxCost.Add(totalTxCost, commission)
 
-		if checkState.Accounts().GetBalance(sender, types.GetBaseCoinID()).Cmp(totalTxCost) < 0 {
+		if checkState.Accounts().GetSpendableBalance(sender, types.GetBaseCoinID()).Cmp(totalTxCost) < 0 {
 			return Response{
 				Code: code.InsufficientFunds,
 				Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), totalTxCost.String(), gasCoin.GetFullSymbol()),
@@ -109,6 +109,10 @@ func (data RedeemCheckData) Run(tx *Transaction, context state.Interface, reward
 		}
 	}
 
+	if errResp := checkAuthorizedSigner(checkState, checkSender, checkSender); errResp != nil {
+		return *errResp
+	}
+
 	if !checkState.Coins().Exists(decodedCheck.Coin) {
 		return Response{
 			Code: code.CoinNotExists,
@@ -196,7 +200,7 @@ func (data RedeemCheckData) Run(tx *Transaction, context state.Interface, reward
 
 	if decodedCheck.Coin == decodedCheck.GasCoin {
 		totalTxCost := big.NewInt(0).Add(decodedCheck.Value, commission)
-		if checkState.Accounts().GetBalance(checkSender, decodedCheck.Coin).Cmp(totalTxCost) < 0 {
+		if checkState.Accounts().GetSpendableBalance(checkSender, decodedCheck.Coin).Cmp(totalTxCost) < 0 {
 			return Response{
 				Code: code.InsufficientFunds,
 				Log:  fmt.Sprintf("Insufficient funds for check issuer account: %s %s. Wanted %s %s", decodedCheck.Value.String(), coin.GetFullSymbol(), totalTxCost.String(), coin.GetFullSymbol()),
@@ -204,7 +208,7 @@ func (data RedeemCheckData) Run(tx *Transaction, context state.Interface, reward
 			}
 		}
 	} else {
-		if checkState.Accounts().GetBalance(checkSender, decodedCheck.Coin).Cmp(decodedCheck.Value) < 0 {
+		if checkState.Accounts().GetSpendableBalance(checkSender, decodedCheck.Coin).Cmp(decodedCheck.Value) < 0 {
 			return Response{
 				Code: code.InsufficientFunds,
 				Log:  fmt.Sprintf("Insufficient funds for check issuer account: %s %s. Wanted %s %s", decodedCheck.Value.String(), decodedCheck.Coin, decodedCheck.Value.String(), coin.GetFullSymbol()),
@@ -212,7 +216,7 @@ func (data RedeemCheckData) Run(tx *Transaction, context state.Interface, reward
 			}
 		}
 
-		if checkState.Accounts().GetBalance(checkSender, decodedCheck.GasCoin).Cmp(commission) < 0 {
+		if checkState.Accounts().GetSpendableBalance(checkSender, decodedCheck.GasCoin).Cmp(commission) < 0 {
 			return Response{
 				Code: code.InsufficientFunds,
 				Log:  fmt.Sprintf("Insufficient funds for check issuer account: %s %s. Wanted %s %s", decodedCheck.Value.String(), decodedCheck.GasCoin, commission.String(), gasCoin.GetFullSymbol()),
@@ -220,9 +224,15 @@ func (data RedeemCheckData) Run(tx *Transaction, context state.Interface, reward
 			}
 		}
 	}
+
+	if errResp := checkIssuerPolicy(checkState, checkSender, sender, decodedCheck.Coin, decodedCheck.Value); errResp != nil {
+		return *errResp
+	}
+
 	var tags []abcTypes.EventAttribute
 	if deliverState, ok := context.(*state.State); ok {
 		deliverState.Checks.UseCheck(decodedCheck)
+		deliverState.Accounts.AddPolicySpent(checkSender, decodedCheck.Coin, decodedCheck.Value)
 		var tagsCom *tagPoolChange
 		if isGasCommissionFromPoolSwap {
 			var (
@@ -0,0 +1,339 @@
+package transaction
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/check"
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/commission"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/swap"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/crypto"
+	"github.com/MinterTeam/minter-go-node/rlp"
+	abcTypes "github.com/tendermint/tendermint/abci/types"
+	"golang.org/x/crypto/sha3"
+)
+
+// RedeemCheckV2Data redeems Value of the check v2, the check can be redeemed several times until its value is exhausted
+type RedeemCheckV2Data struct {
+	RawCheck []byte
+	Proof    [65]byte
+	Value    *big.Int
+}
+
+func (data RedeemCheckV2Data) Gas() int64 {
+	return gasRedeemCheck
+}
+func (data RedeemCheckV2Data) TxType() TxType {
+	return TypeRedeemCheckV2
+}
+
+func (data RedeemCheckV2Data) basicCheck(tx *Transaction, context *state.CheckState) *Response {
+	if len(data.RawCheck) == 0 || data.Value == nil {
+		return &Response{
+			Code: code.DecodeError,
+			Log:  "Incorrect tx data",
+			Info: EncodeError(code.NewDecodeError()),
+		}
+	}
+
+	// fixed potential problem with making too high commission for sender
+	if tx.GasPrice != 1 {
+		return &Response{
+			Code: code.TooHighGasPrice,
+			Log:  "Gas price for check is limited to 1",
+			Info: EncodeError(code.NewTooHighGasPrice("1", strconv.Itoa(int(tx.GasPrice)))),
+		}
+	}
+
+	return nil
+}
+
+func (data RedeemCheckV2Data) String() string {
+	return fmt.Sprintf("REDEEM CHECK V2 value: %s proof: %x", data.Value, data.Proof)
+}
+
+func (data RedeemCheckV2Data) CommissionData(price *commission.Price) *big.Int {
+	return price.RedeemCheck
+}
+
+// checkIssuer returns the issuer of the check, signatures of a multisig issuer are checked against weights of the multisig account,
+// the check should be signed by the authorized keys of the issuer or of the members of the multisig
+func (data RedeemCheckV2Data) checkIssuer(context *state.CheckState, decodedCheck *check.CheckV2) (types.Address, *Response) {
+	checkSender, err := decodedCheck.Sender()
+	if err != nil {
+		return types.Address{}, &Response{
+			Code: code.DecodeError,
+			Log:  err.Error(),
+			Info: EncodeError(code.NewDecodeError()),
+		}
+	}
+
+	if !decodedCheck.IsMultisig() {
+		if errResp := checkAuthorizedSigner(context, checkSender, checkSender); errResp != nil {
+			return types.Address{}, errResp
+		}
+		return checkSender, nil
+	}
+
+	multisig := context.Accounts().GetAccount(checkSender)
+	if !multisig.IsMultisig() {
+		return types.Address{}, &Response{
+			Code: code.MultisigNotExists,
+			Log:  "Multisig does not exists",
+			Info: EncodeError(code.NewMultisigNotExists(checkSender.String())),
+		}
+	}
+
+	multisigData := multisig.Multisig()
+	if len(decodedCheck.Signatures) > 32 || len(multisigData.Weights) < len(decodedCheck.Signatures) {
+		return types.Address{}, &Response{
+			Code: code.IncorrectMultiSignature,
+			Log:  "Incorrect multi-signature",
+			Info: EncodeError(code.NewIncorrectMultiSignature("error in the number of signers")),
+		}
+	}
+
+	signers, err := decodedCheck.Signers()
+	if err != nil {
+		return types.Address{}, &Response{
+			Code: code.IncorrectMultiSignature,
+			Log:  "Incorrect multi-signature",
+			Info: EncodeError(code.NewIncorrectMultiSignature(err.Error())),
+		}
+	}
+
+	totalWeight, errResp := multisigSignersWeight(context, &multisigData, signers)
+	if errResp != nil {
+		return types.Address{}, errResp
+	}
+
+	if totalWeight < multisigData.Threshold {
+		return types.Address{}, &Response{
+			Code: code.NotEnoughMultisigVotes,
+			Log:  fmt.Sprintf("Not enough multisig votes. Needed %d, has %d", multisigData.Threshold, totalWeight),
+			Info: EncodeError(code.NewNotEnoughMultisigVotes(fmt.Sprintf("%d", multisigData.Threshold), fmt.Sprintf("%d", totalWeight))),
+		}
+	}
+
+	return checkSender, nil
+}
+
+func (data RedeemCheckV2Data) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, price *big.Int) Response {
+	sender, _ := tx.Sender()
+
+	var checkState *state.CheckState
+	var isCheck bool
+	if checkState, isCheck = context.(*state.CheckState); !isCheck {
+		checkState = state.NewCheckState(context.(*state.State))
+	}
+
+	response := data.basicCheck(tx, checkState)
+	if response != nil {
+		return *response
+	}
+
+	decodedCheck, err := check.DecodeV2FromBytes(data.RawCheck)
+	if err != nil {
+		return Response{
+			Code: code.DecodeError,
+			Log:  err.Error(),
+			Info: EncodeError(code.NewDecodeError()),
+		}
+	}
+
+	if decodedCheck.ChainID != types.CurrentChainID {
+		return Response{
+			Code: code.WrongChainID,
+			Log:  "Wrong chain id",
+			Info: EncodeError(code.NewWrongChainID(fmt.Sprintf("%d", types.CurrentChainID), fmt.Sprintf("%d", decodedCheck.ChainID))),
+		}
+	}
+
+	if len(decodedCheck.Nonce) > 16 {
+		return Response{
+			Code: code.TooLongNonce,
+			Log:  "Nonce is too big. Should be up to 16 bytes.",
+			Info: EncodeError(code.NewTooLongNonce(strconv.Itoa(len(decodedCheck.Nonce)), "16")),
+		}
+	}
+
+	checkSender, errResp := data.checkIssuer(checkState, decodedCheck)
+	if errResp != nil {
+		return *errResp
+	}
+
+	if !checkState.Coins().Exists(decodedCheck.Coin) {
+		return Response{
+			Code: code.CoinNotExists,
+			Log:  "Coin not exists",
+			Info: EncodeError(code.NewCoinNotExists("", decodedCheck.Coin.String())),
+		}
+	}
+
+	if !checkState.Coins().Exists(decodedCheck.GasCoin) {
+		return Response{
+			Code: code.CoinNotExists,
+			Log:  "Gas coin not exists",
+			Info: EncodeError(code.NewCoinNotExists("", decodedCheck.GasCoin.String())),
+		}
+	}
+
+	if tx.GasCoin != decodedCheck.GasCoin {
+		return Response{
+			Code: code.WrongGasCoin,
+			Log:  fmt.Sprintf("CommissionData coin for redeem check transaction can only be %s", decodedCheck.GasCoin),
+			Info: EncodeError(code.NewWrongGasCoin(checkState.Coins().GetCoin(tx.GasCoin).GetFullSymbol(), tx.GasCoin.String(), checkState.Coins().GetCoin(decodedCheck.GasCoin).GetFullSymbol(), decodedCheck.GasCoin.String())),
+		}
+	}
+
+	if decodedCheck.DueBlock < currentBlock {
+		return Response{
+			Code: code.CheckExpired,
+			Log:  "Check expired",
+			Info: EncodeError(code.MewCheckExpired(fmt.Sprintf("%d", decodedCheck.DueBlock), fmt.Sprintf("%d", currentBlock))),
+		}
+	}
+
+	checkHash := decodedCheck.Hash()
+	if checkState.Checks().IsCheckHashUsed(checkHash) {
+		return Response{
+			Code: code.CheckUsed,
+			Log:  "Check already redeemed",
+			Info: EncodeError(code.NewCheckUsed()),
+		}
+	}
+
+	redeemed := checkState.Checks().GetRedeemedValue(checkHash)
+	remaining := big.NewInt(0).Sub(decodedCheck.Value, redeemed)
+	if data.Value.Sign() <= 0 || data.Value.Cmp(remaining) > 0 ||
+		(decodedCheck.MaxRedeemPerCall.Sign() > 0 && data.Value.Cmp(decodedCheck.MaxRedeemPerCall) > 0) {
+		return Response{
+			Code: code.WrongCheckRedeemValue,
+			Log:  fmt.Sprintf("Wrong value to redeem: %s, remaining %s, max per call %s", data.Value, remaining, decodedCheck.MaxRedeemPerCall),
+			Info: EncodeError(code.NewWrongCheckRedeemValue(data.Value.String(), remaining.String(), decodedCheck.MaxRedeemPerCall.String())),
+		}
+	}
+
+	lockPublicKey, err := decodedCheck.LockPubKey()
+	if err != nil {
+		return Response{
+			Code: code.DecodeError,
+			Log:  err.Error(),
+			Info: EncodeError(code.NewDecodeError()),
+		}
+	}
+
+	var senderAddressHash types.Hash
+	hw := sha3.NewLegacyKeccak256()
+	_ = rlp.Encode(hw, []interface{}{
+		sender,
+	})
+	hw.Sum(senderAddressHash[:0])
+
+	pub, err := crypto.Ecrecover(senderAddressHash[:], data.Proof[:])
+	if err != nil {
+		return Response{
+			Code: code.DecodeError,
+			Log:  err.Error(),
+			Info: EncodeError(code.NewDecodeError()),
+		}
+	}
+
+	if !bytes.Equal(lockPublicKey, pub) {
+		return Response{
+			Code: code.CheckInvalidLock,
+			Log:  "Invalid proof",
+			Info: EncodeError(code.NewCheckInvalidLock()),
+		}
+	}
+
+	commissionInBaseCoin := price
+	commissionPoolSwapper := checkState.Swap().GetSwapper(tx.GasCoin, types.GetBaseCoinID())
+	gasCoin := checkState.Coins().GetCoin(tx.GasCoin)
+	commission, isGasCommissionFromPoolSwap, errResp := CalculateCommission(checkState, commissionPoolSwapper, gasCoin, commissionInBaseCoin)
+	if errResp != nil {
+		return *errResp
+	}
+
+	spends := totalSpends{}
+	spends.Add(decodedCheck.GasCoin, commission)
+	spends.Add(decodedCheck.Coin, data.Value)
+	for _, spend := range spends {
+		if checkState.Accounts().GetSpendableBalance(checkSender, spend.Coin).Cmp(spend.Value) < 0 {
+			coin := checkState.Coins().GetCoin(spend.Coin)
+			return Response{
+				Code: code.InsufficientFunds,
+				Log:  fmt.Sprintf("Insufficient funds for check issuer account: %s. Wanted %s %s", checkSender.String(), spend.Value.String(), coin.GetFullSymbol()),
+				Info: EncodeError(code.NewInsufficientFunds(checkSender.String(), spend.Value.String(), coin.GetFullSymbol(), coin.ID().String())),
+			}
+		}
+	}
+
+	if errResp := checkIssuerPolicy(checkState, checkSender, sender, decodedCheck.Coin, data.Value); errResp != nil {
+		return *errResp
+	}
+
+	var tags []abcTypes.EventAttribute
+	if deliverState, ok := context.(*state.State); ok {
+		deliverState.Accounts.AddPolicySpent(checkSender, decodedCheck.Coin, data.Value)
+		if redeemed.Add(redeemed, data.Value).Cmp(decodedCheck.Value) == 0 {
+			deliverState.Checks.UseCheckHash(checkHash)
+			deliverState.Checks.SetRedeemedValue(checkHash, big.NewInt(0))
+		} else {
+			deliverState.Checks.SetRedeemedValue(checkHash, redeemed)
+		}
+
+		var tagsCom *tagPoolChange
+		if isGasCommissionFromPoolSwap {
+			var (
+				poolIDCom  uint32
+				detailsCom *swap.ChangeDetailsWithOrders
+				ownersCom  []*swap.OrderDetail
+			)
+			commission, commissionInBaseCoin, poolIDCom, detailsCom, ownersCom = deliverState.Swapper().PairSellWithOrders(tx.CommissionCoin(), types.GetBaseCoinID(), commission, big.NewInt(0))
+			tagsCom = &tagPoolChange{
+				PoolID:   poolIDCom,
+				CoinIn:   tx.CommissionCoin(),
+				ValueIn:  commission.String(),
+				CoinOut:  types.GetBaseCoinID(),
+				ValueOut: commissionInBaseCoin.String(),
+				Orders:   detailsCom,
+				// Sellers:  ownersCom,
+			}
+			for _, value := range ownersCom {
+				deliverState.Accounts.AddBalance(value.Owner, tx.CommissionCoin(), value.ValueBigInt)
+			}
+		} else if !tx.GasCoin.IsBaseCoin() {
+			deliverState.Coins.SubVolume(tx.CommissionCoin(), commission)
+			deliverState.Coins.SubReserve(tx.CommissionCoin(), commissionInBaseCoin)
+		}
+		rewardPool.Add(rewardPool, commissionInBaseCoin)
+		deliverState.Accounts.SubBalance(checkSender, decodedCheck.GasCoin, commission)
+		deliverState.Accounts.SubBalance(checkSender, decodedCheck.Coin, data.Value)
+		deliverState.Accounts.AddBalance(sender, decodedCheck.Coin, data.Value)
+		deliverState.Accounts.SetNonce(sender, tx.Nonce)
+
+		tags = []abcTypes.EventAttribute{
+			{Key: []byte("tx.commission_in_base_coin"), Value: []byte(commissionInBaseCoin.String())},
+			{Key: []byte("tx.commission_conversion"), Value: []byte(isGasCommissionFromPoolSwap.String()), Index: true},
+			{Key: []byte("tx.commission_amount"), Value: []byte(commission.String())},
+			{Key: []byte("tx.commission_details"), Value: []byte(tagsCom.string())},
+			{Key: []byte("tx.to"), Value: []byte(hex.EncodeToString(sender[:])), Index: true},
+			{Key: []byte("tx.coin_id"), Value: []byte(decodedCheck.Coin.String()), Index: true},
+			{Key: []byte("tx.from"), Value: []byte(hex.EncodeToString(checkSender[:])), Index: true},
+			{Key: []byte("tx.check_hash"), Value: []byte(hex.EncodeToString(checkHash[:])), Index: true},
+			{Key: []byte("tx.check_redeemed"), Value: []byte(redeemed.String())},
+		}
+	}
+
+	return Response{
+		Code: code.OK,
+		Tags: tags,
+	}
+}
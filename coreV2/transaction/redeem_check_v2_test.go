@@ -0,0 +1,202 @@
+package transaction
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"math/big"
+	"sync"
+	"testing"
+
+	c "github.com/MinterTeam/minter-go-node/coreV2/check"
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/accounts"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/crypto"
+	"github.com/MinterTeam/minter-go-node/helpers"
+	"github.com/MinterTeam/minter-go-node/rlp"
+	"golang.org/x/crypto/sha3"
+)
+
+func makeTestCheckV2(t *testing.T, receiver types.Address, value, maxRedeemPerCall *big.Int, multisig types.Address, signerKeys ...*ecdsa.PrivateKey) (rawCheck []byte, proof [65]byte) {
+	passphraseHash := sha256.Sum256([]byte("password"))
+	passphrasePk, err := crypto.ToECDSA(passphraseHash[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	check := c.CheckV2{
+		Nonce:            []byte{1},
+		ChainID:          types.CurrentChainID,
+		DueBlock:         100,
+		Coin:             types.GetBaseCoinID(),
+		Value:            value,
+		GasCoin:          types.GetBaseCoinID(),
+		MaxRedeemPerCall: maxRedeemPerCall,
+		Multisig:         multisig,
+	}
+
+	lock, err := crypto.Sign(check.HashWithoutLock().Bytes(), passphrasePk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	check.Lock = big.NewInt(0).SetBytes(lock)
+
+	for _, key := range signerKeys {
+		if err := check.Sign(key); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	rawCheck, err = rlp.EncodeToBytes(check)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var receiverAddressHash types.Hash
+	hw := sha3.NewLegacyKeccak256()
+	_ = rlp.Encode(hw, []interface{}{
+		receiver,
+	})
+	hw.Sum(receiverAddressHash[:0])
+
+	sig, err := crypto.Sign(receiverAddressHash.Bytes(), passphrasePk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	copy(proof[:], sig)
+
+	return rawCheck, proof
+}
+
+func TestRedeemCheckV2TxPartially(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+	coin := types.GetBaseCoinID()
+
+	issuerKey, _ := crypto.GenerateKey()
+	issuer := crypto.PubkeyToAddress(issuerKey.PublicKey)
+	cState.Accounts.AddBalance(issuer, coin, helpers.BipToPip(big.NewInt(1000)))
+
+	receiverKey, _ := crypto.GenerateKey()
+	receiver := crypto.PubkeyToAddress(receiverKey.PublicKey)
+
+	rawCheck, proof := makeTestCheckV2(t, receiver, helpers.BipToPip(big.NewInt(10)), helpers.BipToPip(big.NewInt(6)), types.Address{}, issuerKey)
+
+	nonce := uint64(1)
+	for i, item := range []struct {
+		value *big.Int
+		code  uint32
+	}{
+		{value: helpers.BipToPip(big.NewInt(7)), code: code.WrongCheckRedeemValue},
+		{value: helpers.BipToPip(big.NewInt(6)), code: code.OK},
+		{value: helpers.BipToPip(big.NewInt(5)), code: code.WrongCheckRedeemValue},
+		{value: helpers.BipToPip(big.NewInt(4)), code: code.OK},
+		{value: helpers.BipToPip(big.NewInt(1)), code: code.CheckUsed},
+	} {
+		encodedTx, err := makeTestTx(TypeRedeemCheckV2, RedeemCheckV2Data{RawCheck: rawCheck, Proof: proof, Value: item.value}, nonce, receiverKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		response := NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 1, &sync.Map{}, 0, false)
+		if response.Code != item.code {
+			t.Fatalf("Tx %d: Response code is not %d. Error %s", i, item.code, response.Log)
+		}
+		if response.Code == code.OK {
+			nonce++
+		}
+	}
+
+	if balance := cState.Accounts.GetBalance(receiver, coin); balance.Cmp(helpers.BipToPip(big.NewInt(10))) != 0 {
+		t.Fatalf("Receiver balance is not correct. Expected %s, got %s", helpers.BipToPip(big.NewInt(10)), balance)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRedeemCheckV2TxFromMultisig(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+	coin := types.GetBaseCoinID()
+
+	memberKey1, _ := crypto.GenerateKey()
+	member1 := crypto.PubkeyToAddress(memberKey1.PublicKey)
+	memberKey2, _ := crypto.GenerateKey()
+	member2 := crypto.PubkeyToAddress(memberKey2.PublicKey)
+	multisig := cState.Accounts.CreateMultisig([]uint32{1, 1}, []types.Address{member1, member2}, 2, accounts.CreateMultisigAddress(member1, 1))
+	cState.Accounts.AddBalance(multisig, coin, helpers.BipToPip(big.NewInt(1000)))
+
+	receiverKey, _ := crypto.GenerateKey()
+	receiver := crypto.PubkeyToAddress(receiverKey.PublicKey)
+
+	value := helpers.BipToPip(big.NewInt(10))
+	rawCheck, proof := makeTestCheckV2(t, receiver, value, big.NewInt(0), multisig, memberKey1)
+	encodedTx, err := makeTestTx(TypeRedeemCheckV2, RedeemCheckV2Data{RawCheck: rawCheck, Proof: proof, Value: value}, 1, receiverKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.NotEnoughMultisigVotes {
+		t.Fatalf("Response code is not %d. Error %s", code.NotEnoughMultisigVotes, response.Log)
+	}
+
+	rawCheck, proof = makeTestCheckV2(t, receiver, value, big.NewInt(0), multisig, memberKey1, memberKey2)
+	encodedTx, err = makeTestTx(TypeRedeemCheckV2, RedeemCheckV2Data{RawCheck: rawCheck, Proof: proof, Value: value}, 1, receiverKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response = NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not %d. Error %s", code.OK, response.Log)
+	}
+
+	if balance := cState.Accounts.GetBalance(receiver, coin); balance.Cmp(value) != 0 {
+		t.Fatalf("Receiver balance is not correct. Expected %s, got %s", value, balance)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRedeemCheckV2TxToFailedCommission(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+	coin := types.GetBaseCoinID()
+
+	issuerKey, _ := crypto.GenerateKey()
+	issuer := crypto.PubkeyToAddress(issuerKey.PublicKey)
+	cState.Accounts.AddBalance(issuer, coin, helpers.BipToPip(big.NewInt(1000)))
+
+	receiverKey, _ := crypto.GenerateKey()
+	receiver := crypto.PubkeyToAddress(receiverKey.PublicKey)
+	cState.Accounts.AddBalance(receiver, coin, helpers.BipToPip(big.NewInt(1000)))
+
+	rawCheck, proof := makeTestCheckV2(t, receiver, helpers.BipToPip(big.NewInt(10)), big.NewInt(0), types.Address{}, issuerKey)
+	encodedTx, err := makeTestTx(TypeRedeemCheckV2, RedeemCheckV2Data{RawCheck: rawCheck, Proof: proof, Value: helpers.BipToPip(big.NewInt(11))}, 1, receiverKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.WrongCheckRedeemValue {
+		t.Fatalf("Response code is not %d. Error %s", code.WrongCheckRedeemValue, response.Log)
+	}
+
+	expectedBalance := big.NewInt(0).Sub(helpers.BipToPip(big.NewInt(1000)), cState.Commission.GetCommissions().FailedTx)
+	if balance := cState.Accounts.GetBalance(issuer, coin); balance.Cmp(expectedBalance) != 0 {
+		t.Fatalf("Issuer balance is not correct. Expected %s, got %s", expectedBalance, balance)
+	}
+
+	if balance := cState.Accounts.GetBalance(receiver, coin); balance.Cmp(helpers.BipToPip(big.NewInt(1000))) != 0 {
+		t.Fatalf("Receiver balance is not correct. Expected %s, got %s", helpers.BipToPip(big.NewInt(1000)), balance)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,169 @@
+package transaction
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/check"
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/commission"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	abcTypes "github.com/tendermint/tendermint/abci/types"
+)
+
+const maxRedeemChecks = 32
+
+// RedeemChecksData redeems several checks to the sender, every check issuer pays an equal part of the commission
+type RedeemChecksData struct {
+	Checks []RedeemCheckData
+}
+
+func (data RedeemChecksData) Gas() int64 {
+	return gasRedeemCheck * int64(len(data.Checks))
+}
+func (data RedeemChecksData) TxType() TxType {
+	return TypeRedeemChecks
+}
+
+func (data RedeemChecksData) basicCheck(tx *Transaction, context *state.CheckState) ([]*check.Check, *Response) {
+	if len(data.Checks) == 0 || len(data.Checks) > maxRedeemChecks {
+		return nil, &Response{
+			Code: code.DecodeError,
+			Log:  fmt.Sprintf("Number of checks should be from 1 to %d", maxRedeemChecks),
+			Info: EncodeError(code.NewDecodeError()),
+		}
+	}
+
+	decodedChecks := make([]*check.Check, 0, len(data.Checks))
+	hashes := map[types.Hash]struct{}{}
+	for _, item := range data.Checks {
+		decodedCheck, err := check.DecodeFromBytes(item.RawCheck)
+		if err != nil {
+			return nil, &Response{
+				Code: code.DecodeError,
+				Log:  err.Error(),
+				Info: EncodeError(code.NewDecodeError()),
+			}
+		}
+
+		if _, has := hashes[decodedCheck.Hash()]; has {
+			return nil, &Response{
+				Code: code.CheckUsed,
+				Log:  "Check is duplicated",
+				Info: EncodeError(code.NewCheckUsed()),
+			}
+		}
+		hashes[decodedCheck.Hash()] = struct{}{}
+
+		decodedChecks = append(decodedChecks, decodedCheck)
+	}
+
+	return decodedChecks, nil
+}
+
+func (data RedeemChecksData) String() string {
+	return fmt.Sprintf("REDEEM CHECKS count: %d", len(data.Checks))
+}
+
+func (data RedeemChecksData) CommissionData(price *commission.Price) *big.Int {
+	return big.NewInt(0).Mul(price.RedeemCheck, big.NewInt(int64(len(data.Checks))))
+}
+
+// checkPrice returns the part of the commission paid by the issuer of the check with the index, the first one pays the remainder
+func (data RedeemChecksData) checkPrice(price *big.Int, index int) *big.Int {
+	count := big.NewInt(int64(len(data.Checks)))
+	checkPrice, remainder := big.NewInt(0).QuoRem(price, count, big.NewInt(0))
+	if index == 0 {
+		checkPrice.Add(checkPrice, remainder)
+	}
+	return checkPrice
+}
+
+func (data RedeemChecksData) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, price *big.Int) Response {
+	sender, _ := tx.Sender()
+
+	var checkState *state.CheckState
+	var isCheck bool
+	if checkState, isCheck = context.(*state.CheckState); !isCheck {
+		checkState = state.NewCheckState(context.(*state.State))
+	}
+
+	decodedChecks, response := data.basicCheck(tx, checkState)
+	if response != nil {
+		return *response
+	}
+
+	// the same issuer may pay for several checks, so spending of every issuer is checked in total
+	spends := map[types.Address]*totalSpends{}
+	redeemed := map[types.Address]*totalSpends{}
+	for i, item := range data.Checks {
+		checkPrice := data.checkPrice(price, i)
+		if response := item.Run(tx, checkState, rewardPool, currentBlock, checkPrice); response.Code != code.OK {
+			response.Log = fmt.Sprintf("Check %d: %s", i, response.Log)
+			return response
+		}
+
+		decodedCheck := decodedChecks[i]
+		checkSender, _ := decodedCheck.Sender()
+		if spends[checkSender] == nil {
+			spends[checkSender] = &totalSpends{}
+		}
+		gasCoin := checkState.Coins().GetCoin(decodedCheck.GasCoin)
+		commission, _, _ := CalculateCommission(checkState, checkState.Swap().GetSwapper(decodedCheck.GasCoin, types.GetBaseCoinID()), gasCoin, checkPrice)
+		spends[checkSender].Add(decodedCheck.GasCoin, commission)
+		spends[checkSender].Add(decodedCheck.Coin, decodedCheck.Value)
+		if redeemed[checkSender] == nil {
+			redeemed[checkSender] = &totalSpends{}
+		}
+		redeemed[checkSender].Add(decodedCheck.Coin, decodedCheck.Value)
+	}
+
+	for i, decodedCheck := range decodedChecks {
+		checkSender, _ := decodedCheck.Sender()
+		for _, spend := range *spends[checkSender] {
+			if checkState.Accounts().GetSpendableBalance(checkSender, spend.Coin).Cmp(spend.Value) < 0 {
+				coin := checkState.Coins().GetCoin(spend.Coin)
+				return Response{
+					Code: code.InsufficientFunds,
+					Log:  fmt.Sprintf("Check %d: Insufficient funds for check issuer account: %s. Wanted %s %s", i, checkSender.String(), spend.Value.String(), coin.GetFullSymbol()),
+					Info: EncodeError(code.NewInsufficientFunds(checkSender.String(), spend.Value.String(), coin.GetFullSymbol(), coin.ID().String())),
+				}
+			}
+		}
+		for _, value := range *redeemed[checkSender] {
+			if errResp := checkIssuerPolicy(checkState, checkSender, sender, value.Coin, value.Value); errResp != nil {
+				errResp.Log = fmt.Sprintf("Check %d: %s", i, errResp.Log)
+				return *errResp
+			}
+		}
+	}
+
+	var tags []abcTypes.EventAttribute
+	if deliverState, ok := context.(*state.State); ok {
+		tags = []abcTypes.EventAttribute{
+			{Key: []byte("tx.checks_count"), Value: []byte(strconv.Itoa(len(data.Checks)))},
+		}
+		for i, item := range data.Checks {
+			response := item.Run(tx, deliverState, rewardPool, currentBlock, data.checkPrice(price, i))
+			if response.Code != code.OK {
+				response.Log = fmt.Sprintf("Check %d: %s", i, response.Log)
+				return response
+			}
+			for _, tag := range response.Tags {
+				tags = append(tags, abcTypes.EventAttribute{
+					Key:   []byte(fmt.Sprintf("tx.check_%d.%s", i, strings.TrimPrefix(string(tag.Key), "tx."))),
+					Value: tag.Value,
+					Index: tag.Index,
+				})
+			}
+		}
+	}
+
+	return Response{
+		Code: code.OK,
+		Tags: tags,
+	}
+}
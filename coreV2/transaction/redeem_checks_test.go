@@ -0,0 +1,150 @@
+package transaction
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"math/big"
+	"sync"
+	"testing"
+
+	c "github.com/MinterTeam/minter-go-node/coreV2/check"
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/crypto"
+	"github.com/MinterTeam/minter-go-node/helpers"
+	"github.com/MinterTeam/minter-go-node/rlp"
+	"golang.org/x/crypto/sha3"
+)
+
+func makeTestCheck(t *testing.T, issuerKey *ecdsa.PrivateKey, receiver types.Address, nonce []byte, value *big.Int) RedeemCheckData {
+	passphraseHash := sha256.Sum256([]byte("password"))
+	passphrasePk, err := crypto.ToECDSA(passphraseHash[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	check := c.Check{
+		Nonce:    nonce,
+		ChainID:  types.CurrentChainID,
+		DueBlock: 100,
+		Coin:     types.GetBaseCoinID(),
+		Value:    value,
+		GasCoin:  types.GetBaseCoinID(),
+	}
+
+	lock, err := crypto.Sign(check.HashWithoutLock().Bytes(), passphrasePk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	check.Lock = big.NewInt(0).SetBytes(lock)
+
+	if err := check.Sign(issuerKey); err != nil {
+		t.Fatal(err)
+	}
+
+	rawCheck, err := rlp.EncodeToBytes(check)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var receiverAddressHash types.Hash
+	hw := sha3.NewLegacyKeccak256()
+	_ = rlp.Encode(hw, []interface{}{
+		receiver,
+	})
+	hw.Sum(receiverAddressHash[:0])
+
+	sig, err := crypto.Sign(receiverAddressHash.Bytes(), passphrasePk)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	proof := [65]byte{}
+	copy(proof[:], sig)
+
+	return RedeemCheckData{
+		RawCheck: rawCheck,
+		Proof:    proof,
+	}
+}
+
+func TestRedeemChecksTx(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+	coin := types.GetBaseCoinID()
+
+	issuerKey1, _ := crypto.GenerateKey()
+	issuer1 := crypto.PubkeyToAddress(issuerKey1.PublicKey)
+	issuerKey2, _ := crypto.GenerateKey()
+	issuer2 := crypto.PubkeyToAddress(issuerKey2.PublicKey)
+	cState.Accounts.AddBalance(issuer1, coin, helpers.BipToPip(big.NewInt(1000)))
+	cState.Accounts.AddBalance(issuer2, coin, helpers.BipToPip(big.NewInt(1000)))
+
+	receiverKey, _ := crypto.GenerateKey()
+	receiver := crypto.PubkeyToAddress(receiverKey.PublicKey)
+
+	value := helpers.BipToPip(big.NewInt(10))
+	encodedTx, err := makeTestTx(TypeRedeemChecks, RedeemChecksData{Checks: []RedeemCheckData{
+		makeTestCheck(t, issuerKey1, receiver, []byte{1}, value),
+		makeTestCheck(t, issuerKey1, receiver, []byte{2}, value),
+		makeTestCheck(t, issuerKey2, receiver, []byte{3}, value),
+	}}, 1, receiverKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not %d. Error %s", code.OK, response.Log)
+	}
+
+	expectedBalance := big.NewInt(0).Mul(value, big.NewInt(3))
+	if balance := cState.Accounts.GetBalance(receiver, coin); balance.Cmp(expectedBalance) != 0 {
+		t.Fatalf("Receiver balance is not correct. Expected %s, got %s", expectedBalance, balance)
+	}
+
+	commissions := cState.Commission.GetCommissions()
+	expectedBalance = big.NewInt(0).Sub(helpers.BipToPip(big.NewInt(1000)), big.NewInt(0).Add(value, commissions.RedeemCheck))
+	if balance := cState.Accounts.GetBalance(issuer2, coin); balance.Cmp(expectedBalance) != 0 {
+		t.Fatalf("Issuer balance is not correct. Expected %s, got %s", expectedBalance, balance)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRedeemChecksTxToInsufficientFunds(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+	coin := types.GetBaseCoinID()
+
+	issuerKey, _ := crypto.GenerateKey()
+	issuer := crypto.PubkeyToAddress(issuerKey.PublicKey)
+	cState.Accounts.AddBalance(issuer, coin, helpers.BipToPip(big.NewInt(15)))
+
+	receiverKey, _ := crypto.GenerateKey()
+	receiver := crypto.PubkeyToAddress(receiverKey.PublicKey)
+
+	value := helpers.BipToPip(big.NewInt(10))
+	encodedTx, err := makeTestTx(TypeRedeemChecks, RedeemChecksData{Checks: []RedeemCheckData{
+		makeTestCheck(t, issuerKey, receiver, []byte{1}, value),
+		makeTestCheck(t, issuerKey, receiver, []byte{2}, value),
+	}}, 1, receiverKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.InsufficientFunds {
+		t.Fatalf("Response code is not %d. Error %s", code.InsufficientFunds, response.Log)
+	}
+
+	if balance := cState.Accounts.GetBalance(receiver, coin); balance.Sign() != 0 {
+		t.Fatalf("Receiver balance is not correct. Expected %s, got %s", "0", balance)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
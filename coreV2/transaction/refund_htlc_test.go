@@ -0,0 +1,55 @@
+package transaction
+
+import (
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/crypto"
+	"github.com/MinterTeam/minter-go-node/helpers"
+)
+
+func TestRefundHTLCTx(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+	coin := types.GetBaseCoinID()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(1000)))
+
+	value := helpers.BipToPip(big.NewInt(100))
+	createTestHTLC(t, cState, privateKey, types.Address{1}, value, []byte("secret"), 10)
+
+	encodedTx, err := makeTestTx(TypeRefundHTLC, RefundHTLCData{ID: 1}, 2, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 9, &sync.Map{}, 0, false)
+	if response.Code != code.WrongHTLCTimeout {
+		t.Fatalf("Response code is not %d. Error %s", code.WrongHTLCTimeout, response.Log)
+	}
+
+	response = NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 10, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not %d. Error %s", code.OK, response.Log)
+	}
+
+	commissions := cState.Commission.GetCommissions()
+	expectedBalance := big.NewInt(0).Sub(helpers.BipToPip(big.NewInt(1000)), big.NewInt(0).Add(commissions.CreateHTLCPrice(), commissions.RefundHTLCPrice()))
+	expectedBalance.Sub(expectedBalance, commissions.FailedTx)
+	if balance := cState.Accounts.GetBalance(addr, coin); balance.Cmp(expectedBalance) != 0 {
+		t.Fatalf("Sender balance is not correct. Expected %s, got %s", expectedBalance, balance)
+	}
+
+	if cState.HTLCs.GetHTLC(1) != nil {
+		t.Fatal("HTLC is not deleted")
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
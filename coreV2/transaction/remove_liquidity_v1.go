@@ -81,7 +81,7 @@ func (data RemoveLiquidityV1) Run(tx *Transaction, context state.Interface, rewa
 	}
 
 	coinLiquidity := checkState.Coins().GetCoinBySymbol(LiquidityCoinSymbol(swapper.GetID()), 0)
-	balance := checkState.Accounts().GetBalance(sender, coinLiquidity.ID())
+	balance := checkState.Accounts().GetSpendableBalance(sender, coinLiquidity.ID())
 	if balance.Cmp(data.Liquidity) == -1 {
 		return Response{
 			Code: code.InsufficientFunds,
@@ -122,7 +122,7 @@ func (data RemoveLiquidityV1) Run(tx *Transaction, context state.Interface, rewa
 		}
 	}
 
-	if checkState.Accounts().GetBalance(sender, tx.GasCoin).Cmp(commission) < 0 {
+	if checkState.Accounts().GetSpendableBalance(sender, tx.GasCoin).Cmp(commission) < 0 {
 		return Response{
 			Code: code.InsufficientFunds,
 			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), commission.String(), gasCoin.GetFullSymbol()),
@@ -99,7 +99,7 @@ func (data RemoveLiquidityV240) Run(tx *Transaction, context state.Interface, re
 	}
 
 	coinLiquidity := checkState.Coins().GetCoinBySymbol(LiquidityCoinSymbol(swapper.GetID()), 0)
-	balance := checkState.Accounts().GetBalance(sender, coinLiquidity.ID())
+	balance := checkState.Accounts().GetSpendableBalance(sender, coinLiquidity.ID())
 
 	needValue := big.NewInt(0).Set(commission)
 	if tx.GasCoin == coinLiquidity.ID() {
@@ -113,7 +113,7 @@ func (data RemoveLiquidityV240) Run(tx *Transaction, context state.Interface, re
 			}
 		}
 	}
-	if checkState.Accounts().GetBalance(sender, tx.GasCoin).Cmp(needValue) < 0 {
+	if checkState.Accounts().GetSpendableBalance(sender, tx.GasCoin).Cmp(needValue) < 0 {
 		return Response{
 			Code: code.InsufficientFunds,
 			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), needValue.String(), gasCoin.GetFullSymbol()),
@@ -0,0 +1,199 @@
+package transaction
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/check"
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/commission"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/swap"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	abcTypes "github.com/tendermint/tendermint/abci/types"
+)
+
+type RevokeCheckData struct {
+	RawCheck []byte
+}
+
+func (data RevokeCheckData) Gas() int64 {
+	return gasRevokeCheck
+}
+func (data RevokeCheckData) TxType() TxType {
+	return TypeRevokeCheck
+}
+
+// revokedCheck contains fields of a check or of a check v2 required to revoke it
+type revokedCheck struct {
+	hash     types.Hash
+	chainID  types.ChainID
+	dueBlock uint64
+	issuer   types.Address
+}
+
+// decodeCheck decodes a check or a check v2, the issuer of a multisig check v2 is the multisig account
+func (data RevokeCheckData) decodeCheck() (*revokedCheck, error) {
+	if decodedCheck, err := check.DecodeFromBytes(data.RawCheck); err == nil {
+		issuer, err := decodedCheck.Sender()
+		if err != nil {
+			return nil, err
+		}
+		return &revokedCheck{hash: decodedCheck.Hash(), chainID: decodedCheck.ChainID, dueBlock: decodedCheck.DueBlock, issuer: issuer}, nil
+	}
+
+	decodedCheck, err := check.DecodeV2FromBytes(data.RawCheck)
+	if err != nil {
+		return nil, err
+	}
+	issuer, err := decodedCheck.Sender()
+	if err != nil {
+		return nil, err
+	}
+	return &revokedCheck{hash: decodedCheck.Hash(), chainID: decodedCheck.ChainID, dueBlock: decodedCheck.DueBlock, issuer: issuer}, nil
+}
+
+func (data RevokeCheckData) basicCheck(tx *Transaction, context *state.CheckState, block uint64) (*revokedCheck, *Response) {
+	if len(data.RawCheck) == 0 {
+		return nil, &Response{
+			Code: code.DecodeError,
+			Log:  "Incorrect tx data",
+			Info: EncodeError(code.NewDecodeError()),
+		}
+	}
+
+	decodedCheck, err := data.decodeCheck()
+	if err != nil {
+		return nil, &Response{
+			Code: code.DecodeError,
+			Log:  err.Error(),
+			Info: EncodeError(code.NewDecodeError()),
+		}
+	}
+
+	if decodedCheck.chainID != types.CurrentChainID {
+		return nil, &Response{
+			Code: code.WrongChainID,
+			Log:  "Wrong chain id",
+			Info: EncodeError(code.NewWrongChainID(fmt.Sprintf("%d", types.CurrentChainID), fmt.Sprintf("%d", decodedCheck.chainID))),
+		}
+	}
+
+	sender, _ := tx.Sender()
+	if decodedCheck.issuer != sender {
+		return nil, &Response{
+			Code: code.IsNotCheckIssuer,
+			Log:  "Sender is not an issuer of the check",
+			Info: EncodeError(code.NewIsNotCheckIssuer(sender.String(), decodedCheck.issuer.String())),
+		}
+	}
+
+	if decodedCheck.dueBlock < block {
+		return nil, &Response{
+			Code: code.CheckExpired,
+			Log:  "Check expired",
+			Info: EncodeError(code.MewCheckExpired(fmt.Sprintf("%d", decodedCheck.dueBlock), fmt.Sprintf("%d", block))),
+		}
+	}
+
+	if context.Checks().IsCheckHashUsed(decodedCheck.hash) {
+		return nil, &Response{
+			Code: code.CheckUsed,
+			Log:  "Check already redeemed",
+			Info: EncodeError(code.NewCheckUsed()),
+		}
+	}
+
+	return decodedCheck, nil
+}
+
+func (data RevokeCheckData) String() string {
+	return "REVOKE CHECK"
+}
+
+func (data RevokeCheckData) CommissionData(price *commission.Price) *big.Int {
+	return price.RevokeCheckPrice()
+}
+
+func (data RevokeCheckData) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, price *big.Int) Response {
+	sender, _ := tx.Sender()
+
+	var checkState *state.CheckState
+	var isCheck bool
+	if checkState, isCheck = context.(*state.CheckState); !isCheck {
+		checkState = state.NewCheckState(context.(*state.State))
+	}
+
+	decodedCheck, response := data.basicCheck(tx, checkState, currentBlock)
+	if response != nil {
+		return *response
+	}
+
+	commissionInBaseCoin := price
+	commissionPoolSwapper := checkState.Swap().GetSwapper(tx.GasCoin, types.GetBaseCoinID())
+	gasCoin := checkState.Coins().GetCoin(tx.GasCoin)
+	commission, isGasCommissionFromPoolSwap, errResp := CalculateCommission(checkState, commissionPoolSwapper, gasCoin, commissionInBaseCoin)
+	if errResp != nil {
+		return *errResp
+	}
+
+	if checkState.Accounts().GetSpendableBalance(sender, tx.GasCoin).Cmp(commission) < 0 {
+		return Response{
+			Code: code.InsufficientFunds,
+			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), commission.String(), gasCoin.GetFullSymbol()),
+			Info: EncodeError(code.NewInsufficientFunds(sender.String(), commission.String(), gasCoin.GetFullSymbol(), gasCoin.ID().String())),
+		}
+	}
+
+	var tags []abcTypes.EventAttribute
+	if deliverState, ok := context.(*state.State); ok {
+		var tagsCom *tagPoolChange
+		if isGasCommissionFromPoolSwap {
+			var (
+				poolIDCom  uint32
+				detailsCom *swap.ChangeDetailsWithOrders
+				ownersCom  []*swap.OrderDetail
+			)
+			commission, commissionInBaseCoin, poolIDCom, detailsCom, ownersCom = deliverState.Swapper().PairSellWithOrders(tx.CommissionCoin(), types.GetBaseCoinID(), commission, big.NewInt(0))
+			tagsCom = &tagPoolChange{
+				PoolID:   poolIDCom,
+				CoinIn:   tx.CommissionCoin(),
+				ValueIn:  commission.String(),
+				CoinOut:  types.GetBaseCoinID(),
+				ValueOut: commissionInBaseCoin.String(),
+				Orders:   detailsCom,
+				// Sellers:  ownersCom,
+			}
+			for _, value := range ownersCom {
+				deliverState.Accounts.AddBalance(value.Owner, tx.CommissionCoin(), value.ValueBigInt)
+			}
+		} else if !tx.GasCoin.IsBaseCoin() {
+			deliverState.Coins.SubVolume(tx.CommissionCoin(), commission)
+			deliverState.Coins.SubReserve(tx.CommissionCoin(), commissionInBaseCoin)
+		}
+		deliverState.Accounts.SubBalance(sender, tx.GasCoin, commission)
+		rewardPool.Add(rewardPool, commissionInBaseCoin)
+
+		deliverState.Checks.UseCheckHash(decodedCheck.hash)
+		if deliverState.Checks.GetRedeemedValue(decodedCheck.hash).Sign() == 1 {
+			// the partially redeemed check v2 can not be redeemed anymore
+			deliverState.Checks.SetRedeemedValue(decodedCheck.hash, big.NewInt(0))
+		}
+		deliverState.Accounts.SetNonce(sender, tx.Nonce)
+
+		checkHash := decodedCheck.hash
+		tags = []abcTypes.EventAttribute{
+			{Key: []byte("tx.commission_in_base_coin"), Value: []byte(commissionInBaseCoin.String())},
+			{Key: []byte("tx.commission_conversion"), Value: []byte(isGasCommissionFromPoolSwap.String()), Index: true},
+			{Key: []byte("tx.commission_amount"), Value: []byte(commission.String())},
+			{Key: []byte("tx.commission_details"), Value: []byte(tagsCom.string())},
+			{Key: []byte("tx.check_hash"), Value: []byte(hex.EncodeToString(checkHash[:])), Index: true},
+		}
+	}
+
+	return Response{
+		Code: code.OK,
+		Tags: tags,
+	}
+}
@@ -0,0 +1,127 @@
+package transaction
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/accounts"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/crypto"
+	"github.com/MinterTeam/minter-go-node/helpers"
+	"github.com/MinterTeam/minter-go-node/rlp"
+)
+
+func TestRevokeCheckTx(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+	coin := types.GetBaseCoinID()
+
+	issuerKey, _ := crypto.GenerateKey()
+	issuer := crypto.PubkeyToAddress(issuerKey.PublicKey)
+	cState.Accounts.AddBalance(issuer, coin, helpers.BipToPip(big.NewInt(1000)))
+
+	receiverKey, _ := crypto.GenerateKey()
+	receiver := crypto.PubkeyToAddress(receiverKey.PublicKey)
+	cState.Accounts.AddBalance(receiver, coin, helpers.BipToPip(big.NewInt(1000)))
+
+	redeemData := makeTestCheck(t, issuerKey, receiver, []byte{1}, helpers.BipToPip(big.NewInt(10)))
+
+	encodedTx, err := makeTestTx(TypeRevokeCheck, RevokeCheckData{RawCheck: redeemData.RawCheck}, 1, receiverKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.IsNotCheckIssuer {
+		t.Fatalf("Response code is not %d. Error %s", code.IsNotCheckIssuer, response.Log)
+	}
+
+	encodedTx, err = makeTestTx(TypeRevokeCheck, RevokeCheckData{RawCheck: redeemData.RawCheck}, 1, issuerKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response = NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not %d. Error %s", code.OK, response.Log)
+	}
+
+	encodedTx, err = makeTestTx(TypeRedeemCheck, redeemData, 1, receiverKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response = NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.CheckUsed {
+		t.Fatalf("Response code is not %d. Error %s", code.CheckUsed, response.Log)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRevokeCheckV2TxFromMultisig(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+	coin := types.GetBaseCoinID()
+
+	memberKey1, _ := crypto.GenerateKey()
+	member1 := crypto.PubkeyToAddress(memberKey1.PublicKey)
+	memberKey2, _ := crypto.GenerateKey()
+	member2 := crypto.PubkeyToAddress(memberKey2.PublicKey)
+	multisig := cState.Accounts.CreateMultisig([]uint32{1, 1}, []types.Address{member1, member2}, 2, accounts.CreateMultisigAddress(member1, 1))
+	cState.Accounts.AddBalance(multisig, coin, helpers.BipToPip(big.NewInt(1000)))
+
+	receiverKey, _ := crypto.GenerateKey()
+	receiver := crypto.PubkeyToAddress(receiverKey.PublicKey)
+
+	value := helpers.BipToPip(big.NewInt(10))
+	rawCheck, proof := makeTestCheckV2(t, receiver, value, big.NewInt(0), multisig, memberKey1, memberKey2)
+
+	encodedData, err := rlp.EncodeToBytes(RevokeCheckData{RawCheck: rawCheck})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx := Transaction{
+		Nonce:         1,
+		GasPrice:      1,
+		ChainID:       types.CurrentChainID,
+		GasCoin:       coin,
+		Type:          TypeRevokeCheck,
+		Data:          encodedData,
+		SignatureType: SigTypeMulti,
+	}
+	tx.SetMultisigAddress(multisig)
+	for _, key := range []*ecdsa.PrivateKey{memberKey1, memberKey2} {
+		if err := tx.Sign(key); err != nil {
+			t.Fatal(err)
+		}
+	}
+	encodedTx, err := rlp.EncodeToBytes(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not %d. Error %s", code.OK, response.Log)
+	}
+
+	encodedTx, err = makeTestTx(TypeRedeemCheckV2, RedeemCheckV2Data{RawCheck: rawCheck, Proof: proof, Value: value}, 1, receiverKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response = NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.CheckUsed {
+		t.Fatalf("Response code is not %d. Error %s", code.CheckUsed, response.Log)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
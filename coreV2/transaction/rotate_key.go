@@ -0,0 +1,197 @@
+package transaction
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/accounts"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/commission"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/swap"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	abcTypes "github.com/tendermint/tendermint/abci/types"
+)
+
+// RotateKeyData binds the single-key Account to the NewKey, transactions of the account should be signed by it with SigTypeAuthorized.
+// The transaction is sent by the account itself or by its guardian multisig to recover the account, NewKey equal to Account resets the original key
+type RotateKeyData struct {
+	Account types.Address
+	NewKey  types.Address
+}
+
+func (data RotateKeyData) TxType() TxType {
+	return TypeRotateKey
+}
+
+func (data RotateKeyData) Gas() int64 {
+	return gasRotateKey
+}
+
+func (data RotateKeyData) basicCheck(tx *Transaction, context *state.CheckState) *Response {
+	if data.NewKey == (types.Address{}) {
+		return &Response{
+			Code: code.DecodeError,
+			Log:  "Incorrect tx data",
+			Info: EncodeError(code.NewDecodeError()),
+		}
+	}
+
+	if context.Accounts().GetAccount(data.Account).IsMultisig() {
+		return &Response{
+			Code: code.MultisigExists,
+			Log:  "Key of the multisig can not be rotated",
+			Info: EncodeError(code.NewMultisigExists(data.Account.String())),
+		}
+	}
+
+	sender, _ := tx.Sender()
+	if sender != data.Account {
+		if guardian := context.Accounts().GetGuardian(data.Account); guardian == (types.Address{}) || guardian != sender {
+			return &Response{
+				Code: code.IsNotKeyGuardian,
+				Log:  fmt.Sprintf("Sender is not a guardian of the account %s", data.Account.String()),
+				Info: EncodeError(code.NewIsNotKeyGuardian(data.Account.String(), sender.String())),
+			}
+		}
+	}
+
+	return nil
+}
+
+func (data RotateKeyData) String() string {
+	return fmt.Sprintf("ROTATE KEY account:%s new key:%s", data.Account.String(), data.NewKey.String())
+}
+
+func (data RotateKeyData) CommissionData(price *commission.Price) *big.Int {
+	return price.RotateKeyPrice()
+}
+
+func (data RotateKeyData) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, price *big.Int) Response {
+	sender, _ := tx.Sender()
+	var checkState *state.CheckState
+	var isCheck bool
+	if checkState, isCheck = context.(*state.CheckState); !isCheck {
+		checkState = state.NewCheckState(context.(*state.State))
+	}
+
+	response := data.basicCheck(tx, checkState)
+	if response != nil {
+		return *response
+	}
+
+	commissionInBaseCoin := price
+	commissionPoolSwapper := checkState.Swap().GetSwapper(tx.GasCoin, types.GetBaseCoinID())
+	gasCoin := checkState.Coins().GetCoin(tx.GasCoin)
+	commission, isGasCommissionFromPoolSwap, errResp := CalculateCommission(checkState, commissionPoolSwapper, gasCoin, commissionInBaseCoin)
+	if errResp != nil {
+		return *errResp
+	}
+
+	if checkState.Accounts().GetSpendableBalance(sender, tx.GasCoin).Cmp(commission) < 0 {
+		return Response{
+			Code: code.InsufficientFunds,
+			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), commission.String(), gasCoin.GetFullSymbol()),
+			Info: EncodeError(code.NewInsufficientFunds(sender.String(), commission.String(), gasCoin.GetFullSymbol(), gasCoin.ID().String())),
+		}
+	}
+
+	var tags []abcTypes.EventAttribute
+	if deliverState, ok := context.(*state.State); ok {
+		var tagsCom *tagPoolChange
+		if isGasCommissionFromPoolSwap {
+			var (
+				poolIDCom  uint32
+				detailsCom *swap.ChangeDetailsWithOrders
+				ownersCom  []*swap.OrderDetail
+			)
+			commission, commissionInBaseCoin, poolIDCom, detailsCom, ownersCom = deliverState.Swapper().PairSellWithOrders(tx.CommissionCoin(), types.GetBaseCoinID(), commission, big.NewInt(0))
+			tagsCom = &tagPoolChange{
+				PoolID:   poolIDCom,
+				CoinIn:   tx.CommissionCoin(),
+				ValueIn:  commission.String(),
+				CoinOut:  types.GetBaseCoinID(),
+				ValueOut: commissionInBaseCoin.String(),
+				Orders:   detailsCom,
+				// Sellers:  ownersCom,
+			}
+			for _, value := range ownersCom {
+				deliverState.Accounts.AddBalance(value.Owner, tx.CommissionCoin(), value.ValueBigInt)
+			}
+		} else if !tx.GasCoin.IsBaseCoin() {
+			deliverState.Coins.SubVolume(tx.CommissionCoin(), commission)
+			deliverState.Coins.SubReserve(tx.CommissionCoin(), commissionInBaseCoin)
+		}
+		deliverState.Accounts.SubBalance(sender, tx.GasCoin, commission)
+		rewardPool.Add(rewardPool, commissionInBaseCoin)
+
+		newKey := data.NewKey
+		if newKey == data.Account {
+			newKey = types.Address{}
+		}
+		deliverState.Accounts.SetAuthorizedKey(data.Account, newKey)
+		deliverState.Accounts.SetNonce(sender, tx.Nonce)
+
+		tags = []abcTypes.EventAttribute{
+			{Key: []byte("tx.commission_in_base_coin"), Value: []byte(commissionInBaseCoin.String())},
+			{Key: []byte("tx.commission_conversion"), Value: []byte(isGasCommissionFromPoolSwap.String()), Index: true},
+			{Key: []byte("tx.commission_amount"), Value: []byte(commission.String())},
+			{Key: []byte("tx.commission_details"), Value: []byte(tagsCom.string())},
+			{Key: []byte("tx.account"), Value: []byte(hex.EncodeToString(data.Account[:])), Index: true},
+			{Key: []byte("tx.authorized_key"), Value: []byte(hex.EncodeToString(data.NewKey[:])), Index: true},
+		}
+	}
+
+	return Response{
+		Code: code.OK,
+		Tags: tags,
+	}
+}
+
+// checkAuthorizedSigner checks that the signature of the account is made by its authorized key,
+// signatures of the original key are rejected once the key of the account is rotated
+func checkAuthorizedSigner(context *state.CheckState, account types.Address, signer types.Address) *Response {
+	if authorizedKey := context.Accounts().GetAuthorizedKey(account); authorizedKey != signer {
+		return &Response{
+			Code: code.KeyIsNotAuthorized,
+			Log:  fmt.Sprintf("Key %s is not authorized to sign for the account %s", signer.String(), account.String()),
+			Info: EncodeError(code.NewKeyIsNotAuthorized(account.String(), signer.String())),
+		}
+	}
+
+	return nil
+}
+
+// multisigSignersWeight returns the total weight of the signers of the multisig, every member signs with its authorized key
+func multisigSignersWeight(context *state.CheckState, multisigData *accounts.Multisig, signers []types.Address) (uint32, *Response) {
+	members := make(map[types.Address]types.Address, len(multisigData.Addresses))
+	for _, member := range multisigData.Addresses {
+		members[context.Accounts().GetAuthorizedKey(member)] = member
+	}
+
+	var totalWeight uint32
+	var usedAccounts = map[types.Address]bool{}
+	for _, signer := range signers {
+		member, ok := members[signer]
+		if !ok {
+			// the signer is not a member or its key is rotated
+			member = signer
+		}
+
+		if usedAccounts[member] {
+			return 0, &Response{
+				Code: code.DuplicatedAddresses,
+				Log:  "Duplicated multisig addresses",
+				Info: EncodeError(code.NewDuplicatedAddresses(member.String())),
+			}
+		}
+
+		usedAccounts[member] = true
+		if ok {
+			totalWeight += multisigData.GetWeight(member)
+		}
+	}
+
+	return totalWeight, nil
+}
@@ -0,0 +1,315 @@
+package transaction
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/accounts"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/crypto"
+	"github.com/MinterTeam/minter-go-node/helpers"
+	"github.com/MinterTeam/minter-go-node/rlp"
+)
+
+func makeTestAuthorizedTx(txType TxType, data interface{}, nonce uint64, account types.Address, privateKey *ecdsa.PrivateKey) ([]byte, error) {
+	encodedData, err := rlp.EncodeToBytes(data)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := Transaction{
+		Nonce:         nonce,
+		GasPrice:      1,
+		ChainID:       types.CurrentChainID,
+		GasCoin:       types.GetBaseCoinID(),
+		Type:          txType,
+		Data:          encodedData,
+		SignatureType: SigTypeAuthorized,
+	}
+
+	tx.SetAuthorizedAccount(account)
+
+	if err := tx.Sign(privateKey); err != nil {
+		return nil, err
+	}
+
+	return rlp.EncodeToBytes(tx)
+}
+
+func TestRotateKeyTx(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+	coin := types.GetBaseCoinID()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(1000)))
+
+	newKey, _ := crypto.GenerateKey()
+	newKeyAddr := crypto.PubkeyToAddress(newKey.PublicKey)
+
+	encodedTx, err := makeTestTx(TypeRotateKey, RotateKeyData{Account: addr, NewKey: newKeyAddr}, 1, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 0, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not %d. Error %s", code.OK, response.Log)
+	}
+
+	if key := cState.Accounts.GetAuthorizedKey(addr); key != newKeyAddr {
+		t.Fatalf("Authorized key is not correct. Expected %s, got %s", newKeyAddr.String(), key.String())
+	}
+
+	encodedTx, err = makeTestTx(TypeSend, SendData{Coin: coin, To: types.Address{1}, Value: big.NewInt(1)}, 2, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response = NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 0, &sync.Map{}, 0, false)
+	if response.Code != code.KeyIsNotAuthorized {
+		t.Fatalf("Response code is not %d. Error %s", code.KeyIsNotAuthorized, response.Log)
+	}
+
+	encodedTx, err = makeTestAuthorizedTx(TypeSend, SendData{Coin: coin, To: types.Address{1}, Value: big.NewInt(1)}, 2, addr, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response = NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 0, &sync.Map{}, 0, false)
+	if response.Code != code.KeyIsNotAuthorized {
+		t.Fatalf("Response code is not %d. Error %s", code.KeyIsNotAuthorized, response.Log)
+	}
+
+	encodedTx, err = makeTestAuthorizedTx(TypeSend, SendData{Coin: coin, To: types.Address{1}, Value: big.NewInt(1)}, 2, addr, newKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response = NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 0, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not %d. Error %s", code.OK, response.Log)
+	}
+
+	if balance := cState.Accounts.GetBalance(types.Address{1}, coin); balance.Cmp(big.NewInt(1)) != 0 {
+		t.Fatalf("Recipient balance is not correct. Expected %d, got %s", 1, balance)
+	}
+
+	encodedTx, err = makeTestAuthorizedTx(TypeRotateKey, RotateKeyData{Account: addr, NewKey: addr}, 3, addr, newKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response = NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 0, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not %d. Error %s", code.OK, response.Log)
+	}
+
+	if key := cState.Accounts.GetAuthorizedKey(addr); key != addr {
+		t.Fatalf("Authorized key is not reset. Expected %s, got %s", addr.String(), key.String())
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRotateKeyTxByGuardian(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+	coin := types.GetBaseCoinID()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(1000)))
+
+	guardianKey, _ := crypto.GenerateKey()
+	guardianKeyAddr := crypto.PubkeyToAddress(guardianKey.PublicKey)
+	guardian := cState.Accounts.CreateMultisig([]uint32{1}, []types.Address{guardianKeyAddr}, 1, types.Address{2})
+	cState.Accounts.AddBalance(guardian, coin, helpers.BipToPip(big.NewInt(1000)))
+
+	newKey, _ := crypto.GenerateKey()
+	newKeyAddr := crypto.PubkeyToAddress(newKey.PublicKey)
+
+	data := RotateKeyData{Account: addr, NewKey: newKeyAddr}
+	encodedData, err := rlp.EncodeToBytes(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx := Transaction{
+		Nonce:         1,
+		GasPrice:      1,
+		ChainID:       types.CurrentChainID,
+		GasCoin:       coin,
+		Type:          TypeRotateKey,
+		Data:          encodedData,
+		SignatureType: SigTypeMulti,
+	}
+
+	tx.SetMultisigAddress(guardian)
+
+	if err := tx.Sign(guardianKey); err != nil {
+		t.Fatal(err)
+	}
+
+	encodedTx, err := rlp.EncodeToBytes(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 0, &sync.Map{}, 0, false)
+	if response.Code != code.IsNotKeyGuardian {
+		t.Fatalf("Response code is not %d. Error %s", code.IsNotKeyGuardian, response.Log)
+	}
+
+	encodedGuardianTx, err := makeTestTx(TypeSetKeyGuardian, SetKeyGuardianData{Account: addr, Guardian: guardian}, 1, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response = NewExecutorV350(GetDataV350).RunTx(cState, encodedGuardianTx, big.NewInt(0), 0, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not %d. Error %s", code.OK, response.Log)
+	}
+
+	response = NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 0, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not %d. Error %s", code.OK, response.Log)
+	}
+
+	if key := cState.Accounts.GetAuthorizedKey(addr); key != newKeyAddr {
+		t.Fatalf("Authorized key is not correct. Expected %s, got %s", newKeyAddr.String(), key.String())
+	}
+
+	commissions := cState.Commission.GetCommissions()
+	expectedBalance := big.NewInt(0).Sub(helpers.BipToPip(big.NewInt(1000)), commissions.SetKeyGuardianPrice())
+	if balance := cState.Accounts.GetBalance(addr, coin); balance.Cmp(expectedBalance) != 0 {
+		t.Fatalf("Account balance is not correct. Expected %s, got %s", expectedBalance, balance)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRotateKeyToRejectOriginalKeySignatures(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+	coin := types.GetBaseCoinID()
+
+	originalKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(originalKey.PublicKey)
+	newKey, _ := crypto.GenerateKey()
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(1000)))
+	cState.Accounts.SetAuthorizedKey(addr, crypto.PubkeyToAddress(newKey.PublicKey))
+
+	receiverKey, _ := crypto.GenerateKey()
+	receiver := crypto.PubkeyToAddress(receiverKey.PublicKey)
+	cState.Accounts.AddBalance(receiver, coin, helpers.BipToPip(big.NewInt(1000)))
+
+	// check signed by the original key
+	encodedTx, err := makeTestTx(TypeRedeemCheck, makeTestCheck(t, originalKey, receiver, []byte{1}, helpers.BipToPip(big.NewInt(10))), 1, receiverKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.KeyIsNotAuthorized {
+		t.Fatalf("Response code is not %d. Error %s", code.KeyIsNotAuthorized, response.Log)
+	}
+
+	if balance := cState.Accounts.GetBalance(addr, coin); balance.Cmp(helpers.BipToPip(big.NewInt(1000))) != 0 {
+		t.Fatalf("Issuer balance is not correct. Expected %s, got %s", helpers.BipToPip(big.NewInt(1000)), balance)
+	}
+
+	// multisig signed by the original key of the member
+	memberKey, _ := crypto.GenerateKey()
+	member := crypto.PubkeyToAddress(memberKey.PublicKey)
+	multisig := cState.Accounts.CreateMultisig([]uint32{1, 1}, []types.Address{addr, member}, 2, accounts.CreateMultisigAddress(member, 1))
+	cState.Accounts.AddBalance(multisig, coin, helpers.BipToPip(big.NewInt(1000)))
+
+	for i, item := range []struct {
+		key  *ecdsa.PrivateKey
+		code uint32
+	}{
+		{key: originalKey, code: code.NotEnoughMultisigVotes},
+		{key: newKey, code: code.OK},
+	} {
+		encodedData, err := rlp.EncodeToBytes(SendData{Coin: coin, To: types.Address{1}, Value: big.NewInt(1)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		tx := Transaction{
+			Nonce:         1,
+			GasPrice:      1,
+			ChainID:       types.CurrentChainID,
+			GasCoin:       coin,
+			Type:          TypeSend,
+			Data:          encodedData,
+			SignatureType: SigTypeMulti,
+		}
+		tx.SetMultisigAddress(multisig)
+		for _, key := range []*ecdsa.PrivateKey{item.key, memberKey} {
+			if err := tx.Sign(key); err != nil {
+				t.Fatal(err)
+			}
+		}
+		encodedTx, err := rlp.EncodeToBytes(tx)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		response := NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 1, &sync.Map{}, 0, false)
+		if response.Code != item.code {
+			t.Fatalf("Multisig tx %d: Response code is not %d. Error %s", i, item.code, response.Log)
+		}
+	}
+
+	// sponsorship signed by the original key of the sponsor
+	for i, item := range []struct {
+		key  *ecdsa.PrivateKey
+		code uint32
+	}{
+		{key: originalKey, code: code.KeyIsNotAuthorized},
+		{key: newKey, code: code.OK},
+	} {
+		encodedData, err := rlp.EncodeToBytes(SendData{Coin: coin, To: types.Address{1}, Value: big.NewInt(1)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		tx := Transaction{
+			Nonce:         1,
+			GasPrice:      1,
+			ChainID:       types.CurrentChainID,
+			GasCoin:       coin,
+			Type:          TypeSend,
+			Data:          encodedData,
+			SignatureType: SigTypeSingle,
+		}
+		tx.SetSponsor(addr)
+		if err := tx.Sign(receiverKey); err != nil {
+			t.Fatal(err)
+		}
+		if err := tx.SignSponsorship(helpers.BipToPip(big.NewInt(100)), nil, item.key); err != nil {
+			t.Fatal(err)
+		}
+		encodedTx, err := rlp.EncodeToBytes(tx)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		response := NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 1, &sync.Map{}, 0, false)
+		if response.Code != item.code {
+			t.Fatalf("Sponsored tx %d: Response code is not %d. Error %s", i, item.code, response.Log)
+		}
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
@@ -116,7 +116,7 @@ func (data SellAllCoinData) Run(tx *Transaction, context state.Interface, reward
 		return *errResp
 	}
 
-	balance := checkState.Accounts().GetBalance(sender, data.CoinToSell)
+	balance := checkState.Accounts().GetSpendableBalance(sender, data.CoinToSell)
 	if balance.Cmp(commission) != 1 {
 		return Response{
 			Code: code.InsufficientFunds,
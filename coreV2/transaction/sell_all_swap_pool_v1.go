@@ -94,7 +94,7 @@ func (data SellAllSwapPoolDataV1) Run(tx *Transaction, context state.Interface,
 		return *errResp
 	}
 
-	balance := checkState.Accounts().GetBalance(sender, coinToSell)
+	balance := checkState.Accounts().GetSpendableBalance(sender, coinToSell)
 	available := big.NewInt(0).Set(balance)
 	balance.Sub(available, commission)
 
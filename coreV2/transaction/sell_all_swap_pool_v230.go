@@ -96,7 +96,7 @@ func (data SellAllSwapPoolDataV230) Run(tx *Transaction, context state.Interface
 		return *errResp
 	}
 
-	balance := checkState.Accounts().GetBalance(sender, coinToSell)
+	balance := checkState.Accounts().GetSpendableBalance(sender, coinToSell)
 	available := big.NewInt(0).Set(balance)
 	balance.Sub(available, commission)
 
@@ -160,7 +160,7 @@ func (data SellAllSwapPoolDataV260) Run(tx *Transaction, context state.Interface
 		return *errResp
 	}
 
-	balance := checkState.Accounts().GetBalance(sender, coinToSell)
+	balance := checkState.Accounts().GetSpendableBalance(sender, coinToSell)
 	available := big.NewInt(0).Set(balance)
 	balance.Sub(available, commission)
 
@@ -223,6 +223,10 @@ func (data SellAllSwapPoolDataV260) Run(tx *Transaction, context state.Interface
 			coinToSellModel = coinToBuyModel
 			coinToSell = coinToBuy
 		}
+
+		if response := checkMinimumReceived(checkState, coinToSellModel, sender, valueToSell, data.MinimumValueToBuy); response != nil {
+			return *response
+		}
 	}
 
 	var tags []abcTypes.EventAttribute
@@ -257,6 +261,7 @@ func (data SellAllSwapPoolDataV260) Run(tx *Transaction, context state.Interface
 		valueToSell := big.NewInt(0).Set(balance)
 
 		var poolIDs tagPoolsChange
+		var transferFee *big.Int
 
 		for i, coinToBuy := range data.Coins[1:] {
 			amountIn, amountOut, poolID, details, owners := deliverState.Swapper().PairSellWithOrders(coinToSell, coinToBuy, valueToSell, big.NewInt(0))
@@ -284,7 +289,8 @@ func (data SellAllSwapPoolDataV260) Run(tx *Transaction, context state.Interface
 			coinToSell = coinToBuy
 
 			if i == lastIteration {
-				deliverState.Accounts.AddBalance(sender, coinToBuy, amountOut)
+				valueToSell, transferFee = payTransferFee(deliverState, coinToBuy, sender, amountOut)
+				deliverState.Accounts.AddBalance(sender, coinToBuy, valueToSell)
 			}
 		}
 
@@ -304,6 +310,9 @@ func (data SellAllSwapPoolDataV260) Run(tx *Transaction, context state.Interface
 			{Key: []byte("tx.sell_amount"), Value: []byte(available.String())},
 			{Key: []byte("tx.pools"), Value: []byte(poolIDs.string())},
 		}
+		if transferFee.Sign() != 0 {
+			tags = append(tags, abcTypes.EventAttribute{Key: []byte("tx.transfer_fee"), Value: []byte(transferFee.String())})
+		}
 	}
 
 	return Response{
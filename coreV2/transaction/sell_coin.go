@@ -136,7 +136,7 @@ func (data SellCoinData) Run(tx *Transaction, context state.Interface, rewardPoo
 	if tx.GasCoin == coinToSell {
 		spendInGasCoin.Add(spendInGasCoin, data.ValueToSell)
 	} else {
-		if checkState.Accounts().GetBalance(sender, data.CoinToSell).Cmp(value) < 0 {
+		if checkState.Accounts().GetSpendableBalance(sender, data.CoinToSell).Cmp(value) < 0 {
 			return Response{
 				Code: code.InsufficientFunds,
 				Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), value.String(), coinFrom.GetFullSymbol()),
@@ -144,7 +144,7 @@ func (data SellCoinData) Run(tx *Transaction, context state.Interface, rewardPoo
 			}
 		}
 	}
-	if checkState.Accounts().GetBalance(sender, tx.GasCoin).Cmp(spendInGasCoin) < 0 {
+	if checkState.Accounts().GetSpendableBalance(sender, tx.GasCoin).Cmp(spendInGasCoin) < 0 {
 		return Response{
 			Code: code.InsufficientFunds,
 			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), spendInGasCoin.String(), gasCoin.GetFullSymbol()),
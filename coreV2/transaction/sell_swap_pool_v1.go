@@ -139,7 +139,7 @@ func (data SellSwapPoolDataV1) Run(tx *Transaction, context state.Interface, rew
 	coinToSell := data.Coins[0]
 	amount0 := new(big.Int).Set(data.ValueToSell)
 	if tx.GasCoin != coinToSell {
-		if checkState.Accounts().GetBalance(sender, tx.GasCoin).Cmp(commission) == -1 {
+		if checkState.Accounts().GetSpendableBalance(sender, tx.GasCoin).Cmp(commission) == -1 {
 			return Response{
 				Code: code.InsufficientFunds,
 				Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), commission.String(), gasCoin.GetFullSymbol()),
@@ -149,7 +149,7 @@ func (data SellSwapPoolDataV1) Run(tx *Transaction, context state.Interface, rew
 	} else {
 		amount0.Add(amount0, commission)
 	}
-	if checkState.Accounts().GetBalance(sender, coinToSell).Cmp(amount0) == -1 {
+	if checkState.Accounts().GetSpendableBalance(sender, coinToSell).Cmp(amount0) == -1 {
 		symbol := checkState.Coins().GetCoin(coinToSell).GetFullSymbol()
 		return Response{
 			Code: code.InsufficientFunds,
@@ -146,12 +146,16 @@ func (data SellSwapPoolDataV260) Run(tx *Transaction, context state.Interface, r
 			coinToSellModel = coinToBuyModel
 			coinToSell = coinToBuy
 		}
+
+		if response := checkMinimumReceived(checkState, coinToSellModel, sender, valueToSell, data.MinimumValueToBuy); response != nil {
+			return *response
+		}
 	}
 
 	coinToSell := data.Coins[0]
 	amount0 := new(big.Int).Set(data.ValueToSell)
 	if tx.GasCoin != coinToSell {
-		if checkState.Accounts().GetBalance(sender, tx.GasCoin).Cmp(commission) == -1 {
+		if checkState.Accounts().GetSpendableBalance(sender, tx.GasCoin).Cmp(commission) == -1 {
 			return Response{
 				Code: code.InsufficientFunds,
 				Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), commission.String(), gasCoin.GetFullSymbol()),
@@ -161,7 +165,7 @@ func (data SellSwapPoolDataV260) Run(tx *Transaction, context state.Interface, r
 	} else {
 		amount0.Add(amount0, commission)
 	}
-	if checkState.Accounts().GetBalance(sender, coinToSell).Cmp(amount0) == -1 {
+	if checkState.Accounts().GetSpendableBalance(sender, coinToSell).Cmp(amount0) == -1 {
 		symbol := checkState.Coins().GetCoin(coinToSell).GetFullSymbol()
 		return Response{
 			Code: code.InsufficientFunds,
@@ -203,6 +207,7 @@ func (data SellSwapPoolDataV260) Run(tx *Transaction, context state.Interface, r
 		valueToSell := data.ValueToSell
 
 		var poolIDs tagPoolsChange
+		var transferFee *big.Int
 
 		for i, coinToBuy := range data.Coins[1:] {
 			amountIn, amountOut, poolID, details, owners := deliverState.Swapper().PairSellWithOrders(coinToSell, coinToBuy, valueToSell, big.NewInt(0))
@@ -230,7 +235,8 @@ func (data SellSwapPoolDataV260) Run(tx *Transaction, context state.Interface, r
 			coinToSell = coinToBuy
 
 			if i == lastIteration {
-				deliverState.Accounts.AddBalance(sender, coinToBuy, amountOut)
+				valueToSell, transferFee = payTransferFee(deliverState, coinToBuy, sender, amountOut)
+				deliverState.Accounts.AddBalance(sender, coinToBuy, valueToSell)
 			}
 		}
 
@@ -247,6 +253,9 @@ func (data SellSwapPoolDataV260) Run(tx *Transaction, context state.Interface, r
 			{Key: []byte("tx.return"), Value: []byte(amountOut.String())},
 			{Key: []byte("tx.pools"), Value: []byte(poolIDs.string())},
 		}
+		if transferFee.Sign() != 0 {
+			tags = append(tags, abcTypes.EventAttribute{Key: []byte("tx.transfer_fee"), Value: []byte(transferFee.String())})
+		}
 	}
 
 	return Response{
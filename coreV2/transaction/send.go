@@ -78,7 +78,7 @@ func (data SendData) Run(tx *Transaction, context state.Interface, rewardPool *b
 	if tx.GasCoin == data.Coin {
 		needValue.Add(data.Value, needValue)
 	} else {
-		if checkState.Accounts().GetBalance(sender, data.Coin).Cmp(data.Value) < 0 {
+		if checkState.Accounts().GetSpendableBalance(sender, data.Coin).Cmp(data.Value) < 0 {
 			coin := checkState.Coins().GetCoin(data.Coin)
 			return Response{
 				Code: code.InsufficientFunds,
@@ -87,7 +87,7 @@ func (data SendData) Run(tx *Transaction, context state.Interface, rewardPool *b
 			}
 		}
 	}
-	if checkState.Accounts().GetBalance(sender, tx.GasCoin).Cmp(needValue) < 0 {
+	if checkState.Accounts().GetSpendableBalance(sender, tx.GasCoin).Cmp(needValue) < 0 {
 		return Response{
 			Code: code.InsufficientFunds,
 			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), needValue.String(), gasCoin.GetFullSymbol()),
@@ -124,7 +124,8 @@ func (data SendData) Run(tx *Transaction, context state.Interface, rewardPool *b
 		deliverState.Accounts.SubBalance(sender, tx.GasCoin, commission)
 		rewardPool.Add(rewardPool, commissionInBaseCoin)
 		deliverState.Accounts.SubBalance(sender, data.Coin, data.Value)
-		deliverState.Accounts.AddBalance(data.To, data.Coin, data.Value)
+		received, transferFee := payTransferFee(deliverState, data.Coin, sender, data.Value)
+		deliverState.Accounts.AddBalance(data.To, data.Coin, received)
 		deliverState.Accounts.SetNonce(sender, tx.Nonce)
 
 		tags = []abcTypes.EventAttribute{
@@ -135,6 +136,12 @@ func (data SendData) Run(tx *Transaction, context state.Interface, rewardPool *b
 			{Key: []byte("tx.to"), Value: []byte(hex.EncodeToString(data.To[:])), Index: true},
 			{Key: []byte("tx.coin_id"), Value: []byte(data.Coin.String()), Index: true},
 		}
+		if transferFee.Sign() != 0 {
+			tags = append(tags,
+				abcTypes.EventAttribute{Key: []byte("tx.transfer_fee"), Value: []byte(transferFee.String())},
+				abcTypes.EventAttribute{Key: []byte("tx.received"), Value: []byte(received.String())},
+			)
+		}
 	}
 
 	return Response{
@@ -0,0 +1,192 @@
+package transaction
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/accounts"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/commission"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/swap"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	abcTypes "github.com/tendermint/tendermint/abci/types"
+)
+
+// accountPolicyDelay is the number of blocks after which the new policy of the account takes effect
+const accountPolicyDelay = 17280
+
+const maxAccountPolicyItems = 32
+
+type AccountPolicyLimit struct {
+	Coin  types.CoinID
+	Value *big.Int
+}
+
+// SetAccountPolicyData schedules the new policy of the sender account, it takes effect after accountPolicyDelay blocks.
+// Limits cap the value of coins sent by Send and Multisend within a day, Recipients is the allow-list of their recipients
+// and ForbiddenTypes are types of transactions the account can not send. While Limits or Recipients are set,
+// other transactions which may move value of the account are forbidden. The empty policy removes all restrictions
+type SetAccountPolicyData struct {
+	Limits         []AccountPolicyLimit
+	Recipients     []types.Address
+	ForbiddenTypes []TxType
+}
+
+func (data SetAccountPolicyData) TxType() TxType {
+	return TypeSetAccountPolicy
+}
+
+func (data SetAccountPolicyData) Gas() int64 {
+	return gasSetAccountPolicy
+}
+
+func (data SetAccountPolicyData) basicCheck(tx *Transaction, context *state.CheckState) *Response {
+	if len(data.Limits) > maxAccountPolicyItems || len(data.Recipients) > maxAccountPolicyItems || len(data.ForbiddenTypes) > maxAccountPolicyItems {
+		return &Response{
+			Code: code.WrongAccountPolicy,
+			Log:  fmt.Sprintf("Lists of policy are limited to %d items", maxAccountPolicyItems),
+			Info: EncodeError(code.NewWrongAccountPolicy("too many items")),
+		}
+	}
+
+	usedCoins := map[types.CoinID]bool{}
+	for _, limit := range data.Limits {
+		if limit.Value == nil || limit.Value.Sign() == -1 {
+			return &Response{
+				Code: code.DecodeError,
+				Log:  "Incorrect tx data",
+				Info: EncodeError(code.NewDecodeError()),
+			}
+		}
+
+		if usedCoins[limit.Coin] {
+			return &Response{
+				Code: code.WrongAccountPolicy,
+				Log:  fmt.Sprintf("Duplicated limit of coin %s", limit.Coin.String()),
+				Info: EncodeError(code.NewWrongAccountPolicy("duplicated coin " + limit.Coin.String())),
+			}
+		}
+		usedCoins[limit.Coin] = true
+
+		if !context.Coins().Exists(limit.Coin) {
+			return &Response{
+				Code: code.CoinNotExists,
+				Log:  fmt.Sprintf("Coin %s not exists", limit.Coin),
+				Info: EncodeError(code.NewCoinNotExists("", limit.Coin.String())),
+			}
+		}
+	}
+
+	for _, txType := range data.ForbiddenTypes {
+		if txType == TypeSetAccountPolicy {
+			return &Response{
+				Code: code.WrongAccountPolicy,
+				Log:  fmt.Sprintf("Tx type %s can not be forbidden", txType.String()),
+				Info: EncodeError(code.NewWrongAccountPolicy("tx type " + txType.String() + " can not be forbidden")),
+			}
+		}
+	}
+
+	return nil
+}
+
+func (data SetAccountPolicyData) String() string {
+	return fmt.Sprintf("SET ACCOUNT POLICY limits:%d recipients:%d forbidden types:%d", len(data.Limits), len(data.Recipients), len(data.ForbiddenTypes))
+}
+
+func (data SetAccountPolicyData) CommissionData(price *commission.Price) *big.Int {
+	return price.SetAccountPolicyPrice()
+}
+
+func (data SetAccountPolicyData) policy() accounts.Policy {
+	policy := accounts.Policy{
+		Recipients: data.Recipients,
+	}
+	for _, limit := range data.Limits {
+		policy.Limits = append(policy.Limits, accounts.PolicyLimit{Coin: limit.Coin, Value: big.NewInt(0).Set(limit.Value)})
+	}
+	for _, txType := range data.ForbiddenTypes {
+		policy.ForbiddenTypes = append(policy.ForbiddenTypes, byte(txType))
+	}
+
+	return policy
+}
+
+func (data SetAccountPolicyData) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, price *big.Int) Response {
+	sender, _ := tx.Sender()
+	var checkState *state.CheckState
+	var isCheck bool
+	if checkState, isCheck = context.(*state.CheckState); !isCheck {
+		checkState = state.NewCheckState(context.(*state.State))
+	}
+
+	response := data.basicCheck(tx, checkState)
+	if response != nil {
+		return *response
+	}
+
+	commissionInBaseCoin := price
+	commissionPoolSwapper := checkState.Swap().GetSwapper(tx.GasCoin, types.GetBaseCoinID())
+	gasCoin := checkState.Coins().GetCoin(tx.GasCoin)
+	commission, isGasCommissionFromPoolSwap, errResp := CalculateCommission(checkState, commissionPoolSwapper, gasCoin, commissionInBaseCoin)
+	if errResp != nil {
+		return *errResp
+	}
+
+	if checkState.Accounts().GetSpendableBalance(sender, tx.GasCoin).Cmp(commission) < 0 {
+		return Response{
+			Code: code.InsufficientFunds,
+			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), commission.String(), gasCoin.GetFullSymbol()),
+			Info: EncodeError(code.NewInsufficientFunds(sender.String(), commission.String(), gasCoin.GetFullSymbol(), gasCoin.ID().String())),
+		}
+	}
+
+	var tags []abcTypes.EventAttribute
+	if deliverState, ok := context.(*state.State); ok {
+		var tagsCom *tagPoolChange
+		if isGasCommissionFromPoolSwap {
+			var (
+				poolIDCom  uint32
+				detailsCom *swap.ChangeDetailsWithOrders
+				ownersCom  []*swap.OrderDetail
+			)
+			commission, commissionInBaseCoin, poolIDCom, detailsCom, ownersCom = deliverState.Swapper().PairSellWithOrders(tx.CommissionCoin(), types.GetBaseCoinID(), commission, big.NewInt(0))
+			tagsCom = &tagPoolChange{
+				PoolID:   poolIDCom,
+				CoinIn:   tx.CommissionCoin(),
+				ValueIn:  commission.String(),
+				CoinOut:  types.GetBaseCoinID(),
+				ValueOut: commissionInBaseCoin.String(),
+				Orders:   detailsCom,
+				// Sellers:  ownersCom,
+			}
+			for _, value := range ownersCom {
+				deliverState.Accounts.AddBalance(value.Owner, tx.CommissionCoin(), value.ValueBigInt)
+			}
+		} else if !tx.GasCoin.IsBaseCoin() {
+			deliverState.Coins.SubVolume(tx.CommissionCoin(), commission)
+			deliverState.Coins.SubReserve(tx.CommissionCoin(), commissionInBaseCoin)
+		}
+		deliverState.Accounts.SubBalance(sender, tx.GasCoin, commission)
+		rewardPool.Add(rewardPool, commissionInBaseCoin)
+
+		policyHeight := currentBlock + accountPolicyDelay
+		deliverState.Accounts.SetPendingPolicy(sender, data.policy(), policyHeight)
+		deliverState.Accounts.SetNonce(sender, tx.Nonce)
+
+		tags = []abcTypes.EventAttribute{
+			{Key: []byte("tx.commission_in_base_coin"), Value: []byte(commissionInBaseCoin.String())},
+			{Key: []byte("tx.commission_conversion"), Value: []byte(isGasCommissionFromPoolSwap.String()), Index: true},
+			{Key: []byte("tx.commission_amount"), Value: []byte(commission.String())},
+			{Key: []byte("tx.commission_details"), Value: []byte(tagsCom.string())},
+			{Key: []byte("tx.policy_height"), Value: []byte(strconv.FormatUint(policyHeight, 10))},
+		}
+	}
+
+	return Response{
+		Code: code.OK,
+		Tags: tags,
+	}
+}
@@ -0,0 +1,77 @@
+package transaction
+
+import (
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/crypto"
+	"github.com/MinterTeam/minter-go-node/helpers"
+)
+
+func TestSetAccountPolicyTx(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+	coin := types.GetBaseCoinID()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(1000)))
+
+	data := SetAccountPolicyData{
+		Limits:         []AccountPolicyLimit{{Coin: coin, Value: helpers.BipToPip(big.NewInt(10))}},
+		Recipients:     []types.Address{{1}},
+		ForbiddenTypes: []TxType{TypeSellCoin},
+	}
+	encodedTx, err := makeTestTx(TypeSetAccountPolicy, data, 1, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 10, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not %d. Error %s", code.OK, response.Log)
+	}
+
+	if policy := cState.Accounts.GetPolicy(addr); !policy.IsEmpty() {
+		t.Fatal("Policy takes effect before the delay")
+	}
+
+	policy := cState.Accounts.GetPolicyState(addr)
+	if policy.PendingHeight != 10+accountPolicyDelay {
+		t.Fatalf("Pending height is not correct. Expected %d, got %d", 10+accountPolicyDelay, policy.PendingHeight)
+	}
+	if policy.Pending.GetLimit(coin).Cmp(helpers.BipToPip(big.NewInt(10))) != 0 || !policy.Pending.IsForbiddenType(byte(TypeSellCoin)) {
+		t.Fatal("Pending policy is not correct")
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSetAccountPolicyTxToForbidItself(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+	coin := types.GetBaseCoinID()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(1000)))
+
+	encodedTx, err := makeTestTx(TypeSetAccountPolicy, SetAccountPolicyData{ForbiddenTypes: []TxType{TypeSetAccountPolicy}}, 1, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 0, &sync.Map{}, 0, false)
+	if response.Code != code.WrongAccountPolicy {
+		t.Fatalf("Response code is not %d. Error %s", code.WrongAccountPolicy, response.Log)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
@@ -84,7 +84,7 @@ func (data SetHaltBlockData) Run(tx *Transaction, context state.Interface, rewar
 		return *errResp
 	}
 
-	if checkState.Accounts().GetBalance(sender, tx.GasCoin).Cmp(commission) < 0 {
+	if checkState.Accounts().GetSpendableBalance(sender, tx.GasCoin).Cmp(commission) < 0 {
 		gasCoin := checkState.Coins().GetCoin(tx.GasCoin)
 
 		return Response{
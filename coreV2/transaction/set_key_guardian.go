@@ -0,0 +1,144 @@
+package transaction
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/commission"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/swap"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	abcTypes "github.com/tendermint/tendermint/abci/types"
+)
+
+// SetKeyGuardianData registers the Guardian multisig which is able to rotate the key of the single-key Account, the empty address removes it.
+// The transaction is sent by the account itself while it has no guardian, afterwards only the current guardian can change or remove it
+type SetKeyGuardianData struct {
+	Account  types.Address
+	Guardian types.Address
+}
+
+func (data SetKeyGuardianData) TxType() TxType {
+	return TypeSetKeyGuardian
+}
+
+func (data SetKeyGuardianData) Gas() int64 {
+	return gasSetKeyGuardian
+}
+
+func (data SetKeyGuardianData) basicCheck(tx *Transaction, context *state.CheckState) *Response {
+	if context.Accounts().GetAccount(data.Account).IsMultisig() {
+		return &Response{
+			Code: code.MultisigExists,
+			Log:  "Guardian can not be set for the multisig",
+			Info: EncodeError(code.NewMultisigExists(data.Account.String())),
+		}
+	}
+
+	if data.Guardian != (types.Address{}) && !context.Accounts().GetAccount(data.Guardian).IsMultisig() {
+		return &Response{
+			Code: code.MultisigNotExists,
+			Log:  "Multisig does not exists",
+			Info: EncodeError(code.NewMultisigNotExists(data.Guardian.String())),
+		}
+	}
+
+	sender, _ := tx.Sender()
+	guardian := context.Accounts().GetGuardian(data.Account)
+	if (guardian == (types.Address{}) && sender != data.Account) || (guardian != (types.Address{}) && sender != guardian) {
+		return &Response{
+			Code: code.IsNotKeyGuardian,
+			Log:  fmt.Sprintf("Sender is not a guardian of the account %s", data.Account.String()),
+			Info: EncodeError(code.NewIsNotKeyGuardian(data.Account.String(), sender.String())),
+		}
+	}
+
+	return nil
+}
+
+func (data SetKeyGuardianData) String() string {
+	return fmt.Sprintf("SET KEY GUARDIAN account:%s guardian:%s", data.Account.String(), data.Guardian.String())
+}
+
+func (data SetKeyGuardianData) CommissionData(price *commission.Price) *big.Int {
+	return price.SetKeyGuardianPrice()
+}
+
+func (data SetKeyGuardianData) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, price *big.Int) Response {
+	sender, _ := tx.Sender()
+	var checkState *state.CheckState
+	var isCheck bool
+	if checkState, isCheck = context.(*state.CheckState); !isCheck {
+		checkState = state.NewCheckState(context.(*state.State))
+	}
+
+	response := data.basicCheck(tx, checkState)
+	if response != nil {
+		return *response
+	}
+
+	commissionInBaseCoin := price
+	commissionPoolSwapper := checkState.Swap().GetSwapper(tx.GasCoin, types.GetBaseCoinID())
+	gasCoin := checkState.Coins().GetCoin(tx.GasCoin)
+	commission, isGasCommissionFromPoolSwap, errResp := CalculateCommission(checkState, commissionPoolSwapper, gasCoin, commissionInBaseCoin)
+	if errResp != nil {
+		return *errResp
+	}
+
+	if checkState.Accounts().GetSpendableBalance(sender, tx.GasCoin).Cmp(commission) < 0 {
+		return Response{
+			Code: code.InsufficientFunds,
+			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), commission.String(), gasCoin.GetFullSymbol()),
+			Info: EncodeError(code.NewInsufficientFunds(sender.String(), commission.String(), gasCoin.GetFullSymbol(), gasCoin.ID().String())),
+		}
+	}
+
+	var tags []abcTypes.EventAttribute
+	if deliverState, ok := context.(*state.State); ok {
+		var tagsCom *tagPoolChange
+		if isGasCommissionFromPoolSwap {
+			var (
+				poolIDCom  uint32
+				detailsCom *swap.ChangeDetailsWithOrders
+				ownersCom  []*swap.OrderDetail
+			)
+			commission, commissionInBaseCoin, poolIDCom, detailsCom, ownersCom = deliverState.Swapper().PairSellWithOrders(tx.CommissionCoin(), types.GetBaseCoinID(), commission, big.NewInt(0))
+			tagsCom = &tagPoolChange{
+				PoolID:   poolIDCom,
+				CoinIn:   tx.CommissionCoin(),
+				ValueIn:  commission.String(),
+				CoinOut:  types.GetBaseCoinID(),
+				ValueOut: commissionInBaseCoin.String(),
+				Orders:   detailsCom,
+				// Sellers:  ownersCom,
+			}
+			for _, value := range ownersCom {
+				deliverState.Accounts.AddBalance(value.Owner, tx.CommissionCoin(), value.ValueBigInt)
+			}
+		} else if !tx.GasCoin.IsBaseCoin() {
+			deliverState.Coins.SubVolume(tx.CommissionCoin(), commission)
+			deliverState.Coins.SubReserve(tx.CommissionCoin(), commissionInBaseCoin)
+		}
+		deliverState.Accounts.SubBalance(sender, tx.GasCoin, commission)
+		rewardPool.Add(rewardPool, commissionInBaseCoin)
+
+		deliverState.Accounts.SetGuardian(data.Account, data.Guardian)
+		deliverState.Accounts.SetNonce(sender, tx.Nonce)
+
+		tags = []abcTypes.EventAttribute{
+			{Key: []byte("tx.commission_in_base_coin"), Value: []byte(commissionInBaseCoin.String())},
+			{Key: []byte("tx.commission_conversion"), Value: []byte(isGasCommissionFromPoolSwap.String()), Index: true},
+			{Key: []byte("tx.commission_amount"), Value: []byte(commission.String())},
+			{Key: []byte("tx.commission_details"), Value: []byte(tagsCom.string())},
+			{Key: []byte("tx.account"), Value: []byte(hex.EncodeToString(data.Account[:])), Index: true},
+			{Key: []byte("tx.guardian"), Value: []byte(hex.EncodeToString(data.Guardian[:])), Index: true},
+		}
+	}
+
+	return Response{
+		Code: code.OK,
+		Tags: tags,
+	}
+}
@@ -0,0 +1,114 @@
+package transaction
+
+import (
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/crypto"
+	"github.com/MinterTeam/minter-go-node/helpers"
+	"github.com/MinterTeam/minter-go-node/rlp"
+)
+
+func TestSetKeyGuardianTxToNotMultisig(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+	coin := types.GetBaseCoinID()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(1000)))
+
+	encodedTx, err := makeTestTx(TypeSetKeyGuardian, SetKeyGuardianData{Account: addr, Guardian: types.Address{1}}, 1, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 0, &sync.Map{}, 0, false)
+	if response.Code != code.MultisigNotExists {
+		t.Fatalf("Response code is not %d. Error %s", code.MultisigNotExists, response.Log)
+	}
+
+	if guardian := cState.Accounts.GetGuardian(addr); guardian != (types.Address{}) {
+		t.Fatalf("Guardian is set to %s", guardian.String())
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSetKeyGuardianTxToChangeByGuardianOnly(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+	coin := types.GetBaseCoinID()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(1000)))
+
+	guardianKey, _ := crypto.GenerateKey()
+	guardian := cState.Accounts.CreateMultisig([]uint32{1}, []types.Address{crypto.PubkeyToAddress(guardianKey.PublicKey)}, 1, types.Address{2})
+	cState.Accounts.AddBalance(guardian, coin, helpers.BipToPip(big.NewInt(1000)))
+	cState.Accounts.SetGuardian(addr, guardian)
+
+	otherGuardian := cState.Accounts.CreateMultisig([]uint32{1}, []types.Address{addr}, 1, types.Address{3})
+
+	// the original key can neither replace nor remove the guardian
+	for _, newGuardian := range []types.Address{otherGuardian, {}} {
+		encodedTx, err := makeTestTx(TypeSetKeyGuardian, SetKeyGuardianData{Account: addr, Guardian: newGuardian}, 1, privateKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		response := NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 0, &sync.Map{}, 0, false)
+		if response.Code != code.IsNotKeyGuardian {
+			t.Fatalf("Response code is not %d. Error %s", code.IsNotKeyGuardian, response.Log)
+		}
+	}
+
+	if g := cState.Accounts.GetGuardian(addr); g != guardian {
+		t.Fatalf("Guardian is changed to %s", g.String())
+	}
+
+	encodedData, err := rlp.EncodeToBytes(SetKeyGuardianData{Account: addr, Guardian: otherGuardian})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx := Transaction{
+		Nonce:         1,
+		GasPrice:      1,
+		ChainID:       types.CurrentChainID,
+		GasCoin:       coin,
+		Type:          TypeSetKeyGuardian,
+		Data:          encodedData,
+		SignatureType: SigTypeMulti,
+	}
+
+	tx.SetMultisigAddress(guardian)
+
+	if err := tx.Sign(guardianKey); err != nil {
+		t.Fatal(err)
+	}
+
+	encodedTx, err := rlp.EncodeToBytes(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 0, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not %d. Error %s", code.OK, response.Log)
+	}
+
+	if g := cState.Accounts.GetGuardian(addr); g != otherGuardian {
+		t.Fatalf("Guardian is not correct. Expected %s, got %s", otherGuardian.String(), g.String())
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,159 @@
+package transaction
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/coins"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/commission"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/swap"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	abcTypes "github.com/tendermint/tendermint/abci/types"
+)
+
+// SetTokenTransferFeeData sets the share of transferred tokens in basis points withheld by the issuer,
+// it can be sent by the owner of the coin symbol only
+type SetTokenTransferFeeData struct {
+	Coin types.CoinID
+	Bps  uint32
+	Burn bool
+}
+
+func (data SetTokenTransferFeeData) Gas() int64 {
+	return gasSetTokenTransferFee
+}
+
+func (data SetTokenTransferFeeData) TxType() TxType {
+	return TypeSetTokenTransferFee
+}
+
+func (data SetTokenTransferFeeData) basicCheck(tx *Transaction, context *state.CheckState) *Response {
+	sender, _ := tx.Sender()
+
+	coin := context.Coins().GetCoin(data.Coin)
+	if coin == nil {
+		return &Response{
+			Code: code.CoinNotExists,
+			Log:  fmt.Sprintf("Coin %s not exists", data.Coin),
+			Info: EncodeError(code.NewCoinNotExists("", data.Coin.String())),
+		}
+	}
+
+	if owner := coinOwner(context, data.Coin); owner == nil || *owner != sender {
+		var ownerS *string
+		if owner != nil {
+			address := owner.String()
+			ownerS = &address
+		}
+		return &Response{
+			Code: code.IsNotOwnerOfCoin,
+			Log:  "Sender is not owner of coin",
+			Info: EncodeError(code.NewIsNotOwnerOfCoin(coin.Symbol().String(), ownerS)),
+		}
+	}
+
+	if !coin.IsToken() {
+		return &Response{
+			Code: code.WrongTransferFee,
+			Log:  "Transfer fee can be set for tokens only",
+			Info: EncodeError(code.NewWrongTransferFee(data.Coin.String(), "coin has reserve")),
+		}
+	}
+
+	if data.Bps > coins.MaxTransferFeeBps {
+		return &Response{
+			Code: code.WrongTransferFee,
+			Log:  fmt.Sprintf("Transfer fee should be not greater than %d basis points", coins.MaxTransferFeeBps),
+			Info: EncodeError(code.NewWrongTransferFee(data.Coin.String(), "fee is too high")),
+		}
+	}
+
+	return nil
+}
+
+func (data SetTokenTransferFeeData) String() string {
+	return fmt.Sprintf("SET TOKEN TRANSFER FEE coin:%s bps:%d burn:%t", data.Coin.String(), data.Bps, data.Burn)
+}
+
+func (data SetTokenTransferFeeData) CommissionData(price *commission.Price) *big.Int {
+	return price.SetTokenTransferFeePrice()
+}
+
+func (data SetTokenTransferFeeData) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, price *big.Int) Response {
+	sender, _ := tx.Sender()
+
+	var checkState *state.CheckState
+	var isCheck bool
+	if checkState, isCheck = context.(*state.CheckState); !isCheck {
+		checkState = state.NewCheckState(context.(*state.State))
+	}
+
+	response := data.basicCheck(tx, checkState)
+	if response != nil {
+		return *response
+	}
+
+	commissionInBaseCoin := price
+	commissionPoolSwapper := checkState.Swap().GetSwapper(tx.GasCoin, types.GetBaseCoinID())
+	gasCoin := checkState.Coins().GetCoin(tx.GasCoin)
+	commission, isGasCommissionFromPoolSwap, errResp := CalculateCommission(checkState, commissionPoolSwapper, gasCoin, commissionInBaseCoin)
+	if errResp != nil {
+		return *errResp
+	}
+
+	if checkState.Accounts().GetSpendableBalance(sender, tx.GasCoin).Cmp(commission) < 0 {
+		return Response{
+			Code: code.InsufficientFunds,
+			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), commission.String(), gasCoin.GetFullSymbol()),
+			Info: EncodeError(code.NewInsufficientFunds(sender.String(), commission.String(), gasCoin.GetFullSymbol(), gasCoin.ID().String())),
+		}
+	}
+
+	var tags []abcTypes.EventAttribute
+	if deliverState, ok := context.(*state.State); ok {
+		var tagsCom *tagPoolChange
+		if isGasCommissionFromPoolSwap {
+			var (
+				poolIDCom  uint32
+				detailsCom *swap.ChangeDetailsWithOrders
+				ownersCom  []*swap.OrderDetail
+			)
+			commission, commissionInBaseCoin, poolIDCom, detailsCom, ownersCom = deliverState.Swapper().PairSellWithOrders(tx.CommissionCoin(), types.GetBaseCoinID(), commission, big.NewInt(0))
+			tagsCom = &tagPoolChange{
+				PoolID:   poolIDCom,
+				CoinIn:   tx.CommissionCoin(),
+				ValueIn:  commission.String(),
+				CoinOut:  types.GetBaseCoinID(),
+				ValueOut: commissionInBaseCoin.String(),
+				Orders:   detailsCom,
+				// Sellers:  ownersCom,
+			}
+			for _, value := range ownersCom {
+				deliverState.Accounts.AddBalance(value.Owner, tx.CommissionCoin(), value.ValueBigInt)
+			}
+		} else if !tx.GasCoin.IsBaseCoin() {
+			deliverState.Coins.SubVolume(tx.CommissionCoin(), commission)
+			deliverState.Coins.SubReserve(tx.CommissionCoin(), commissionInBaseCoin)
+		}
+		deliverState.Accounts.SubBalance(sender, tx.GasCoin, commission)
+		rewardPool.Add(rewardPool, commissionInBaseCoin)
+
+		deliverState.Coins.SetTransferFee(data.Coin, coins.TransferFee{Bps: data.Bps, Burn: data.Burn})
+		deliverState.Accounts.SetNonce(sender, tx.Nonce)
+
+		tags = []abcTypes.EventAttribute{
+			{Key: []byte("tx.commission_in_base_coin"), Value: []byte(commissionInBaseCoin.String())},
+			{Key: []byte("tx.commission_conversion"), Value: []byte(isGasCommissionFromPoolSwap.String()), Index: true},
+			{Key: []byte("tx.commission_amount"), Value: []byte(commission.String())},
+			{Key: []byte("tx.commission_details"), Value: []byte(tagsCom.string())},
+			{Key: []byte("tx.coin_id"), Value: []byte(data.Coin.String()), Index: true},
+		}
+	}
+
+	return Response{
+		Code: code.OK,
+		Tags: tags,
+	}
+}
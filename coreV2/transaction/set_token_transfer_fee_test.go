@@ -0,0 +1,163 @@
+package transaction
+
+import (
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/coins"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/crypto"
+	"github.com/MinterTeam/minter-go-node/helpers"
+)
+
+func TestSetTokenTransferFeeTx(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	cState.Accounts.AddBalance(addr, types.GetBaseCoinID(), helpers.BipToPip(big.NewInt(1000)))
+
+	coin := createTestFreezableToken(cState, addr)
+
+	encodedTx, err := makeTestTx(TypeSetTokenTransferFee, SetTokenTransferFeeData{Coin: coin, Bps: 100}, 1, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 0, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not %d. Error %s", code.OK, response.Log)
+	}
+
+	if fee := cState.Coins.GetTransferFee(coin); fee.Bps != 100 || fee.Burn {
+		t.Fatalf("Transfer fee is not correct: %+v", fee)
+	}
+
+	holderKey, _ := crypto.GenerateKey()
+	holder := crypto.PubkeyToAddress(holderKey.PublicKey)
+	cState.Accounts.AddBalance(holder, types.GetBaseCoinID(), helpers.BipToPip(big.NewInt(1000)))
+	cState.Accounts.SubBalance(addr, coin, big.NewInt(1000))
+	cState.Accounts.AddBalance(holder, coin, big.NewInt(1000))
+
+	encodedTx, err = makeTestTx(TypeSend, SendData{Coin: coin, To: types.Address{1}, Value: big.NewInt(1000)}, 1, holderKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ownerBalance := cState.Accounts.GetBalance(addr, coin)
+	response = NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 0, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not %d. Error %s", code.OK, response.Log)
+	}
+
+	if balance := cState.Accounts.GetBalance(types.Address{1}, coin); balance.Cmp(big.NewInt(990)) != 0 {
+		t.Fatalf("Recipient balance is not correct. Expected %s, got %s", big.NewInt(990), balance)
+	}
+
+	if balance := cState.Accounts.GetBalance(addr, coin); big.NewInt(0).Sub(balance, ownerBalance).Cmp(big.NewInt(10)) != 0 {
+		t.Fatalf("Owner did not receive the transfer fee. Got %s", big.NewInt(0).Sub(balance, ownerBalance))
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSetTokenTransferFeeTxToTooHighFee(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	cState.Accounts.AddBalance(addr, types.GetBaseCoinID(), helpers.BipToPip(big.NewInt(1000)))
+
+	coin := createTestFreezableToken(cState, addr)
+
+	encodedTx, err := makeTestTx(TypeSetTokenTransferFee, SetTokenTransferFeeData{Coin: coin, Bps: coins.MaxTransferFeeBps + 1}, 1, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 0, &sync.Map{}, 0, false)
+	if response.Code != code.WrongTransferFee {
+		t.Fatalf("Response code is not %d. Error %s", code.WrongTransferFee, response.Log)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSellSwapPoolTxWithTransferFee(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	coin := createNonReserveCoin(cState)
+	cState.Coins.SetTransferFee(coin, coins.TransferFee{Bps: 100, Burn: true})
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	cState.Accounts.AddBalance(addr, types.GetBaseCoinID(), helpers.BipToPip(big.NewInt(1000000)))
+	cState.Accounts.SubBalance(types.Address{}, coin, helpers.BipToPip(big.NewInt(100000)))
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(100000)))
+
+	encodedTx, err := makeTestTx(TypeCreateSwapPool, CreateSwapPoolData{
+		Coin0:   types.GetBaseCoinID(),
+		Volume0: helpers.BipToPip(big.NewInt(1000)),
+		Coin1:   coin,
+		Volume1: helpers.BipToPip(big.NewInt(1000)),
+	}, 1, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 0, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not %d. Error %s", code.OK, response.Log)
+	}
+
+	encodedTx, err = makeTestTx(TypeSellSwapPool, SellSwapPoolDataV260{
+		Coins:             []types.CoinID{types.GetBaseCoinID(), coin},
+		ValueToSell:       helpers.BipToPip(big.NewInt(10)),
+		MinimumValueToBuy: helpers.BipToPip(big.NewInt(9)),
+	}, 2, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	balance := cState.Accounts.GetBalance(addr, coin)
+	volume := cState.Coins.GetCoin(coin).Volume()
+	response = NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 0, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not %d. Error %s", code.OK, response.Log)
+	}
+
+	var received, transferFee string
+	for _, tag := range response.Tags {
+		switch string(tag.Key) {
+		case "tx.return":
+			received = string(tag.Value)
+		case "tx.transfer_fee":
+			transferFee = string(tag.Value)
+		}
+	}
+
+	if transferFee == "" {
+		t.Fatal("Transfer fee tag is not set")
+	}
+
+	if diff := big.NewInt(0).Sub(cState.Accounts.GetBalance(addr, coin), balance); diff.String() != received {
+		t.Fatalf("Received value is not correct. Expected %s, got %s", received, diff)
+	}
+
+	if diff := big.NewInt(0).Sub(volume, cState.Coins.GetCoin(coin).Volume()); diff.String() != transferFee {
+		t.Fatalf("Transfer fee is not burned. Expected %s, got %s", transferFee, diff)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,185 @@
+package transaction
+
+import (
+	"crypto/ecdsa"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"strings"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/swap"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/crypto"
+	"github.com/MinterTeam/minter-go-node/rlp"
+	abcTypes "github.com/tendermint/tendermint/abci/types"
+)
+
+// Sponsorship is a permission of the sponsor to pay commission of the tx with the given nonce of the sender.
+// Sponsor is the address chosen by the sender and covered by the signature of the sender,
+// MaxFee limits the commission in base coin, TxTypes limits types of the tx, empty list allows any type
+type Sponsorship struct {
+	Sponsor       types.Address
+	MaxFee        *big.Int
+	TxTypes       []TxType
+	SignatureData []byte
+
+	signer *types.Address
+}
+
+// IsSponsored returns true if commission of the tx is paid by a sponsor
+func (tx *Transaction) IsSponsored() bool {
+	return len(tx.Sponsor) != 0
+}
+
+// SetSponsor sets the sponsor of the tx, it should be called before the tx is signed by the sender
+func (tx *Transaction) SetSponsor(sponsor types.Address) {
+	tx.Sponsor = []Sponsorship{{Sponsor: sponsor}}
+}
+
+// SponsorHash returns hash of the sponsorship to be signed by the sponsor, it covers the whole unsigned tx
+func (tx *Transaction) SponsorHash(sender types.Address, maxFee *big.Int, txTypes []TxType) types.Hash {
+	return rlpHash([]interface{}{
+		tx.Hash(),
+		sender,
+		maxFee,
+		txTypes,
+	})
+}
+
+// SignSponsorship attaches the sponsorship signed by the authorized key of the sponsor set by SetSponsor, the tx should be signed by the sender first
+func (tx *Transaction) SignSponsorship(maxFee *big.Int, txTypes []TxType, prv *ecdsa.PrivateKey) error {
+	if !tx.IsSponsored() {
+		return errors.New("tx sponsor is not set")
+	}
+
+	sender, err := tx.Sender()
+	if err != nil {
+		return err
+	}
+
+	h := tx.SponsorHash(sender, maxFee, txTypes)
+	sig, err := crypto.Sign(h[:], prv)
+	if err != nil {
+		return err
+	}
+
+	signatureData, err := rlp.EncodeToBytes(&Signature{
+		V: new(big.Int).SetBytes([]byte{sig[64] + 27}),
+		R: new(big.Int).SetBytes(sig[:32]),
+		S: new(big.Int).SetBytes(sig[32:64]),
+	})
+	if err != nil {
+		return err
+	}
+
+	sponsorship := &tx.Sponsor[0]
+	sponsorship.MaxFee = maxFee
+	sponsorship.TxTypes = txTypes
+	sponsorship.SignatureData = signatureData
+
+	return nil
+}
+
+// SponsorAddress returns address of the sponsor of the tx chosen by the sender
+func (tx *Transaction) SponsorAddress() (types.Address, error) {
+	if !tx.IsSponsored() {
+		return types.Address{}, errors.New("tx is not sponsored")
+	}
+
+	return tx.Sponsor[0].Sponsor, nil
+}
+
+// SponsorSigner recovers address of the key which signed the sponsorship, it should be the authorized key of the sponsor
+func (tx *Transaction) SponsorSigner() (types.Address, error) {
+	if !tx.IsSponsored() {
+		return types.Address{}, errors.New("tx is not sponsored")
+	}
+
+	sponsorship := &tx.Sponsor[0]
+	if sponsorship.signer != nil {
+		return *sponsorship.signer, nil
+	}
+
+	if sponsorship.MaxFee == nil {
+		return types.Address{}, errors.New("incorrect sponsor max fee")
+	}
+
+	sender, err := tx.Sender()
+	if err != nil {
+		return types.Address{}, err
+	}
+
+	sig := &Signature{}
+	if err := rlp.DecodeBytes(sponsorship.SignatureData, sig); err != nil {
+		return types.Address{}, err
+	}
+
+	signer, err := RecoverPlain(tx.SponsorHash(sender, sponsorship.MaxFee, sponsorship.TxTypes), sig.R, sig.S, sig.V)
+	if err != nil {
+		return types.Address{}, err
+	}
+
+	sponsorship.signer = &signer
+	return signer, nil
+}
+
+// IsAllowedType returns true if the sponsor agreed to pay for the tx type
+func (s *Sponsorship) IsAllowedType(txType TxType) bool {
+	if len(s.TxTypes) == 0 {
+		return true
+	}
+
+	for _, t := range s.TxTypes {
+		if t == txType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// chargeSponsorCommission subtracts commission of the delivered tx from the sponsor and replaces commission tags of the tx
+func chargeSponsorCommission(deliverState *state.State, tx *Transaction, sponsor types.Address, commission, commissionInBaseCoin *big.Int, isGasCommissionFromPoolSwap gasMethod, rewardPool *big.Int, txTags []abcTypes.EventAttribute) []abcTypes.EventAttribute {
+	var tagsCom *tagPoolChange
+	if isGasCommissionFromPoolSwap {
+		var (
+			poolIDCom  uint32
+			detailsCom *swap.ChangeDetailsWithOrders
+			ownersCom  []*swap.OrderDetail
+		)
+		commission, commissionInBaseCoin, poolIDCom, detailsCom, ownersCom = deliverState.Swapper().PairSellWithOrders(tx.CommissionCoin(), types.GetBaseCoinID(), commission, big.NewInt(0))
+		tagsCom = &tagPoolChange{
+			PoolID:   poolIDCom,
+			CoinIn:   tx.CommissionCoin(),
+			ValueIn:  commission.String(),
+			CoinOut:  types.GetBaseCoinID(),
+			ValueOut: commissionInBaseCoin.String(),
+			Orders:   detailsCom,
+			// Sellers:  ownersCom,
+		}
+		for _, value := range ownersCom {
+			deliverState.Accounts.AddBalance(value.Owner, tx.CommissionCoin(), value.ValueBigInt)
+		}
+	} else if !tx.CommissionCoin().IsBaseCoin() {
+		deliverState.Coins.SubVolume(tx.CommissionCoin(), commission)
+		deliverState.Coins.SubReserve(tx.CommissionCoin(), commissionInBaseCoin)
+	}
+	deliverState.Accounts.SubBalance(sponsor, tx.CommissionCoin(), commission)
+	rewardPool.Add(rewardPool, commissionInBaseCoin)
+
+	tags := []abcTypes.EventAttribute{
+		{Key: []byte("tx.commission_in_base_coin"), Value: []byte(commissionInBaseCoin.String())},
+		{Key: []byte("tx.commission_conversion"), Value: []byte(isGasCommissionFromPoolSwap.String()), Index: true},
+		{Key: []byte("tx.commission_amount"), Value: []byte(commission.String())},
+		{Key: []byte("tx.commission_details"), Value: []byte(tagsCom.string())},
+		{Key: []byte("tx.sponsor"), Value: []byte(hex.EncodeToString(sponsor[:])), Index: true},
+	}
+	for _, tag := range txTags {
+		if !strings.HasPrefix(string(tag.Key), "tx.commission_") {
+			tags = append(tags, tag)
+		}
+	}
+
+	return tags
+}
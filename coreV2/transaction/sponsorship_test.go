@@ -0,0 +1,263 @@
+package transaction
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/crypto"
+	"github.com/MinterTeam/minter-go-node/helpers"
+	"github.com/MinterTeam/minter-go-node/rlp"
+)
+
+func makeTestSponsoredTx(txType TxType, data interface{}, nonce uint64, privateKey *ecdsa.PrivateKey, maxFee *big.Int, txTypes []TxType, sponsorKey *ecdsa.PrivateKey) ([]byte, error) {
+	encodedData, err := rlp.EncodeToBytes(data)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := Transaction{
+		Nonce:         nonce,
+		GasPrice:      1,
+		ChainID:       types.CurrentChainID,
+		GasCoin:       types.GetBaseCoinID(),
+		Type:          txType,
+		Data:          encodedData,
+		SignatureType: SigTypeSingle,
+	}
+
+	tx.SetSponsor(crypto.PubkeyToAddress(sponsorKey.PublicKey))
+	if err := tx.Sign(privateKey); err != nil {
+		return nil, err
+	}
+
+	if err := tx.SignSponsorship(maxFee, txTypes, sponsorKey); err != nil {
+		return nil, err
+	}
+
+	return rlp.EncodeToBytes(tx)
+}
+
+func TestSponsoredTx(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	sponsorKey, _ := crypto.GenerateKey()
+	sponsor := crypto.PubkeyToAddress(sponsorKey.PublicKey)
+
+	coin := types.GetBaseCoinID()
+	value := helpers.BipToPip(big.NewInt(10))
+	cState.Accounts.AddBalance(addr, coin, value)
+	cState.Accounts.AddBalance(sponsor, coin, helpers.BipToPip(big.NewInt(1000)))
+
+	encodedTx, err := makeTestSponsoredTx(TypeSend, SendData{
+		Coin:  coin,
+		To:    types.Address{1},
+		Value: value,
+	}, 1, privateKey, helpers.BipToPip(big.NewInt(100)), []TxType{TypeSend, TypeSellSwapPool}, sponsorKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx, err := NewExecutorV350(GetDataV350).DecodeFromBytes(encodedTx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if txSponsor, err := tx.SponsorAddress(); err != nil || txSponsor != sponsor {
+		t.Fatalf("Sponsor is not correct. Expected %s, got %s", sponsor.String(), txSponsor.String())
+	}
+
+	response := NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not %d. Error %s", code.OK, response.Log)
+	}
+
+	if balance := cState.Accounts.GetBalance(addr, coin); balance.Sign() != 0 {
+		t.Fatalf("Sender balance is not correct. Expected %s, got %s", "0", balance)
+	}
+
+	if balance := cState.Accounts.GetBalance(types.Address{1}, coin); balance.Cmp(value) != 0 {
+		t.Fatalf("Target balance is not correct. Expected %s, got %s", value, balance)
+	}
+
+	commissions := cState.Commission.GetCommissions()
+	expectedBalance := big.NewInt(0).Sub(helpers.BipToPip(big.NewInt(1000)), commissions.Send)
+	if balance := cState.Accounts.GetBalance(sponsor, coin); balance.Cmp(expectedBalance) != 0 {
+		t.Fatalf("Sponsor balance is not correct. Expected %s, got %s", expectedBalance, balance)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSponsoredTxToWrongSponsorship(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	sponsorKey, _ := crypto.GenerateKey()
+	sponsor := crypto.PubkeyToAddress(sponsorKey.PublicKey)
+
+	coin := types.GetBaseCoinID()
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(10)))
+	cState.Accounts.AddBalance(sponsor, coin, helpers.BipToPip(big.NewInt(1000)))
+
+	data := SendData{
+		Coin:  coin,
+		To:    types.Address{1},
+		Value: helpers.BipToPip(big.NewInt(10)),
+	}
+
+	encodedTx, err := makeTestSponsoredTx(TypeSend, data, 1, privateKey, helpers.BipToPip(big.NewInt(100)), []TxType{TypeSellSwapPool}, sponsorKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.WrongSponsorship {
+		t.Fatalf("Response code is not %d. Error %s", code.WrongSponsorship, response.Log)
+	}
+
+	encodedTx, err = makeTestSponsoredTx(TypeSend, data, 1, privateKey, big.NewInt(1), nil, sponsorKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response = NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.TooHighSponsoredFee {
+		t.Fatalf("Response code is not %d. Error %s", code.TooHighSponsoredFee, response.Log)
+	}
+
+	if balance := cState.Accounts.GetBalance(sponsor, coin); balance.Cmp(helpers.BipToPip(big.NewInt(1000))) != 0 {
+		t.Fatalf("Sponsor balance is not correct. Expected %s, got %s", helpers.BipToPip(big.NewInt(1000)), balance)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSponsoredTxToReusedSponsorship(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	sponsorKey, _ := crypto.GenerateKey()
+	sponsor := crypto.PubkeyToAddress(sponsorKey.PublicKey)
+
+	coin := types.GetBaseCoinID()
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(10)))
+	cState.Accounts.AddBalance(sponsor, coin, helpers.BipToPip(big.NewInt(1000)))
+
+	encodedSponsoredTx, err := makeTestSponsoredTx(TypeSend, SendData{
+		Coin:  coin,
+		To:    types.Address{1},
+		Value: helpers.BipToPip(big.NewInt(1)),
+	}, 1, privateKey, helpers.BipToPip(big.NewInt(100)), nil, sponsorKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sponsoredTx, err := NewExecutorV350(GetDataV350).DecodeFromBytes(encodedSponsoredTx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the sponsorship is attached to another tx of the sender with the same nonce
+	tx := *sponsoredTx
+	tx.Payload = make([]byte, 1000)
+	if err := tx.Sign(privateKey); err != nil {
+		t.Fatal(err)
+	}
+	encodedTx, err := rlp.EncodeToBytes(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.KeyIsNotAuthorized {
+		t.Fatalf("Response code is not %d. Error %s", code.KeyIsNotAuthorized, response.Log)
+	}
+
+	// the sponsor is replaced after the tx is signed by the sender
+	otherSponsorKey, _ := crypto.GenerateKey()
+	tx = *sponsoredTx
+	tx.SetSponsor(crypto.PubkeyToAddress(otherSponsorKey.PublicKey))
+	if err := tx.SignSponsorship(helpers.BipToPip(big.NewInt(100)), nil, otherSponsorKey); err != nil {
+		t.Fatal(err)
+	}
+	encodedTx, err = rlp.EncodeToBytes(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response = NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code == code.OK {
+		t.Fatalf("Response code is %d", code.OK)
+	}
+
+	if balance := cState.Accounts.GetBalance(addr, coin); balance.Cmp(helpers.BipToPip(big.NewInt(10))) != 0 {
+		t.Fatalf("Sender balance is not correct. Expected %s, got %s", helpers.BipToPip(big.NewInt(10)), balance)
+	}
+
+	if balance := cState.Accounts.GetBalance(sponsor, coin); balance.Cmp(helpers.BipToPip(big.NewInt(1000))) != 0 {
+		t.Fatalf("Sponsor balance is not correct. Expected %s, got %s", helpers.BipToPip(big.NewInt(1000)), balance)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSponsoredTxBeforeV350(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	sponsorKey, _ := crypto.GenerateKey()
+	sponsor := crypto.PubkeyToAddress(sponsorKey.PublicKey)
+
+	coin := types.GetBaseCoinID()
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(10)))
+	cState.Accounts.AddBalance(sponsor, coin, helpers.BipToPip(big.NewInt(1000)))
+
+	encodedTx, err := makeTestSponsoredTx(TypeSend, SendData{
+		Coin:  coin,
+		To:    types.Address{1},
+		Value: helpers.BipToPip(big.NewInt(1)),
+	}, 1, privateKey, helpers.BipToPip(big.NewInt(100)), []TxType{TypeSend}, sponsorKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := NewExecutorV3(GetDataV3).RunTx(cState, encodedTx, big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.DecodeError {
+		t.Fatalf("Response code is not %d. Error %s", code.DecodeError, response.Log)
+	}
+
+	encodedTx, err = makeTestTx(TypeBatch, BatchData{}, 1, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response = NewExecutorV3(GetDataV3).RunTx(cState, encodedTx, big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.DecodeError {
+		t.Fatalf("Response code is not %d. Error %s", code.DecodeError, response.Log)
+	}
+
+	if balance := cState.Accounts.GetBalance(sponsor, coin); balance.Cmp(helpers.BipToPip(big.NewInt(1000))) != 0 {
+		t.Fatalf("Sponsor balance is not correct. Expected %s, got %s", helpers.BipToPip(big.NewInt(1000)), balance)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
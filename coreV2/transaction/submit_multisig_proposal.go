@@ -0,0 +1,294 @@
+package transaction
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/accounts"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/commission"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/swap"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/rlp"
+	abcTypes "github.com/tendermint/tendermint/abci/types"
+)
+
+type SubmitMultisigProposalData struct {
+	Multisig     types.Address
+	Type         TxType
+	Data         []byte
+	GasCoin      types.CoinID
+	ExpireHeight uint64
+}
+
+func (data SubmitMultisigProposalData) Gas() int64 {
+	return gasSubmitMultisigProposal
+}
+func (data SubmitMultisigProposalData) TxType() TxType {
+	return TypeSubmitMultisigProposal
+}
+
+func (data SubmitMultisigProposalData) basicCheck(tx *Transaction, context *state.CheckState, block uint64) *Response {
+	sender, _ := tx.Sender()
+
+	if data.ExpireHeight <= block {
+		return &Response{
+			Code: code.WrongMultisigProposalExpireHeight,
+			Log:  fmt.Sprintf("Proposal expire height should be greater than current height %d", block),
+			Info: EncodeError(code.NewWrongMultisigProposalExpireHeight(strconv.FormatUint(data.ExpireHeight, 10), strconv.FormatUint(block, 10))),
+		}
+	}
+
+	if _, err := decodeMultisigProposalTx(data.Type, data.Data); err != nil {
+		return &Response{
+			Code: code.WrongMultisigProposalTxType,
+			Log:  fmt.Sprintf("Incorrect proposed tx: %s", err),
+			Info: EncodeError(code.NewWrongMultisigProposalTxType(data.Type.String())),
+		}
+	}
+
+	if !context.Coins().Exists(data.GasCoin) {
+		return &Response{
+			Code: code.CoinNotExists,
+			Log:  fmt.Sprintf("Coin %s not exists", data.GasCoin),
+			Info: EncodeError(code.NewCoinNotExists("", data.GasCoin.String())),
+		}
+	}
+
+	return checkMultisigMember(context, data.Multisig, sender)
+}
+
+func (data SubmitMultisigProposalData) String() string {
+	return fmt.Sprintf("SUBMIT MULTISIG PROPOSAL multisig: %s type: %s", data.Multisig.String(), data.Type.String())
+}
+
+func (data SubmitMultisigProposalData) CommissionData(price *commission.Price) *big.Int {
+	return price.SubmitMultisigProposalPrice()
+}
+
+func (data SubmitMultisigProposalData) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, price *big.Int) Response {
+	sender, _ := tx.Sender()
+
+	var checkState *state.CheckState
+	var isCheck bool
+	if checkState, isCheck = context.(*state.CheckState); !isCheck {
+		checkState = state.NewCheckState(context.(*state.State))
+	}
+
+	response := data.basicCheck(tx, checkState, currentBlock)
+	if response != nil {
+		return *response
+	}
+
+	commissionInBaseCoin := price
+	commissionPoolSwapper := checkState.Swap().GetSwapper(tx.GasCoin, types.GetBaseCoinID())
+	gasCoin := checkState.Coins().GetCoin(tx.GasCoin)
+	commission, isGasCommissionFromPoolSwap, errResp := CalculateCommission(checkState, commissionPoolSwapper, gasCoin, commissionInBaseCoin)
+	if errResp != nil {
+		return *errResp
+	}
+
+	if checkState.Accounts().GetSpendableBalance(sender, tx.GasCoin).Cmp(commission) < 0 {
+		return Response{
+			Code: code.InsufficientFunds,
+			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), commission.String(), gasCoin.GetFullSymbol()),
+			Info: EncodeError(code.NewInsufficientFunds(sender.String(), commission.String(), gasCoin.GetFullSymbol(), gasCoin.ID().String())),
+		}
+	}
+
+	multisig := checkState.Accounts().GetAccount(data.Multisig).Multisig()
+	executed := multisig.GetWeight(sender) >= multisig.Threshold
+	var proposalResponse Response
+	if executed {
+		proposalResponse = runMultisigProposal(context, data.Multisig, data.GasCoin, data.Type, data.Data, rewardPool, currentBlock)
+		if proposalResponse.Code != code.OK {
+			return proposalResponse
+		}
+	}
+
+	var tags []abcTypes.EventAttribute
+	if deliverState, ok := context.(*state.State); ok {
+		var tagsCom *tagPoolChange
+		if isGasCommissionFromPoolSwap {
+			var (
+				poolIDCom  uint32
+				detailsCom *swap.ChangeDetailsWithOrders
+				ownersCom  []*swap.OrderDetail
+			)
+			commission, commissionInBaseCoin, poolIDCom, detailsCom, ownersCom = deliverState.Swapper().PairSellWithOrders(tx.CommissionCoin(), types.GetBaseCoinID(), commission, big.NewInt(0))
+			tagsCom = &tagPoolChange{
+				PoolID:   poolIDCom,
+				CoinIn:   tx.CommissionCoin(),
+				ValueIn:  commission.String(),
+				CoinOut:  types.GetBaseCoinID(),
+				ValueOut: commissionInBaseCoin.String(),
+				Orders:   detailsCom,
+				// Sellers:  ownersCom,
+			}
+			for _, value := range ownersCom {
+				deliverState.Accounts.AddBalance(value.Owner, tx.CommissionCoin(), value.ValueBigInt)
+			}
+		} else if !tx.GasCoin.IsBaseCoin() {
+			deliverState.Coins.SubVolume(tx.CommissionCoin(), commission)
+			deliverState.Coins.SubReserve(tx.CommissionCoin(), commissionInBaseCoin)
+		}
+		deliverState.Accounts.SubBalance(sender, tx.GasCoin, commission)
+		rewardPool.Add(rewardPool, commissionInBaseCoin)
+
+		var id uint64
+		if !executed {
+			id = deliverState.MultisigProposals.CreateProposal(data.Multisig, sender, data.GasCoin, byte(data.Type), data.Data, data.ExpireHeight)
+		}
+		deliverState.Accounts.SetNonce(sender, tx.Nonce)
+
+		tags = []abcTypes.EventAttribute{
+			{Key: []byte("tx.commission_in_base_coin"), Value: []byte(commissionInBaseCoin.String())},
+			{Key: []byte("tx.commission_conversion"), Value: []byte(isGasCommissionFromPoolSwap.String()), Index: true},
+			{Key: []byte("tx.commission_amount"), Value: []byte(commission.String())},
+			{Key: []byte("tx.commission_details"), Value: []byte(tagsCom.string())},
+			{Key: []byte("tx.multisig"), Value: []byte(hex.EncodeToString(data.Multisig[:])), Index: true},
+			{Key: []byte("tx.multisig_proposal_id"), Value: []byte(strconv.FormatUint(id, 10)), Index: true},
+			{Key: []byte("tx.multisig_proposal_executed"), Value: []byte(strconv.FormatBool(executed))},
+		}
+		tags = append(tags, nestedTags("multisig_proposal_tx", proposalResponse.Tags)...)
+	}
+
+	return Response{
+		Code: code.OK,
+		Tags: tags,
+	}
+}
+
+// checkMultisigMember checks that the address has a weight in the multisig
+func checkMultisigMember(context *state.CheckState, multisig types.Address, address types.Address) *Response {
+	account := context.Accounts().GetAccount(multisig)
+	if !account.IsMultisig() {
+		return &Response{
+			Code: code.MultisigNotExists,
+			Log:  "Multisig does not exists",
+			Info: EncodeError(code.NewMultisigNotExists(multisig.String())),
+		}
+	}
+
+	multisigData := account.Multisig()
+	if multisigData.GetWeight(address) == 0 {
+		return &Response{
+			Code: code.IsNotMultisigMember,
+			Log:  "Sender is not a member of the multisig",
+			Info: EncodeError(code.NewIsNotMultisigMember(multisig.String(), address.String())),
+		}
+	}
+
+	return nil
+}
+
+// decodeMultisigProposalTx decodes data of a proposed tx, proposals of proposal txs are not allowed
+func decodeMultisigProposalTx(txType TxType, txData []byte) (Data, error) {
+	if txType == TypeSubmitMultisigProposal || txType == TypeApproveMultisigProposal {
+		return nil, fmt.Errorf("tx type %s can not be proposed", txType)
+	}
+
+	data, ok := GetData(txType)
+	if !ok {
+		return nil, fmt.Errorf("tx type %s is not registered", txType)
+	}
+
+	if err := rlp.DecodeBytes(txData, data); err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// runMultisigProposal runs a proposed tx on behalf of the multisig, the multisig pays its commission and uses its nonce.
+// The tx is checked against the policy and frozen coins of the multisig the same way the executor checks txs
+func runMultisigProposal(context state.Interface, multisig types.Address, gasCoin types.CoinID, txType TxType, txData []byte, rewardPool *big.Int, currentBlock uint64) Response {
+	var checkState *state.CheckState
+	var isCheck bool
+	if checkState, isCheck = context.(*state.CheckState); !isCheck {
+		checkState = state.NewCheckState(context.(*state.State))
+	}
+
+	data, err := decodeMultisigProposalTx(txType, txData)
+	if err != nil {
+		return Response{
+			Code: code.WrongMultisigProposalTxType,
+			Log:  fmt.Sprintf("Incorrect proposed tx: %s", err),
+			Info: EncodeError(code.NewWrongMultisigProposalTxType(txType.String())),
+		}
+	}
+
+	tx := &Transaction{
+		Nonce:         checkState.Accounts().GetNonce(multisig) + 1,
+		ChainID:       types.CurrentChainID,
+		GasPrice:      1,
+		GasCoin:       gasCoin,
+		Type:          txType,
+		Data:          txData,
+		SignatureType: SigTypeMulti,
+		decodedData:   data,
+	}
+	tx.SetMultisigAddress(multisig)
+
+	if !checkState.Coins().Exists(tx.CommissionCoin()) {
+		return Response{
+			Code: code.CoinNotExists,
+			Log:  fmt.Sprintf("Coin %s not exists", tx.CommissionCoin()),
+			Info: EncodeError(code.NewCoinNotExists("", tx.CommissionCoin().String())),
+		}
+	}
+
+	commissions := checkState.Commission().GetCommissions()
+	price := tx.MulGasPrice(tx.Price(commissions))
+	if !commissions.Coin.IsBaseCoin() {
+		price, _ = checkState.Swap().GetSwapper(commissions.Coin, types.GetBaseCoinID()).CalculateBuyForSellWithOrders(price)
+	}
+	if price == nil || price.Sign() != 1 {
+		return Response{
+			Code: code.CommissionCoinNotSufficient,
+			Log:  fmt.Sprint("Not possible to pay commission"),
+			Info: EncodeError(code.NewCommissionCoinNotSufficient("", "")),
+		}
+	}
+
+	policyOutflows, policyResponse := checkAccountPolicy(tx, checkState, multisig)
+	if policyResponse != nil {
+		return *policyResponse
+	}
+
+	if freezeResponse := checkFrozenCoins(tx, checkState, multisig); freezeResponse != nil {
+		return *freezeResponse
+	}
+
+	response := data.Run(tx, context, rewardPool, currentBlock, price)
+	if response.Code != code.OK {
+		return response
+	}
+
+	if deliverState, ok := context.(*state.State); ok {
+		for _, outflow := range policyOutflows {
+			deliverState.Accounts.AddPolicySpent(multisig, outflow.Coin, outflow.Value)
+		}
+
+		tags, errResp := burnForSymbol(deliverState, tx, commissions, rewardPool)
+		if errResp != nil {
+			return *errResp
+		}
+		response.Tags = append(response.Tags, tags...)
+	}
+
+	return response
+}
+
+// multisigProposalWeight returns the sum of current weights of the addresses in the multisig
+func multisigProposalWeight(multisig *accounts.Multisig, approvals []types.Address) uint32 {
+	var weight uint32
+	for _, address := range approvals {
+		weight += multisig.GetWeight(address)
+	}
+
+	return weight
+}
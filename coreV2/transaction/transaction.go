@@ -66,6 +66,35 @@ const (
 	TypeRemoveLimitOrder        TxType = 0x24
 	TypeLockStake               TxType = 0x25
 	TypeLock                    TxType = 0x26
+	TypeEditCandidateDelegation TxType = 0x27
+	TypeSubmitMultisigProposal  TxType = 0x28
+	TypeApproveMultisigProposal TxType = 0x29
+	TypeCreateVesting           TxType = 0x2A
+	TypeBatch                   TxType = 0x2B
+	TypeRevokeCheck             TxType = 0x2C
+	TypeRedeemChecks            TxType = 0x2D
+	TypeRedeemCheckV2           TxType = 0x2E
+	TypeCreateStandingOrder     TxType = 0x2F
+	TypeCancelStandingOrder     TxType = 0x30
+	TypeCreateHTLC              TxType = 0x31
+	TypeClaimHTLC               TxType = 0x32
+	TypeRefundHTLC              TxType = 0x33
+	TypeRotateKey               TxType = 0x34
+	TypeSetKeyGuardian          TxType = 0x35
+	TypeSetAccountPolicy        TxType = 0x36
+	TypeEditCoinMetadata        TxType = 0x37
+	TypePauseToken              TxType = 0x38
+	TypeUnpauseToken            TxType = 0x39
+	TypeFreezeAddress           TxType = 0x3A
+	TypeUnfreezeAddress         TxType = 0x3B
+	TypeSetTokenTransferFee     TxType = 0x3C
+	TypeDistributeToHolders     TxType = 0x3D
+	TypeMigrateCoinToToken      TxType = 0x3E
+	TypeCreateParamProposal     TxType = 0x3F
+	TypeVoteParamProposal       TxType = 0x40
+	TypeCreateTreasuryProposal  TxType = 0x41
+	TypeVoteTreasuryProposal    TxType = 0x42
+	TypeVoteAsDelegator         TxType = 0x43
 )
 
 const (
@@ -100,6 +129,7 @@ const (
 	gasBurnToken = 1
 
 	gasRedeemCheck = 20
+	gasRevokeCheck = 5
 
 	gasDeclareCandidacy = 10
 	gasDelegate         = 6
@@ -107,15 +137,54 @@ const (
 	gasMoveStake        = 6
 	gasLockStake        = 2
 	gasLock             = 2
+	gasCreateVesting    = 2
 
 	gasSetCandidateOnline      = 1
 	gasSetCandidateOffline     = 1
 	gasEditCandidate           = 5
 	gasEditCandidatePublicKey  = 10
 	gasEditCandidateCommission = 1
+	gasEditCandidateDelegation = 5
 
-	gasCreateMultisig = 20
-	gasEditMultisig   = 5
+	gasCreateMultisig          = 20
+	gasEditMultisig            = 5
+	gasSubmitMultisigProposal  = 10
+	gasApproveMultisigProposal = 5
+
+	gasBatch = 10
+
+	gasCreateStandingOrder = 10
+	gasCancelStandingOrder = 5
+
+	gasCreateHTLC = 5
+	gasClaimHTLC  = 5
+	gasRefundHTLC = 5
+
+	gasRotateKey      = 5
+	gasSetKeyGuardian = 5
+
+	gasSetAccountPolicy = 5
+
+	gasEditCoinMetadata = 10
+
+	gasPauseToken      = 5
+	gasUnpauseToken    = 5
+	gasFreezeAddress   = 5
+	gasUnfreezeAddress = 5
+
+	gasSetTokenTransferFee = 5
+
+	gasDistributeToHolders = 10
+
+	gasMigrateCoinToToken = 10
+
+	gasCreateParamProposal = 10
+	gasVoteParamProposal   = 5
+
+	gasCreateTreasuryProposal = 10
+	gasVoteTreasuryProposal   = 5
+
+	gasVoteAsDelegator = 5
 
 	gasSetHaltBlock   = 5
 	gasVoteCommission = 5
@@ -125,8 +194,9 @@ const (
 type SigType byte
 
 const (
-	SigTypeSingle SigType = 0x01
-	SigTypeMulti  SigType = 0x02
+	SigTypeSingle     SigType = 0x01
+	SigTypeMulti      SigType = 0x02
+	SigTypeAuthorized SigType = 0x03
 )
 
 var (
@@ -144,10 +214,12 @@ type Transaction struct {
 	ServiceData   []byte
 	SignatureType SigType
 	SignatureData []byte
+	Sponsor       []Sponsorship `rlp:"tail"`
 
 	decodedData Data
 	sig         *Signature
 	multisig    *SignatureMulti
+	authorized  *SignatureAuthorized
 	sender      *types.Address
 }
 
@@ -162,6 +234,12 @@ type SignatureMulti struct {
 	Signatures []Signature
 }
 
+// SignatureAuthorized is a signature of the account with a rotated key, it is made by the authorized key of the Account
+type SignatureAuthorized struct {
+	Account   types.Address
+	Signature Signature
+}
+
 type RawData []byte
 
 type totalSpends []totalSpend
@@ -202,6 +280,12 @@ type Data interface {
 	Gas() int64
 }
 
+// scaledData is implemented by txs whose cost grows with the state they process,
+// the gas and the commission of the data are multiplied by the returned number of units
+type scaledData interface {
+	units(context *state.CheckState) int64
+}
+
 func (tx *Transaction) Serialize() ([]byte, error) {
 	return rlp.EncodeToBytes(tx)
 }
@@ -217,6 +301,9 @@ func (tx *Transaction) Gas() int64 {
 	if tx.SignatureType == SigTypeMulti {
 		base += int64(len(tx.multisig.Signatures)) * gasSign
 	}
+	if tx.IsSponsored() {
+		base += gasSign
+	}
 	return base + tx.decodedData.Gas()
 }
 
@@ -294,6 +381,26 @@ func (tx *Transaction) SetSignature(sig []byte) {
 				panic(err)
 			}
 
+			tx.SignatureData = data
+		}
+	case SigTypeAuthorized:
+		{
+			if tx.authorized == nil {
+				tx.authorized = &SignatureAuthorized{}
+			}
+
+			tx.authorized.Signature = Signature{
+				V: new(big.Int).SetBytes([]byte{sig[64] + 27}),
+				R: new(big.Int).SetBytes(sig[:32]),
+				S: new(big.Int).SetBytes(sig[32:64]),
+			}
+
+			data, err := rlp.EncodeToBytes(tx.authorized)
+
+			if err != nil {
+				panic(err)
+			}
+
 			tx.SignatureData = data
 		}
 	}
@@ -322,13 +429,15 @@ func (tx *Transaction) Sender() (types.Address, error) {
 		return sender, nil
 	case SigTypeMulti:
 		return tx.multisig.Multisig, nil
+	case SigTypeAuthorized:
+		return tx.authorized.Account, nil
 	}
 
 	return types.Address{}, errors.New("unknown signature type")
 }
 
 func (tx *Transaction) Hash() types.Hash {
-	return rlpHash([]interface{}{
+	fields := []interface{}{
 		tx.Nonce,
 		tx.ChainID,
 		tx.GasPrice,
@@ -338,7 +447,12 @@ func (tx *Transaction) Hash() types.Hash {
 		tx.Payload,
 		tx.ServiceData,
 		tx.SignatureType,
-	})
+	}
+	if tx.IsSponsored() {
+		// the sender agrees to the sponsor, hash of a tx without the sponsor is not changed
+		fields = append(fields, tx.Sponsor[0].Sponsor)
+	}
+	return rlpHash(fields)
 }
 
 func (tx *Transaction) SetDecodedData(data Data) {
@@ -365,6 +479,22 @@ func (tx *Transaction) SetMultisigAddress(address types.Address) {
 	tx.SignatureData = data
 }
 
+func (tx *Transaction) SetAuthorizedAccount(address types.Address) {
+	if tx.authorized == nil {
+		tx.authorized = &SignatureAuthorized{}
+	}
+
+	tx.authorized.Account = address
+
+	data, err := rlp.EncodeToBytes(tx.authorized)
+
+	if err != nil {
+		panic(err)
+	}
+
+	tx.SignatureData = data
+}
+
 func RecoverPlain(sighash types.Hash, R, S, Vb *big.Int) (types.Address, error) {
 	if Vb.BitLen() > 8 {
 		return types.Address{}, ErrInvalidSig
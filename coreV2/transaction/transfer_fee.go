@@ -0,0 +1,90 @@
+package transaction
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+)
+
+// TransferFee returns the fee withheld by the issuer of the coin from the transferred value
+func TransferFee(context *state.CheckState, coin types.CoinID, value *big.Int) *big.Int {
+	return context.Coins().GetTransferFee(coin).Of(value)
+}
+
+// TransferFeeGross returns the value to be transferred for the recipient to receive at least the given value
+func TransferFeeGross(context *state.CheckState, coin types.CoinID, value *big.Int) *big.Int {
+	return context.Coins().GetTransferFee(coin).Gross(value)
+}
+
+// coinOwner returns the owner of the coin symbol or nil if the coin has no owner
+func coinOwner(context *state.CheckState, coin types.CoinID) *types.Address {
+	model := context.Coins().GetCoin(coin)
+	if model == nil {
+		return nil
+	}
+
+	info := context.Coins().GetSymbolInfo(model.Symbol())
+	if info == nil {
+		return nil
+	}
+
+	return info.OwnerAddress()
+}
+
+// transferFeeOf returns the fee of the value transferred by the sender, transfers of the owner of the coin are exempt
+func transferFeeOf(context *state.CheckState, coin types.CoinID, sender types.Address, value *big.Int) *big.Int {
+	fee := context.Coins().GetTransferFee(coin)
+	if fee.Bps == 0 {
+		return big.NewInt(0)
+	}
+
+	if owner := coinOwner(context, coin); owner != nil && *owner == sender {
+		return big.NewInt(0)
+	}
+
+	return fee.Of(value)
+}
+
+// transferFeeGrossOf returns the value to be transferred to the sender for it to receive at least the given value
+func transferFeeGrossOf(context *state.CheckState, coin types.CoinID, sender types.Address, value *big.Int) *big.Int {
+	if owner := coinOwner(context, coin); owner != nil && *owner == sender {
+		return big.NewInt(0).Set(value)
+	}
+
+	return TransferFeeGross(context, coin, value)
+}
+
+// payTransferFee withholds the fee from the value transferred by the sender and returns the value left for the recipient and the fee,
+// the fee is paid to the owner of the coin or burned
+func payTransferFee(deliverState *state.State, coin types.CoinID, sender types.Address, value *big.Int) (*big.Int, *big.Int) {
+	checkState := state.NewCheckState(deliverState)
+	fee := transferFeeOf(checkState, coin, sender, value)
+	if fee.Sign() == 0 {
+		return value, fee
+	}
+
+	if owner := coinOwner(checkState, coin); owner != nil && !checkState.Coins().GetTransferFee(coin).Burn {
+		deliverState.Accounts.AddBalance(*owner, coin, fee)
+	} else {
+		deliverState.Coins.SubVolume(coin, fee)
+	}
+
+	return big.NewInt(0).Sub(value, fee), fee
+}
+
+// checkMinimumReceived checks the value of the coin left for the sender after the transfer fee against the minimum value to buy
+func checkMinimumReceived(context *state.CheckState, coin CalculateCoin, sender types.Address, value, minimumValueToBuy *big.Int) *Response {
+	received := big.NewInt(0).Sub(value, transferFeeOf(context, coin.ID(), sender, value))
+	if received.Cmp(minimumValueToBuy) == -1 {
+		return &Response{
+			Code: code.MinimumValueToBuyReached,
+			Log:  fmt.Sprintf("You wanted to buy minimum %s %s, but currently you will receive only %s %s after transfer fee", minimumValueToBuy, coin.GetFullSymbol(), received, coin.GetFullSymbol()),
+			Info: EncodeError(code.NewMinimumValueToBuyReached(minimumValueToBuy.String(), received.String(), coin.GetFullSymbol(), coin.ID().String())),
+		}
+	}
+
+	return nil
+}
@@ -124,7 +124,7 @@ func (data UnbondDataV3) Run(tx *Transaction, context state.Interface, rewardPoo
 		return *errResp
 	}
 
-	if checkState.Accounts().GetBalance(sender, tx.GasCoin).Cmp(commission) < 0 {
+	if checkState.Accounts().GetSpendableBalance(sender, tx.GasCoin).Cmp(commission) < 0 {
 		return Response{
 			Code: code.InsufficientFunds,
 			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), commission, gasCoin.GetFullSymbol()),
@@ -135,7 +135,7 @@ func (data UnbondDataV3) Run(tx *Transaction, context state.Interface, rewardPoo
 	var tags []abcTypes.EventAttribute
 	if deliverState, ok := context.(*state.State); ok {
 		// now + 30 days
-		unbondAtBlock := currentBlock + types.GetUnbondPeriod()
+		unbondAtBlock := currentBlock + checkState.Governance().UnbondPeriod()
 
 		var tagsCom *tagPoolChange
 		if isGasCommissionFromPoolSwap {
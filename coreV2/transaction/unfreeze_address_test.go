@@ -0,0 +1,92 @@
+package transaction
+
+import (
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/crypto"
+	"github.com/MinterTeam/minter-go-node/helpers"
+)
+
+func TestUnfreezeAddressTx(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	cState.Accounts.AddBalance(addr, types.GetBaseCoinID(), helpers.BipToPip(big.NewInt(1000)))
+
+	holderKey, _ := crypto.GenerateKey()
+	holder := crypto.PubkeyToAddress(holderKey.PublicKey)
+	cState.Accounts.AddBalance(holder, types.GetBaseCoinID(), helpers.BipToPip(big.NewInt(1000)))
+
+	coin := createTestFreezableToken(cState, addr)
+	cState.Accounts.SubBalance(addr, coin, big.NewInt(10))
+	cState.Accounts.AddBalance(holder, coin, big.NewInt(10))
+	cState.Coins.Freeze(coin, holder)
+
+	sendTx, err := makeTestTx(TypeSend, SendData{Coin: coin, To: types.Address{1}, Value: big.NewInt(1)}, 1, holderKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := NewExecutorV350(GetDataV350).RunTx(cState, sendTx, big.NewInt(0), 0, &sync.Map{}, 0, false)
+	if response.Code != code.AddressIsFrozen {
+		t.Fatalf("Response code is not %d. Error %s", code.AddressIsFrozen, response.Log)
+	}
+
+	encodedTx, err := makeTestTx(TypeUnfreezeAddress, UnfreezeAddressData{Coin: coin, Address: holder}, 1, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response = NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 0, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not %d. Error %s", code.OK, response.Log)
+	}
+
+	if cState.Coins.IsFrozen(coin, holder) {
+		t.Fatal("Address is still frozen")
+	}
+
+	response = NewExecutorV350(GetDataV350).RunTx(cState, sendTx, big.NewInt(0), 0, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not %d. Error %s", code.OK, response.Log)
+	}
+
+	if balance := cState.Accounts.GetBalance(holder, coin); balance.Cmp(big.NewInt(9)) != 0 {
+		t.Fatalf("Holder balance is not correct. Expected %s, got %s", big.NewInt(9), balance)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestUnfreezeAddressTxToNotFreezable(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	cState.Accounts.AddBalance(addr, types.GetBaseCoinID(), helpers.BipToPip(big.NewInt(1000)))
+
+	coin := createTestCoinWithOwner(cState, addr)
+
+	encodedTx, err := makeTestTx(TypeUnfreezeAddress, UnfreezeAddressData{Coin: coin, Address: types.Address{1}}, 1, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 0, &sync.Map{}, 0, false)
+	if response.Code != code.CoinIsNotFreezable {
+		t.Fatalf("Response code is not %d. Error %s", code.CoinIsNotFreezable, response.Log)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
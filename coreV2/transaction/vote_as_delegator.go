@@ -0,0 +1,186 @@
+package transaction
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/commission"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/delegatorvotes"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/swap"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	abcTypes "github.com/tendermint/tendermint/abci/types"
+)
+
+// VoteAsDelegatorData is a vote of the delegator in the commission, update or halt voting at Height.
+// The vote overrides the votes of the delegator's validators for the delegated stake. Option is an index of the
+// price or version voted by validators at the height and is ignored for the halt voting, Abstain excludes the stake from the tally
+type VoteAsDelegatorData struct {
+	Kind    byte
+	Height  uint64
+	Option  uint32
+	Abstain bool
+}
+
+func (data VoteAsDelegatorData) Gas() int64 {
+	return gasVoteAsDelegator
+}
+
+func (data VoteAsDelegatorData) TxType() TxType {
+	return TypeVoteAsDelegator
+}
+
+func (data VoteAsDelegatorData) basicCheck(tx *Transaction, context *state.CheckState, block uint64) *Response {
+	kind, height := strconv.Itoa(int(data.Kind)), strconv.FormatUint(data.Height, 10)
+
+	if !delegatorvotes.IsValidKind(data.Kind) {
+		return &Response{
+			Code: code.WrongDelegatorVote,
+			Log:  fmt.Sprintf("Unknown kind of voting: %d", data.Kind),
+			Info: EncodeError(code.NewWrongDelegatorVote(kind, height, "unknown kind of voting")),
+		}
+	}
+
+	if data.Height < block {
+		return &Response{
+			Code: code.VoteExpired,
+			Log:  fmt.Sprintf("Voting height should be equal or bigger than current: %d", block),
+			Info: EncodeError(code.NewVoteExpired(height, strconv.FormatUint(block, 10))),
+		}
+	}
+
+	var options int
+	switch data.Kind {
+	case delegatorvotes.KindCommission:
+		options = len(context.Commission().GetVotes(data.Height))
+	case delegatorvotes.KindUpdate:
+		options = len(context.Updates().GetVotes(data.Height))
+	case delegatorvotes.KindHalt:
+		if context.Halts().GetHaltBlocks(data.Height) != nil {
+			options = 1
+		}
+	}
+
+	if options == 0 {
+		return &Response{
+			Code: code.WrongDelegatorVote,
+			Log:  fmt.Sprintf("There is no voting of kind %d at height %d", data.Kind, data.Height),
+			Info: EncodeError(code.NewWrongDelegatorVote(kind, height, "voting not exists")),
+		}
+	}
+
+	if !data.Abstain && int(data.Option) >= options {
+		return &Response{
+			Code: code.WrongDelegatorVote,
+			Log:  fmt.Sprintf("Option %d not exists", data.Option),
+			Info: EncodeError(code.NewWrongDelegatorVote(kind, height, "option not exists")),
+		}
+	}
+
+	sender, _ := tx.Sender()
+	if len(context.Candidates().GetDelegatorCandidateIDs(sender)) == 0 {
+		return &Response{
+			Code: code.WrongDelegatorVote,
+			Log:  fmt.Sprintf("Address %s has no stakes", sender.String()),
+			Info: EncodeError(code.NewWrongDelegatorVote(kind, height, "sender has no stakes")),
+		}
+	}
+
+	if context.DelegatorVotes().IsVoteExists(data.Kind, data.Height, sender) {
+		return &Response{
+			Code: code.VoteAlreadyExists,
+			Log:  "Delegator vote with such address already exists",
+			Info: EncodeError(code.NewVoteAlreadyExists(height, sender.String())),
+		}
+	}
+
+	return nil
+}
+
+func (data VoteAsDelegatorData) String() string {
+	return fmt.Sprintf("VOTE AS DELEGATOR kind:%d height:%d option:%d", data.Kind, data.Height, data.Option)
+}
+
+func (data VoteAsDelegatorData) CommissionData(price *commission.Price) *big.Int {
+	return price.VoteAsDelegatorPrice()
+}
+
+func (data VoteAsDelegatorData) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, price *big.Int) Response {
+	sender, _ := tx.Sender()
+
+	var checkState *state.CheckState
+	var isCheck bool
+	if checkState, isCheck = context.(*state.CheckState); !isCheck {
+		checkState = state.NewCheckState(context.(*state.State))
+	}
+
+	response := data.basicCheck(tx, checkState, currentBlock)
+	if response != nil {
+		return *response
+	}
+
+	commissionInBaseCoin := price
+	commissionPoolSwapper := checkState.Swap().GetSwapper(tx.GasCoin, types.GetBaseCoinID())
+	gasCoin := checkState.Coins().GetCoin(tx.GasCoin)
+	commission, isGasCommissionFromPoolSwap, errResp := CalculateCommission(checkState, commissionPoolSwapper, gasCoin, commissionInBaseCoin)
+	if errResp != nil {
+		return *errResp
+	}
+
+	if checkState.Accounts().GetSpendableBalance(sender, tx.GasCoin).Cmp(commission) < 0 {
+		return Response{
+			Code: code.InsufficientFunds,
+			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), commission.String(), gasCoin.GetFullSymbol()),
+			Info: EncodeError(code.NewInsufficientFunds(sender.String(), commission.String(), gasCoin.GetFullSymbol(), gasCoin.ID().String())),
+		}
+	}
+
+	var tags []abcTypes.EventAttribute
+	if deliverState, ok := context.(*state.State); ok {
+		var tagsCom *tagPoolChange
+		if isGasCommissionFromPoolSwap {
+			var (
+				poolIDCom  uint32
+				detailsCom *swap.ChangeDetailsWithOrders
+				ownersCom  []*swap.OrderDetail
+			)
+			commission, commissionInBaseCoin, poolIDCom, detailsCom, ownersCom = deliverState.Swapper().PairSellWithOrders(tx.CommissionCoin(), types.GetBaseCoinID(), commission, big.NewInt(0))
+			tagsCom = &tagPoolChange{
+				PoolID:   poolIDCom,
+				CoinIn:   tx.CommissionCoin(),
+				ValueIn:  commission.String(),
+				CoinOut:  types.GetBaseCoinID(),
+				ValueOut: commissionInBaseCoin.String(),
+				Orders:   detailsCom,
+				// Sellers:  ownersCom,
+			}
+			for _, value := range ownersCom {
+				deliverState.Accounts.AddBalance(value.Owner, tx.CommissionCoin(), value.ValueBigInt)
+			}
+		} else if !tx.GasCoin.IsBaseCoin() {
+			deliverState.Coins.SubVolume(tx.CommissionCoin(), commission)
+			deliverState.Coins.SubReserve(tx.CommissionCoin(), commissionInBaseCoin)
+		}
+
+		deliverState.DelegatorVotes.AddVote(data.Kind, data.Height, sender, data.Option, data.Abstain)
+
+		deliverState.Accounts.SubBalance(sender, tx.GasCoin, commission)
+		rewardPool.Add(rewardPool, commissionInBaseCoin)
+		deliverState.Accounts.SetNonce(sender, tx.Nonce)
+
+		tags = []abcTypes.EventAttribute{
+			{Key: []byte("tx.commission_in_base_coin"), Value: []byte(commissionInBaseCoin.String())},
+			{Key: []byte("tx.commission_conversion"), Value: []byte(isGasCommissionFromPoolSwap.String()), Index: true},
+			{Key: []byte("tx.commission_amount"), Value: []byte(commission.String())},
+			{Key: []byte("tx.commission_details"), Value: []byte(tagsCom.string())},
+			{Key: []byte("tx.vote_height"), Value: []byte(strconv.FormatUint(data.Height, 10)), Index: true},
+		}
+	}
+
+	return Response{
+		Code: code.OK,
+		Tags: tags,
+	}
+}
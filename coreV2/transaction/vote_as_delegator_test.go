@@ -0,0 +1,82 @@
+package transaction
+
+import (
+	"math/big"
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/delegatorvotes"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/crypto"
+	"github.com/MinterTeam/minter-go-node/helpers"
+)
+
+func TestVoteAsDelegatorTx(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+	cState.Candidates.EnableDelegatorsIndex()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	coin := types.GetBaseCoinID()
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(1000)))
+
+	pubkey := types.Pubkey{}
+	rand.Read(pubkey[:])
+	cState.Candidates.Create(types.Address{1}, types.Address{1}, types.Address{1}, pubkey, 10, 0, 0)
+
+	haltHeight := uint64(100)
+	data := VoteAsDelegatorData{Kind: delegatorvotes.KindHalt, Height: haltHeight}
+	encodedTx, err := makeTestTx(TypeVoteAsDelegator, data, 1, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.WrongDelegatorVote {
+		t.Fatalf("Response code is not %d. Error %s", code.WrongDelegatorVote, response.Log)
+	}
+
+	cState.Halts.AddHaltBlock(haltHeight, pubkey)
+
+	response = NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.WrongDelegatorVote {
+		t.Fatalf("Response code is not %d. Error %s", code.WrongDelegatorVote, response.Log)
+	}
+
+	cState.Candidates.Delegate(addr, pubkey, coin, helpers.BipToPip(big.NewInt(10)), helpers.BipToPip(big.NewInt(10)))
+	cState.Candidates.RecalculateStakes(109000)
+	if _, err := cState.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	response = NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not %d. Error %s", code.OK, response.Log)
+	}
+
+	if !cState.DelegatorVotes.IsVoteExists(delegatorvotes.KindHalt, haltHeight, addr) {
+		t.Fatal("Delegator vote is not added")
+	}
+
+	encodedTx, err = makeTestTx(TypeVoteAsDelegator, data, 2, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response = NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.VoteAlreadyExists {
+		t.Fatalf("Response code is not %d. Error %s", code.VoteAlreadyExists, response.Log)
+	}
+
+	response = NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), haltHeight+1, &sync.Map{}, 0, false)
+	if response.Code != code.VoteExpired {
+		t.Fatalf("Response code is not %d. Error %s", code.VoteExpired, response.Log)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
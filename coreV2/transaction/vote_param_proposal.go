@@ -0,0 +1,149 @@
+package transaction
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/commission"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/swap"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	abcTypes "github.com/tendermint/tendermint/abci/types"
+)
+
+// VoteParamProposalData is a vote of the validator for the param proposal, it can be sent by the owner of the candidate until the end of voting
+type VoteParamProposalData struct {
+	PubKey types.Pubkey
+	ID     uint64
+}
+
+func (data VoteParamProposalData) Gas() int64 {
+	return gasVoteParamProposal
+}
+
+func (data VoteParamProposalData) TxType() TxType {
+	return TypeVoteParamProposal
+}
+
+func (data VoteParamProposalData) GetPubKey() types.Pubkey {
+	return data.PubKey
+}
+
+func (data VoteParamProposalData) basicCheck(tx *Transaction, context *state.CheckState, block uint64) *Response {
+	proposal := context.Governance().GetProposal(data.ID)
+	if proposal == nil {
+		return &Response{
+			Code: code.ParamProposalNotExists,
+			Log:  fmt.Sprintf("Param proposal %d not exists", data.ID),
+			Info: EncodeError(code.NewParamProposalNotExists(strconv.FormatUint(data.ID, 10))),
+		}
+	}
+
+	if proposal.IsPassed() || proposal.VotingEnd < block {
+		return &Response{
+			Code: code.VoteExpired,
+			Log:  "voting for the proposal is over",
+			Info: EncodeError(code.NewVoteExpired(strconv.Itoa(int(block)), strconv.Itoa(int(proposal.VotingEnd)))),
+		}
+	}
+
+	if proposal.HasVote(data.PubKey) {
+		return &Response{
+			Code: code.VoteAlreadyExists,
+			Log:  "Param proposal vote with such public key already exists",
+			Info: EncodeError(code.NewVoteAlreadyExists(strconv.FormatUint(proposal.VotingEnd, 10), data.GetPubKey().String())),
+		}
+	}
+
+	return checkCandidateOwnership(data, tx, context)
+}
+
+func (data VoteParamProposalData) String() string {
+	return fmt.Sprintf("VOTE PARAM PROPOSAL id:%d", data.ID)
+}
+
+func (data VoteParamProposalData) CommissionData(price *commission.Price) *big.Int {
+	return price.VoteParamProposalPrice()
+}
+
+func (data VoteParamProposalData) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, price *big.Int) Response {
+	sender, _ := tx.Sender()
+
+	var checkState *state.CheckState
+	var isCheck bool
+	if checkState, isCheck = context.(*state.CheckState); !isCheck {
+		checkState = state.NewCheckState(context.(*state.State))
+	}
+
+	response := data.basicCheck(tx, checkState, currentBlock)
+	if response != nil {
+		return *response
+	}
+
+	commissionInBaseCoin := price
+	commissionPoolSwapper := checkState.Swap().GetSwapper(tx.GasCoin, types.GetBaseCoinID())
+	gasCoin := checkState.Coins().GetCoin(tx.GasCoin)
+	commission, isGasCommissionFromPoolSwap, errResp := CalculateCommission(checkState, commissionPoolSwapper, gasCoin, commissionInBaseCoin)
+	if errResp != nil {
+		return *errResp
+	}
+
+	if checkState.Accounts().GetSpendableBalance(sender, tx.GasCoin).Cmp(commission) < 0 {
+		return Response{
+			Code: code.InsufficientFunds,
+			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), commission.String(), gasCoin.GetFullSymbol()),
+			Info: EncodeError(code.NewInsufficientFunds(sender.String(), commission.String(), gasCoin.GetFullSymbol(), gasCoin.ID().String())),
+		}
+	}
+
+	var tags []abcTypes.EventAttribute
+	if deliverState, ok := context.(*state.State); ok {
+		var tagsCom *tagPoolChange
+		if isGasCommissionFromPoolSwap {
+			var (
+				poolIDCom  uint32
+				detailsCom *swap.ChangeDetailsWithOrders
+				ownersCom  []*swap.OrderDetail
+			)
+			commission, commissionInBaseCoin, poolIDCom, detailsCom, ownersCom = deliverState.Swapper().PairSellWithOrders(tx.CommissionCoin(), types.GetBaseCoinID(), commission, big.NewInt(0))
+			tagsCom = &tagPoolChange{
+				PoolID:   poolIDCom,
+				CoinIn:   tx.CommissionCoin(),
+				ValueIn:  commission.String(),
+				CoinOut:  types.GetBaseCoinID(),
+				ValueOut: commissionInBaseCoin.String(),
+				Orders:   detailsCom,
+				// Sellers:  ownersCom,
+			}
+			for _, value := range ownersCom {
+				deliverState.Accounts.AddBalance(value.Owner, tx.CommissionCoin(), value.ValueBigInt)
+			}
+		} else if !tx.GasCoin.IsBaseCoin() {
+			deliverState.Coins.SubVolume(tx.CommissionCoin(), commission)
+			deliverState.Coins.SubReserve(tx.CommissionCoin(), commissionInBaseCoin)
+		}
+
+		deliverState.Governance.AddVote(data.ID, data.PubKey)
+
+		deliverState.Accounts.SubBalance(sender, tx.GasCoin, commission)
+		rewardPool.Add(rewardPool, commissionInBaseCoin)
+		deliverState.Accounts.SetNonce(sender, tx.Nonce)
+
+		tags = []abcTypes.EventAttribute{
+			{Key: []byte("tx.commission_in_base_coin"), Value: []byte(commissionInBaseCoin.String())},
+			{Key: []byte("tx.commission_conversion"), Value: []byte(isGasCommissionFromPoolSwap.String()), Index: true},
+			{Key: []byte("tx.commission_amount"), Value: []byte(commission.String())},
+			{Key: []byte("tx.commission_details"), Value: []byte(tagsCom.string())},
+			{Key: []byte("tx.public_key"), Value: []byte(hex.EncodeToString(data.PubKey[:])), Index: true},
+			{Key: []byte("tx.proposal_id"), Value: []byte(strconv.FormatUint(data.ID, 10)), Index: true},
+		}
+	}
+
+	return Response{
+		Code: code.OK,
+		Tags: tags,
+	}
+}
@@ -0,0 +1,175 @@
+package transaction
+
+import (
+	"math/big"
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/governance"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/crypto"
+	"github.com/MinterTeam/minter-go-node/helpers"
+)
+
+func TestVoteParamProposalTx(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	coin := types.GetBaseCoinID()
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(1000)))
+
+	pubkey := types.Pubkey{}
+	rand.Read(pubkey[:])
+	cState.Candidates.Create(addr, addr, addr, pubkey, 10, 0, 0)
+
+	id := cState.Governance.Create(addr, governance.ParamMaxGas, 50000, big.NewInt(0), 10, 20)
+
+	encodedTx, err := makeTestTx(TypeVoteParamProposal, VoteParamProposalData{PubKey: pubkey, ID: id}, 1, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not %d. Error %s", code.OK, response.Log)
+	}
+
+	if votes := cState.Governance.GetProposal(id).GetVotes(); len(votes) != 1 || votes[0] != pubkey {
+		t.Fatalf("Vote is not added: %v", votes)
+	}
+
+	if nonce := cState.Accounts.GetNonce(addr); nonce != 1 {
+		t.Fatalf("Nonce is not correct. Expected %d, got %d", 1, nonce)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestVoteParamProposalTxToNonExistentProposal(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	coin := types.GetBaseCoinID()
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(1000)))
+
+	pubkey := types.Pubkey{}
+	rand.Read(pubkey[:])
+	cState.Candidates.Create(addr, addr, addr, pubkey, 10, 0, 0)
+
+	encodedTx, err := makeTestTx(TypeVoteParamProposal, VoteParamProposalData{PubKey: pubkey, ID: 1}, 1, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.ParamProposalNotExists {
+		t.Fatalf("Response code is not %d. Error %s", code.ParamProposalNotExists, response.Log)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestVoteParamProposalTxToPassedProposal(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	coin := types.GetBaseCoinID()
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(1000)))
+
+	pubkey := types.Pubkey{}
+	rand.Read(pubkey[:])
+	cState.Candidates.Create(addr, addr, addr, pubkey, 10, 0, 0)
+
+	cState.Governance.SetProposal(1, addr, governance.ParamMaxGas, 50000, big.NewInt(0), 10, 20, nil, true)
+	cState.Governance.SetNextID(2)
+
+	encodedTx, err := makeTestTx(TypeVoteParamProposal, VoteParamProposalData{PubKey: pubkey, ID: 1}, 1, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.VoteExpired {
+		t.Fatalf("Response code is not %d. Error %s", code.VoteExpired, response.Log)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestVoteParamProposalTxAfterVotingEnd(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	coin := types.GetBaseCoinID()
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(1000)))
+
+	pubkey := types.Pubkey{}
+	rand.Read(pubkey[:])
+	cState.Candidates.Create(addr, addr, addr, pubkey, 10, 0, 0)
+
+	id := cState.Governance.Create(addr, governance.ParamMaxGas, 50000, big.NewInt(0), 10, 20)
+
+	encodedTx, err := makeTestTx(TypeVoteParamProposal, VoteParamProposalData{PubKey: pubkey, ID: id}, 1, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 11, &sync.Map{}, 0, false)
+	if response.Code != code.VoteExpired {
+		t.Fatalf("Response code is not %d. Error %s", code.VoteExpired, response.Log)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestVoteParamProposalTxToNotOwnerOfCandidate(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	coin := types.GetBaseCoinID()
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(1000)))
+
+	pubkey := types.Pubkey{}
+	rand.Read(pubkey[:])
+	cState.Candidates.Create(types.Address{2}, types.Address{2}, types.Address{2}, pubkey, 10, 0, 0)
+
+	id := cState.Governance.Create(addr, governance.ParamMaxGas, 50000, big.NewInt(0), 10, 20)
+
+	encodedTx, err := makeTestTx(TypeVoteParamProposal, VoteParamProposalData{PubKey: pubkey, ID: id}, 1, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.IsNotOwnerOfCandidate {
+		t.Fatalf("Response code is not %d. Error %s", code.IsNotOwnerOfCandidate, response.Log)
+	}
+
+	if cState.Governance.GetProposal(id).HasVote(pubkey) {
+		t.Fatal("Vote is added by not owner of the candidate")
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,145 @@
+package transaction
+
+import (
+	"math/big"
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/crypto"
+	"github.com/MinterTeam/minter-go-node/helpers"
+)
+
+func TestVoteTreasuryProposalTx(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	coin := types.GetBaseCoinID()
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(1000)))
+
+	pubkey := types.Pubkey{}
+	rand.Read(pubkey[:])
+	cState.Candidates.Create(addr, addr, addr, pubkey, 10, 0, 0)
+
+	id := cState.Treasury.Create(addr, types.Address{1}, coin, helpers.BipToPip(big.NewInt(100)), "grant", 10)
+
+	data := VoteTreasuryProposalData{PubKey: pubkey, ID: id}
+	encodedTx, err := makeTestTx(TypeVoteTreasuryProposal, data, 1, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not %d. Error %s", code.OK, response.Log)
+	}
+
+	if votes := cState.Treasury.GetProposal(id).GetVotes(); len(votes) != 1 || votes[0] != pubkey {
+		t.Fatalf("Vote is not added: %v", votes)
+	}
+
+	if nonce := cState.Accounts.GetNonce(addr); nonce != 1 {
+		t.Fatalf("Nonce is not correct. Expected %d, got %d", 1, nonce)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestVoteTreasuryProposalTxToVoteAgain(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	coin := types.GetBaseCoinID()
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(1000)))
+
+	pubkey := types.Pubkey{}
+	rand.Read(pubkey[:])
+	cState.Candidates.Create(addr, addr, addr, pubkey, 10, 0, 0)
+
+	id := cState.Treasury.Create(addr, types.Address{1}, coin, helpers.BipToPip(big.NewInt(100)), "grant", 10)
+	cState.Treasury.AddVote(id, pubkey)
+
+	encodedTx, err := makeTestTx(TypeVoteTreasuryProposal, VoteTreasuryProposalData{PubKey: pubkey, ID: id}, 1, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.VoteAlreadyExists {
+		t.Fatalf("Response code is not %d. Error %s", code.VoteAlreadyExists, response.Log)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestVoteTreasuryProposalTxToNonExistentProposal(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	coin := types.GetBaseCoinID()
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(1000)))
+
+	pubkey := types.Pubkey{}
+	rand.Read(pubkey[:])
+	cState.Candidates.Create(addr, addr, addr, pubkey, 10, 0, 0)
+
+	encodedTx, err := makeTestTx(TypeVoteTreasuryProposal, VoteTreasuryProposalData{PubKey: pubkey, ID: 1}, 1, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.TreasuryProposalNotExists {
+		t.Fatalf("Response code is not %d. Error %s", code.TreasuryProposalNotExists, response.Log)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestVoteTreasuryProposalTxToNotOwnerOfCandidate(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	coin := types.GetBaseCoinID()
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(1000)))
+
+	pubkey := types.Pubkey{}
+	rand.Read(pubkey[:])
+	cState.Candidates.Create(types.Address{2}, types.Address{2}, types.Address{2}, pubkey, 10, 0, 0)
+
+	id := cState.Treasury.Create(addr, types.Address{1}, coin, helpers.BipToPip(big.NewInt(100)), "grant", 10)
+
+	encodedTx, err := makeTestTx(TypeVoteTreasuryProposal, VoteTreasuryProposalData{PubKey: pubkey, ID: id}, 1, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := NewExecutorV350(GetDataV350).RunTx(cState, encodedTx, big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.IsNotOwnerOfCandidate {
+		t.Fatalf("Response code is not %d. Error %s", code.IsNotOwnerOfCandidate, response.Log)
+	}
+
+	if cState.Treasury.GetProposal(id).HasVote(pubkey) {
+		t.Fatal("Vote is added by not owner of the candidate")
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
@@ -9,24 +9,41 @@ import (
 )
 
 type AppState struct {
-	Note                string             `json:"note"`
-	Validators          []Validator        `json:"validators,omitempty"`
-	Candidates          []Candidate        `json:"candidates,omitempty"`
-	BlockListCandidates []Pubkey           `json:"block_list_candidates,omitempty"`
-	DeletedCandidates   []DeletedCandidate `json:"deleted_candidates,omitempty"`
-	Waitlist            []Waitlist         `json:"waitlist,omitempty"`
-	Pools               []Pool             `json:"pools,omitempty"`
-	NextOrderID         uint64             `json:"next_order_id"`
-	Accounts            []Account          `json:"accounts,omitempty"`
-	Coins               []Coin             `json:"coins,omitempty"`
-	FrozenFunds         []FrozenFund       `json:"frozen_funds,omitempty"`
-	HaltBlocks          []HaltBlock        `json:"halt_blocks,omitempty"`
-	Commission          Commission         `json:"commission,omitempty"`
-	CommissionVotes     []CommissionVote   `json:"commission_votes,omitempty"`
-	UpdateVotes         []UpdateVote       `json:"update_votes,omitempty"`
-	UsedChecks          []UsedCheck        `json:"used_checks,omitempty"`
-	MaxGas              uint64             `json:"max_gas"`
-	TotalSlashed        string             `json:"total_slashed"`
+	Note                   string             `json:"note"`
+	Validators             []Validator        `json:"validators,omitempty"`
+	Candidates             []Candidate        `json:"candidates,omitempty"`
+	BlockListCandidates    []Pubkey           `json:"block_list_candidates,omitempty"`
+	DeletedCandidates      []DeletedCandidate `json:"deleted_candidates,omitempty"`
+	Waitlist               []Waitlist         `json:"waitlist,omitempty"`
+	Pools                  []Pool             `json:"pools,omitempty"`
+	NextOrderID            uint64             `json:"next_order_id"`
+	NextMultisigProposalID uint64             `json:"next_multisig_proposal_id,omitempty"`
+	NextStandingOrderID    uint64             `json:"next_standing_order_id,omitempty"`
+	NextHTLCID             uint64             `json:"next_htlc_id,omitempty"`
+	NextDistributionID     uint64             `json:"next_distribution_id,omitempty"`
+	NextParamProposalID    uint64             `json:"next_param_proposal_id,omitempty"`
+	NextTreasuryProposalID uint64             `json:"next_treasury_proposal_id,omitempty"`
+	Accounts               []Account          `json:"accounts,omitempty"`
+	Coins                  []Coin             `json:"coins,omitempty"`
+	FrozenFunds            []FrozenFund       `json:"frozen_funds,omitempty"`
+	Redelegations          []Redelegation     `json:"redelegations,omitempty"`
+	MultisigProposals      []MultisigProposal `json:"multisig_proposals,omitempty"`
+	StandingOrders         []StandingOrder    `json:"standing_orders,omitempty"`
+	HTLCs                  []HTLC             `json:"htlcs,omitempty"`
+	Distributions          []Distribution     `json:"distributions,omitempty"`
+	Params                 []Param            `json:"params,omitempty"`
+	ParamProposals         []ParamProposal    `json:"param_proposals,omitempty"`
+	Treasury               []TreasuryBalance  `json:"treasury,omitempty"`
+	TreasuryProposals      []TreasuryProposal `json:"treasury_proposals,omitempty"`
+	HaltBlocks             []HaltBlock        `json:"halt_blocks,omitempty"`
+	DelegatorVotes         []DelegatorVote    `json:"delegator_votes,omitempty"`
+	Commission             Commission         `json:"commission,omitempty"`
+	CommissionVotes        []CommissionVote   `json:"commission_votes,omitempty"`
+	UpdateVotes            []UpdateVote       `json:"update_votes,omitempty"`
+	UsedChecks             []UsedCheck        `json:"used_checks,omitempty"`
+	RedeemedChecks         []RedeemedCheck    `json:"redeemed_checks,omitempty"`
+	MaxGas                 uint64             `json:"max_gas"`
+	TotalSlashed           string             `json:"total_slashed"`
 
 	Emission   string      `json:"emission"`
 	PrevReward RewardPrice `json:"prev_reward"`
@@ -100,6 +117,20 @@ func (s *AppState) Verify() error {
 
 		accounts[acc.Address] = struct{}{}
 
+		if acc.Policy != nil {
+			policies := []*Policy{acc.Policy}
+			if acc.Policy.Pending != nil {
+				policies = append(policies, acc.Policy.Pending)
+			}
+			for _, policy := range policies {
+				for _, value := range append(policy.Limits, policy.Spent...) {
+					if !helpers.IsValidBigInt(value.Value) {
+						return fmt.Errorf("not valid policy value for account %s", acc.Address.String())
+					}
+				}
+			}
+		}
+
 		for _, bal := range acc.Balance {
 			if !helpers.IsValidBigInt(bal.Value) {
 				return fmt.Errorf("not valid balance for account %s", acc.Address.String())
@@ -122,9 +153,29 @@ func (s *AppState) Verify() error {
 				}
 			}
 		}
+
+		for _, vesting := range acc.Vestings {
+			if !helpers.IsValidBigInt(vesting.Value) {
+				return fmt.Errorf("not valid vesting value for account %s", acc.Address.String())
+			}
+
+			if vesting.StartHeight > vesting.CliffHeight || vesting.CliffHeight > vesting.EndHeight || vesting.StartHeight >= vesting.EndHeight {
+				return fmt.Errorf("not valid vesting schedule for account %s", acc.Address.String())
+			}
+		}
 	}
 
 	for _, candidate := range s.Candidates {
+		if settings := candidate.DelegationSettings; settings != nil {
+			if !helpers.IsValidBigInt(settings.MaxStake) || !helpers.IsValidBigInt(settings.MinDelegation) {
+				return fmt.Errorf("delegation settings of candidate %s are not valid", candidate.PubKey.String())
+			}
+		}
+
+		if pending := candidate.PendingCommission; pending != nil && pending.Commission > 100 {
+			return fmt.Errorf("pending commission of candidate %s is not valid", candidate.PubKey.String())
+		}
+
 		stakes := map[string]struct{}{}
 		for _, stake := range candidate.Stakes {
 			// check duplicated stakes
@@ -166,6 +217,30 @@ func (s *AppState) Verify() error {
 
 		coins[coin.ID] = struct{}{}
 
+		if coin.Freeze != nil {
+			if coin.Crr != 0 {
+				return fmt.Errorf("coin %s with reserve cannot be freezable", coin.Symbol)
+			}
+
+			frozen := map[Address]struct{}{}
+			for _, address := range coin.Freeze.FrozenAddresses {
+				if _, exists := frozen[address]; exists {
+					return fmt.Errorf("duplicated frozen address %s of coin %s", address.String(), coin.Symbol)
+				}
+				frozen[address] = struct{}{}
+			}
+		}
+
+		if coin.TransferFee != nil {
+			if coin.Crr != 0 {
+				return fmt.Errorf("coin %s with reserve cannot have transfer fee", coin.Symbol)
+			}
+
+			if coin.TransferFee.Bps == 0 || coin.TransferFee.Bps >= 10000 {
+				return fmt.Errorf("wrong transfer fee of coin %s", coin.Symbol)
+			}
+		}
+
 		// check coins' volume
 		volume := big.NewInt(0)
 
@@ -203,6 +278,25 @@ func (s *AppState) Verify() error {
 
 		}
 
+		for _, htlc := range s.HTLCs {
+			if htlc.Coin == coin.ID {
+				volume.Add(volume, helpers.StringToBigInt(htlc.Amount))
+			}
+		}
+
+		for _, distribution := range s.Distributions {
+			if distribution.Coin == coin.ID {
+				volume.Add(volume, helpers.StringToBigInt(distribution.Amount))
+				volume.Sub(volume, helpers.StringToBigInt(distribution.Paid))
+			}
+		}
+
+		for _, balance := range s.Treasury {
+			if balance.Coin == coin.ID {
+				volume.Add(volume, helpers.StringToBigInt(balance.Value))
+			}
+		}
+
 		if coin.Crr == 0 {
 			if volume.Cmp(helpers.StringToBigInt(coin.Volume)) != 0 {
 				return fmt.Errorf("wrong token %s (%d) volume (%s)", coin.Symbol.String(), coin.ID, big.NewInt(0).Sub(volume, helpers.StringToBigInt(coin.Volume)))
@@ -286,6 +380,164 @@ func (s *AppState) Verify() error {
 		}
 	}
 
+	for _, r := range s.Redelegations {
+		if !helpers.IsValidBigInt(r.Value) {
+			return fmt.Errorf("wrong redelegation value: %s", r.Value)
+		}
+	}
+
+	for _, p := range s.MultisigProposals {
+		if p.ID >= s.NextMultisigProposalID {
+			return fmt.Errorf("wrong multisig proposal id: %d", p.ID)
+		}
+
+		if _, err := hex.DecodeString(p.TxData); err != nil {
+			return fmt.Errorf("wrong multisig proposal %d tx data: %s", p.ID, err)
+		}
+
+		if len(p.Approvals) == 0 {
+			return fmt.Errorf("multisig proposal %d has no approvals", p.ID)
+		}
+	}
+
+	for _, h := range s.HTLCs {
+		if h.ID >= s.NextHTLCID {
+			return fmt.Errorf("wrong htlc id: %d", h.ID)
+		}
+
+		if !helpers.IsValidBigInt(h.Amount) {
+			return fmt.Errorf("not valid amount of htlc %d", h.ID)
+		}
+
+		if b, err := hex.DecodeString(h.Hashlock); err != nil || len(b) != 32 {
+			return fmt.Errorf("wrong hashlock of htlc %d", h.ID)
+		}
+
+		// check not existing coins
+		coinID := CoinID(h.Coin)
+		if !coinID.IsBaseCoin() {
+			foundCoin := false
+			for _, coin := range s.Coins {
+				id := CoinID(coin.ID)
+				if id == coinID {
+					foundCoin = true
+					break
+				}
+			}
+
+			if !foundCoin {
+				return fmt.Errorf("coin %s not found", coinID)
+			}
+		}
+	}
+
+	for _, d := range s.Distributions {
+		if d.ID >= s.NextDistributionID {
+			return fmt.Errorf("wrong distribution id: %d", d.ID)
+		}
+
+		if !helpers.IsValidBigInt(d.Amount) || !helpers.IsValidBigInt(d.Paid) || helpers.StringToBigInt(d.Paid).Cmp(helpers.StringToBigInt(d.Amount)) == 1 {
+			return fmt.Errorf("not valid amount of distribution %d", d.ID)
+		}
+
+		if len(d.Shares) == 0 {
+			return fmt.Errorf("distribution %d has no shares", d.ID)
+		}
+
+		for _, share := range d.Shares {
+			if !helpers.IsValidBigInt(share.Balance) || helpers.StringToBigInt(share.Balance).Sign() != 1 {
+				return fmt.Errorf("not valid share of %s in distribution %d", share.Address.String(), d.ID)
+			}
+		}
+
+		// check not existing coins
+		for _, coinID := range []CoinID{CoinID(d.Coin), CoinID(d.HolderCoin)} {
+			if coinID.IsBaseCoin() {
+				continue
+			}
+
+			foundCoin := false
+			for _, coin := range s.Coins {
+				id := CoinID(coin.ID)
+				if id == coinID {
+					foundCoin = true
+					break
+				}
+			}
+
+			if !foundCoin {
+				return fmt.Errorf("coin %s not found", coinID)
+			}
+		}
+	}
+
+	for _, p := range s.ParamProposals {
+		if p.ID >= s.NextParamProposalID {
+			return fmt.Errorf("wrong param proposal id: %d", p.ID)
+		}
+
+		if !helpers.IsValidBigInt(p.Deposit) {
+			return fmt.Errorf("not valid deposit of param proposal %d", p.ID)
+		}
+
+		if p.TargetHeight <= p.VotingEnd {
+			return fmt.Errorf("wrong target height of param proposal %d", p.ID)
+		}
+	}
+
+	for _, balance := range s.Treasury {
+		if !helpers.IsValidBigInt(balance.Value) {
+			return fmt.Errorf("not valid treasury balance of coin %d", balance.Coin)
+		}
+	}
+
+	for _, p := range s.TreasuryProposals {
+		if p.ID >= s.NextTreasuryProposalID {
+			return fmt.Errorf("wrong treasury proposal id: %d", p.ID)
+		}
+
+		if !helpers.IsValidBigInt(p.Amount) {
+			return fmt.Errorf("not valid amount of treasury proposal %d", p.ID)
+		}
+	}
+
+	for _, v := range s.DelegatorVotes {
+		if v.Kind == 0 || v.Kind > 3 {
+			return fmt.Errorf("wrong kind of delegator vote of %s at height %d", v.Address.String(), v.Height)
+		}
+	}
+
+	for _, o := range s.StandingOrders {
+		if o.ID >= s.NextStandingOrderID {
+			return fmt.Errorf("wrong standing order id: %d", o.ID)
+		}
+
+		if !helpers.IsValidBigInt(o.Amount) {
+			return fmt.Errorf("not valid amount of standing order %d", o.ID)
+		}
+
+		if o.Interval == 0 || o.Remaining == 0 {
+			return fmt.Errorf("wrong schedule of standing order %d", o.ID)
+		}
+
+		// check not existing coins
+		coinID := CoinID(o.Coin)
+		if !coinID.IsBaseCoin() {
+			foundCoin := false
+			for _, coin := range s.Coins {
+				id := CoinID(coin.ID)
+				if id == coinID {
+					foundCoin = true
+					break
+				}
+			}
+
+			if !foundCoin {
+				return fmt.Errorf("coin %s not found", coinID)
+			}
+		}
+	}
+
 	// check used checks length
 	for _, check := range s.UsedChecks {
 		b, err := hex.DecodeString(string(check))
@@ -298,6 +550,21 @@ func (s *AppState) Verify() error {
 		}
 	}
 
+	for _, check := range s.RedeemedChecks {
+		b, err := hex.DecodeString(check.Hash)
+		if err != nil {
+			return err
+		}
+
+		if len(b) != 32 {
+			return fmt.Errorf("wrong redeemed check size %s", check.Hash)
+		}
+
+		if !helpers.IsValidBigInt(check.Value) {
+			return fmt.Errorf("not valid redeemed value of check %s", check.Hash)
+		}
+	}
+
 	return nil
 }
 
@@ -321,6 +588,20 @@ type Candidate struct {
 	Status                   uint64  `json:"status"`
 	JailedUntil              uint64  `json:"jailed_until,omitempty"`
 	LastEditCommissionHeight uint64  `json:"last_edit_commission_height,omitempty"`
+
+	DelegationSettings *DelegationSettings `json:"delegation_settings,omitempty"`
+	PendingCommission  *PendingCommission  `json:"pending_commission,omitempty"`
+}
+
+type PendingCommission struct {
+	Commission uint64 `json:"commission"`
+	Height     uint64 `json:"height"`
+}
+
+type DelegationSettings struct {
+	MaxStake      string    `json:"max_stake"`
+	MinDelegation string    `json:"min_delegation"`
+	AllowList     []Address `json:"allow_list,omitempty"`
 }
 
 type Stake struct {
@@ -354,17 +635,37 @@ type Pool struct {
 }
 
 type Coin struct {
-	ID           uint64     `json:"id"`
-	Name         string     `json:"name"`
-	Symbol       CoinSymbol `json:"symbol"`
-	Volume       string     `json:"volume"`
-	Crr          uint64     `json:"crr,omitempty"`
-	Reserve      string     `json:"reserve,omitempty"`
-	MaxSupply    string     `json:"max_supply"`
-	Version      uint64     `json:"version,omitempty"`
-	OwnerAddress *Address   `json:"owner_address,omitempty"`
-	Mintable     bool       `json:"mintable,omitempty"`
-	Burnable     bool       `json:"burnable,omitempty"`
+	ID           uint64           `json:"id"`
+	Name         string           `json:"name"`
+	Symbol       CoinSymbol       `json:"symbol"`
+	Volume       string           `json:"volume"`
+	Crr          uint64           `json:"crr,omitempty"`
+	Reserve      string           `json:"reserve,omitempty"`
+	MaxSupply    string           `json:"max_supply"`
+	Version      uint64           `json:"version,omitempty"`
+	OwnerAddress *Address         `json:"owner_address,omitempty"`
+	Mintable     bool             `json:"mintable,omitempty"`
+	Burnable     bool             `json:"burnable,omitempty"`
+	Metadata     *CoinMetadata    `json:"metadata,omitempty"`
+	Freeze       *CoinFreeze      `json:"freeze,omitempty"`
+	TransferFee  *CoinTransferFee `json:"transfer_fee,omitempty"`
+}
+
+type CoinMetadata struct {
+	Description string `json:"description,omitempty"`
+	URI         string `json:"uri,omitempty"`
+	IconHash    Hash   `json:"icon_hash"`
+	Decimals    uint64 `json:"decimals,omitempty"`
+}
+
+type CoinFreeze struct {
+	Paused          bool      `json:"paused,omitempty"`
+	FrozenAddresses []Address `json:"frozen_addresses,omitempty"`
+}
+
+type CoinTransferFee struct {
+	Bps  uint64 `json:"bps"`
+	Burn bool   `json:"burn,omitempty"`
 }
 
 type DeletedCandidate struct {
@@ -382,14 +683,132 @@ type FrozenFund struct {
 	MoveToCandidateID uint64  `json:"move_to_candidate_id,omitempty"`
 }
 
+type Redelegation struct {
+	Height          uint64  `json:"height"`
+	Address         Address `json:"address"`
+	FromCandidateID uint64  `json:"from_candidate_id"`
+	ToCandidateID   uint64  `json:"to_candidate_id"`
+	Coin            uint64  `json:"coin"`
+	Value           string  `json:"value"`
+	DelegateHeight  uint64  `json:"delegate_height"`
+}
+
+type MultisigProposal struct {
+	ID           uint64    `json:"id"`
+	Multisig     Address   `json:"multisig"`
+	Proposer     Address   `json:"proposer"`
+	GasCoin      uint64    `json:"gas_coin"`
+	TxType       uint64    `json:"tx_type"`
+	TxData       string    `json:"tx_data"`
+	ExpireHeight uint64    `json:"expire_height"`
+	Approvals    []Address `json:"approvals"`
+}
+
+type StandingOrder struct {
+	ID         uint64  `json:"id"`
+	Owner      Address `json:"owner"`
+	Recipient  Address `json:"recipient"`
+	Coin       uint64  `json:"coin"`
+	Amount     string  `json:"amount"`
+	Interval   uint64  `json:"interval"`
+	NextHeight uint64  `json:"next_height"`
+	Remaining  uint64  `json:"remaining"`
+	Failures   uint64  `json:"failures"`
+}
+
+type HTLC struct {
+	ID        uint64  `json:"id"`
+	Sender    Address `json:"sender"`
+	Recipient Address `json:"recipient"`
+	Coin      uint64  `json:"coin"`
+	Amount    string  `json:"amount"`
+	Hashlock  string  `json:"hashlock"`
+	Timeout   uint64  `json:"timeout"`
+}
+
+type Distribution struct {
+	ID         uint64              `json:"id"`
+	Owner      Address             `json:"owner"`
+	Coin       uint64              `json:"coin"`
+	Amount     string              `json:"amount"`
+	HolderCoin uint64              `json:"holder_coin"`
+	Paid       string              `json:"paid"`
+	Shares     []DistributionShare `json:"shares"`
+}
+
+type DistributionShare struct {
+	Address Address `json:"address"`
+	Balance string  `json:"balance"`
+}
+
+type Param struct {
+	Key   string `json:"key"`
+	Value uint64 `json:"value"`
+}
+
+type ParamProposal struct {
+	ID           uint64   `json:"id"`
+	Proposer     Address  `json:"proposer"`
+	Key          string   `json:"key"`
+	Value        uint64   `json:"value"`
+	Deposit      string   `json:"deposit"`
+	VotingEnd    uint64   `json:"voting_end"`
+	TargetHeight uint64   `json:"target_height"`
+	Votes        []Pubkey `json:"votes,omitempty"`
+	Passed       bool     `json:"passed"`
+}
+
+type TreasuryBalance struct {
+	Coin  uint64 `json:"coin"`
+	Value string `json:"value"`
+}
+
+type TreasuryProposal struct {
+	ID          uint64   `json:"id"`
+	Proposer    Address  `json:"proposer"`
+	Recipient   Address  `json:"recipient"`
+	Coin        uint64   `json:"coin"`
+	Amount      string   `json:"amount"`
+	Description string   `json:"description"`
+	VotingEnd   uint64   `json:"voting_end"`
+	Votes       []Pubkey `json:"votes,omitempty"`
+}
+
 type UsedCheck string
 
+type RedeemedCheck struct {
+	Hash  string `json:"hash"`
+	Value string `json:"value"`
+}
+
 type Account struct {
 	Address             Address   `json:"address"`
 	Balance             []Balance `json:"balance,omitempty"`
 	Nonce               uint64    `json:"nonce"`
 	MultisigData        *Multisig `json:"multisig_data,omitempty"`
 	LockStakeUntilBlock uint64    `json:"lock_stake_until_block,omitempty"`
+	Vestings            []Vesting `json:"vestings,omitempty"`
+	AuthorizedKey       *Address  `json:"authorized_key,omitempty"`
+	Guardian            *Address  `json:"guardian,omitempty"`
+	Policy              *Policy   `json:"policy,omitempty"`
+}
+
+type Policy struct {
+	Limits         []Balance `json:"limits,omitempty"`
+	Recipients     []Address `json:"recipients,omitempty"`
+	ForbiddenTypes []uint64  `json:"forbidden_types,omitempty"`
+	Pending        *Policy   `json:"pending,omitempty"`
+	PendingHeight  uint64    `json:"pending_height,omitempty"`
+	PeriodStart    uint64    `json:"period_start,omitempty"`
+	Spent          []Balance `json:"spent,omitempty"`
+}
+
+type Vesting struct {
+	Coin        uint64 `json:"coin"`
+	Value       string `json:"value"`
+	StartHeight uint64 `json:"start_height"`
+	CliffHeight uint64 `json:"cliff_height"`
+	EndHeight   uint64 `json:"end_height"`
 }
 
 type Balance struct {
@@ -407,6 +826,13 @@ type HaltBlock struct {
 	Height       uint64 `json:"height"`
 	CandidateKey Pubkey `json:"candidate_key"`
 }
+type DelegatorVote struct {
+	Kind    uint64  `json:"kind"`
+	Height  uint64  `json:"height"`
+	Address Address `json:"address"`
+	Option  uint64  `json:"option"`
+	Abstain bool    `json:"abstain,omitempty"`
+}
 type CommissionVote struct {
 	Height     uint64     `json:"height"`
 	Votes      []Pubkey   `json:"votes"`
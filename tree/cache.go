@@ -0,0 +1,199 @@
+package tree
+
+import (
+	"bytes"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// AtomicTree keeps a tree which is read and replaced concurrently.
+// Unlike atomic.Value it can keep trees of different types one after another
+type AtomicTree struct {
+	value atomic.Value
+}
+
+type atomicTreeValue struct {
+	tree ImmutableTree
+}
+
+// Load returns the kept tree or nil
+func (a *AtomicTree) Load() ImmutableTree {
+	value := a.value.Load()
+	if value == nil {
+		return nil
+	}
+	return value.(atomicTreeValue).tree
+}
+
+// Store replaces the kept tree
+func (a *AtomicTree) Store(tree ImmutableTree) {
+	a.value.Store(atomicTreeValue{tree: tree})
+}
+
+type cacheWrite struct {
+	key     []byte
+	value   []byte
+	removed bool
+}
+
+// Cache keeps writes to the state in memory on top of a tree, reads of the cache see them.
+// On Commit the writes are applied to the mutable tree in the order they were made, so the hash of the tree
+// is the same as if they were made directly
+type Cache struct {
+	lock   sync.RWMutex
+	tree   ImmutableTree
+	values map[string][]byte
+	writes []cacheWrite
+}
+
+// NewCache returns an empty cache on top of the given tree
+func NewCache(tree ImmutableTree) *Cache {
+	return &Cache{tree: tree, values: map[string][]byte{}}
+}
+
+// Get returns the value of the key, the index is not tracked by the cache and is always 0
+func (c *Cache) Get(key []byte) (index int64, value []byte) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	return 0, c.get(key)
+}
+
+func (c *Cache) get(key []byte) []byte {
+	if value, ok := c.values[string(key)]; ok {
+		return value
+	}
+	if c.tree == nil {
+		return nil
+	}
+	_, value := c.tree.Get(key)
+	return value
+}
+
+// Set writes the value of the key to the cache
+func (c *Cache) Set(key, value []byte) (updated bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	updated = c.get(key) != nil
+	c.values[string(key)] = value
+	c.writes = append(c.writes, cacheWrite{key: key, value: value})
+
+	return updated
+}
+
+// Remove removes the key in the cache and returns its previous value
+func (c *Cache) Remove(key []byte) ([]byte, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	value := c.get(key)
+	if value == nil {
+		return nil, false
+	}
+	c.values[string(key)] = nil
+	c.writes = append(c.writes, cacheWrite{key: key, removed: true})
+
+	return value, true
+}
+
+// Version returns the version of the underlying tree
+func (c *Cache) Version() int64 {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	if c.tree == nil {
+		return 0
+	}
+	return c.tree.Version()
+}
+
+// IterateRange iterates over the keys of the range [start, end) of the tree and of the cache, keys removed in the cache are skipped
+func (c *Cache) IterateRange(start, end []byte, ascending bool, fn func(key []byte, value []byte) bool) (stopped bool) {
+	c.lock.RLock()
+	underlying := c.tree
+	var keys []string
+	for key := range c.values {
+		if start != nil && bytes.Compare([]byte(key), start) < 0 {
+			continue
+		}
+		if end != nil && bytes.Compare([]byte(key), end) >= 0 {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	values := make(map[string][]byte, len(keys))
+	for _, key := range keys {
+		values[key] = c.values[key]
+	}
+	c.lock.RUnlock()
+
+	sort.Strings(keys)
+	if !ascending {
+		for i, j := 0, len(keys)-1; i < j; i, j = i+1, j-1 {
+			keys[i], keys[j] = keys[j], keys[i]
+		}
+	}
+
+	// before returns if the key of the cache goes before the key of the tree in the order of the iteration
+	before := func(cacheKey string, treeKey []byte) bool {
+		if ascending {
+			return bytes.Compare([]byte(cacheKey), treeKey) < 0
+		}
+		return bytes.Compare([]byte(cacheKey), treeKey) > 0
+	}
+
+	next := 0
+	emitCached := func(treeKey []byte) bool {
+		for ; next < len(keys) && (treeKey == nil || before(keys[next], treeKey)); next++ {
+			if value := values[keys[next]]; value != nil && fn([]byte(keys[next]), value) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if underlying != nil {
+		stopped = underlying.IterateRange(start, end, ascending, func(key []byte, value []byte) bool {
+			if emitCached(key) {
+				return true
+			}
+			if _, ok := values[string(key)]; ok {
+				return false
+			}
+			return fn(key, value)
+		})
+		if stopped {
+			return true
+		}
+	}
+
+	return emitCached(nil)
+}
+
+// Commit applies the writes of the cache to the mutable tree in the order they were made
+func (c *Cache) Commit(db MutableTree, version int64) error {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	for _, write := range c.writes {
+		if write.removed {
+			db.Remove(write.key)
+			continue
+		}
+		db.Set(write.key, write.value)
+	}
+
+	return nil
+}
+
+// SetImmutableTree puts the cache on top of the given tree and drops its writes, they are expected to be committed to the tree
+func (c *Cache) SetImmutableTree(immutableTree ImmutableTree) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.tree = immutableTree
+	c.values = map[string][]byte{}
+	c.writes = nil
+}
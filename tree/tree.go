@@ -8,9 +8,23 @@ import (
 	dbm "github.com/tendermint/tm-db"
 )
 
+// ImmutableTree is a read-only view of the state tree
+type ImmutableTree interface {
+	Get(key []byte) (index int64, value []byte)
+	IterateRange(start, end []byte, ascending bool, fn func(key []byte, value []byte) bool) (stopped bool)
+	Version() int64
+}
+
+// MutableTree is the state tree changes of the state are written to
+type MutableTree interface {
+	ImmutableTree
+	Set(key, value []byte) (updated bool)
+	Remove(key []byte) ([]byte, bool)
+}
+
 type saver interface {
-	Commit(db *iavl.MutableTree, version int64) error
-	SetImmutableTree(immutableTree *iavl.ImmutableTree)
+	Commit(db MutableTree, version int64) error
+	SetImmutableTree(immutableTree ImmutableTree)
 	// ModuleName() string // todo
 }
 